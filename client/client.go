@@ -35,6 +35,7 @@ import (
 	"github.com/tikv/pd/client/grpcutil"
 	"github.com/tikv/pd/client/tlsutil"
 	"github.com/tikv/pd/client/tsoutil"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -138,7 +139,9 @@ type Client interface {
 	StoreGlobalConfig(ctx context.Context, configPath string, items []GlobalConfigItem) error
 	// WatchGlobalConfig returns a stream with all global config and updates
 	WatchGlobalConfig(ctx context.Context, configPath string, revision int64) (chan []GlobalConfigItem, error)
-	// UpdateOption updates the client option.
+	// UpdateOption updates the client option. The client created with `WithSharedTransport` returns
+	// ErrClientUpdateSharedOption, since its TSO client is shared, use `SharedTransport.UpdateOption`
+	// to update the option of all the clients on the transport instead.
 	UpdateOption(option DynamicOption, value interface{}) error
 
 	// GetExternalTimestamp returns external timestamp
@@ -272,7 +275,59 @@ func WithInitMetricsOption(initMetrics bool) ClientOption {
 	}
 }
 
+// WithTSOTracing configures the client to sample the TSO requests with the given rate in [0, 1], and
+// report the OpenTelemetry spans which break down the latency of the sampled requests through the
+// tracer provider, the global tracer provider is used if it's nil. The trace ID is propagated to the
+// server, so the client-side and the server-side spans of the same request share the trace ID.
+func WithTSOTracing(tracerProvider oteltrace.TracerProvider, sampleRate float64) ClientOption {
+	return func(c *client) {
+		c.option.tsoTracerProvider = tracerProvider
+		c.option.tsoTraceSampleRate = sampleRate
+	}
+}
+
+// WithMaxStaleness configures the client to read the regions and stores from the followers, which
+// may lag behind the leader by at most the given staleness. The reads fall back to the leader if
+// the followers are not able to serve them, e.g, the follower read is not enabled in PD.
+func WithMaxStaleness(maxStaleness time.Duration) ClientOption {
+	return func(c *client) {
+		c.option.maxStaleness = maxStaleness
+	}
+}
+
+// WithTSOLayoutNegotiation configures the client to accept the non-default TSO layout from the server.
+// The caller must compose the TSO with the layout returned by `TSOLayoutClient.GetTSOLayout` once it's enabled.
+func WithTSOLayoutNegotiation() ClientOption {
+	return func(c *client) {
+		c.option.negotiateTSOLayout = true
+	}
+}
+
+// WithTSOCircuitBreaker configures the client to fail the TSO requests fast with
+// `ErrClientTSOCircuitBreakerOpen` once the TSO requests fail consecutively for failureThreshold
+// times, and to probe whether the TSO service is recovered with a request every openDuration.
+func WithTSOCircuitBreaker(failureThreshold int, openDuration time.Duration) ClientOption {
+	return func(c *client) {
+		c.option.tsoCircuitBreakerThreshold = failureThreshold
+		c.option.tsoCircuitBreakerOpenDuration = openDuration
+	}
+}
+
+// WithRegionCache configures the client to cache the regions in [startKey, endKey), an empty
+// endKey means the end of the key space. The cache is kept fresh by the region changes pushed
+// by the PD leader, and the region reads which are not requiring the buckets are served by it.
+func WithRegionCache(startKey, endKey []byte) ClientOption {
+	return func(c *client) {
+		c.option.enableRegionCache = true
+		c.option.regionCacheStartKey = startKey
+		c.option.regionCacheEndKey = endKey
+	}
+}
+
 var _ Client = (*client)(nil)
+var _ TSOLayoutClient = (*client)(nil)
+var _ LastKnownTSClient = (*client)(nil)
+var _ MetaStorageExtensionClient = (*client)(nil)
 
 // serviceModeKeeper is for service mode switching.
 type serviceModeKeeper struct {
@@ -282,6 +337,11 @@ type serviceModeKeeper struct {
 	serviceMode     pdpb.ServiceMode
 	tsoClient       *tsoClient
 	tsoSvcDiscovery ServiceDiscovery
+	// transport is the shared transport which the TSO client is acquired from, it's nil if the
+	// client does not use a shared transport.
+	transport *SharedTransport
+	// tsoHolder holds the TSO client acquired from the shared transport.
+	tsoHolder *sharedTSOClientHolder
 }
 
 func (k *serviceModeKeeper) close() {
@@ -289,16 +349,29 @@ func (k *serviceModeKeeper) close() {
 	defer k.Unlock()
 	switch k.serviceMode {
 	case pdpb.ServiceMode_API_SVC_MODE:
-		k.tsoSvcDiscovery.Close()
+		// The TSO service discovery is owned by the shared TSO client if the client uses a
+		// shared transport.
+		if k.tsoSvcDiscovery != nil {
+			k.tsoSvcDiscovery.Close()
+		}
 		fallthrough
 	case pdpb.ServiceMode_PD_SVC_MODE:
 		if k.tsoClient != nil {
-			k.tsoClient.Close()
+			k.closeTSOClient(k.tsoClient, k.tsoHolder)
 		}
 	case pdpb.ServiceMode_UNKNOWN_SVC_MODE:
 	}
 }
 
+// closeTSOClient closes the TSO client, or releases it if it's acquired from the shared transport.
+func (k *serviceModeKeeper) closeTSOClient(cli *tsoClient, holder *sharedTSOClientHolder) {
+	if holder != nil {
+		k.transport.releaseTSOClient(holder)
+		return
+	}
+	cli.Close()
+}
+
 type client struct {
 	keyspaceID      uint32
 	svrUrls         []string
@@ -311,6 +384,10 @@ type client struct {
 	// For internal usage.
 	updateTokenConnectionCh chan struct{}
 	leaderNetworkFailure    int32
+	// watchers are the meta storage watchers to be notified when the leader is switched.
+	watchers sync.Map
+	// regionCache is nil if the region cache is not enabled.
+	regionCache *regionCache
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -389,9 +466,7 @@ func createClientWithKeyspace(
 		opt(c)
 	}
 
-	c.pdSvcDiscovery = newPDServiceDiscovery(
-		clientCtx, clientCancel, &c.wg, c.setServiceMode,
-		nil, keyspaceID, c.svrUrls, c.tlsCfg, c.option)
+	c.pdSvcDiscovery = c.newPDServiceDiscovery(clientCtx, clientCancel, nil, keyspaceID)
 	if err := c.setup(); err != nil {
 		c.cancel()
 		return nil, err
@@ -508,14 +583,13 @@ func newClientWithKeyspaceName(
 			return err
 		}
 		// c.keyspaceID is the source of truth for keyspace id.
-		c.pdSvcDiscovery.(*pdServiceDiscovery).SetKeyspaceID(c.keyspaceID)
+		c.pdSvcDiscovery.(interface{ SetKeyspaceID(uint32) }).SetKeyspaceID(c.keyspaceID)
 		return nil
 	}
 
 	// Create a PD service discovery with null keyspace id, then query the real id wth the keyspace name,
 	// finally update the keyspace id to the PD service discovery for the following interactions.
-	c.pdSvcDiscovery = newPDServiceDiscovery(
-		clientCtx, clientCancel, &c.wg, c.setServiceMode, updateKeyspaceIDCb, nullKeyspaceID, c.svrUrls, c.tlsCfg, c.option)
+	c.pdSvcDiscovery = c.newPDServiceDiscovery(clientCtx, clientCancel, updateKeyspaceIDCb, nullKeyspaceID)
 	if err := c.setup(); err != nil {
 		c.cancel()
 		return nil, err
@@ -527,6 +601,19 @@ func newClientWithKeyspaceName(
 	return c, nil
 }
 
+// newPDServiceDiscovery creates the PD service discovery of the client, it's a view of the shared
+// one if the client uses a shared transport.
+func (c *client) newPDServiceDiscovery(
+	ctx context.Context, cancel context.CancelFunc, updateKeyspaceIDCb updateKeyspaceIDFunc, keyspaceID uint32,
+) ServiceDiscovery {
+	if c.transport != nil {
+		c.tlsCfg = c.transport.tlsCfg
+		return c.transport.newServiceDiscovery(c.setServiceMode, updateKeyspaceIDCb, keyspaceID)
+	}
+	return newPDServiceDiscovery(
+		ctx, cancel, &c.wg, c.setServiceMode, updateKeyspaceIDCb, keyspaceID, c.svrUrls, c.tlsCfg, c.option)
+}
+
 func (c *client) initRetry(f func(s string) error, str string) error {
 	var err error
 	ticker := time.NewTicker(time.Second)
@@ -565,7 +652,7 @@ func (c *client) setup() error {
 	}
 
 	// Register callbacks
-	c.pdSvcDiscovery.AddServingAddrSwitchedCallback(c.scheduleUpdateTokenConnection)
+	c.pdSvcDiscovery.AddServingAddrSwitchedCallback(c.scheduleUpdateTokenConnection, c.notifyWatchersLeaderSwitched)
 
 	// Create dispatchers
 	c.createTokenDispatcher()
@@ -573,6 +660,12 @@ func (c *client) setup() error {
 	// Start the daemons.
 	c.wg.Add(1)
 	go c.leaderCheckLoop()
+	if c.option.enableRegionCache {
+		c.regionCache = newRegionCache(c.option.regionCacheStartKey, c.option.regionCacheEndKey)
+		c.pdSvcDiscovery.AddServingAddrSwitchedCallback(c.regionCache.notifyLeaderSwitched)
+		c.wg.Add(1)
+		go c.regionCacheLoop()
+	}
 	return nil
 }
 
@@ -605,10 +698,28 @@ func (c *client) setServiceMode(newMode pdpb.ServiceMode) {
 		newTSOCli          *tsoClient
 		newTSOSvcDiscovery ServiceDiscovery
 	)
+	if c.transport != nil && newMode != pdpb.ServiceMode_UNKNOWN_SVC_MODE {
+		// The TSO client is shared by the clients whose keyspaces are served by the same keyspace
+		// group, which is set up already.
+		holder, err := c.transport.acquireTSOClient(newMode, c.keyspaceID, c.onSharedTSOClientDetached)
+		if err != nil {
+			log.Error("[pd] failed to acquire the shared tso client. keep the current service mode",
+				zap.String("current-mode", c.serviceMode.String()),
+				zap.String("new-mode", newMode.String()),
+				zap.Error(err))
+			return
+		}
+		c.replaceTSOClient(newMode, holder.shared.cli, nil, holder)
+		return
+	}
 	switch newMode {
 	case pdpb.ServiceMode_PD_SVC_MODE:
 		newTSOCli = newTSOClient(c.ctx, c.option,
-			c.pdSvcDiscovery, &pdTSOStreamBuilderFactory{})
+			c.pdSvcDiscovery, &pdTSOStreamBuilderFactory{
+				negotiateLayout:     c.option.negotiateTSOLayout,
+				traceSampleInterval: tsoTraceSampleInterval(c.option.tsoTraceSampleRate),
+			})
+		newTSOCli.Setup()
 	case pdpb.ServiceMode_API_SVC_MODE:
 		newTSOSvcDiscovery = newTSOServiceDiscovery(
 			c.ctx, MetaStorageClient(c), c.pdSvcDiscovery,
@@ -616,7 +727,10 @@ func (c *client) setServiceMode(newMode pdpb.ServiceMode) {
 		// At this point, the keyspace group isn't known yet. Starts from the default keyspace group,
 		// and will be updated later.
 		newTSOCli = newTSOClient(c.ctx, c.option,
-			newTSOSvcDiscovery, &tsoTSOStreamBuilderFactory{})
+			newTSOSvcDiscovery, &tsoTSOStreamBuilderFactory{
+				negotiateLayout:     c.option.negotiateTSOLayout,
+				traceSampleInterval: tsoTraceSampleInterval(c.option.tsoTraceSampleRate),
+			})
 		if err := newTSOSvcDiscovery.Init(); err != nil {
 			log.Error("[pd] failed to initialize tso service discovery. keep the current service mode",
 				zap.Strings("svr-urls", c.svrUrls),
@@ -624,15 +738,25 @@ func (c *client) setServiceMode(newMode pdpb.ServiceMode) {
 				zap.Error(err))
 			return
 		}
+		newTSOCli.Setup()
 	case pdpb.ServiceMode_UNKNOWN_SVC_MODE:
 		log.Warn("[pd] intend to switch to unknown service mode, just return")
 		return
 	}
-	newTSOCli.Setup()
+	c.replaceTSOClient(newMode, newTSOCli, newTSOSvcDiscovery, nil)
+}
+
+// replaceTSOClient replaces the TSO client and the TSO service discovery, the caller should hold
+// the lock of the service mode keeper.
+func (c *client) replaceTSOClient(
+	newMode pdpb.ServiceMode, newTSOCli *tsoClient, newTSOSvcDiscovery ServiceDiscovery, newTSOHolder *sharedTSOClientHolder,
+) {
 	// Replace the old TSO client.
-	oldTSOClient := c.tsoClient
-	c.tsoClient = newTSOCli
-	oldTSOClient.Close()
+	oldTSOClient, oldTSOHolder := c.tsoClient, c.tsoHolder
+	c.tsoClient, c.tsoHolder = newTSOCli, newTSOHolder
+	if oldTSOClient != nil {
+		c.closeTSOClient(oldTSOClient, oldTSOHolder)
+	}
 	// Replace the old TSO service discovery if needed.
 	oldTSOSvcDiscovery := c.tsoSvcDiscovery
 	// If newTSOSvcDiscovery is nil, that's expected, as it means we are switching to PD service mode and
@@ -650,6 +774,22 @@ func (c *client) setServiceMode(newMode pdpb.ServiceMode) {
 		zap.String("new-mode", newMode.String()))
 }
 
+// onSharedTSOClientDetached acquires the shared TSO client again once the keyspace of the client
+// isn't served by the keyspace group of the current one anymore.
+func (c *client) onSharedTSOClientDetached(holder *sharedTSOClientHolder) {
+	c.Lock()
+	defer c.Unlock()
+	if c.tsoHolder != holder || c.ctx.Err() != nil {
+		return
+	}
+	newHolder, err := c.transport.acquireTSOClient(c.serviceMode, c.keyspaceID, c.onSharedTSOClientDetached)
+	if err != nil {
+		log.Error("[pd] failed to acquire the shared tso client of the new keyspace group", zap.Error(err))
+		return
+	}
+	c.replaceTSOClient(c.serviceMode, newHolder.shared.cli, nil, newHolder)
+}
+
 func (c *client) getTSOClient() *tsoClient {
 	c.RLock()
 	defer c.RUnlock()
@@ -686,13 +826,22 @@ func (c *client) GetServiceDiscovery() ServiceDiscovery {
 
 // UpdateOption updates the client option.
 func (c *client) UpdateOption(option DynamicOption, value interface{}) error {
+	// The TSO clients on the shared transport are shared by the clients, so are the options of
+	// them, which should be updated by the transport.
+	if c.transport != nil {
+		return errs.ErrClientUpdateSharedOption.FastGenByArgs()
+	}
+	return updateDynamicOption(c.option, option, value)
+}
+
+func updateDynamicOption(o *option, option DynamicOption, value interface{}) error {
 	switch option {
 	case MaxTSOBatchWaitInterval:
 		interval, ok := value.(time.Duration)
 		if !ok {
 			return errors.New("[pd] invalid value type for MaxTSOBatchWaitInterval option, it should be time.Duration")
 		}
-		if err := c.option.setMaxTSOBatchWaitInterval(interval); err != nil {
+		if err := o.setMaxTSOBatchWaitInterval(interval); err != nil {
 			return err
 		}
 	case EnableTSOFollowerProxy:
@@ -700,7 +849,7 @@ func (c *client) UpdateOption(option DynamicOption, value interface{}) error {
 		if !ok {
 			return errors.New("[pd] invalid value type for EnableTSOFollowerProxy option, it should be bool")
 		}
-		c.option.setEnableTSOFollowerProxy(enable)
+		o.setEnableTSOFollowerProxy(enable)
 	default:
 		return errors.New("[pd] unsupported client option")
 	}
@@ -837,6 +986,11 @@ func (c *client) GetLocalTSAsync(ctx context.Context, dcLocation string) TSFutur
 	}
 
 	if err := tsoClient.dispatchRequest(dcLocation, req); err != nil {
+		// Fail fast if the circuit breaker is open.
+		if errs.ErrClientTSOCircuitBreakerOpen.Equal(err) {
+			req.done <- err
+			return req
+		}
 		// Wait for a while and try again
 		time.Sleep(50 * time.Millisecond)
 		if err = tsoClient.dispatchRequest(dcLocation, req); err != nil {
@@ -846,6 +1000,24 @@ func (c *client) GetLocalTSAsync(ctx context.Context, dcLocation string) TSFutur
 	return req
 }
 
+// GetTSOLayout implements the TSOLayoutClient interface.
+func (c *client) GetTSOLayout() tsoutil.Layout {
+	tsoClient := c.getTSOClient()
+	if tsoClient == nil {
+		return tsoutil.DefaultLayout
+	}
+	return tsoClient.getTSOLayout()
+}
+
+// GetLastKnownTS implements the LastKnownTSClient interface.
+func (c *client) GetLastKnownTS(dcLocation string, maxUncertainty time.Duration) (*LastKnownTS, error) {
+	tsoClient := c.getTSOClient()
+	if tsoClient == nil {
+		return nil, errs.ErrClientGetTSO.FastGenByArgs("tso client is nil")
+	}
+	return tsoClient.getLastKnownTS(dcLocation, maxUncertainty)
+}
+
 func (c *client) GetTS(ctx context.Context) (physical int64, logical int64, err error) {
 	resp := c.GetTSAsync(ctx)
 	return resp.Wait()
@@ -930,11 +1102,33 @@ func (c *client) GetRegion(ctx context.Context, key []byte, opts ...GetRegionOpt
 	for _, opt := range opts {
 		opt(options)
 	}
+	var cacheGeneration uint64
+	if c.regionCache != nil {
+		// The region loaded before the subscription is reestablished may miss some changes,
+		// so it's only cached if the generation of the cache is not changed after it's loaded.
+		cacheGeneration = c.regionCache.getGeneration()
+		if !options.needBuckets {
+			if region := c.regionCache.getRegion(key); region != nil {
+				cancel()
+				regionCacheCounter.WithLabelValues("get_region", "hit").Inc()
+				return region, nil
+			}
+			regionCacheCounter.WithLabelValues("get_region", "miss").Inc()
+		}
+	}
 	req := &pdpb.GetRegionRequest{
 		Header:      c.requestHeader(),
 		RegionKey:   key,
 		NeedBuckets: options.needBuckets,
 	}
+	var resp *pdpb.GetRegionResponse
+	if c.followerRead(ctx, func(ctx context.Context, cli pdpb.PDClient) (header *pdpb.ResponseHeader, err error) {
+		resp, err = cli.GetRegion(ctx, req)
+		return resp.GetHeader(), err
+	}) {
+		cancel()
+		return handleRegionResponse(resp), nil
+	}
 	ctx = grpcutil.BuildForwardContext(ctx, c.GetLeaderAddr())
 	protoClient := c.getClient()
 	if protoClient == nil {
@@ -947,7 +1141,11 @@ func (c *client) GetRegion(ctx context.Context, key []byte, opts ...GetRegionOpt
 	if err = c.respForErr(cmdFailDurationGetRegion, start, err, resp.GetHeader()); err != nil {
 		return nil, err
 	}
-	return handleRegionResponse(resp), nil
+	region := handleRegionResponse(resp)
+	if c.regionCache != nil {
+		c.regionCache.update(cacheGeneration, region)
+	}
+	return region, nil
 }
 
 func isNetworkError(code codes.Code) bool {
@@ -1010,6 +1208,14 @@ func (c *client) GetPrevRegion(ctx context.Context, key []byte, opts ...GetRegio
 		RegionKey:   key,
 		NeedBuckets: options.needBuckets,
 	}
+	var resp *pdpb.GetRegionResponse
+	if c.followerRead(ctx, func(ctx context.Context, cli pdpb.PDClient) (header *pdpb.ResponseHeader, err error) {
+		resp, err = cli.GetPrevRegion(ctx, req)
+		return resp.GetHeader(), err
+	}) {
+		cancel()
+		return handleRegionResponse(resp), nil
+	}
 	ctx = grpcutil.BuildForwardContext(ctx, c.GetLeaderAddr())
 	protoClient := c.getClient()
 	if protoClient == nil {
@@ -1038,11 +1244,31 @@ func (c *client) GetRegionByID(ctx context.Context, regionID uint64, opts ...Get
 	for _, opt := range opts {
 		opt(options)
 	}
+	var cacheGeneration uint64
+	if c.regionCache != nil {
+		cacheGeneration = c.regionCache.getGeneration()
+		if !options.needBuckets {
+			if region := c.regionCache.getRegionByID(regionID); region != nil {
+				cancel()
+				regionCacheCounter.WithLabelValues("get_region_byid", "hit").Inc()
+				return region, nil
+			}
+			regionCacheCounter.WithLabelValues("get_region_byid", "miss").Inc()
+		}
+	}
 	req := &pdpb.GetRegionByIDRequest{
 		Header:      c.requestHeader(),
 		RegionId:    regionID,
 		NeedBuckets: options.needBuckets,
 	}
+	var resp *pdpb.GetRegionResponse
+	if c.followerRead(ctx, func(ctx context.Context, cli pdpb.PDClient) (header *pdpb.ResponseHeader, err error) {
+		resp, err = cli.GetRegionByID(ctx, req)
+		return resp.GetHeader(), err
+	}) {
+		cancel()
+		return handleRegionResponse(resp), nil
+	}
 	ctx = grpcutil.BuildForwardContext(ctx, c.GetLeaderAddr())
 	protoClient := c.getClient()
 	if protoClient == nil {
@@ -1055,7 +1281,11 @@ func (c *client) GetRegionByID(ctx context.Context, regionID uint64, opts ...Get
 	if err = c.respForErr(cmdFailedDurationGetRegionByID, start, err, resp.GetHeader()); err != nil {
 		return nil, err
 	}
-	return handleRegionResponse(resp), nil
+	region := handleRegionResponse(resp)
+	if c.regionCache != nil {
+		c.regionCache.update(cacheGeneration, region)
+	}
+	return region, nil
 }
 
 func (c *client) ScanRegions(ctx context.Context, key, endKey []byte, limit int) ([]*Region, error) {
@@ -1068,6 +1298,15 @@ func (c *client) ScanRegions(ctx context.Context, key, endKey []byte, limit int)
 		cmdDurationScanRegions.Observe(time.Since(start).Seconds())
 	}()
 
+	var cacheGeneration uint64
+	if c.regionCache != nil {
+		cacheGeneration = c.regionCache.getGeneration()
+		if regions := c.regionCache.scanRegions(key, endKey, limit); regions != nil {
+			regionCacheCounter.WithLabelValues("scan_regions", "hit").Inc()
+			return regions, nil
+		}
+		regionCacheCounter.WithLabelValues("scan_regions", "miss").Inc()
+	}
 	var cancel context.CancelFunc
 	scanCtx := ctx
 	if _, ok := ctx.Deadline(); !ok {
@@ -1080,6 +1319,13 @@ func (c *client) ScanRegions(ctx context.Context, key, endKey []byte, limit int)
 		EndKey:   endKey,
 		Limit:    int32(limit),
 	}
+	var resp *pdpb.ScanRegionsResponse
+	if c.followerRead(scanCtx, func(ctx context.Context, cli pdpb.PDClient) (header *pdpb.ResponseHeader, err error) {
+		resp, err = cli.ScanRegions(ctx, req)
+		return resp.GetHeader(), err
+	}) {
+		return handleRegionsResponse(resp), nil
+	}
 	scanCtx = grpcutil.BuildForwardContext(scanCtx, c.GetLeaderAddr())
 	protoClient := c.getClient()
 	if protoClient == nil {
@@ -1092,7 +1338,13 @@ func (c *client) ScanRegions(ctx context.Context, key, endKey []byte, limit int)
 		return nil, err
 	}
 
-	return handleRegionsResponse(resp), nil
+	regions := handleRegionsResponse(resp)
+	if c.regionCache != nil {
+		for _, region := range regions {
+			c.regionCache.update(cacheGeneration, region)
+		}
+	}
+	return regions, nil
 }
 
 func handleRegionsResponse(resp *pdpb.ScanRegionsResponse) []*Region {
@@ -1136,6 +1388,14 @@ func (c *client) GetStore(ctx context.Context, storeID uint64) (*metapb.Store, e
 		Header:  c.requestHeader(),
 		StoreId: storeID,
 	}
+	var resp *pdpb.GetStoreResponse
+	if c.followerRead(ctx, func(ctx context.Context, cli pdpb.PDClient) (header *pdpb.ResponseHeader, err error) {
+		resp, err = cli.GetStore(ctx, req)
+		return resp.GetHeader(), err
+	}) {
+		cancel()
+		return handleStoreResponse(resp)
+	}
 	ctx = grpcutil.BuildForwardContext(ctx, c.GetLeaderAddr())
 	protoClient := c.getClient()
 	if protoClient == nil {
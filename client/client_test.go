@@ -25,6 +25,8 @@ import (
 	"github.com/tikv/pd/client/testutil"
 	"github.com/tikv/pd/client/tlsutil"
 	"github.com/tikv/pd/client/tsoutil"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/goleak"
 	"google.golang.org/grpc"
 )
@@ -68,6 +70,30 @@ func TestUpdateURLs(t *testing.T) {
 
 const testClientURL = "tmp://test.url:5255"
 
+func TestLeaderSwitchNotifier(t *testing.T) {
+	re := require.New(t)
+	n := newLeaderSwitchNotifier()
+	// All the waiters are notified by one switch.
+	ch1, ch2 := n.wait(), n.wait()
+	n.notify()
+	for _, ch := range []<-chan struct{}{ch1, ch2} {
+		select {
+		case <-ch:
+		default:
+			re.FailNow("the waiter is not notified")
+		}
+	}
+	// The waiters after the switch wait for the next one.
+	ch3 := n.wait()
+	select {
+	case <-ch3:
+		re.FailNow("the waiter is notified by the previous switch")
+	default:
+	}
+	n.notify()
+	<-ch3
+}
+
 func TestClientCtx(t *testing.T) {
 	re := require.New(t)
 	start := time.Now()
@@ -131,3 +157,45 @@ func TestTsoRequestWait(t *testing.T) {
 	_, _, err = req.Wait()
 	re.ErrorIs(errors.Cause(err), context.Canceled)
 }
+
+func TestTSOTracing(t *testing.T) {
+	re := require.New(t)
+	recorder := tracetest.NewSpanRecorder()
+	opt := newOption()
+	WithTSOTracing(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), 0.5)(&client{option: opt})
+	c := &tsoClient{option: opt}
+	trace := tsoutil.NewTraceContext(tsoTraceSampleInterval(opt.tsoTraceSampleRate))
+
+	now := time.Now()
+	requests := []*tsoRequest{{start: now.Add(-2 * time.Millisecond)}, {start: now.Add(-3 * time.Millisecond)}}
+	// Only the second request on the stream is sampled.
+	var reqTrace tsoutil.RequestTrace
+	for i := 0; i < 2; i++ {
+		reqTrace = trace.Next()
+		c.traceTSORequests(requests, reqTrace, globalDCLocation, "addr", 0, now, now.Add(time.Millisecond), nil)
+	}
+	spans := recorder.Ended()
+	re.Len(spans, 3)
+	wait, send, root := spans[0], spans[1], spans[2]
+	re.Equal(TSOSpanRequest, root.Name())
+	// The spans share the trace ID propagated to the server.
+	for _, span := range spans {
+		re.Equal(reqTrace.TraceID, span.SpanContext().TraceID().String())
+	}
+	re.True(root.Parent().IsRemote())
+	re.Contains(root.Attributes(), TSOAttrSeq.Int64(2))
+	re.Contains(root.Attributes(), TSOAttrBatchSize.Int(2))
+	re.Equal(4*time.Millisecond, root.EndTime().Sub(root.StartTime()))
+	re.Equal(TSOSpanBatchWait, wait.Name())
+	re.Equal(root.SpanContext().SpanID(), wait.Parent().SpanID())
+	re.Equal(3*time.Millisecond, wait.EndTime().Sub(wait.StartTime()))
+	re.Equal(TSOSpanSend, send.Name())
+	re.Equal(root.SpanContext().SpanID(), send.Parent().SpanID())
+	re.Equal(time.Millisecond, send.EndTime().Sub(send.StartTime()))
+
+	// Nothing is traced if the tracing is disabled.
+	re.Zero(tsoTraceSampleInterval(0))
+	re.Nil(newTSOStreamTraceContext(0))
+	c.traceTSORequests(requests, newTSOStreamTraceContext(0).Next(), globalDCLocation, "addr", 0, now, now.Add(time.Millisecond), nil)
+	re.Len(recorder.Ended(), 3)
+}
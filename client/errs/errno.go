@@ -55,6 +55,10 @@ var (
 	ErrClientGetServingEndpoint       = errors.Normalize("get serving endpoint failed", errors.RFCCodeText("PD:client:ErrClientGetServingEndpoint"))
 	ErrClientFindGroupByKeyspaceID    = errors.Normalize("can't find keyspace group by keyspace id", errors.RFCCodeText("PD:client:ErrClientFindGroupByKeyspaceID"))
 	ErrClientWatchGCSafePointV2Stream = errors.Normalize("watch gc safe point v2 stream failed", errors.RFCCodeText("PD:client:ErrClientWatchGCSafePointV2Stream"))
+	ErrClientWatchCompacted           = errors.Normalize("the watch revision %d has been compacted, the compact revision is %d", errors.RFCCodeText("PD:client:ErrClientWatchCompacted"))
+	ErrClientTSOCircuitBreakerOpen    = errors.Normalize("the TSO circuit breaker of dc-location %s is open", errors.RFCCodeText("PD:client:ErrClientTSOCircuitBreakerOpen"))
+	ErrClientLastKnownTSUnavailable   = errors.Normalize("no TSO of dc-location %s is known within the uncertainty %v", errors.RFCCodeText("PD:client:ErrClientLastKnownTSUnavailable"))
+	ErrClientUpdateSharedOption       = errors.Normalize("the client uses a shared transport, update the option by the transport instead", errors.RFCCodeText("PD:client:ErrClientUpdateSharedOption"))
 )
 
 // grpcutil errors
@@ -0,0 +1,59 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/client/grpcutil"
+	"go.uber.org/zap"
+)
+
+// followerReadFunc sends the read request with the given client and returns the response header.
+type followerReadFunc func(ctx context.Context, cli pdpb.PDClient) (*pdpb.ResponseHeader, error)
+
+// followerRead tries to serve the read by a random follower with the staleness bounded by the
+// `WithMaxStaleness` option. It returns false if the read should be served by the leader, e.g,
+// the follower read is not enabled, or the follower is too stale to serve it.
+func (c *client) followerRead(ctx context.Context, read followerReadFunc) bool {
+	if c.option.maxStaleness <= 0 {
+		return false
+	}
+	addrs := c.pdSvcDiscovery.GetBackupAddrs()
+	if len(addrs) == 0 {
+		return false
+	}
+	addr := addrs[rand.Intn(len(addrs))]
+	cc, err := c.pdSvcDiscovery.GetOrCreateGRPCConn(addr)
+	if err != nil {
+		followerReadCounter.WithLabelValues("fallback").Inc()
+		return false
+	}
+	// Leave the rest of the timeout to the leader in case the follower is unavailable.
+	ctx, cancel := context.WithTimeout(ctx, c.option.timeout/2)
+	defer cancel()
+	header, err := read(grpcutil.BuildFollowerReadContext(ctx, c.option.maxStaleness), pdpb.NewPDClient(cc))
+	if err != nil || header.GetError() != nil {
+		log.Debug("[pd] failed to read from the follower, fall back to the leader",
+			zap.String("follower", addr), zap.Error(err), zap.Stringer("header-error", header.GetError()))
+		followerReadCounter.WithLabelValues("fallback").Inc()
+		return false
+	}
+	followerReadCounter.WithLabelValues("ok").Inc()
+	return true
+}
@@ -37,6 +37,8 @@ const (
 	dialTimeout = 3 * time.Second
 	// ForwardMetadataKey is used to record the forwarded host of PD.
 	ForwardMetadataKey = "pd-forwarded-host"
+	// FollowerReadMetadataKey is used to record the max staleness of the follower read.
+	FollowerReadMetadataKey = "pd-follower-read-max-staleness"
 )
 
 // GetClientConn returns a gRPC client connection.
@@ -76,6 +78,12 @@ func BuildForwardContext(ctx context.Context, addr string) context.Context {
 	return metadata.NewOutgoingContext(ctx, md)
 }
 
+// BuildFollowerReadContext creates a context asking the follower to serve the request
+// with the staleness bounded by the given max staleness.
+func BuildFollowerReadContext(ctx context.Context, maxStaleness time.Duration) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, FollowerReadMetadataKey, maxStaleness.String())
+}
+
 // GetOrCreateGRPCConn returns the corresponding grpc client connection of the given addr.
 // Returns the old one if's already existed in the clientConns; otherwise creates a new one and returns it.
 func GetOrCreateGRPCConn(ctx context.Context, clientConns *sync.Map, addr string, tlsCfg *tlsutil.TLSConfig, opt ...grpc.DialOption) (*grpc.ClientConn, error) {
@@ -37,9 +37,67 @@ type MetaStorageClient interface {
 	Put(ctx context.Context, key []byte, value []byte, opts ...OpOption) (*meta_storagepb.PutResponse, error)
 }
 
+// MetaStorageExtensionClient is an optional interface implemented by the clients which support the
+// requests beyond the meta storage protocol, use a type assertion on `MetaStorageClient` to access it.
+// The keys reserved by PD and the other microservices can be read but not written through them.
+type MetaStorageExtensionClient interface {
+	// Delete deletes a key or the keys with the prefix or in the range.
+	Delete(ctx context.Context, key []byte, opts ...OpOption) (*DeleteResponse, error)
+	// Txn executes the success operations if all the comparisons succeed, otherwise
+	// executes the failure operations, atomically.
+	Txn(ctx context.Context, cmps []Compare, success []TxnOp, failure []TxnOp) (*TxnResponse, error)
+	// LeaseGrant grants a lease with the TTL in seconds, which can be attached to the keys by `WithLease`.
+	// The TTL should be in [1, 3600], a lease lasting longer is rejected by the server.
+	LeaseGrant(ctx context.Context, ttl int64) (*LeaseGrantResponse, error)
+	// KeepAlive keeps the lease alive until the context is canceled or the lease expires.
+	// The channel is closed once the lease can't be kept alive anymore.
+	KeepAlive(ctx context.Context, id int64) (<-chan *LeaseKeepAliveResponse, error)
+	// Revoke revokes the lease and deletes the keys attached to it.
+	Revoke(ctx context.Context, id int64) error
+}
+
+// pdMetaStorageClient serves the meta storage requests by the PD leader of the service discovery,
+// which is used by the components sharing the service discovery without a client, e.g, the shared
+// transport.
+type pdMetaStorageClient struct {
+	svcDiscovery ServiceDiscovery
+	option       *option
+}
+
+var _ MetaStorageClient = (*pdMetaStorageClient)(nil)
+
+// metaStorage returns the meta storage client served by the PD service discovery of the client.
+func (c *client) metaStorage() *pdMetaStorageClient {
+	return &pdMetaStorageClient{svcDiscovery: c.pdSvcDiscovery, option: c.option}
+}
+
 // metaStorageClient gets the meta storage client from current PD leader.
 func (c *client) metaStorageClient() meta_storagepb.MetaStorageClient {
-	if client := c.pdSvcDiscovery.GetServingEndpointClientConn(); client != nil {
+	return c.metaStorage().metaStorageClient()
+}
+
+func (c *client) respForMetaStorageErr(observer prometheus.Observer, start time.Time, err error, header *meta_storagepb.ResponseHeader) error {
+	return c.metaStorage().respForMetaStorageErr(observer, start, err, header)
+}
+
+// Put puts a key-value pair into meta storage.
+func (c *client) Put(ctx context.Context, key, value []byte, opts ...OpOption) (*meta_storagepb.PutResponse, error) {
+	return c.metaStorage().Put(ctx, key, value, opts...)
+}
+
+// Get gets the value for a key.
+func (c *client) Get(ctx context.Context, key []byte, opts ...OpOption) (*meta_storagepb.GetResponse, error) {
+	return c.metaStorage().Get(ctx, key, opts...)
+}
+
+// Watch watches on a key or prefix.
+func (c *client) Watch(ctx context.Context, key []byte, opts ...OpOption) (chan []*meta_storagepb.Event, error) {
+	return c.metaStorage().Watch(ctx, key, opts...)
+}
+
+// metaStorageClient gets the meta storage client from current PD leader.
+func (c *pdMetaStorageClient) metaStorageClient() meta_storagepb.MetaStorageClient {
+	if client := c.svcDiscovery.GetServingEndpointClientConn(); client != nil {
 		return meta_storagepb.NewMetaStorageClient(client)
 	}
 	return nil
@@ -104,7 +162,7 @@ func getPrefix(key []byte) []byte {
 	return []byte{0}
 }
 
-func (c *client) Put(ctx context.Context, key, value []byte, opts ...OpOption) (*meta_storagepb.PutResponse, error) {
+func (c *pdMetaStorageClient) Put(ctx context.Context, key, value []byte, opts ...OpOption) (*meta_storagepb.PutResponse, error) {
 	options := &Op{}
 	for _, opt := range opts {
 		opt(options)
@@ -124,7 +182,7 @@ func (c *client) Put(ctx context.Context, key, value []byte, opts ...OpOption) (
 		Lease:  options.lease,
 		PrevKv: options.prevKv,
 	}
-	ctx = grpcutil.BuildForwardContext(ctx, c.GetLeaderAddr())
+	ctx = grpcutil.BuildForwardContext(ctx, c.svcDiscovery.GetServingAddr())
 	cli := c.metaStorageClient()
 	if cli == nil {
 		cancel()
@@ -139,7 +197,7 @@ func (c *client) Put(ctx context.Context, key, value []byte, opts ...OpOption) (
 	return resp, nil
 }
 
-func (c *client) Get(ctx context.Context, key []byte, opts ...OpOption) (*meta_storagepb.GetResponse, error) {
+func (c *pdMetaStorageClient) Get(ctx context.Context, key []byte, opts ...OpOption) (*meta_storagepb.GetResponse, error) {
 	options := &Op{}
 	for _, opt := range opts {
 		opt(options)
@@ -162,7 +220,7 @@ func (c *client) Get(ctx context.Context, key []byte, opts ...OpOption) (*meta_s
 		Limit:    options.limit,
 		Revision: options.revision,
 	}
-	ctx = grpcutil.BuildForwardContext(ctx, c.GetLeaderAddr())
+	ctx = grpcutil.BuildForwardContext(ctx, c.svcDiscovery.GetServingAddr())
 	cli := c.metaStorageClient()
 	if cli == nil {
 		cancel()
@@ -177,7 +235,7 @@ func (c *client) Get(ctx context.Context, key []byte, opts ...OpOption) (*meta_s
 	return resp, nil
 }
 
-func (c *client) Watch(ctx context.Context, key []byte, opts ...OpOption) (chan []*meta_storagepb.Event, error) {
+func (c *pdMetaStorageClient) Watch(ctx context.Context, key []byte, opts ...OpOption) (chan []*meta_storagepb.Event, error) {
 	eventCh := make(chan []*meta_storagepb.Event, 100)
 	options := &Op{}
 	for _, opt := range opts {
@@ -223,11 +281,11 @@ func (c *client) Watch(ctx context.Context, key []byte, opts ...OpOption) (chan
 	return eventCh, err
 }
 
-func (c *client) respForMetaStorageErr(observer prometheus.Observer, start time.Time, err error, header *meta_storagepb.ResponseHeader) error {
+func (c *pdMetaStorageClient) respForMetaStorageErr(observer prometheus.Observer, start time.Time, err error, header *meta_storagepb.ResponseHeader) error {
 	if err != nil || header.GetError() != nil {
 		observer.Observe(time.Since(start).Seconds())
 		if err != nil {
-			c.pdSvcDiscovery.ScheduleCheckMemberChanged()
+			c.svcDiscovery.ScheduleCheckMemberChanged()
 			return errors.WithStack(err)
 		}
 		return errors.WithStack(errors.New(header.GetError().String()))
@@ -0,0 +1,183 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pingcap/kvproto/pkg/meta_storagepb"
+)
+
+// InformerHandler is notified after the cache of the Informer is changed. All the callbacks are
+// optional and called in order.
+type InformerHandler struct {
+	// OnPut is called after the key is put.
+	OnPut func(kv *meta_storagepb.KeyValue)
+	// OnDelete is called after the key is deleted with the last cached key-value pair.
+	OnDelete func(kv *meta_storagepb.KeyValue)
+	// OnRelist is called after the cache is rebuilt since the watched revision is compacted.
+	OnRelist func(kvs []*meta_storagepb.KeyValue)
+}
+
+// Informer lists the keys with a prefix or in a range, then watches them to keep a local
+// cache of them up to date.
+type Informer struct {
+	cli      MetaStorageWatcherClient
+	key      []byte
+	getOpts  []OpOption
+	handler  *InformerHandler
+	watcher  *Watcher
+	wg       sync.WaitGroup
+	mu       sync.RWMutex
+	cache    map[string]*meta_storagepb.KeyValue
+	revision int64
+	// listRevision is the revision of the last listing, the events not newer than it are stale.
+	listRevision int64
+}
+
+// NewInformer creates an informer on the keys, only `WithPrefix` and `WithRangeEnd` are supported.
+// The handler can be nil if the caller only reads the cache.
+func NewInformer(ctx context.Context, cli MetaStorageWatcherClient, key []byte, handler *InformerHandler, opts ...OpOption) (*Informer, error) {
+	options := &Op{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	var getOpts []OpOption
+	if options.isOptsWithPrefix {
+		getOpts = append(getOpts, WithPrefix())
+	} else if options.rangeEnd != nil {
+		getOpts = append(getOpts, WithRangeEnd(options.rangeEnd))
+	}
+	if handler == nil {
+		handler = &InformerHandler{}
+	}
+	i := &Informer{
+		cli:     cli,
+		key:     key,
+		getOpts: getOpts,
+		handler: handler,
+	}
+	revision, err := i.list(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	relist := func(ctx context.Context) (int64, error) { return i.list(ctx, true) }
+	watcher, err := cli.NewWatcher(ctx, key, relist, append(getOpts, WithRev(revision+1))...)
+	if err != nil {
+		return nil, err
+	}
+	i.watcher = watcher
+	i.wg.Add(1)
+	go i.run()
+	return i, nil
+}
+
+// Get returns the cached key-value pair of the key, or nil if it does not exist.
+func (i *Informer) Get(key []byte) *meta_storagepb.KeyValue {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.cache[string(key)]
+}
+
+// List returns all the cached key-value pairs in the order of the keys.
+func (i *Informer) List() []*meta_storagepb.KeyValue {
+	i.mu.RLock()
+	kvs := make([]*meta_storagepb.KeyValue, 0, len(i.cache))
+	for _, kv := range i.cache {
+		kvs = append(kvs, kv)
+	}
+	i.mu.RUnlock()
+	sort.Slice(kvs, func(a, b int) bool { return bytes.Compare(kvs[a].Key, kvs[b].Key) < 0 })
+	return kvs
+}
+
+// Revision returns the revision of the cache.
+func (i *Informer) Revision() int64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.revision
+}
+
+// Err returns the error which stops the informer, see `Watcher.Err` for details.
+func (i *Informer) Err() error {
+	return i.watcher.Err()
+}
+
+// Close stops the informer.
+func (i *Informer) Close() {
+	i.watcher.Close()
+	i.wg.Wait()
+}
+
+// list gets all the keys and rebuilds the cache with them.
+func (i *Informer) list(ctx context.Context, relist bool) (int64, error) {
+	resp, err := i.cli.Get(ctx, i.key, i.getOpts...)
+	if err != nil {
+		return 0, err
+	}
+	cache := make(map[string]*meta_storagepb.KeyValue, len(resp.GetKvs()))
+	for _, kv := range resp.GetKvs() {
+		cache[string(kv.Key)] = kv
+	}
+	revision := resp.GetHeader().GetRevision()
+	i.mu.Lock()
+	i.cache, i.revision, i.listRevision = cache, revision, revision
+	i.mu.Unlock()
+	if relist && i.handler.OnRelist != nil {
+		i.handler.OnRelist(resp.GetKvs())
+	}
+	return revision, nil
+}
+
+func (i *Informer) run() {
+	defer i.wg.Done()
+	for events := range i.watcher.EventChan() {
+		for _, event := range events {
+			i.apply(event)
+		}
+	}
+}
+
+func (i *Informer) apply(event *meta_storagepb.Event) {
+	kv := event.GetKv()
+	i.mu.Lock()
+	// The events received before the cache is rebuilt by relisting are stale.
+	if kv.GetModRevision() <= i.listRevision {
+		i.mu.Unlock()
+		return
+	}
+	i.revision = kv.GetModRevision()
+	key := string(kv.GetKey())
+	switch event.GetType() {
+	case meta_storagepb.Event_PUT:
+		i.cache[key] = kv
+		i.mu.Unlock()
+		if i.handler.OnPut != nil {
+			i.handler.OnPut(kv)
+		}
+	case meta_storagepb.Event_DELETE:
+		prev, ok := i.cache[key]
+		delete(i.cache, key)
+		i.mu.Unlock()
+		if ok && i.handler.OnDelete != nil {
+			i.handler.OnDelete(prev)
+		}
+	default:
+		i.mu.Unlock()
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/meta_storagepb"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMetaStorageClient struct {
+	MetaStorageWatcherClient
+	getResp *meta_storagepb.GetResponse
+}
+
+func (m *mockMetaStorageClient) Get(context.Context, []byte, ...OpOption) (*meta_storagepb.GetResponse, error) {
+	return m.getResp, nil
+}
+
+func TestInformerCache(t *testing.T) {
+	re := require.New(t)
+	kv := func(key, value string, revision int64) *meta_storagepb.KeyValue {
+		return &meta_storagepb.KeyValue{Key: []byte(key), Value: []byte(value), ModRevision: revision}
+	}
+	cli := &mockMetaStorageClient{getResp: &meta_storagepb.GetResponse{
+		Header: &meta_storagepb.ResponseHeader{Revision: 10},
+		Kvs:    []*meta_storagepb.KeyValue{kv("b", "1", 5), kv("a", "1", 3)},
+	}}
+	var puts, deletes, relists int
+	i := &Informer{cli: cli, key: []byte("a"), handler: &InformerHandler{
+		OnPut:    func(*meta_storagepb.KeyValue) { puts++ },
+		OnDelete: func(*meta_storagepb.KeyValue) { deletes++ },
+		OnRelist: func([]*meta_storagepb.KeyValue) { relists++ },
+	}}
+	revision, err := i.list(context.Background(), false)
+	re.NoError(err)
+	re.Equal(int64(10), revision)
+	re.Equal([]*meta_storagepb.KeyValue{kv("a", "1", 3), kv("b", "1", 5)}, i.List())
+	re.Zero(relists)
+
+	// The events in one revision are all applied.
+	i.apply(&meta_storagepb.Event{Type: meta_storagepb.Event_PUT, Kv: kv("a", "2", 11)})
+	i.apply(&meta_storagepb.Event{Type: meta_storagepb.Event_DELETE, Kv: kv("b", "", 11)})
+	i.apply(&meta_storagepb.Event{Type: meta_storagepb.Event_DELETE, Kv: kv("c", "", 12)})
+	re.Equal([]*meta_storagepb.KeyValue{kv("a", "2", 11)}, i.List())
+	re.Nil(i.Get([]byte("b")))
+	re.Equal(int64(12), i.Revision())
+	re.Equal(1, puts)
+	re.Equal(1, deletes)
+
+	// The events which are not newer than the relisting are ignored.
+	cli.getResp = &meta_storagepb.GetResponse{
+		Header: &meta_storagepb.ResponseHeader{Revision: 20},
+		Kvs:    []*meta_storagepb.KeyValue{kv("c", "1", 18)},
+	}
+	revision, err = i.list(context.Background(), true)
+	re.NoError(err)
+	re.Equal(int64(20), revision)
+	re.Equal(1, relists)
+	i.apply(&meta_storagepb.Event{Type: meta_storagepb.Event_DELETE, Kv: kv("c", "", 13)})
+	re.Equal([]*meta_storagepb.KeyValue{kv("c", "1", 18)}, i.List())
+	i.apply(&meta_storagepb.Event{Type: meta_storagepb.Event_PUT, Kv: kv("d", "1", 21)})
+	re.Len(i.List(), 2)
+	re.Equal(int64(21), i.Revision())
+}
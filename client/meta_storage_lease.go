@@ -0,0 +1,130 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pingcap/kvproto/pkg/meta_storagepb"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// minLeaseKeepAliveInterval is the min interval to refresh the lease, in case the TTL is too short.
+const minLeaseKeepAliveInterval = 500 * time.Millisecond
+
+// LeaseGrantResponse is the response of LeaseGrant.
+type LeaseGrantResponse struct {
+	Header *meta_storagepb.ResponseHeader `json:"header"`
+	ID     int64                          `json:"id"`
+	TTL    int64                          `json:"ttl"`
+}
+
+// LeaseKeepAliveResponse is the response of refreshing the lease.
+type LeaseKeepAliveResponse struct {
+	Header *meta_storagepb.ResponseHeader `json:"header"`
+	ID     int64                          `json:"id"`
+	// TTL is the remaining TTL in seconds after refreshing.
+	TTL int64 `json:"ttl"`
+}
+
+type leaseRequest struct {
+	ID int64 `json:"id"`
+}
+
+func (c *client) LeaseGrant(ctx context.Context, ttl int64) (*LeaseGrantResponse, error) {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span = opentracing.StartSpan("pdclient.LeaseGrant", opentracing.ChildOf(span.Context()))
+		defer span.Finish()
+	}
+	start := time.Now()
+	defer func() { cmdDurationLeaseGrant.Observe(time.Since(start).Seconds()) }()
+
+	req := &struct {
+		TTL int64 `json:"ttl"`
+	}{TTL: ttl}
+	resp := &LeaseGrantResponse{}
+	err := c.metaStorageRequest(ctx, "LeaseGrant", req, resp)
+	if err = c.respForMetaStorageErr(cmdFailedDurationLeaseGrant, start, err, resp.Header); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *client) KeepAlive(ctx context.Context, id int64) (<-chan *LeaseKeepAliveResponse, error) {
+	// Refresh the lease once to make sure it's alive before returning the channel.
+	resp, err := c.leaseKeepAliveOnce(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan *LeaseKeepAliveResponse, 1)
+	ch <- resp
+	go func() {
+		defer close(ch)
+		for resp.TTL > 0 {
+			// Refresh the lease at 1/3 of the TTL to tolerate the failures of the refreshing.
+			interval := time.Duration(resp.TTL) * time.Second / 3
+			if interval < minLeaseKeepAliveInterval {
+				interval = minLeaseKeepAliveInterval
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+			resp, err = c.leaseKeepAliveOnce(ctx, id)
+			if err != nil {
+				log.Warn("[pd] failed to keep the lease alive", zap.Int64("lease", id), zap.Error(err))
+				return
+			}
+			// Drop the stale response if the receiver is slow, only the latest one matters.
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- resp
+		}
+	}()
+	return ch, nil
+}
+
+func (c *client) leaseKeepAliveOnce(ctx context.Context, id int64) (*LeaseKeepAliveResponse, error) {
+	start := time.Now()
+	defer func() { cmdDurationLeaseKeepAlive.Observe(time.Since(start).Seconds()) }()
+
+	resp := &LeaseKeepAliveResponse{}
+	err := c.metaStorageRequest(ctx, "LeaseKeepAlive", &leaseRequest{ID: id}, resp)
+	if err = c.respForMetaStorageErr(cmdFailedDurationLeaseKeepAlive, start, err, resp.Header); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *client) Revoke(ctx context.Context, id int64) error {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span = opentracing.StartSpan("pdclient.Revoke", opentracing.ChildOf(span.Context()))
+		defer span.Finish()
+	}
+	start := time.Now()
+	defer func() { cmdDurationLeaseRevoke.Observe(time.Since(start).Seconds()) }()
+
+	resp := &struct {
+		Header *meta_storagepb.ResponseHeader `json:"header"`
+	}{}
+	err := c.metaStorageRequest(ctx, "LeaseRevoke", &leaseRequest{ID: id}, resp)
+	return c.respForMetaStorageErr(cmdFailedDurationLeaseRevoke, start, err, resp.Header)
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/meta_storagepb"
+	"github.com/tikv/pd/client/errs"
+)
+
+// The meta storage protocol only defines Watch, Get and Put, so the Delete, Txn and the lease
+// requests are sent to the extension gRPC service of the meta storage on the PD leader. Since the
+// messages are not defined by the protocol either, they are encoded in JSON and carried by
+// BytesValue. They share the same key space with Get, Put and Watch, and the keys reserved by PD
+// and the other microservices are read-only for all of them.
+// Note: keep the same as the one defined on the server side.
+const metaStorageExtensionServiceName = "pd.metastorage.MetaStorageExtension"
+
+// DeleteResponse is the response of Delete.
+type DeleteResponse struct {
+	Header  *meta_storagepb.ResponseHeader `json:"header"`
+	Deleted int64                          `json:"deleted"`
+	// PrevKvs is only returned with the `WithPrevKV` option.
+	PrevKvs []*meta_storagepb.KeyValue `json:"prev_kvs,omitempty"`
+}
+
+// CompareResult is the expected result of the comparison in Txn.
+type CompareResult string
+
+// The results of the comparison.
+const (
+	CompareEqual    CompareResult = "equal"
+	CompareNotEqual CompareResult = "not_equal"
+	CompareGreater  CompareResult = "greater"
+	CompareLess     CompareResult = "less"
+)
+
+// Compare is the condition of Txn, use `CompareValue` or `CompareModRevision` to build it.
+type Compare struct {
+	Key         []byte        `json:"key"`
+	Target      string        `json:"target"`
+	Result      CompareResult `json:"result"`
+	Value       []byte        `json:"value,omitempty"`
+	ModRevision int64         `json:"mod_revision,omitempty"`
+}
+
+// CompareValue compares the value of the key with the given value.
+func CompareValue(key []byte, result CompareResult, value []byte) Compare {
+	return Compare{Key: key, Target: "value", Result: result, Value: value}
+}
+
+// CompareModRevision compares the mod revision of the key with the given revision.
+// The mod revision of a key which does not exist is 0, so comparing it with 0 checks
+// whether the key exists.
+func CompareModRevision(key []byte, result CompareResult, revision int64) Compare {
+	return Compare{Key: key, Target: "mod_revision", Result: result, ModRevision: revision}
+}
+
+// TxnOp is the operation in Txn, use `OpGet`, `OpPut` or `OpDelete` to build it.
+type TxnOp struct {
+	Type     string `json:"type"`
+	Key      []byte `json:"key"`
+	RangeEnd []byte `json:"range_end,omitempty"`
+	Value    []byte `json:"value,omitempty"`
+	Lease    int64  `json:"lease,omitempty"`
+}
+
+// OpGet returns a get operation, `WithPrefix` and `WithRangeEnd` are supported.
+func OpGet(key []byte, opts ...OpOption) TxnOp {
+	return TxnOp{Type: "get", Key: key, RangeEnd: getRangeEnd(key, opts...)}
+}
+
+// OpPut returns a put operation, `WithLease` is supported.
+func OpPut(key, value []byte, opts ...OpOption) TxnOp {
+	options := &Op{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return TxnOp{Type: "put", Key: key, Value: value, Lease: options.lease}
+}
+
+// OpDelete returns a delete operation, `WithPrefix` and `WithRangeEnd` are supported.
+func OpDelete(key []byte, opts ...OpOption) TxnOp {
+	return TxnOp{Type: "delete", Key: key, RangeEnd: getRangeEnd(key, opts...)}
+}
+
+// TxnOpResponse is the response of the operation in Txn.
+type TxnOpResponse struct {
+	// Kvs is only returned by the get operation.
+	Kvs []*meta_storagepb.KeyValue `json:"kvs,omitempty"`
+	// Deleted is only returned by the delete operation.
+	Deleted int64 `json:"deleted,omitempty"`
+}
+
+// TxnResponse is the response of Txn.
+type TxnResponse struct {
+	Header *meta_storagepb.ResponseHeader `json:"header"`
+	// Succeeded is true if all the comparisons succeed and the success operations are executed.
+	Succeeded bool `json:"succeeded"`
+	// Responses are the responses of the executed operations in order.
+	Responses []*TxnOpResponse `json:"responses"`
+}
+
+func getRangeEnd(key []byte, opts ...OpOption) []byte {
+	options := &Op{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.isOptsWithPrefix {
+		return getPrefix(key)
+	}
+	return options.rangeEnd
+}
+
+func (c *client) Delete(ctx context.Context, key []byte, opts ...OpOption) (*DeleteResponse, error) {
+	options := &Op{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.isOptsWithPrefix {
+		options.rangeEnd = getPrefix(key)
+	}
+
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span = opentracing.StartSpan("pdclient.Delete", opentracing.ChildOf(span.Context()))
+		defer span.Finish()
+	}
+	start := time.Now()
+	defer func() { cmdDurationDelete.Observe(time.Since(start).Seconds()) }()
+
+	req := &struct {
+		Key      []byte `json:"key"`
+		RangeEnd []byte `json:"range_end,omitempty"`
+		PrevKv   bool   `json:"prev_kv,omitempty"`
+	}{
+		Key:      key,
+		RangeEnd: options.rangeEnd,
+		PrevKv:   options.prevKv,
+	}
+	resp := &DeleteResponse{}
+	err := c.metaStorageRequest(ctx, "Delete", req, resp)
+	if err = c.respForMetaStorageErr(cmdFailedDurationDelete, start, err, resp.Header); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *client) Txn(ctx context.Context, cmps []Compare, success []TxnOp, failure []TxnOp) (*TxnResponse, error) {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span = opentracing.StartSpan("pdclient.Txn", opentracing.ChildOf(span.Context()))
+		defer span.Finish()
+	}
+	start := time.Now()
+	defer func() { cmdDurationTxn.Observe(time.Since(start).Seconds()) }()
+
+	req := &struct {
+		Compare []Compare `json:"compare"`
+		Success []TxnOp   `json:"success"`
+		Failure []TxnOp   `json:"failure"`
+	}{
+		Compare: cmps,
+		Success: success,
+		Failure: failure,
+	}
+	resp := &TxnResponse{}
+	err := c.metaStorageRequest(ctx, "Txn", req, resp)
+	if err = c.respForMetaStorageErr(cmdFailedDurationTxn, start, err, resp.Header); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// metaStorageRequest sends the request to the extension service of the meta storage on the
+// PD leader, and decodes the response into resp.
+func (c *client) metaStorageRequest(ctx context.Context, method string, req, resp interface{}) error {
+	cc := c.pdSvcDiscovery.GetServingEndpointClientConn()
+	if cc == nil {
+		return errs.ErrClientGetMetaStorageClient
+	}
+	value, err := json.Marshal(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.option.timeout)
+	defer cancel()
+	out := &types.BytesValue{}
+	if err = cc.Invoke(ctx, "/"+metaStorageExtensionServiceName+"/"+method, &types.BytesValue{Value: value}, out); err != nil {
+		return err
+	}
+	return errors.WithStack(json.Unmarshal(out.GetValue(), resp))
+}
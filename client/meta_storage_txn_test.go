@@ -0,0 +1,108 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pingcap/kvproto/pkg/meta_storagepb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestMetaStorageExtensionRequests(t *testing.T) {
+	re := require.New(t)
+	var (
+		method string
+		body   map[string]interface{}
+	)
+	handler := func(name, resp string) grpc.MethodDesc {
+		return grpc.MethodDesc{
+			MethodName: name,
+			Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				method, body = name, nil
+				req := &types.BytesValue{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				if err := json.Unmarshal(req.GetValue(), &body); err != nil {
+					return nil, err
+				}
+				return &types.BytesValue{Value: []byte(resp)}, nil
+			},
+		}
+	}
+	svr := grpc.NewServer()
+	svr.RegisterService(&grpc.ServiceDesc{
+		ServiceName: metaStorageExtensionServiceName,
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			handler("Delete", `{"header":{"cluster_id":1,"revision":2},"deleted":3}`),
+			handler("Txn", `{"header":{"cluster_id":1,"revision":3},"succeeded":true,"responses":[{"deleted":1}]}`),
+			handler("LeaseRevoke", `{"header":{"cluster_id":1,"error":{"message":"lease not found"}}}`),
+		},
+	}, struct{}{})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	re.NoError(err)
+	go svr.Serve(lis)
+	defer svr.Stop()
+
+	cc, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	re.NoError(err)
+	defer cc.Close()
+	discovery := &pdServiceDiscovery{checkMembershipCh: make(chan struct{}, 1)}
+	discovery.leader.Store(lis.Addr().String())
+	discovery.clientConns.Store(lis.Addr().String(), cc)
+	c := &client{option: newOption(), pdSvcDiscovery: discovery}
+	ctx := context.Background()
+
+	deleteResp, err := c.Delete(ctx, []byte("a"), WithPrefix(), WithPrevKV())
+	re.NoError(err)
+	re.Equal("Delete", method)
+	re.Equal(map[string]interface{}{"key": "YQ==", "range_end": "Yg==", "prev_kv": true}, body)
+	re.Equal(&meta_storagepb.ResponseHeader{ClusterId: 1, Revision: 2}, deleteResp.Header)
+	re.Equal(int64(3), deleteResp.Deleted)
+
+	txnResp, err := c.Txn(ctx,
+		[]Compare{CompareModRevision([]byte("a"), CompareEqual, 0)},
+		[]TxnOp{OpPut([]byte("a"), []byte("1"), WithLease(5))},
+		[]TxnOp{OpDelete([]byte("a"))})
+	re.NoError(err)
+	re.Equal("Txn", method)
+	re.Equal(map[string]interface{}{
+		"compare": []interface{}{map[string]interface{}{"key": "YQ==", "target": "mod_revision", "result": "equal"}},
+		"success": []interface{}{map[string]interface{}{"type": "put", "key": "YQ==", "value": "MQ==", "lease": float64(5)}},
+		"failure": []interface{}{map[string]interface{}{"type": "delete", "key": "YQ=="}},
+	}, body)
+	re.True(txnResp.Succeeded)
+	re.Len(txnResp.Responses, 1)
+	re.Equal(int64(1), txnResp.Responses[0].Deleted)
+
+	// The error in the header should be returned.
+	err = c.Revoke(ctx, 7)
+	re.Error(err)
+	re.Contains(err.Error(), "lease not found")
+	re.Equal(map[string]interface{}{"id": float64(7)}, body)
+
+	// The unimplemented method should be returned as the error.
+	_, err = c.LeaseGrant(ctx, 10)
+	re.Error(err)
+	re.Contains(err.Error(), "Unimplemented")
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/kvproto/pkg/meta_storagepb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/client/errs"
+	"go.uber.org/zap"
+)
+
+// MetaStorageWatcherClient is the meta storage client which is able to create the watchers resuming
+// from the last received revision.
+type MetaStorageWatcherClient interface {
+	MetaStorageClient
+	// NewWatcher creates a watcher on a key or prefix, which resumes from the last received revision
+	// after the watch stream is broken, e.g, the PD leader changes.
+	NewWatcher(ctx context.Context, key []byte, relist RelistFunc, opts ...OpOption) (*Watcher, error)
+}
+
+var _ MetaStorageWatcherClient = (*client)(nil)
+
+// RelistFunc is called when the revision to resume the watch from has been compacted. It should
+// rebuild the state of the caller, e.g, by getting all the keys again, and return the revision of
+// the new state, the watcher will resume from the next revision of it.
+type RelistFunc func(ctx context.Context) (revision int64, err error)
+
+// Watcher watches a key or prefix in meta storage. Unlike `Watch`, it tracks the revision of the
+// received events, and resumes from the next revision after the watch stream is broken, e.g, the
+// PD leader changes, so that no event is lost or received twice.
+type Watcher struct {
+	client *client
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	key      []byte
+	rangeEnd []byte
+	prevKv   bool
+	relist   RelistFunc
+	// revision is the last revision which has been received.
+	revision atomic.Int64
+	eventCh  chan []*meta_storagepb.Event
+	// leaderSwitched is notified when the PD leader is switched, to rebuild the stream at once.
+	leaderSwitched *leaderSwitchNotifier
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewWatcher creates a watcher on a key or prefix, `WithPrefix`, `WithRangeEnd`, `WithRev` and
+// `WithPrevKV` are supported. It watches from the current revision if `WithRev` is not specified.
+// If relist is nil, the watcher stops with `ErrClientWatchCompacted` once the revision to resume
+// from is compacted.
+func (c *client) NewWatcher(ctx context.Context, key []byte, relist RelistFunc, opts ...OpOption) (*Watcher, error) {
+	options := &Op{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.isOptsWithPrefix {
+		options.rangeEnd = getPrefix(key)
+	}
+	revision := options.revision - 1
+	if options.revision <= 0 {
+		// Get the current revision to make sure the events after it can be resumed.
+		resp, err := c.Get(ctx, key, WithLimit(1))
+		if err != nil {
+			return nil, err
+		}
+		revision = resp.GetHeader().GetRevision()
+	}
+	w := &Watcher{
+		client:         c,
+		key:            key,
+		rangeEnd:       options.rangeEnd,
+		prevKv:         options.prevKv,
+		relist:         relist,
+		eventCh:        make(chan []*meta_storagepb.Event, 100),
+		leaderSwitched: newLeaderSwitchNotifier(),
+	}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	w.revision.Store(revision)
+	c.watchers.Store(w, struct{}{})
+	w.wg.Add(1)
+	go w.run()
+	return w, nil
+}
+
+// EventChan returns the channel of the events, which is closed after the watcher stops.
+func (w *Watcher) EventChan() <-chan []*meta_storagepb.Event {
+	return w.eventCh
+}
+
+// Revision returns the last revision which has been received.
+func (w *Watcher) Revision() int64 {
+	return w.revision.Load()
+}
+
+// Err returns the error which stops the watcher, it's nil if the watcher is closed by the caller.
+func (w *Watcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Close stops the watcher.
+func (w *Watcher) Close() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *Watcher) run() {
+	defer func() {
+		w.client.watchers.Delete(w)
+		close(w.eventCh)
+		w.wg.Done()
+	}()
+	for {
+		// Take the channel before watching, so the leader switch during the watch is not missed.
+		leaderSwitched := w.leaderSwitched.wait()
+		compactRevision, err := w.watch(leaderSwitched)
+		if w.ctx.Err() != nil {
+			return
+		}
+		if compactRevision > 0 {
+			if w.relist == nil {
+				w.mu.Lock()
+				w.err = errs.ErrClientWatchCompacted.FastGenByArgs(w.revision.Load()+1, compactRevision)
+				w.mu.Unlock()
+				return
+			}
+			w.resumeAfterRelist(compactRevision)
+			continue
+		}
+		log.Info("[pd] meta storage watch stream is broken, resume it later",
+			zap.ByteString("key", w.key), zap.Int64("revision", w.revision.Load()), zap.Error(err))
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-leaderSwitched:
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// watch watches from the next revision of the last received one until the stream is broken or
+// the leader is switched. It returns the compact revision if the revision to watch from has been
+// compacted.
+func (w *Watcher) watch(leaderSwitched <-chan struct{}) (compactRevision int64, err error) {
+	cli := w.client.metaStorageClient()
+	if cli == nil {
+		return 0, errs.ErrClientGetMetaStorageClient
+	}
+	ctx, cancel := context.WithCancel(w.ctx)
+	defer cancel()
+	stream, err := cli.Watch(ctx, &meta_storagepb.WatchRequest{
+		Key:           w.key,
+		RangeEnd:      w.rangeEnd,
+		StartRevision: w.revision.Load() + 1,
+		PrevKv:        w.prevKv,
+	})
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	// Break the stream once the leader is switched, the old one may still be alive but
+	// not be able to serve the watch anymore.
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-leaderSwitched:
+			cancel()
+		}
+	}()
+	for {
+		resp, err := stream.Recv()
+		failpoint.Inject("watcherStreamError", func() {
+			err = errors.Errorf("fake error")
+		})
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		if header := resp.GetHeader(); header.GetError() != nil {
+			if header.GetError().GetType() == meta_storagepb.ErrorType_DATA_COMPACTED {
+				return resp.GetCompactRevision(), nil
+			}
+			return 0, errors.New(header.GetError().String())
+		}
+		events := resp.GetEvents()
+		if len(events) == 0 {
+			continue
+		}
+		select {
+		case <-w.ctx.Done():
+			return 0, w.ctx.Err()
+		case w.eventCh <- events:
+		}
+		w.revision.Store(events[len(events)-1].GetKv().GetModRevision())
+	}
+}
+
+// resumeAfterRelist calls the relist callback until it succeeds, and resumes from the revision
+// it returns.
+func (w *Watcher) resumeAfterRelist(compactRevision int64) {
+	log.Warn("[pd] meta storage watch revision has been compacted, relist it",
+		zap.ByteString("key", w.key), zap.Int64("revision", w.revision.Load()), zap.Int64("compact-revision", compactRevision))
+	for {
+		revision, err := w.relist(w.ctx)
+		if err == nil {
+			w.revision.Store(revision)
+			return
+		}
+		log.Warn("[pd] failed to relist the meta storage keys", zap.ByteString("key", w.key), zap.Error(err))
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// notifyWatchersLeaderSwitched is called after the PD leader is switched.
+func (c *client) notifyWatchersLeaderSwitched() {
+	c.watchers.Range(func(w, _ any) bool {
+		w.(*Watcher).leaderSwitched.notify()
+		return true
+	})
+}
@@ -46,6 +46,10 @@ var (
 	tsoBatchSize        prometheus.Histogram
 	tsoBatchSendLatency prometheus.Histogram
 	requestForwarded    *prometheus.GaugeVec
+	followerReadCounter *prometheus.CounterVec
+	regionCacheCounter  *prometheus.CounterVec
+	// tsoCircuitBreakerCounter counts the events of the TSO circuit breaker.
+	tsoCircuitBreakerCounter *prometheus.CounterVec
 )
 
 func initMetrics(constLabels prometheus.Labels) {
@@ -117,6 +121,33 @@ func initMetrics(constLabels prometheus.Labels) {
 			Help:        "The status to indicate if the request is forwarded",
 			ConstLabels: constLabels,
 		}, []string{"host", "delegate"})
+
+	followerReadCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   "pd_client",
+			Subsystem:   "request",
+			Name:        "follower_read_total",
+			Help:        "Counter of the reads served by the followers or fallen back to the leader.",
+			ConstLabels: constLabels,
+		}, []string{"result"})
+
+	regionCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   "pd_client",
+			Subsystem:   "request",
+			Name:        "region_cache_total",
+			Help:        "Counter of the region reads which hit or miss the region cache.",
+			ConstLabels: constLabels,
+		}, []string{"type", "result"})
+
+	tsoCircuitBreakerCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   "pd_client",
+			Subsystem:   "request",
+			Name:        "tso_circuit_breaker_total",
+			Help:        "Counter of the events of the TSO circuit breaker.",
+			ConstLabels: constLabels,
+		}, []string{"event"})
 }
 
 var (
@@ -142,6 +173,11 @@ var (
 	cmdDurationGetAllKeyspaces          prometheus.Observer
 	cmdDurationGet                      prometheus.Observer
 	cmdDurationPut                      prometheus.Observer
+	cmdDurationDelete                   prometheus.Observer
+	cmdDurationTxn                      prometheus.Observer
+	cmdDurationLeaseGrant               prometheus.Observer
+	cmdDurationLeaseKeepAlive           prometheus.Observer
+	cmdDurationLeaseRevoke              prometheus.Observer
 	cmdDurationUpdateGCSafePointV2      prometheus.Observer
 	cmdDurationUpdateServiceSafePointV2 prometheus.Observer
 
@@ -160,6 +196,11 @@ var (
 	requestDurationTSO                        prometheus.Observer
 	cmdFailedDurationGet                      prometheus.Observer
 	cmdFailedDurationPut                      prometheus.Observer
+	cmdFailedDurationDelete                   prometheus.Observer
+	cmdFailedDurationTxn                      prometheus.Observer
+	cmdFailedDurationLeaseGrant               prometheus.Observer
+	cmdFailedDurationLeaseKeepAlive           prometheus.Observer
+	cmdFailedDurationLeaseRevoke              prometheus.Observer
 	cmdFailedDurationUpdateGCSafePointV2      prometheus.Observer
 	cmdFailedDurationUpdateServiceSafePointV2 prometheus.Observer
 )
@@ -188,6 +229,11 @@ func initCmdDurations() {
 	cmdDurationGetAllKeyspaces = cmdDuration.WithLabelValues("get_all_keyspaces")
 	cmdDurationGet = cmdDuration.WithLabelValues("get")
 	cmdDurationPut = cmdDuration.WithLabelValues("put")
+	cmdDurationDelete = cmdDuration.WithLabelValues("delete")
+	cmdDurationTxn = cmdDuration.WithLabelValues("txn")
+	cmdDurationLeaseGrant = cmdDuration.WithLabelValues("lease_grant")
+	cmdDurationLeaseKeepAlive = cmdDuration.WithLabelValues("lease_keepalive")
+	cmdDurationLeaseRevoke = cmdDuration.WithLabelValues("lease_revoke")
 	cmdDurationUpdateGCSafePointV2 = cmdDuration.WithLabelValues("update_gc_safe_point_v2")
 	cmdDurationUpdateServiceSafePointV2 = cmdDuration.WithLabelValues("update_service_safe_point_v2")
 
@@ -206,6 +252,11 @@ func initCmdDurations() {
 	requestDurationTSO = requestDuration.WithLabelValues("tso")
 	cmdFailedDurationGet = cmdFailedDuration.WithLabelValues("get")
 	cmdFailedDurationPut = cmdFailedDuration.WithLabelValues("put")
+	cmdFailedDurationDelete = cmdFailedDuration.WithLabelValues("delete")
+	cmdFailedDurationTxn = cmdFailedDuration.WithLabelValues("txn")
+	cmdFailedDurationLeaseGrant = cmdFailedDuration.WithLabelValues("lease_grant")
+	cmdFailedDurationLeaseKeepAlive = cmdFailedDuration.WithLabelValues("lease_keepalive")
+	cmdFailedDurationLeaseRevoke = cmdFailedDuration.WithLabelValues("lease_revoke")
 	cmdFailedDurationUpdateGCSafePointV2 = cmdFailedDuration.WithLabelValues("update_gc_safe_point_v2")
 	cmdFailedDurationUpdateServiceSafePointV2 = cmdFailedDuration.WithLabelValues("update_service_safe_point_v2")
 }
@@ -218,4 +269,7 @@ func registerMetrics() {
 	prometheus.MustRegister(tsoBatchSize)
 	prometheus.MustRegister(tsoBatchSendLatency)
 	prometheus.MustRegister(requestForwarded)
+	prometheus.MustRegister(followerReadCounter)
+	prometheus.MustRegister(regionCacheCounter)
+	prometheus.MustRegister(tsoCircuitBreakerCounter)
 }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 )
 
@@ -54,6 +55,24 @@ type option struct {
 	enableForwarding bool
 	metricsLabels    prometheus.Labels
 	initMetrics      bool
+	// negotiateTSOLayout indicates whether the client accepts the non-default TSO layout.
+	negotiateTSOLayout bool
+	// tsoTracerProvider provides the tracer to report the spans of the sampled TSO requests,
+	// the global tracer provider is used if it's nil.
+	tsoTracerProvider trace.TracerProvider
+	// tsoTraceSampleRate is the rate of the TSO requests to be traced, 0 means the tracing is disabled.
+	tsoTraceSampleRate float64
+	// maxStaleness is the max staleness of the region and store reads served by the followers,
+	// 0 means the reads are always served by the leader.
+	maxStaleness time.Duration
+	// tsoCircuitBreakerThreshold is the number of the consecutive TSO failures to open the
+	// circuit breaker, 0 means the circuit breaker is disabled.
+	tsoCircuitBreakerThreshold    int
+	tsoCircuitBreakerOpenDuration time.Duration
+	// enableRegionCache indicates whether the regions in the key range are cached.
+	enableRegionCache   bool
+	regionCacheStartKey []byte
+	regionCacheEndKey   []byte
 
 	// Dynamic options.
 	dynamicOptions [dynamicOptionCount]atomic.Value
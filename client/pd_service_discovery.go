@@ -673,3 +673,28 @@ func (c *pdServiceDiscovery) switchTSOAllocatorLeaders(allocatorMap map[string]*
 func (c *pdServiceDiscovery) GetOrCreateGRPCConn(addr string) (*grpc.ClientConn, error) {
 	return grpcutil.GetOrCreateGRPCConn(c.ctx, &c.clientConns, addr, c.tlsCfg, c.option.gRPCDialOptions...)
 }
+
+// leaderSwitchNotifier broadcasts the leader switch to all the waiters. The channel is closed and
+// replaced by a new one on every switch, so a switch is never consumed by only one of the waiters.
+type leaderSwitchNotifier struct {
+	sync.Mutex
+	ch chan struct{}
+}
+
+func newLeaderSwitchNotifier() *leaderSwitchNotifier {
+	return &leaderSwitchNotifier{ch: make(chan struct{})}
+}
+
+// wait returns the channel which is closed on the next leader switch.
+func (n *leaderSwitchNotifier) wait() <-chan struct{} {
+	n.Lock()
+	defer n.Unlock()
+	return n.ch
+}
+
+func (n *leaderSwitchNotifier) notify() {
+	n.Lock()
+	defer n.Unlock()
+	close(n.ch)
+	n.ch = make(chan struct{})
+}
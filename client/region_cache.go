@@ -0,0 +1,369 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/client/errs"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// subscribeRegionsMethod is the method of the region subscription service, which is not defined by pdpb.
+// Note: keep the same as the one defined on the server side.
+const subscribeRegionsMethod = "/pd.region.RegionSubscription/SubscribeRegions"
+
+var subscribeRegionsStreamDesc = &grpc.StreamDesc{
+	StreamName:    "SubscribeRegions",
+	ServerStreams: true,
+}
+
+// regionCache caches the regions in a key range. It's kept fresh by the region changes
+// pushed by the PD leader, and it's only used while the subscription is alive since
+// the changes may be missed otherwise.
+// Note that only the epoch and leader changes and the removals are pushed, so the down and
+// pending peers of the cached regions may be stale.
+type regionCache struct {
+	startKey []byte
+	endKey   []byte
+	// leaderSwitched is used to break the subscription once the PD leader is switched.
+	leaderSwitched *leaderSwitchNotifier
+
+	mu sync.RWMutex
+	// synced indicates whether the subscription is alive.
+	synced bool
+	// generation is increased every time the cache is reset. The regions loaded from PD are
+	// only cached if the generation is not changed since they are loaded, otherwise they may
+	// be loaded before the subscription is established and miss some changes.
+	generation uint64
+	// regions are sorted by the start key and never overlap with each other.
+	regions []*Region
+	byID    map[uint64]*Region
+}
+
+func newRegionCache(startKey, endKey []byte) *regionCache {
+	return &regionCache{
+		startKey:       startKey,
+		endKey:         endKey,
+		leaderSwitched: newLeaderSwitchNotifier(),
+		byID:           make(map[uint64]*Region),
+	}
+}
+
+func (rc *regionCache) notifyLeaderSwitched() {
+	rc.leaderSwitched.notify()
+}
+
+// reset drops all the cached regions, sets whether the cache can be used and returns the new generation.
+func (rc *regionCache) reset(synced bool) uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.synced = synced
+	rc.generation++
+	rc.regions = nil
+	rc.byID = make(map[uint64]*Region)
+	return rc.generation
+}
+
+// getGeneration returns the current generation, which should be got before loading the regions to cache.
+func (rc *regionCache) getGeneration() uint64 {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.generation
+}
+
+func (rc *regionCache) overlaps(region *Region) bool {
+	return (len(rc.endKey) == 0 || bytes.Compare(region.Meta.GetStartKey(), rc.endKey) < 0) &&
+		(len(region.Meta.GetEndKey()) == 0 || bytes.Compare(rc.startKey, region.Meta.GetEndKey()) < 0)
+}
+
+func regionContains(region *Region, key []byte) bool {
+	return bytes.Compare(key, region.Meta.GetStartKey()) >= 0 &&
+		(len(region.Meta.GetEndKey()) == 0 || bytes.Compare(key, region.Meta.GetEndKey()) < 0)
+}
+
+func regionsOverlap(a, b *Region) bool {
+	return (len(a.Meta.GetEndKey()) == 0 || bytes.Compare(b.Meta.GetStartKey(), a.Meta.GetEndKey()) < 0) &&
+		(len(b.Meta.GetEndKey()) == 0 || bytes.Compare(a.Meta.GetStartKey(), b.Meta.GetEndKey()) < 0)
+}
+
+// searchLocked returns the index of the first region whose start key is greater than the key.
+func (rc *regionCache) searchLocked(key []byte) int {
+	return sort.Search(len(rc.regions), func(i int) bool {
+		return bytes.Compare(rc.regions[i].Meta.GetStartKey(), key) > 0
+	})
+}
+
+// getRegion returns the cached region which contains the key, or nil if it's not cached.
+func (rc *regionCache) getRegion(key []byte) *Region {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	if !rc.synced {
+		return nil
+	}
+	i := rc.searchLocked(key)
+	if i == 0 || !regionContains(rc.regions[i-1], key) {
+		return nil
+	}
+	return cloneRegion(rc.regions[i-1])
+}
+
+// getRegionByID returns the cached region with the ID, or nil if it's not cached.
+func (rc *regionCache) getRegionByID(regionID uint64) *Region {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	if !rc.synced {
+		return nil
+	}
+	if region, ok := rc.byID[regionID]; ok {
+		return cloneRegion(region)
+	}
+	return nil
+}
+
+// scanRegions returns the cached regions in [key, endKey) up to the limit, or nil if
+// the range is not fully covered by the cached regions.
+func (rc *regionCache) scanRegions(key, endKey []byte, limit int) []*Region {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	if !rc.synced {
+		return nil
+	}
+	i := rc.searchLocked(key)
+	if i == 0 || !regionContains(rc.regions[i-1], key) {
+		return nil
+	}
+	var regions []*Region
+	for i--; i < len(rc.regions); i++ {
+		region := rc.regions[i]
+		if len(regions) > 0 && !bytes.Equal(regions[len(regions)-1].Meta.GetEndKey(), region.Meta.GetStartKey()) {
+			// There is a hole in the cached regions.
+			return nil
+		}
+		regions = append(regions, cloneRegion(region))
+		if (limit > 0 && len(regions) >= limit) || len(region.Meta.GetEndKey()) == 0 ||
+			(len(endKey) > 0 && bytes.Compare(region.Meta.GetEndKey(), endKey) >= 0) {
+			return regions
+		}
+	}
+	return nil
+}
+
+// update caches the region of the generation and drops the cached regions overlapping with it.
+// The region is ignored if it's staler than any of the cached ones, which happens if the region
+// is loaded before some changes but cached after they are pushed.
+func (rc *regionCache) update(generation uint64, region *Region) {
+	if region == nil || region.Meta == nil || !rc.overlaps(region) {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if !rc.synced || rc.generation != generation {
+		return
+	}
+	epoch := region.Meta.GetRegionEpoch()
+	origin := rc.byID[region.Meta.GetId()]
+	if origin != nil && (origin.Meta.GetRegionEpoch().GetVersion() > epoch.GetVersion() ||
+		origin.Meta.GetRegionEpoch().GetConfVer() > epoch.GetConfVer()) {
+		return
+	}
+	start, end := rc.overlapRangeLocked(region)
+	for _, r := range rc.regions[start:end] {
+		if r.Meta.GetRegionEpoch().GetVersion() > epoch.GetVersion() {
+			return
+		}
+	}
+	if origin != nil {
+		if i := rc.searchLocked(origin.Meta.GetStartKey()) - 1; i >= 0 && rc.regions[i] == origin {
+			rc.regions = slices.Delete(rc.regions, i, i+1)
+		}
+		start, end = rc.overlapRangeLocked(region)
+	}
+	for _, r := range rc.regions[start:end] {
+		delete(rc.byID, r.Meta.GetId())
+	}
+	// The buckets are not pushed, so they are not cached either.
+	cached := cloneRegion(&Region{
+		Meta:         region.Meta,
+		Leader:       region.Leader,
+		DownPeers:    region.DownPeers,
+		PendingPeers: region.PendingPeers,
+	})
+	rc.regions = slices.Replace(rc.regions, start, end, cached)
+	rc.byID[cached.Meta.GetId()] = cached
+}
+
+// remove drops the removed region of the generation from the cache unless a newer one is cached.
+func (rc *regionCache) remove(generation uint64, region *Region) {
+	if region == nil || region.Meta == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if !rc.synced || rc.generation != generation {
+		return
+	}
+	epoch := region.Meta.GetRegionEpoch()
+	origin := rc.byID[region.Meta.GetId()]
+	if origin == nil || origin.Meta.GetRegionEpoch().GetVersion() > epoch.GetVersion() ||
+		origin.Meta.GetRegionEpoch().GetConfVer() > epoch.GetConfVer() {
+		return
+	}
+	if i := rc.searchLocked(origin.Meta.GetStartKey()) - 1; i >= 0 && rc.regions[i] == origin {
+		rc.regions = slices.Delete(rc.regions, i, i+1)
+	}
+	delete(rc.byID, origin.Meta.GetId())
+}
+
+// cloneRegion returns a deep copy of the region, so that the cached regions are never shared
+// with the callers, who may modify the returned regions.
+func cloneRegion(region *Region) *Region {
+	cloned := &Region{
+		DownPeers:    clonePeers(region.DownPeers),
+		PendingPeers: clonePeers(region.PendingPeers),
+	}
+	if region.Meta != nil {
+		cloned.Meta = proto.Clone(region.Meta).(*metapb.Region)
+	}
+	if region.Leader != nil {
+		cloned.Leader = proto.Clone(region.Leader).(*metapb.Peer)
+	}
+	if region.Buckets != nil {
+		cloned.Buckets = proto.Clone(region.Buckets).(*metapb.Buckets)
+	}
+	return cloned
+}
+
+func clonePeers(peers []*metapb.Peer) []*metapb.Peer {
+	if peers == nil {
+		return nil
+	}
+	cloned := make([]*metapb.Peer, 0, len(peers))
+	for _, peer := range peers {
+		cloned = append(cloned, proto.Clone(peer).(*metapb.Peer))
+	}
+	return cloned
+}
+
+// overlapRangeLocked returns the index range of the cached regions overlapping with the region.
+func (rc *regionCache) overlapRangeLocked(region *Region) (start, end int) {
+	start = rc.searchLocked(region.Meta.GetStartKey())
+	if start > 0 && regionsOverlap(rc.regions[start-1], region) {
+		start--
+	}
+	end = start
+	for end < len(rc.regions) && regionsOverlap(rc.regions[end], region) {
+		end++
+	}
+	return start, end
+}
+
+// regionCacheLoop keeps the region cache fresh by subscribing the region changes from
+// the PD leader, and resubscribes after the subscription is broken.
+func (c *client) regionCacheLoop() {
+	defer c.wg.Done()
+	for {
+		leaderSwitched := c.regionCache.leaderSwitched.wait()
+		err := c.subscribeRegions(leaderSwitched)
+		// The changes may be missed before the next subscription is established.
+		c.regionCache.reset(false)
+		if c.ctx.Err() != nil {
+			return
+		}
+		log.Info("[pd] region subscription is broken, resume it later", zap.Error(err))
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-leaderSwitched:
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// subscribeRegions applies the region changes pushed by the PD leader to the region cache
+// until the subscription is broken or the leader is switched.
+func (c *client) subscribeRegions(leaderSwitched <-chan struct{}) error {
+	cc := c.pdSvcDiscovery.GetServingEndpointClientConn()
+	if cc == nil {
+		return errs.ErrClientGetProtoClient
+	}
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+	stream, err := cc.NewStream(ctx, subscribeRegionsStreamDesc, subscribeRegionsMethod)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req := &pdpb.ScanRegionsRequest{
+		Header:   c.requestHeader(),
+		StartKey: c.regionCache.startKey,
+		EndKey:   c.regionCache.endKey,
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		return errors.WithStack(err)
+	}
+	// Break the stream once the leader is switched, the old one may still be alive but
+	// not be able to push the changes anymore.
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-leaderSwitched:
+			cancel()
+		}
+	}()
+	var generation uint64
+	for synced := false; ; synced = true {
+		resp := &pdpb.GetRegionResponse{}
+		err := stream.RecvMsg(resp)
+		failpoint.Inject("regionSubscriptionError", func() {
+			if synced {
+				err = errors.Errorf("fake error")
+			}
+		})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if header := resp.GetHeader(); header.GetError() != nil {
+			return errors.New(header.GetError().String())
+		}
+		if !synced {
+			// The first response acknowledges the subscription, the regions loaded
+			// since now are kept fresh.
+			generation = c.regionCache.reset(true)
+			continue
+		}
+		region := handleRegionResponse(resp)
+		if len(resp.GetRegion().GetPeers()) == 0 {
+			// The removed region is pushed without any peer.
+			c.regionCache.remove(generation, region)
+			continue
+		}
+		c.regionCache.update(generation, region)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegion(id uint64, startKey, endKey string, version uint64) *Region {
+	return &Region{
+		Meta: &metapb.Region{
+			Id:          id,
+			StartKey:    []byte(startKey),
+			EndKey:      []byte(endKey),
+			RegionEpoch: &metapb.RegionEpoch{Version: version, ConfVer: 1},
+		},
+		Leader: &metapb.Peer{Id: id, StoreId: 1},
+	}
+}
+
+func TestRegionCache(t *testing.T) {
+	re := require.New(t)
+	cache := newRegionCache([]byte("b"), nil)
+	checkRegionIDs := func(regions []*Region, ids ...uint64) {
+		re.Len(regions, len(ids))
+		for i, id := range ids {
+			re.Equal(id, regions[i].Meta.GetId())
+		}
+	}
+
+	// The cache is not used before the subscription is established.
+	generation := cache.getGeneration()
+	cache.update(generation, newTestRegion(1, "b", "c", 1))
+	re.Nil(cache.getRegion([]byte("b")))
+	// The region loaded before the subscription is established is not cached.
+	staleGeneration := cache.getGeneration()
+	generation = cache.reset(true)
+	cache.update(staleGeneration, newTestRegion(1, "b", "c", 1))
+	re.Nil(cache.getRegion([]byte("b")))
+	cache.update(generation, newTestRegion(1, "b", "c", 1))
+	re.Equal(uint64(1), cache.getRegion([]byte("b")).Meta.GetId())
+	re.Equal(uint64(1), cache.getRegionByID(1).Meta.GetId())
+	re.Nil(cache.getRegion([]byte("c")))
+	// The region out of the range is not cached.
+	cache.update(generation, newTestRegion(2, "", "b", 1))
+	re.Nil(cache.getRegion([]byte("a")))
+	re.Nil(cache.getRegionByID(2))
+
+	// The regions are scanned only if the range is fully covered.
+	cache.update(generation, newTestRegion(4, "d", "", 1))
+	re.Nil(cache.scanRegions([]byte("b"), nil, 0))
+	cache.update(generation, newTestRegion(3, "c", "d", 1))
+	checkRegionIDs(cache.scanRegions([]byte("b"), nil, 0), 1, 3, 4)
+	checkRegionIDs(cache.scanRegions([]byte("bb"), []byte("c"), 0), 1)
+	checkRegionIDs(cache.scanRegions([]byte("c"), []byte("e"), 0), 3, 4)
+	checkRegionIDs(cache.scanRegions([]byte("b"), nil, 2), 1, 3)
+
+	// The split region replaces the overlapping ones.
+	cache.update(generation, newTestRegion(3, "c", "cc", 2))
+	re.Nil(cache.getRegion([]byte("cc")))
+	re.Equal(uint64(2), cache.getRegionByID(3).Meta.GetRegionEpoch().GetVersion())
+	re.Nil(cache.scanRegions([]byte("b"), nil, 0))
+	cache.update(generation, newTestRegion(5, "cc", "d", 2))
+	checkRegionIDs(cache.scanRegions([]byte("b"), nil, 0), 1, 3, 5, 4)
+	// The stale region is ignored.
+	cache.update(generation, newTestRegion(3, "c", "d", 1))
+	checkRegionIDs(cache.scanRegions([]byte("b"), nil, 0), 1, 3, 5, 4)
+	cache.update(generation, newTestRegion(6, "c", "d", 1))
+	checkRegionIDs(cache.scanRegions([]byte("b"), nil, 0), 1, 3, 5, 4)
+	re.Nil(cache.getRegionByID(6))
+	// The merged region replaces the overlapping ones.
+	cache.update(generation, newTestRegion(5, "c", "", 3))
+	checkRegionIDs(cache.scanRegions([]byte("b"), nil, 0), 1, 5)
+	re.Nil(cache.getRegionByID(3))
+	re.Nil(cache.getRegionByID(4))
+
+	// The removed region is dropped unless a newer one is cached.
+	cache.remove(generation, newTestRegion(5, "c", "", 2))
+	checkRegionIDs(cache.scanRegions([]byte("b"), nil, 0), 1, 5)
+	cache.remove(generation, newTestRegion(5, "c", "", 3))
+	re.Nil(cache.getRegionByID(5))
+	re.Nil(cache.scanRegions([]byte("b"), nil, 0))
+	re.Equal(uint64(1), cache.getRegion([]byte("b")).Meta.GetId())
+
+	// All the regions are dropped once the subscription is broken.
+	cache.reset(false)
+	re.Nil(cache.getRegion([]byte("b")))
+	re.Greater(cache.reset(true), generation)
+	re.Nil(cache.getRegion([]byte("b")))
+}
+
+func TestRegionCacheCopies(t *testing.T) {
+	re := require.New(t)
+	cache := newRegionCache(nil, nil)
+	generation := cache.reset(true)
+	region := newTestRegion(1, "", "", 1)
+	region.DownPeers = []*metapb.Peer{{Id: 2, StoreId: 2}}
+	cache.update(generation, region)
+	// Modifying the region after it's cached doesn't affect the cache.
+	region.Meta.EndKey = []byte("b")
+	re.Empty(cache.getRegionByID(1).Meta.GetEndKey())
+
+	// Modifying the returned regions doesn't affect the cache either.
+	cached := cache.getRegion([]byte("a"))
+	cached.Meta.RegionEpoch.Version = 2
+	cached.Leader.StoreId = 3
+	cached.DownPeers[0].StoreId = 3
+	cached = cache.getRegionByID(1)
+	cached.Meta.Peers = append(cached.Meta.Peers, &metapb.Peer{Id: 4, StoreId: 4})
+	cache.scanRegions([]byte(""), nil, 0)[0].Meta.StartKey = []byte("z")
+
+	cached = cache.getRegion([]byte("a"))
+	re.Equal(uint64(1), cached.Meta.GetRegionEpoch().GetVersion())
+	re.Equal(uint64(1), cached.Leader.GetStoreId())
+	re.Equal(uint64(2), cached.DownPeers[0].GetStoreId())
+	re.Empty(cached.Meta.GetPeers())
+	re.Empty(cached.Meta.GetStartKey())
+}
@@ -58,6 +58,9 @@ const (
 	defaultWaitRetryTimes = 20
 	// defaultWaitRetryInterval is the interval to retry when waiting for the token.
 	defaultWaitRetryInterval = 50 * time.Millisecond
+	// defaultLowPriorityShare is the share of the rate which the low priority requests are guaranteed
+	// when the high priority requests hold the tokens.
+	defaultLowPriorityShare = 0.2
 )
 
 const (
@@ -212,6 +215,8 @@ type RUConfig struct {
 	WaitRetryInterval        time.Duration
 	WaitRetryTimes           int
 	DegradedModeWaitDuration time.Duration
+	// LowPriorityShare is the share of the rate guaranteed to the low priority requests, see `RequestPriorityLow`.
+	LowPriorityShare float64
 }
 
 // DefaultRUConfig returns the default configuration.
@@ -235,5 +240,6 @@ func GenerateRUConfig(config *Config) *RUConfig {
 		WaitRetryInterval:        config.WaitRetryInterval.Duration,
 		WaitRetryTimes:           config.WaitRetryTimes,
 		DegradedModeWaitDuration: config.DegradedModeWaitDuration.Duration,
+		LowPriorityShare:         defaultLowPriorityShare,
 	}
 }
@@ -72,6 +72,14 @@ type ResourceGroupKVInterceptor interface {
 	IsBackgroundRequest(ctx context.Context, resourceGroupName, requestResource string) bool
 }
 
+// ResourceGroupPriorityKVInterceptor is the ResourceGroupKVInterceptor which is able to wait for
+// the tokens of the request by its priority, see `RequestPriority`.
+type ResourceGroupPriorityKVInterceptor interface {
+	ResourceGroupKVInterceptor
+	// OnRequestWaitWithPriority is like OnRequestWait, but the request waits by the given priority.
+	OnRequestWaitWithPriority(ctx context.Context, resourceGroupName string, info RequestInfo, priority RequestPriority) (*rmpb.Consumption, *rmpb.Consumption, time.Duration, uint32, error)
+}
+
 // ResourceGroupProvider provides some api to interact with resource manager server.
 type ResourceGroupProvider interface {
 	GetResourceGroup(ctx context.Context, resourceGroupName string) (*rmpb.ResourceGroup, error)
@@ -110,6 +118,14 @@ func WithWaitRetryInterval(d time.Duration) ResourceControlCreateOption {
 	}
 }
 
+// WithLowPriorityShare is the option to set the share of the rate which the low priority requests
+// are guaranteed when the high priority requests hold the tokens.
+func WithLowPriorityShare(share float64) ResourceControlCreateOption {
+	return func(controller *ResourceGroupsController) {
+		controller.ruConfig.LowPriorityShare = share
+	}
+}
+
 // WithWaitRetryTimes is the option to set the times to retry when waiting for the token.
 func WithWaitRetryTimes(times int) ResourceControlCreateOption {
 	return func(controller *ResourceGroupsController) {
@@ -117,7 +133,7 @@ func WithWaitRetryTimes(times int) ResourceControlCreateOption {
 	}
 }
 
-var _ ResourceGroupKVInterceptor = (*ResourceGroupsController)(nil)
+var _ ResourceGroupPriorityKVInterceptor = (*ResourceGroupsController)(nil)
 
 // ResourceGroupsController implements ResourceGroupKVInterceptor.
 type ResourceGroupsController struct {
@@ -130,6 +146,7 @@ type ResourceGroupsController struct {
 	loopCancel func()
 
 	calculators []ResourceCalculator
+	runaway     *runawayChecker
 
 	// When a signal is received, it means the number of available token is low.
 	lowTokenNotifyChan chan notifyMsg
@@ -177,6 +194,7 @@ func NewResourceGroupController(
 		lowTokenNotifyChan:    make(chan notifyMsg, 1),
 		tokenResponseChan:     make(chan []*rmpb.TokenBucketResponse, 1),
 		tokenBucketUpdateChan: make(chan *groupCostController, maxNotificationChanLen),
+		runaway:               newRunawayChecker(),
 		opts:                  opts,
 	}
 	for _, opt := range opts {
@@ -234,6 +252,8 @@ func (c *ResourceGroupsController) Start(ctx context.Context) {
 		defer stateUpdateTicker.Stop()
 		emergencyTokenAcquisitionTicker := time.NewTicker(defaultTargetPeriod)
 		defer emergencyTokenAcquisitionTicker.Stop()
+		runawayReportTicker := time.NewTicker(defaultRunawayReportInterval)
+		defer runawayReportTicker.Stop()
 
 		failpoint.Inject("fastCleanup", func() {
 			cleanupTicker.Stop()
@@ -305,6 +325,8 @@ func (c *ResourceGroupsController) Start(ctx context.Context) {
 				}
 			case <-emergencyTokenAcquisitionTicker.C:
 				c.executeOnAllGroups((*groupCostController).resetEmergencyTokenAcquisition)
+			case <-runawayReportTicker.C:
+				c.reportRunawayViolations(c.loopCtx)
 			/* channels */
 			case <-c.loopCtx.Done():
 				resourceGroupStatusGauge.Reset()
@@ -553,12 +575,19 @@ func (c *ResourceGroupsController) sendTokenBucketRequests(ctx context.Context,
 // OnRequestWait is used to check whether resource group has enough tokens. It maybe needs to wait some time.
 func (c *ResourceGroupsController) OnRequestWait(
 	ctx context.Context, resourceGroupName string, info RequestInfo,
+) (*rmpb.Consumption, *rmpb.Consumption, time.Duration, uint32, error) {
+	return c.OnRequestWaitWithPriority(ctx, resourceGroupName, info, RequestPriorityHigh)
+}
+
+// OnRequestWaitWithPriority is like OnRequestWait, but the request waits by the given priority.
+func (c *ResourceGroupsController) OnRequestWaitWithPriority(
+	ctx context.Context, resourceGroupName string, info RequestInfo, priority RequestPriority,
 ) (*rmpb.Consumption, *rmpb.Consumption, time.Duration, uint32, error) {
 	gc, err := c.tryGetResourceGroup(ctx, resourceGroupName)
 	if err != nil {
 		return nil, nil, time.Duration(0), 0, err
 	}
-	return gc.onRequestWait(ctx, info)
+	return gc.onRequestWait(ctx, info, priority)
 }
 
 // OnResponse is used to consume tokens after receiving response
@@ -570,7 +599,12 @@ func (c *ResourceGroupsController) OnResponse(
 		log.Warn("[resource group controller] resource group name does not exist", zap.String("name", resourceGroupName))
 		return &rmpb.Consumption{}, nil
 	}
-	return tmp.(*groupCostController).onResponse(req, resp)
+	gc := tmp.(*groupCostController)
+	delta, err := gc.onResponse(req, resp)
+	if err == nil {
+		c.checkRunaway(gc, req, resp, delta)
+	}
+	return delta, err
 }
 
 // IsBackgroundRequest If the resource group has background jobs, we should not record consumption and wait for it.
@@ -623,6 +657,8 @@ type groupCostController struct {
 	// meta info
 	meta     *rmpb.ResourceGroup
 	metaLock sync.RWMutex
+	// runawayPolicy is converted from the runaway settings of the meta when the meta is set.
+	runawayPolicy *RunawayPolicy
 
 	// following fields are used for token limiter.
 	calculators    []ResourceCalculator
@@ -681,6 +717,7 @@ type groupMetricsCollection struct {
 	successfulRequestDuration         prometheus.Observer
 	failedLimitReserveDuration        prometheus.Observer
 	requestRetryCounter               prometheus.Counter
+	lowPriorityQueuedCounter          prometheus.Counter
 	failedRequestCounterWithOthers    prometheus.Counter
 	failedRequestCounterWithThrottled prometheus.Counter
 	tokenRequestCounter               prometheus.Counter
@@ -697,6 +734,7 @@ func initMetrics(oldName, name string) *groupMetricsCollection {
 		failedRequestCounterWithOthers:    failedRequestCounter.WithLabelValues(oldName, name, otherType),
 		failedRequestCounterWithThrottled: failedRequestCounter.WithLabelValues(oldName, name, throttledType),
 		requestRetryCounter:               requestRetryCounter.WithLabelValues(oldName, name),
+		lowPriorityQueuedCounter:          lowPriorityQueuedCounter.WithLabelValues(oldName, name),
 		tokenRequestCounter:               resourceGroupTokenRequestCounter.WithLabelValues(oldName, name),
 	}
 }
@@ -764,6 +802,7 @@ func newGroupCostController(
 		gc.handleRespFunc = gc.handleRawResourceTokenResponse
 	}
 
+	gc.runawayPolicy = runawayPolicyFromSettings(group.GetRunawaySettings())
 	gc.mu.consumption = &rmpb.Consumption{}
 	gc.mu.storeCounter = make(map[uint64]*rmpb.Consumption)
 	gc.mu.globalCounter = &rmpb.Consumption{}
@@ -1215,6 +1254,13 @@ func (gc *groupCostController) modifyMeta(newMeta *rmpb.ResourceGroup) {
 	gc.metaLock.Lock()
 	defer gc.metaLock.Unlock()
 	gc.meta = newMeta
+	gc.runawayPolicy = runawayPolicyFromSettings(newMeta.GetRunawaySettings())
+}
+
+func (gc *groupCostController) getRunawayPolicy() *RunawayPolicy {
+	gc.metaLock.RLock()
+	defer gc.metaLock.RUnlock()
+	return gc.runawayPolicy
 }
 
 func (gc *groupCostController) calcRequest(counter *tokenCounter) float64 {
@@ -1230,7 +1276,7 @@ func (gc *groupCostController) calcRequest(counter *tokenCounter) float64 {
 }
 
 func (gc *groupCostController) onRequestWait(
-	ctx context.Context, info RequestInfo,
+	ctx context.Context, info RequestInfo, priority RequestPriority,
 ) (*rmpb.Consumption, *rmpb.Consumption, time.Duration, uint32, error) {
 	delta := &rmpb.Consumption{}
 	for _, calc := range gc.calculators {
@@ -1243,37 +1289,14 @@ func (gc *groupCostController) onRequestWait(
 	var waitDuration time.Duration
 
 	if !gc.burstable.Load() {
-		var err error
-		now := time.Now()
-		var i int
-		var d time.Duration
-	retryLoop:
-		for i = 0; i < gc.mainCfg.WaitRetryTimes; i++ {
-			switch gc.mode {
-			case rmpb.GroupMode_RawMode:
-				res := make([]*Reservation, 0, len(requestResourceLimitTypeList))
-				for typ, counter := range gc.run.resourceTokens {
-					if v := getRawResourceValueFromConsumption(delta, typ); v > 0 {
-						res = append(res, counter.limiter.Reserve(ctx, gc.mainCfg.LTBMaxWaitDuration, now, v))
-					}
-				}
-				if d, err = WaitReservations(ctx, now, res); err == nil || errs.ErrClientResourceGroupThrottled.NotEqual(err) {
-					break retryLoop
-				}
-			case rmpb.GroupMode_RUMode:
-				res := make([]*Reservation, 0, len(requestUnitLimitTypeList))
-				for typ, counter := range gc.run.requestUnitTokens {
-					if v := getRUValueFromConsumption(delta, typ); v > 0 {
-						res = append(res, counter.limiter.Reserve(ctx, gc.mainCfg.LTBMaxWaitDuration, now, v))
-					}
-				}
-				if d, err = WaitReservations(ctx, now, res); err == nil || errs.ErrClientResourceGroupThrottled.NotEqual(err) {
-					break retryLoop
-				}
-			}
-			gc.metrics.requestRetryCounter.Inc()
-			time.Sleep(gc.mainCfg.WaitRetryInterval)
-			waitDuration += gc.mainCfg.WaitRetryInterval
+		var (
+			d   time.Duration
+			err error
+		)
+		if priority == RequestPriorityLow {
+			d, waitDuration, err = gc.waitLowPriorityTokens(ctx, delta)
+		} else {
+			d, waitDuration, err = gc.waitTokens(ctx, delta, gc.mainCfg.LTBMaxWaitDuration)
 		}
 		if err != nil {
 			if errs.ErrClientResourceGroupThrottled.Equal(err) {
@@ -1311,6 +1334,67 @@ func (gc *groupCostController) onRequestWait(
 	return delta, penalty, waitDuration, gc.getMeta().GetPriority(), nil
 }
 
+// forEachLimiter calls f with the limiters of the group and the tokens the request needs from
+// them, until f returns false.
+func (gc *groupCostController) forEachLimiter(delta *rmpb.Consumption, f func(lim *Limiter, v float64) bool) {
+	switch gc.mode {
+	case rmpb.GroupMode_RawMode:
+		for typ, counter := range gc.run.resourceTokens {
+			if v := getRawResourceValueFromConsumption(delta, typ); v > 0 && !f(counter.limiter, v) {
+				return
+			}
+		}
+	case rmpb.GroupMode_RUMode:
+		for typ, counter := range gc.run.requestUnitTokens {
+			if v := getRUValueFromConsumption(delta, typ); v > 0 && !f(counter.limiter, v) {
+				return
+			}
+		}
+	}
+}
+
+// waitTokens waits for the tokens of the request, it retries for `WaitRetryTimes` times if the
+// request is throttled. It returns the wait duration of the reservations and the retries.
+func (gc *groupCostController) waitTokens(ctx context.Context, delta *rmpb.Consumption, maxWait time.Duration) (d, waitDuration time.Duration, err error) {
+	now := time.Now()
+	for i := 0; i < gc.mainCfg.WaitRetryTimes; i++ {
+		res := make([]*Reservation, 0, len(requestResourceLimitTypeList))
+		gc.forEachLimiter(delta, func(lim *Limiter, v float64) bool {
+			res = append(res, lim.Reserve(ctx, maxWait, now, v))
+			return true
+		})
+		if d, err = WaitReservations(ctx, now, res); err == nil || errs.ErrClientResourceGroupThrottled.NotEqual(err) {
+			break
+		}
+		gc.metrics.requestRetryCounter.Inc()
+		time.Sleep(gc.mainCfg.WaitRetryInterval)
+		waitDuration += gc.mainCfg.WaitRetryInterval
+	}
+	return d, waitDuration, err
+}
+
+// waitLowPriorityTokens waits for the tokens of the low priority request. The request queues in
+// the limiters until it's admitted by all of them, see `Limiter.WaitLowPriority`, then reserves
+// the tokens like the other requests. So the high priority requests are served first when the
+// tokens are scarce, while the low priority ones still get `LowPriorityShare` of the rate.
+func (gc *groupCostController) waitLowPriorityTokens(ctx context.Context, delta *rmpb.Consumption) (d, waitDuration time.Duration, err error) {
+	maxWait := gc.mainCfg.LTBMaxWaitDuration
+	gc.forEachLimiter(delta, func(lim *Limiter, v float64) bool {
+		var queued time.Duration
+		queued, err = lim.WaitLowPriority(ctx, gc.mainCfg.LowPriorityShare, maxWait-waitDuration, v)
+		if queued > 0 {
+			gc.metrics.lowPriorityQueuedCounter.Inc()
+		}
+		waitDuration += queued
+		return err == nil
+	})
+	if err != nil {
+		return 0, waitDuration, err
+	}
+	d, retryDuration, err := gc.waitTokens(ctx, delta, maxWait-waitDuration)
+	return d, waitDuration + retryDuration, err
+}
+
 func (gc *groupCostController) onResponse(
 	req RequestInfo, resp ResponseInfo,
 ) (*rmpb.Consumption, error) {
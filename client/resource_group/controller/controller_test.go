@@ -106,7 +106,7 @@ func TestRequestAndResponseConsumption(t *testing.T) {
 	kvCalculator := gc.getKVCalculator()
 	for idx, testCase := range testCases {
 		caseNum := fmt.Sprintf("case %d", idx)
-		consumption, _, _, priority, err := gc.onRequestWait(context.TODO(), testCase.req)
+		consumption, _, _, priority, err := gc.onRequestWait(context.TODO(), testCase.req, RequestPriorityHigh)
 		re.NoError(err, caseNum)
 		re.Equal(priority, gc.meta.Priority)
 		expectedConsumption := &rmpb.Consumption{}
@@ -132,11 +132,37 @@ func TestResourceGroupThrottledError(t *testing.T) {
 		writeBytes: 10000000,
 	}
 	// The group is throttled
-	_, _, _, _, err := gc.onRequestWait(context.TODO(), req)
+	_, _, _, _, err := gc.onRequestWait(context.TODO(), req, RequestPriorityHigh)
 	re.Error(err)
 	re.True(errs.ErrClientResourceGroupThrottled.Equal(err))
 }
 
+func TestLowPriorityRequestWait(t *testing.T) {
+	re := require.New(t)
+	gc := createTestGroupCostController(re)
+	gc.initRunState()
+	gc.mainCfg.LTBMaxWaitDuration = 200 * time.Millisecond
+	gc.mainCfg.WaitRetryInterval = 10 * time.Millisecond
+
+	req := &TestRequestInfo{
+		isWrite:    true,
+		writeBytes: 100,
+	}
+	_, _, _, _, err := gc.onRequestWait(context.TODO(), req, RequestPriorityLow)
+	re.NoError(err)
+	// The low priority request is throttled if it can't be admitted within the max wait duration.
+	req.writeBytes = 10000000
+	_, _, _, _, err = gc.onRequestWait(context.TODO(), req, RequestPriorityLow)
+	re.Error(err)
+	re.True(errs.ErrClientResourceGroupThrottled.Equal(err))
+	// The canceled low priority request fails.
+	req.writeBytes = 100
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, _, _, _, err = gc.onRequestWait(ctx, req, RequestPriorityLow)
+	re.ErrorIs(err, context.Canceled)
+}
+
 // MockResourceGroupProvider is a mock implementation of the ResourceGroupProvider interface.
 type MockResourceGroupProvider struct {
 	mock.Mock
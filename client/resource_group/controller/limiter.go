@@ -19,6 +19,7 @@
 package controller
 
 import (
+	"container/list"
 	"context"
 	"fmt"
 	"math"
@@ -84,6 +85,14 @@ type Limiter struct {
 	remainingNotifyTimes int
 	name                 string
 
+	// lowPriorityQueue is the FIFO queue of the low priority requests waiting to be admitted,
+	// see WaitLowPriority. lowPriorityCredit is the tokens accumulated for the head of it.
+	lowPriorityQueue      *list.List
+	lowPriorityCredit     float64
+	lowPriorityCreditLast time.Time
+	// lowPriorityChanged is closed and replaced once the queue or the rate changes.
+	lowPriorityChanged chan struct{}
+
 	// metrics
 	metrics *limiterMetricsCollection
 }
@@ -238,6 +247,118 @@ func (lim *Limiter) Reserve(ctx context.Context, waitDuration time.Duration, now
 	return &r
 }
 
+// WaitLowPriority waits until the low priority request of n tokens is admitted, after which its
+// tokens should be reserved by Reserve as usual. The low priority requests are admitted in FIFO
+// order, and only once the tokens are enough for the head of them, i.e, after the reservations of
+// the high priority requests are paid off, so the high priority requests are served first when the
+// tokens are scarce. To bound the starvation, the head also accumulates the tokens at the share of
+// the rate, and is admitted once they are enough for it even if the high priority requests hold
+// the tokens. It returns the queued duration, and the error if the request can't be admitted
+// within maxWait.
+func (lim *Limiter) WaitLowPriority(ctx context.Context, share float64, maxWait time.Duration, n float64) (time.Duration, error) {
+	start := time.Now()
+	deadline := start.Add(maxWait)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	lim.mu.Lock()
+	if lim.lowPriorityQueue == nil {
+		lim.lowPriorityQueue = list.New()
+		lim.lowPriorityChanged = make(chan struct{})
+	}
+	elem := lim.lowPriorityQueue.PushBack(n)
+	if lim.lowPriorityQueue.Len() == 1 {
+		lim.resetLowPriorityCreditLocked(start)
+	}
+	lim.mu.Unlock()
+	defer func() {
+		lim.mu.Lock()
+		defer lim.mu.Unlock()
+		if lim.lowPriorityQueue.Front() == elem {
+			lim.resetLowPriorityCreditLocked(time.Now())
+		}
+		lim.lowPriorityQueue.Remove(elem)
+		lim.notifyLowPriorityChangedLocked()
+	}()
+
+	for queued := false; ; queued = true {
+		now := time.Now()
+		lim.mu.Lock()
+		wait, admitted := lim.admitLowPriorityLocked(now, elem, share, n)
+		changed := lim.lowPriorityChanged
+		lim.mu.Unlock()
+		if admitted {
+			if !queued {
+				return 0, nil
+			}
+			return now.Sub(start), nil
+		}
+		remaining := deadline.Sub(now)
+		if remaining <= 0 || (wait != InfDuration && wait > remaining) {
+			// The request can't be admitted in time even if no more high priority request comes.
+			return now.Sub(start), errs.ErrClientResourceGroupThrottled.FastGenByArgs(wait, lim.Limit(), lim.AvailableTokens(now))
+		}
+		if wait > remaining {
+			wait = remaining
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return time.Since(start), ctx.Err()
+		case <-changed:
+		case <-t.C:
+		}
+		t.Stop()
+	}
+}
+
+// admitLowPriorityLocked returns whether the low priority request of the element is admitted,
+// otherwise the duration to wait before checking it again. The duration is InfDuration if the
+// request is not the head of the queue, which waits for the requests ahead of it.
+func (lim *Limiter) admitLowPriorityLocked(now time.Time, elem *list.Element, share, n float64) (time.Duration, bool) {
+	if lim.burst < 0 || lim.limit == Inf {
+		return 0, true
+	}
+	if lim.lowPriorityQueue.Front() != elem {
+		return InfDuration, false
+	}
+	_, _, tokens := lim.advance(now)
+	if tokens >= n {
+		return 0, true
+	}
+	shareLimit := Limit(float64(lim.limit) * share)
+	if now.After(lim.lowPriorityCreditLast) {
+		lim.lowPriorityCredit += shareLimit.tokensFromDuration(now.Sub(lim.lowPriorityCreditLast))
+		lim.lowPriorityCreditLast = now
+	}
+	if lim.lowPriorityCredit >= n {
+		return 0, true
+	}
+	if lim.limit == 0 {
+		lim.notify()
+	} else {
+		lim.maybeNotify()
+	}
+	wait := lim.limit.durationFromTokens(n - tokens)
+	if creditWait := shareLimit.durationFromTokens(n - lim.lowPriorityCredit); creditWait < wait {
+		wait = creditWait
+	}
+	return wait, false
+}
+
+func (lim *Limiter) resetLowPriorityCreditLocked(now time.Time) {
+	lim.lowPriorityCredit = 0
+	lim.lowPriorityCreditLast = now
+}
+
+func (lim *Limiter) notifyLowPriorityChangedLocked() {
+	if lim.lowPriorityChanged != nil {
+		close(lim.lowPriorityChanged)
+		lim.lowPriorityChanged = make(chan struct{})
+	}
+}
+
 // SetupNotificationThreshold enables the notification at the given threshold.
 func (lim *Limiter) SetupNotificationThreshold(now time.Time, threshold float64) {
 	lim.mu.Lock()
@@ -351,6 +472,7 @@ func (lim *Limiter) Reconfigure(now time.Time,
 		opt(lim)
 	}
 	lim.maybeNotify()
+	lim.notifyLowPriorityChangedLocked()
 	logControllerTrace("[resource group controller] after reconfigure", zap.String("name", lim.name), zap.Float64("tokens", lim.tokens), zap.Float64("rate", float64(lim.limit)), zap.Float64("notify-threshold", args.NotifyThreshold), zap.Int64("burst", lim.burst))
 }
 
@@ -19,6 +19,7 @@
 package controller
 
 import (
+	"container/list"
 	"context"
 	"fmt"
 	"math"
@@ -28,6 +29,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/client/errs"
 )
 
 const (
@@ -115,6 +117,74 @@ func TestSimpleReserve(t *testing.T) {
 	runReserveMax(t, lim, request{t5, 2000, t5, true})
 }
 
+func TestLowPriorityAdmission(t *testing.T) {
+	re := require.New(t)
+	lim := NewLimiter(t0, 1, 0, 2, make(chan notifyMsg, 1))
+	lim.lowPriorityQueue = list.New()
+	first, second := lim.lowPriorityQueue.PushBack(1), lim.lowPriorityQueue.PushBack(1)
+	lim.resetLowPriorityCreditLocked(t0)
+
+	// The tokens are enough for the low priority request.
+	_, admitted := lim.admitLowPriorityLocked(t0, first, 0.5, 1)
+	re.True(admitted)
+	// The high priority request holds the tokens, so the low priority ones wait for it.
+	runReserveMax(t, lim, request{t0, 3, t1, true})
+	wait, admitted := lim.admitLowPriorityLocked(t0, second, 0.5, 1)
+	re.False(admitted)
+	re.Equal(InfDuration, wait)
+	wait, admitted = lim.admitLowPriorityLocked(t0, first, 0.5, 1)
+	re.False(admitted)
+	re.Equal(2, dFromDuration(wait))
+	// More high priority requests come, the head is admitted by the share of the rate.
+	runReserveMax(t, lim, request{t0, 3, t4, true})
+	wait, admitted = lim.admitLowPriorityLocked(t1, first, 0.5, 1)
+	re.False(admitted)
+	re.Equal(1, dFromDuration(wait))
+	_, admitted = lim.admitLowPriorityLocked(t2, first, 0.5, 1)
+	re.True(admitted)
+	// Without the share, the head waits for all the high priority requests.
+	lim.resetLowPriorityCreditLocked(t2)
+	wait, admitted = lim.admitLowPriorityLocked(t2, first, 0, 1)
+	re.False(admitted)
+	re.Equal(3, dFromDuration(wait))
+}
+
+func TestWaitLowPriority(t *testing.T) {
+	re := require.New(t)
+	lim := NewLimiter(time.Now(), 100, 0, 0, make(chan notifyMsg, 1))
+	ctx := context.Background()
+
+	// The low priority request is throttled if it can't be admitted in time.
+	r := lim.Reserve(ctx, InfDuration, time.Now(), 100)
+	re.True(r.OK())
+	_, err := lim.WaitLowPriority(ctx, 0, 100*time.Millisecond, 1)
+	re.Error(err)
+	re.True(errs.ErrClientResourceGroupThrottled.Equal(err))
+
+	// The waiting low priority request is admitted once the limiter is reconfigured.
+	lim.Reconfigure(time.Now(), tokenBucketReconfigureArgs{NewRate: 0, NewBurst: 0})
+	var queued time.Duration
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		queued, err = lim.WaitLowPriority(ctx, 0, time.Minute, 1)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	lim.Reconfigure(time.Now(), tokenBucketReconfigureArgs{NewTokens: 200, NewRate: 100, NewBurst: 0})
+	<-done
+	re.NoError(err)
+	re.Positive(queued)
+
+	// The canceled low priority request leaves the queue.
+	r = lim.Reserve(ctx, InfDuration, time.Now(), 1000)
+	re.True(r.OK())
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = lim.WaitLowPriority(ctx, 0, time.Minute, 1)
+	re.ErrorIs(err, context.Canceled)
+	re.Zero(lim.lowPriorityQueue.Len())
+}
+
 func TestReconfig(t *testing.T) {
 	re := require.New(t)
 	lim := NewLimiter(t0, 1, 0, 2, make(chan notifyMsg, 1))
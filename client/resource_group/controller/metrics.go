@@ -25,7 +25,8 @@ const (
 	resourceGroupNameLabel    = "name"
 	newResourceGroupNameLabel = "resource_group"
 
-	errType = "type"
+	errType       = "type"
+	runawayAction = "action"
 )
 
 var (
@@ -71,6 +72,22 @@ var (
 			Help:      "Counter of retry time for request.",
 		}, []string{resourceGroupNameLabel, newResourceGroupNameLabel})
 
+	lowPriorityQueuedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: requestSubsystem,
+			Name:      "low_priority_queued",
+			Help:      "Counter of the times the low priority request queued for the tokens.",
+		}, []string{resourceGroupNameLabel, newResourceGroupNameLabel})
+
+	runawayViolationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: requestSubsystem,
+			Name:      "runaway",
+			Help:      "Counter of the requests violating the runaway policy.",
+		}, []string{newResourceGroupNameLabel, runawayAction})
+
 	tokenRequestDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: namespace,
@@ -109,6 +126,8 @@ func init() {
 	prometheus.MustRegister(failedRequestCounter)
 	prometheus.MustRegister(failedLimitReserveDuration)
 	prometheus.MustRegister(requestRetryCounter)
+	prometheus.MustRegister(lowPriorityQueuedCounter)
+	prometheus.MustRegister(runawayViolationCounter)
 	prometheus.MustRegister(tokenRequestDuration)
 	prometheus.MustRegister(resourceGroupTokenRequestCounter)
 	prometheus.MustRegister(lowTokenRequestNotifyCounter)
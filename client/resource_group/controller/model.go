@@ -39,6 +39,19 @@ type RequestInfo interface {
 	StoreID() uint64
 }
 
+// RequestPriority is the priority of the request within its resource group, which is passed to
+// `ResourceGroupPriorityKVInterceptor.OnRequestWaitWithPriority`. When the tokens of the group are
+// scarce, the high priority requests are served first, while the low priority ones still get a
+// bounded share of the tokens.
+type RequestPriority int
+
+const (
+	// RequestPriorityHigh is the priority of the interactive requests, which is the default one.
+	RequestPriorityHigh RequestPriority = iota
+	// RequestPriorityLow is the priority of the background requests.
+	RequestPriorityLow
+)
+
 // ResponseInfo is the interface of the response information provider. A response should be
 // able to tell how many bytes it read and KV CPU cost in milliseconds.
 type ResponseInfo interface {
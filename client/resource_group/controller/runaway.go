@@ -0,0 +1,295 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/pingcap/log"
+	pd "github.com/tikv/pd/client"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const defaultRunawayReportInterval = 10 * time.Second
+
+// The RU threshold of the runaway rule and the switch group action are not defined by the kvproto
+// the client depends on, they are decoded from the wire format of the runaway settings.
+const (
+	runawaySettingsRuleField        protowire.Number = 1
+	runawaySettingsSwitchGroupField protowire.Number = 4
+	runawayRuleRequestUnitField     protowire.Number = 3
+	// runawayActionSwitchGroup switches the request to another resource group.
+	runawayActionSwitchGroup rmpb.RunawayAction = 4
+)
+
+// RunawayPolicy is the policy to detect the runaway requests of a resource group, which is converted
+// from the runaway settings of the resource group. A request is runaway if it exceeds any of the thresholds.
+type RunawayPolicy struct {
+	// MaxRUPerRequest is the max RU consumed by a request, 0 means no limit.
+	MaxRUPerRequest float64
+	// MaxExecElapsedTimeMs is the max execution time of a request, 0 means no limit.
+	MaxExecElapsedTimeMs uint64
+	Action               rmpb.RunawayAction
+	// SwitchGroup is the resource group to switch to, only used by the switch group action.
+	SwitchGroup string
+}
+
+// runawayPolicyFromSettings converts the runaway settings of the resource group meta to the policy,
+// nil if no threshold is set.
+func runawayPolicyFromSettings(settings *rmpb.RunawaySettings) *RunawayPolicy {
+	if settings == nil {
+		return nil
+	}
+	policy := &RunawayPolicy{
+		MaxExecElapsedTimeMs: settings.GetRule().GetExecElapsedTimeMs(),
+		Action:               settings.GetAction(),
+	}
+	data, err := proto.Marshal(settings)
+	if err != nil {
+		log.Warn("[resource group controller] failed to marshal runaway settings", zap.Error(err))
+		return nil
+	}
+	rangeFields(data, func(num protowire.Number, typ protowire.Type, value []byte) {
+		switch {
+		case num == runawaySettingsRuleField && typ == protowire.BytesType:
+			rangeFields(value, func(num protowire.Number, typ protowire.Type, value []byte) {
+				if num == runawayRuleRequestUnitField && typ == protowire.VarintType {
+					if ru, n := protowire.ConsumeVarint(value); n > 0 {
+						policy.MaxRUPerRequest = float64(int64(ru))
+					}
+				}
+			})
+		case num == runawaySettingsSwitchGroupField && typ == protowire.BytesType:
+			policy.SwitchGroup = string(value)
+		}
+	})
+	if policy.MaxRUPerRequest <= 0 && policy.MaxExecElapsedTimeMs == 0 {
+		return nil
+	}
+	return policy
+}
+
+// rangeFields calls f with the number, the type and the value of each field in the wire format,
+// the value of the bytes field excludes the length prefix.
+func rangeFields(data []byte, f func(num protowire.Number, typ protowire.Type, value []byte)) {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return
+		}
+		data = data[n:]
+		n = protowire.ConsumeFieldValue(num, typ, data)
+		if n < 0 {
+			return
+		}
+		value := data[:n]
+		if typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(value)
+		}
+		f(num, typ, value)
+		data = data[n:]
+	}
+}
+
+// runawayActionName returns the name of the action reported to the resource manager.
+func runawayActionName(action rmpb.RunawayAction) string {
+	if action == runawayActionSwitchGroup {
+		return "SwitchGroup"
+	}
+	return action.String()
+}
+
+// ExecElapsedTimeInfo is optionally implemented by the ResponseInfo to report the execution
+// time of the request, which is required to check the execution time threshold.
+type ExecElapsedTimeInfo interface {
+	ExecElapsedTime() time.Duration
+}
+
+// RunawayViolation is the request which violates the runaway policy.
+type RunawayViolation struct {
+	ResourceGroupName string
+	Action            rmpb.RunawayAction
+	SwitchGroup       string
+	// RU is the RU consumed by the request.
+	RU float64
+	// ExecElapsedTime is the execution time of the request, 0 if it's not reported.
+	ExecElapsedTime         time.Duration
+	RUExceeded              bool
+	ExecElapsedTimeExceeded bool
+}
+
+// WithRunawayHandler is the option to set the handler called when a request violates the runaway
+// policy, the caller is expected to take the action of the violation, e.g, kill the request.
+func WithRunawayHandler(handler func(*RunawayViolation)) ResourceControlCreateOption {
+	return func(controller *ResourceGroupsController) {
+		controller.runaway.handler = handler
+	}
+}
+
+// runawayChecker checks the responses against the runaway policies and collects the violations.
+type runawayChecker struct {
+	handler func(*RunawayViolation)
+
+	mu sync.Mutex
+	// reports is the violations of the resource groups since the last report.
+	reports map[string]*pd.RunawayViolationReport
+}
+
+func newRunawayChecker() *runawayChecker {
+	return &runawayChecker{
+		reports: make(map[string]*pd.RunawayViolationReport),
+	}
+}
+
+// check returns the violation if the request violates the policy, otherwise nil.
+func (rc *runawayChecker) check(name string, policy *RunawayPolicy, ru float64, resp ResponseInfo) *RunawayViolation {
+	var elapsed time.Duration
+	if info, ok := resp.(ExecElapsedTimeInfo); ok {
+		elapsed = info.ExecElapsedTime()
+	}
+	ruExceeded := policy.MaxRUPerRequest > 0 && ru > policy.MaxRUPerRequest
+	elapsedExceeded := policy.MaxExecElapsedTimeMs > 0 && uint64(elapsed.Milliseconds()) > policy.MaxExecElapsedTimeMs
+	if !ruExceeded && !elapsedExceeded {
+		return nil
+	}
+	violation := &RunawayViolation{
+		ResourceGroupName:       name,
+		Action:                  policy.Action,
+		SwitchGroup:             policy.SwitchGroup,
+		RU:                      ru,
+		ExecElapsedTime:         elapsed,
+		RUExceeded:              ruExceeded,
+		ExecElapsedTimeExceeded: elapsedExceeded,
+	}
+	rc.record(violation)
+	runawayViolationCounter.WithLabelValues(name, runawayActionName(policy.Action)).Inc()
+	if rc.handler != nil {
+		rc.handler(violation)
+	}
+	return violation
+}
+
+func (rc *runawayChecker) record(violation *RunawayViolation) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	report := &pd.RunawayViolationReport{
+		Name:                 violation.ResourceGroupName,
+		Count:                1,
+		ActionCount:          map[string]uint64{runawayActionName(violation.Action): 1},
+		MaxRU:                violation.RU,
+		MaxExecElapsedTimeMs: uint64(violation.ExecElapsedTime.Milliseconds()),
+		LastViolationTime:    time.Now(),
+	}
+	if violation.RUExceeded {
+		report.RUExceededCount = 1
+	}
+	if violation.ExecElapsedTimeExceeded {
+		report.ExecElapsedTimeExceededCount = 1
+	}
+	rc.mergeLocked(report)
+}
+
+func (rc *runawayChecker) mergeLocked(report *pd.RunawayViolationReport) {
+	merged, ok := rc.reports[report.Name]
+	if !ok {
+		merged = &pd.RunawayViolationReport{
+			Name:        report.Name,
+			ActionCount: make(map[string]uint64),
+		}
+		rc.reports[report.Name] = merged
+	}
+	merged.Count += report.Count
+	for action, count := range report.ActionCount {
+		merged.ActionCount[action] += count
+	}
+	merged.RUExceededCount += report.RUExceededCount
+	merged.ExecElapsedTimeExceededCount += report.ExecElapsedTimeExceededCount
+	if report.MaxRU > merged.MaxRU {
+		merged.MaxRU = report.MaxRU
+	}
+	if report.MaxExecElapsedTimeMs > merged.MaxExecElapsedTimeMs {
+		merged.MaxExecElapsedTimeMs = report.MaxExecElapsedTimeMs
+	}
+	if report.LastViolationTime.After(merged.LastViolationTime) {
+		merged.LastViolationTime = report.LastViolationTime
+	}
+}
+
+// takeReports returns the violations since the last report and clears them.
+func (rc *runawayChecker) takeReports() []*pd.RunawayViolationReport {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	reports := make([]*pd.RunawayViolationReport, 0, len(rc.reports))
+	for _, report := range rc.reports {
+		reports = append(reports, report)
+	}
+	rc.reports = make(map[string]*pd.RunawayViolationReport)
+	return reports
+}
+
+// restoreReports merges the reports back to be reported again after the report fails.
+func (rc *runawayChecker) restoreReports(reports []*pd.RunawayViolationReport) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, report := range reports {
+		rc.mergeLocked(report)
+	}
+}
+
+// GetRunawayPolicy returns the runaway policy of the resource group, nil if it's not set.
+func (c *ResourceGroupsController) GetRunawayPolicy(resourceGroupName string) *RunawayPolicy {
+	tmp, ok := c.groupsController.Load(resourceGroupName)
+	if !ok {
+		return nil
+	}
+	return tmp.(*groupCostController).getRunawayPolicy()
+}
+
+// checkRunaway checks the response against the runaway policy of the resource group.
+func (c *ResourceGroupsController) checkRunaway(gc *groupCostController, req RequestInfo, resp ResponseInfo, delta *rmpb.Consumption) {
+	policy := gc.getRunawayPolicy()
+	if policy == nil {
+		return
+	}
+	// The RU of the request includes the write cost which is added before the request is sent.
+	consumption := *delta
+	for _, calc := range gc.calculators {
+		calc.BeforeKVRequest(&consumption, req)
+	}
+	c.runaway.check(gc.name, policy, consumption.RRU+consumption.WRU, resp)
+}
+
+// reportRunawayViolations reports the violations since the last report to the resource manager,
+// which aggregates the reports of all the clients. The violations are kept until they are
+// reported if the provider is not able to report them.
+func (c *ResourceGroupsController) reportRunawayViolations(ctx context.Context) {
+	reporter, ok := c.provider.(pd.RunawayViolationReporter)
+	if !ok {
+		return
+	}
+	reports := c.runaway.takeReports()
+	if len(reports) == 0 {
+		return
+	}
+	if err := reporter.ReportRunawayViolations(ctx, reports); err != nil {
+		log.Warn("[resource group controller] failed to report runaway violations", zap.Error(err))
+		c.runaway.restoreReports(reports)
+	}
+}
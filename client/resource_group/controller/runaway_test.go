@@ -0,0 +1,164 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/meta_storagepb"
+	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	pd "github.com/tikv/pd/client"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type testExecResponseInfo struct {
+	*TestResponseInfo
+	elapsed time.Duration
+}
+
+func (r *testExecResponseInfo) ExecElapsedTime() time.Duration {
+	return r.elapsed
+}
+
+type mockRunawayReporter struct {
+	*MockResourceGroupProvider
+	err     error
+	reports [][]*pd.RunawayViolationReport
+}
+
+func (m *mockRunawayReporter) ReportRunawayViolations(_ context.Context, reports []*pd.RunawayViolationReport) error {
+	m.reports = append(m.reports, reports)
+	return m.err
+}
+
+func TestRunawayPolicyFromSettings(t *testing.T) {
+	re := require.New(t)
+	re.Nil(runawayPolicyFromSettings(nil))
+	re.Nil(runawayPolicyFromSettings(&rmpb.RunawaySettings{Action: rmpb.RunawayAction_Kill}))
+
+	policy := runawayPolicyFromSettings(&rmpb.RunawaySettings{
+		Rule:   &rmpb.RunawayRule{ExecElapsedTimeMs: 100},
+		Action: rmpb.RunawayAction_CoolDown,
+	})
+	re.Equal(&RunawayPolicy{MaxExecElapsedTimeMs: 100, Action: rmpb.RunawayAction_CoolDown}, policy)
+
+	// The RU threshold and the switch group are decoded from the wire format.
+	policy = runawayPolicyFromSettings(newSwitchGroupSettings(100, "other"))
+	re.Equal(&RunawayPolicy{MaxRUPerRequest: 100, Action: runawayActionSwitchGroup, SwitchGroup: "other"}, policy)
+	re.Equal("SwitchGroup", runawayActionName(policy.Action))
+	re.Equal("Kill", runawayActionName(rmpb.RunawayAction_Kill))
+}
+
+// newSwitchGroupSettings builds the runaway settings with the RU threshold and the switch group
+// action, which are not defined by the kvproto of the client.
+func newSwitchGroupSettings(ru uint64, switchGroup string) *rmpb.RunawaySettings {
+	rule := protowire.AppendTag(nil, runawayRuleRequestUnitField, protowire.VarintType)
+	rule = protowire.AppendVarint(rule, ru)
+	settings := protowire.AppendTag(nil, runawaySettingsSwitchGroupField, protowire.BytesType)
+	settings = protowire.AppendString(settings, switchGroup)
+	return &rmpb.RunawaySettings{
+		Rule:             &rmpb.RunawayRule{XXX_unrecognized: rule},
+		Action:           runawayActionSwitchGroup,
+		XXX_unrecognized: settings,
+	}
+}
+
+func TestRunawayViolation(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mockProvider := &mockRunawayReporter{MockResourceGroupProvider: new(MockResourceGroupProvider)}
+	mockProvider.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&meta_storagepb.GetResponse{}, nil)
+	var violations []*RunawayViolation
+	controller, err := NewResourceGroupController(ctx, 1, mockProvider, nil, WithRunawayHandler(func(v *RunawayViolation) {
+		violations = append(violations, v)
+	}))
+	re.NoError(err)
+	gc := createTestGroupCostController(re)
+	gc.initRunState()
+	controller.groupsController.Store(gc.name, gc)
+
+	// No policy is set.
+	req := NewTestRequestInfo(false, 0, 1)
+	_, err = controller.OnResponse(gc.name, req, NewTestResponseInfo(1024*1024*1024, 0, true))
+	re.NoError(err)
+	re.Empty(violations)
+
+	gc.modifyMeta(&rmpb.ResourceGroup{
+		Name:       gc.name,
+		Mode:       rmpb.GroupMode_RUMode,
+		RUSettings: gc.meta.RUSettings,
+		RunawaySettings: &rmpb.RunawaySettings{
+			Rule:   &rmpb.RunawayRule{ExecElapsedTimeMs: 100},
+			Action: rmpb.RunawayAction_Kill,
+		},
+	})
+	resp := &testExecResponseInfo{TestResponseInfo: NewTestResponseInfo(0, 0, true), elapsed: time.Second}
+	_, err = controller.OnResponse(gc.name, req, resp)
+	re.NoError(err)
+	re.Len(violations, 1)
+	re.Equal(rmpb.RunawayAction_Kill, violations[0].Action)
+	re.True(violations[0].ExecElapsedTimeExceeded)
+	re.False(violations[0].RUExceeded)
+
+	// The policy is converted once when the meta is modified.
+	gc.modifyMeta(&rmpb.ResourceGroup{
+		Name:            gc.name,
+		Mode:            rmpb.GroupMode_RUMode,
+		RUSettings:      gc.meta.RUSettings,
+		RunawaySettings: newSwitchGroupSettings(100, "other"),
+	})
+	policy := controller.GetRunawayPolicy(gc.name)
+	re.Equal(&RunawayPolicy{MaxRUPerRequest: 100, Action: runawayActionSwitchGroup, SwitchGroup: "other"}, policy)
+	re.Same(policy, controller.GetRunawayPolicy(gc.name))
+	_, err = controller.OnResponse(gc.name, req, resp)
+	re.NoError(err)
+	re.Len(violations, 1)
+	_, err = controller.OnResponse(gc.name, req, NewTestResponseInfo(1024*1024*1024, 0, true))
+	re.NoError(err)
+	re.Len(violations, 2)
+	re.Equal(runawayActionSwitchGroup, violations[1].Action)
+	re.Equal("other", violations[1].SwitchGroup)
+	re.True(violations[1].RUExceeded)
+	re.Greater(violations[1].RU, 100.0)
+
+	// The violations are kept if the report fails.
+	mockProvider.err = errors.New("report failed")
+	controller.reportRunawayViolations(ctx)
+	re.Len(mockProvider.reports, 1)
+	// The violations since the last report are reported only once.
+	mockProvider.err = nil
+	controller.reportRunawayViolations(ctx)
+	controller.reportRunawayViolations(ctx)
+	re.Len(mockProvider.reports, 2)
+	re.Len(mockProvider.reports[1], 1)
+	report := mockProvider.reports[1][0]
+	re.Equal(gc.name, report.Name)
+	re.Equal(uint64(2), report.Count)
+	re.Equal(uint64(1), report.ActionCount["Kill"])
+	re.Equal(uint64(1), report.ActionCount["SwitchGroup"])
+	re.Equal(uint64(1), report.RUExceededCount)
+	re.Equal(uint64(1), report.ExecElapsedTimeExceededCount)
+	re.Equal(uint64(1000), report.MaxExecElapsedTimeMs)
+
+	// The policy is removed with the runaway settings.
+	gc.modifyMeta(&rmpb.ResourceGroup{Name: gc.name, Mode: rmpb.GroupMode_RUMode, RUSettings: gc.meta.RUSettings})
+	re.Nil(controller.GetRunawayPolicy(gc.name))
+}
@@ -16,13 +16,17 @@ package pd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/meta_storagepb"
 	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/pingcap/kvproto/pkg/tsopb"
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/client/errs"
 	"go.uber.org/zap"
@@ -35,6 +39,13 @@ const (
 	modify                     actionType = 1
 	groupSettingsPathPrefix               = "resource_group/settings"
 	controllerConfigPathPrefix            = "resource_group/controller"
+	// resourceManagerSvcDiscoveryFormat defines the primary key of the resource manager microservice,
+	// the entire key is in the format of "/ms/<cluster-id>/resource_manager/primary".
+	resourceManagerSvcDiscoveryFormat = msServiceRootPath + "/%d/resource_manager/primary"
+	// resourceManagerExtensionServiceName is the name of the gRPC service serving the resource
+	// manager requests which are not defined by the resource manager protocol.
+	// Note: keep the same as the one defined on the server side.
+	resourceManagerExtensionServiceName = "pd.resourcemanager.ResourceManagerExtension"
 	// errNotPrimary is returned when the requested server is not primary.
 	errNotPrimary = "not primary"
 	// errNotLeader is returned when the requested server is not pd leader.
@@ -47,6 +58,28 @@ var GroupSettingsPathPrefixBytes = []byte(groupSettingsPathPrefix)
 // ControllerConfigPathPrefixBytes is used to watch or get controller config.
 var ControllerConfigPathPrefixBytes = []byte(controllerConfigPathPrefix)
 
+// RunawayViolationReport is the runaway violations of a resource group detected by the client
+// since its last report, the resource manager aggregates the reports of all the clients.
+type RunawayViolationReport struct {
+	Name  string `json:"name"`
+	Count uint64 `json:"count"`
+	// ActionCount is the number of the violations by the action taken.
+	ActionCount                  map[string]uint64 `json:"action_count,omitempty"`
+	RUExceededCount              uint64            `json:"ru_exceeded_count"`
+	ExecElapsedTimeExceededCount uint64            `json:"exec_elapsed_time_exceeded_count"`
+	MaxRU                        float64           `json:"max_ru"`
+	MaxExecElapsedTimeMs         uint64            `json:"max_exec_elapsed_time_ms"`
+	LastViolationTime            time.Time         `json:"last_violation_time"`
+}
+
+// RunawayViolationReporter reports the runaway violations detected by the resource group
+// controller to the resource manager.
+type RunawayViolationReporter interface {
+	ReportRunawayViolations(ctx context.Context, reports []*RunawayViolationReport) error
+}
+
+var _ RunawayViolationReporter = (*client)(nil)
+
 // ResourceManagerClient manages resource group info and token request.
 type ResourceManagerClient interface {
 	ListResourceGroups(ctx context.Context) ([]*rmpb.ResourceGroup, error)
@@ -384,3 +417,61 @@ func (tbc *tokenBatchController) revokePendingTokenRequest(err error) {
 		req.done <- err
 	}
 }
+
+// discoverResourceManager returns the serving address of the resource manager. It's the primary of the
+// resource manager microservice if it's deployed, otherwise the PD leader which serves the resource manager.
+func (c *client) discoverResourceManager(ctx context.Context) (string, error) {
+	key := fmt.Sprintf(resourceManagerSvcDiscoveryFormat, c.pdSvcDiscovery.GetClusterID())
+	resp, err := c.Get(ctx, []byte(key))
+	if err != nil {
+		return "", err
+	}
+	if len(resp.GetKvs()) == 0 {
+		return c.GetLeaderAddr(), nil
+	}
+	// The participants of the microservices share the same wire format.
+	primary := &tsopb.Participant{}
+	if err := proto.Unmarshal(resp.GetKvs()[0].GetValue(), primary); err != nil {
+		return "", errs.ErrClientProtoUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	if len(primary.GetListenUrls()) == 0 {
+		log.Error("[resource_manager] the primary serving endpoint list is empty", zap.String("discovery-key", key))
+		return "", errs.ErrClientGetServingEndpoint
+	}
+	return primary.GetListenUrls()[0], nil
+}
+
+// ReportRunawayViolations reports the runaway violations to the extension service of the resource
+// manager. Since the messages are not defined by the resource manager protocol, they are encoded
+// in JSON and carried by BytesValue.
+func (c *client) ReportRunawayViolations(ctx context.Context, reports []*RunawayViolationReport) error {
+	addr, err := c.discoverResourceManager(ctx)
+	if err != nil {
+		return err
+	}
+	cc, err := c.pdSvcDiscovery.GetOrCreateGRPCConn(addr)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(&struct {
+		Violations []*RunawayViolationReport `json:"violations"`
+	}{reports})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	out := &types.BytesValue{}
+	if err = cc.Invoke(ctx, "/"+resourceManagerExtensionServiceName+"/ReportRunawayViolations", &types.BytesValue{Value: value}, out); err != nil {
+		c.gRPCErrorHandler(err)
+		return err
+	}
+	resp := &struct {
+		Error string `json:"error"`
+	}{}
+	if err = json.Unmarshal(out.GetValue(), resp); err != nil {
+		return errors.WithStack(err)
+	}
+	if len(resp.Error) > 0 {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/pingcap/kvproto/pkg/meta_storagepb"
+	"github.com/pingcap/kvproto/pkg/tsopb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type mockMetaStorageServer struct {
+	*meta_storagepb.UnimplementedMetaStorageServer
+	kvs map[string][]byte
+}
+
+func (m *mockMetaStorageServer) Get(_ context.Context, req *meta_storagepb.GetRequest) (*meta_storagepb.GetResponse, error) {
+	resp := &meta_storagepb.GetResponse{Header: &meta_storagepb.ResponseHeader{}}
+	if value, ok := m.kvs[string(req.GetKey())]; ok {
+		resp.Kvs = []*meta_storagepb.KeyValue{{Key: req.GetKey(), Value: value}}
+		resp.Count = 1
+	}
+	return resp, nil
+}
+
+// newMockRunawayReportServer starts a gRPC server serving the extension service of the resource
+// manager, which records the reported server and the request body.
+func newMockRunawayReportServer(re *require.Assertions, reported *string, body *map[string]interface{}, resp *string) (*grpc.Server, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	re.NoError(err)
+	addr := lis.Addr().String()
+	svr := grpc.NewServer()
+	svr.RegisterService(&grpc.ServiceDesc{
+		ServiceName: resourceManagerExtensionServiceName,
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "ReportRunawayViolations",
+			Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &types.BytesValue{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				*reported = addr
+				*body = nil
+				if err := json.Unmarshal(req.GetValue(), body); err != nil {
+					return nil, err
+				}
+				return &types.BytesValue{Value: []byte(*resp)}, nil
+			},
+		}},
+	}, struct{}{})
+	go svr.Serve(lis)
+	return svr, addr
+}
+
+func TestReportRunawayViolations(t *testing.T) {
+	re := require.New(t)
+	var (
+		reported string
+		body     map[string]interface{}
+		resp     = `{}`
+	)
+	leader, leaderAddr := newMockRunawayReportServer(re, &reported, &body, &resp)
+	defer leader.Stop()
+	metaStorage := &mockMetaStorageServer{kvs: make(map[string][]byte)}
+	meta_storagepb.RegisterMetaStorageServer(leader, metaStorage)
+	primary, primaryAddr := newMockRunawayReportServer(re, &reported, &body, &resp)
+	defer primary.Stop()
+
+	discovery := &pdServiceDiscovery{checkMembershipCh: make(chan struct{}, 1), option: newOption(), clusterID: 1}
+	discovery.leader.Store(leaderAddr)
+	for _, addr := range []string{leaderAddr, primaryAddr} {
+		cc, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		re.NoError(err)
+		defer cc.Close()
+		discovery.clientConns.Store(addr, cc)
+	}
+	c := &client{option: newOption(), pdSvcDiscovery: discovery}
+	ctx := context.Background()
+
+	// The PD leader serves the resource manager if the microservice is not deployed.
+	reports := []*RunawayViolationReport{{Name: "test", Count: 2, ActionCount: map[string]uint64{"Kill": 2}, MaxRU: 10}}
+	re.NoError(c.ReportRunawayViolations(ctx, reports))
+	re.Equal(leaderAddr, reported)
+	violations := body["violations"].([]interface{})
+	re.Len(violations, 1)
+	violation := violations[0].(map[string]interface{})
+	re.Equal("test", violation["name"])
+	re.Equal(float64(2), violation["count"])
+	re.Equal(map[string]interface{}{"Kill": float64(2)}, violation["action_count"])
+
+	// The primary of the resource manager microservice is discovered.
+	value, err := proto.Marshal(&tsopb.Participant{ListenUrls: []string{primaryAddr}})
+	re.NoError(err)
+	metaStorage.kvs["/ms/1/resource_manager/primary"] = value
+	re.NoError(c.ReportRunawayViolations(ctx, reports))
+	re.Equal(primaryAddr, reported)
+
+	// The error in the response should be returned.
+	resp = `{"error":"not serving"}`
+	err = c.ReportRunawayViolations(ctx, reports)
+	re.Error(err)
+	re.Contains(err.Error(), "not serving")
+}
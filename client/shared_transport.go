@@ -0,0 +1,543 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/client/errs"
+	"github.com/tikv/pd/client/tlsutil"
+	"go.uber.org/zap"
+)
+
+// SharedTransport is shared by the clients in one process which connect to the same PD cluster,
+// e.g, the clients of different keyspaces. The clients reuse the service discovery, the gRPC
+// connections to PD and the TSO streams of the transport instead of creating their own ones.
+//
+// The TSO streams are multiplexed by keyspace group. In PD service mode, all the keyspaces are
+// served by the default keyspace group, so the clients share one TSO client whose streams are
+// connected to the PD leader. In API service mode, the clients whose keyspaces are served by the
+// same keyspace group share one TSO client whose streams are connected to the TSO servers of the
+// group. Once the keyspace of a client is moved to another group, e.g, by a keyspace group split,
+// the client switches to the TSO client of the new group.
+//
+// The dynamic options, e.g, MaxTSOBatchWaitInterval, apply to the shared TSO clients, so they can
+// only be updated by the UpdateOption of the transport rather than the one of a client on it.
+type SharedTransport struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	tlsCfg *tlsutil.TLSConfig
+	option *option
+
+	pdSvcDiscovery *pdServiceDiscovery
+
+	mu          sync.RWMutex
+	serviceMode pdpb.ServiceMode
+	views       map[*sharedServiceDiscovery]struct{}
+
+	// metaCli reads the TSO primaries for the TSO service discoveries of the shared TSO clients.
+	metaCli MetaStorageClient
+
+	// tsoClients are the TSO clients shared by the clients whose keyspaces are served by the same
+	// keyspace group. A TSO client is created by the first client which acquires it and closed
+	// after the last client releases it.
+	tsoMu      sync.Mutex
+	tsoClients map[sharedTSOClientKey]*sharedTSOClient
+}
+
+// sharedTSOClientKey identifies a shared TSO client. The keyspace group is always the default one
+// in PD service mode.
+type sharedTSOClientKey struct {
+	serviceMode     pdpb.ServiceMode
+	keyspaceGroupID uint32
+}
+
+// sharedTSOClient is a TSO client shared by the holders on the transport.
+type sharedTSOClient struct {
+	key sharedTSOClientKey
+	cli *tsoClient
+	// svcDiscovery is the service discovery which the TSO client is created with, it's the view
+	// of the shared PD service discovery in PD service mode, or the TSO service discovery of the
+	// keyspace of the first holder in API service mode.
+	svcDiscovery ServiceDiscovery
+	holders      map[*sharedTSOClientHolder]struct{}
+}
+
+// sharedTSOClientHolder is held by the client which acquires a shared TSO client.
+type sharedTSOClientHolder struct {
+	shared     *sharedTSOClient
+	keyspaceID uint32
+	// onDetached is called when the keyspace of the holder isn't served by the keyspace group of
+	// the shared TSO client anymore, the holder should acquire a TSO client again then.
+	onDetached func(*sharedTSOClientHolder)
+}
+
+// NewSharedTransport creates a transport to be shared by the clients created with the
+// `WithSharedTransport` option. The client options which configure the connections, e.g, the
+// gRPC dial options, the timeout and the TSO related options, apply to the transport and all
+// the clients on it. The transport should be closed after all the clients on it are closed.
+func NewSharedTransport(
+	ctx context.Context, svrAddrs []string, security SecurityOption, opts ...ClientOption,
+) (*SharedTransport, error) {
+	// Reuse the client options to configure the transport.
+	c := &client{option: newOption()}
+	for _, opt := range opts {
+		opt(c)
+	}
+	t := &SharedTransport{
+		tlsCfg: &tlsutil.TLSConfig{
+			CAPath:   security.CAPath,
+			CertPath: security.CertPath,
+			KeyPath:  security.KeyPath,
+
+			SSLCABytes:   security.SSLCABytes,
+			SSLCertBytes: security.SSLCertBytes,
+			SSLKEYBytes:  security.SSLKEYBytes,
+		},
+		option:     c.option,
+		views:      make(map[*sharedServiceDiscovery]struct{}),
+		tsoClients: make(map[sharedTSOClientKey]*sharedTSOClient),
+	}
+	t.ctx, t.cancel = context.WithCancel(ctx)
+	t.pdSvcDiscovery = newPDServiceDiscovery(
+		t.ctx, t.cancel, &t.wg, t.setServiceMode, nil, nullKeyspaceID, addrsToUrls(svrAddrs), t.tlsCfg, t.option)
+	t.pdSvcDiscovery.AddServingAddrSwitchedCallback(t.onLeaderSwitched)
+	t.pdSvcDiscovery.AddServiceAddrsSwitchedCallback(t.onMembersChanged)
+	t.pdSvcDiscovery.SetTSOLocalServAddrsUpdatedCallback(t.onTSOLocalServAddrsUpdated)
+	t.pdSvcDiscovery.SetTSOGlobalServAddrUpdatedCallback(t.onTSOGlobalServAddrUpdated)
+	if err := t.pdSvcDiscovery.Init(); err != nil {
+		t.cancel()
+		return nil, err
+	}
+	// The meta storage requests are served by the shared PD service discovery.
+	t.metaCli = &pdMetaStorageClient{svcDiscovery: t.pdSvcDiscovery, option: t.option}
+	t.wg.Add(1)
+	go t.checkKeyspaceGroupLoop()
+	log.Info("[pd] create shared transport", zap.Strings("pd-address", svrAddrs))
+	return t, nil
+}
+
+// WithSharedTransport configures the client to use the shared transport, the server addresses
+// and the security option passed to create the client are ignored then. The dynamic options of
+// the client can't be updated by its UpdateOption, see `SharedTransport.UpdateOption`.
+func WithSharedTransport(t *SharedTransport) ClientOption {
+	return func(c *client) {
+		c.transport = t
+	}
+}
+
+// Close closes the transport.
+func (t *SharedTransport) Close() {
+	t.cancel()
+	t.wg.Wait()
+
+	t.tsoMu.Lock()
+	for key, shared := range t.tsoClients {
+		shared.close()
+		delete(t.tsoClients, key)
+	}
+	t.tsoMu.Unlock()
+	t.pdSvcDiscovery.Close()
+	log.Info("[pd] shared transport is closed")
+}
+
+// UpdateOption updates the dynamic option of the transport, which applies to all the clients on
+// it. The clients on the transport can't update the dynamic options by themselves.
+func (t *SharedTransport) UpdateOption(option DynamicOption, value interface{}) error {
+	return updateDynamicOption(t.option, option, value)
+}
+
+// GetClusterID returns the ID of the cluster.
+func (t *SharedTransport) GetClusterID() uint64 {
+	return t.pdSvcDiscovery.GetClusterID()
+}
+
+// newServiceDiscovery returns a view of the shared service discovery for a client.
+func (t *SharedTransport) newServiceDiscovery(
+	serviceModeUpdateCb func(pdpb.ServiceMode), updateKeyspaceIDCb updateKeyspaceIDFunc, keyspaceID uint32,
+) *sharedServiceDiscovery {
+	return &sharedServiceDiscovery{
+		pdServiceDiscovery:  t.pdSvcDiscovery,
+		transport:           t,
+		serviceModeUpdateCb: serviceModeUpdateCb,
+		updateKeyspaceIDCb:  updateKeyspaceIDCb,
+		keyspaceID:          keyspaceID,
+	}
+}
+
+func (t *SharedTransport) register(v *sharedServiceDiscovery) pdpb.ServiceMode {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.views[v] = struct{}{}
+	return t.serviceMode
+}
+
+func (t *SharedTransport) unregister(v *sharedServiceDiscovery) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.views, v)
+}
+
+func (t *SharedTransport) getViews() []*sharedServiceDiscovery {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	views := make([]*sharedServiceDiscovery, 0, len(t.views))
+	for v := range t.views {
+		views = append(views, v)
+	}
+	return views
+}
+
+func (t *SharedTransport) setServiceMode(mode pdpb.ServiceMode) {
+	t.mu.Lock()
+	t.serviceMode = mode
+	t.mu.Unlock()
+	for _, v := range t.getViews() {
+		if v.serviceModeUpdateCb != nil {
+			v.serviceModeUpdateCb(mode)
+		}
+	}
+}
+
+func (t *SharedTransport) onLeaderSwitched() {
+	for _, v := range t.getViews() {
+		for _, cb := range v.getLeaderSwitchedCbs() {
+			cb()
+		}
+	}
+}
+
+func (t *SharedTransport) onMembersChanged() {
+	for _, v := range t.getViews() {
+		for _, cb := range v.getMembersChangedCbs() {
+			cb()
+		}
+	}
+}
+
+func (t *SharedTransport) onTSOLocalServAddrsUpdated(allocMap map[string]string) error {
+	for _, v := range t.getViews() {
+		if cb := v.getTSOLocalServAddrsUpdatedCb(); cb != nil {
+			if err := cb(allocMap); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *SharedTransport) onTSOGlobalServAddrUpdated(addr string) error {
+	for _, v := range t.getViews() {
+		if cb := v.getTSOGlobalServAddrUpdatedCb(); cb != nil {
+			if err := cb(addr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// acquireTSOClient returns the TSO client shared by the clients whose keyspaces are served by the
+// same keyspace group in the service mode. The caller should release it by `releaseTSOClient`
+// instead of closing it.
+func (t *SharedTransport) acquireTSOClient(
+	mode pdpb.ServiceMode, keyspaceID uint32, onDetached func(*sharedTSOClientHolder),
+) (*sharedTSOClientHolder, error) {
+	holder := &sharedTSOClientHolder{keyspaceID: keyspaceID, onDetached: onDetached}
+	switch mode {
+	case pdpb.ServiceMode_PD_SVC_MODE:
+		t.tsoMu.Lock()
+		defer t.tsoMu.Unlock()
+		key := sharedTSOClientKey{serviceMode: mode, keyspaceGroupID: defaultKeySpaceGroupID}
+		shared, ok := t.tsoClients[key]
+		if !ok {
+			// The TSO client has its own view to receive the events of the TSO allocators.
+			view := t.newServiceDiscovery(nil, nil, nullKeyspaceID)
+			t.register(view)
+			shared = t.newSharedTSOClient(key, view, &pdTSOStreamBuilderFactory{
+				negotiateLayout:     t.option.negotiateTSOLayout,
+				traceSampleInterval: tsoTraceSampleInterval(t.option.tsoTraceSampleRate),
+			})
+		}
+		shared.attach(holder)
+		return holder, nil
+	case pdpb.ServiceMode_API_SVC_MODE:
+		// Discover the keyspace group of the keyspace out of the lock, since it may take a while.
+		svcDiscovery := newTSOServiceDiscovery(
+			t.ctx, t.metaCli, t.pdSvcDiscovery, t.GetClusterID(), keyspaceID, t.tlsCfg, t.option)
+		if err := svcDiscovery.Init(); err != nil {
+			return nil, err
+		}
+		t.tsoMu.Lock()
+		defer t.tsoMu.Unlock()
+		key := sharedTSOClientKey{serviceMode: mode, keyspaceGroupID: svcDiscovery.GetKeyspaceGroupID()}
+		shared, ok := t.tsoClients[key]
+		if ok {
+			svcDiscovery.Close()
+		} else {
+			shared = t.newSharedTSOClient(key, svcDiscovery, &tsoTSOStreamBuilderFactory{
+				negotiateLayout:     t.option.negotiateTSOLayout,
+				traceSampleInterval: tsoTraceSampleInterval(t.option.tsoTraceSampleRate),
+			})
+		}
+		shared.attach(holder)
+		return holder, nil
+	default:
+		return nil, errors.Errorf("[pd] unable to share the tso client in service mode %s", mode)
+	}
+}
+
+// newSharedTSOClient creates a shared TSO client, the caller should hold tsoMu.
+func (t *SharedTransport) newSharedTSOClient(
+	key sharedTSOClientKey, svcDiscovery ServiceDiscovery, factory tsoStreamBuilderFactory,
+) *sharedTSOClient {
+	shared := &sharedTSOClient{
+		key:          key,
+		cli:          newTSOClient(t.ctx, t.option, svcDiscovery, factory),
+		svcDiscovery: svcDiscovery,
+		holders:      make(map[*sharedTSOClientHolder]struct{}),
+	}
+	shared.cli.Setup()
+	t.tsoClients[key] = shared
+	log.Info("[pd] create shared tso client",
+		zap.String("service-mode", key.serviceMode.String()),
+		zap.Uint32("keyspace-group-id", key.keyspaceGroupID))
+	return shared
+}
+
+func (t *SharedTransport) releaseTSOClient(holder *sharedTSOClientHolder) {
+	t.tsoMu.Lock()
+	defer t.tsoMu.Unlock()
+	shared := holder.shared
+	if shared == nil {
+		return
+	}
+	holder.shared = nil
+	delete(shared.holders, holder)
+	if len(shared.holders) > 0 {
+		return
+	}
+	// The detached TSO client may have been replaced by a new one with the same key.
+	if t.tsoClients[shared.key] == shared {
+		delete(t.tsoClients, shared.key)
+	}
+	shared.close()
+	log.Info("[pd] close shared tso client",
+		zap.String("service-mode", shared.key.serviceMode.String()),
+		zap.Uint32("keyspace-group-id", shared.key.keyspaceGroupID))
+}
+
+// checkKeyspaceGroupLoop periodically checks that the keyspaces of the holders of the shared TSO
+// clients in API service mode are still served by the keyspace groups of the TSO clients.
+func (t *SharedTransport) checkKeyspaceGroupLoop() {
+	defer t.wg.Done()
+	ticker := time.NewTicker(memberUpdateInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		t.checkKeyspaceGroups()
+	}
+}
+
+func (t *SharedTransport) checkKeyspaceGroups() {
+	type check struct {
+		holder          *sharedTSOClientHolder
+		svcDiscovery    *tsoServiceDiscovery
+		keyspaceGroupID uint32
+		tsoServerAddr   string
+	}
+	var (
+		checks   []check
+		detached []*sharedTSOClientHolder
+	)
+	t.tsoMu.Lock()
+	for key, shared := range t.tsoClients {
+		svcDiscovery, ok := shared.svcDiscovery.(*tsoServiceDiscovery)
+		if !ok {
+			continue
+		}
+		// The keyspace group of the first holder is changed, none of the holders can be sure
+		// that its keyspace is still served by the group, so all of them are detached.
+		if svcDiscovery.GetKeyspaceGroupID() != key.keyspaceGroupID {
+			delete(t.tsoClients, key)
+			for holder := range shared.holders {
+				detached = append(detached, holder)
+			}
+			continue
+		}
+		tsoServerAddr := svcDiscovery.GetServingAddr()
+		if len(tsoServerAddr) == 0 {
+			continue
+		}
+		for holder := range shared.holders {
+			if holder.keyspaceID != svcDiscovery.GetKeyspaceID() {
+				checks = append(checks, check{holder, svcDiscovery, key.keyspaceGroupID, tsoServerAddr})
+			}
+		}
+	}
+	t.tsoMu.Unlock()
+	// Query the keyspace groups out of the lock, since the holders may acquire or release the TSO
+	// clients meanwhile.
+	for _, c := range checks {
+		if c.svcDiscovery.ctx.Err() != nil {
+			continue
+		}
+		group, err := c.svcDiscovery.findGroupByKeyspaceID(c.holder.keyspaceID, c.tsoServerAddr, updateMemberTimeout)
+		if err != nil {
+			log.Warn("[pd] failed to check the keyspace group of the shared tso client",
+				zap.Uint32("keyspace-id", c.holder.keyspaceID),
+				zap.Uint32("keyspace-group-id", c.keyspaceGroupID),
+				errs.ZapError(err))
+			continue
+		}
+		if group.GetId() != c.keyspaceGroupID {
+			detached = append(detached, c.holder)
+		}
+	}
+	for _, holder := range detached {
+		log.Info("[pd] the keyspace is moved out of the keyspace group of the shared tso client",
+			zap.Uint32("keyspace-id", holder.keyspaceID))
+		if holder.onDetached != nil {
+			holder.onDetached(holder)
+		}
+	}
+}
+
+func (s *sharedTSOClient) attach(holder *sharedTSOClientHolder) {
+	holder.shared = s
+	s.holders[holder] = struct{}{}
+}
+
+func (s *sharedTSOClient) close() {
+	s.cli.Close()
+	s.svcDiscovery.Close()
+}
+
+var _ ServiceDiscovery = (*sharedServiceDiscovery)(nil)
+var _ tsoAllocatorEventSource = (*sharedServiceDiscovery)(nil)
+
+// sharedServiceDiscovery is the view of the shared PD service discovery for a client. It has its
+// own keyspace and callbacks, the others are served by the shared one.
+type sharedServiceDiscovery struct {
+	*pdServiceDiscovery
+	transport *SharedTransport
+
+	serviceModeUpdateCb func(pdpb.ServiceMode)
+	updateKeyspaceIDCb  updateKeyspaceIDFunc
+	keyspaceID          uint32
+
+	mu                            sync.RWMutex
+	leaderSwitchedCbs             []func()
+	membersChangedCbs             []func()
+	tsoLocalAllocLeadersUpdatedCb tsoLocalServAddrsUpdatedFunc
+	tsoGlobalAllocLeaderUpdatedCb tsoGlobalServAddrUpdatedFunc
+}
+
+// Init updates the keyspace ID and registers the view to receive the events of the shared one.
+func (v *sharedServiceDiscovery) Init() error {
+	if v.updateKeyspaceIDCb != nil {
+		if err := v.updateKeyspaceIDCb(); err != nil {
+			return err
+		}
+	}
+	if mode := v.transport.register(v); v.serviceModeUpdateCb != nil {
+		v.serviceModeUpdateCb(mode)
+	}
+	return nil
+}
+
+// Close unregisters the view, the shared service discovery and connections are kept.
+func (v *sharedServiceDiscovery) Close() {
+	v.transport.unregister(v)
+}
+
+// GetKeyspaceID returns the ID of the keyspace.
+func (v *sharedServiceDiscovery) GetKeyspaceID() uint32 {
+	return v.keyspaceID
+}
+
+// SetKeyspaceID sets the ID of the keyspace.
+func (v *sharedServiceDiscovery) SetKeyspaceID(keyspaceID uint32) {
+	v.keyspaceID = keyspaceID
+}
+
+// AddServingAddrSwitchedCallback adds callbacks which will be called when the leader is switched.
+func (v *sharedServiceDiscovery) AddServingAddrSwitchedCallback(callbacks ...func()) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.leaderSwitchedCbs = append(v.leaderSwitchedCbs, callbacks...)
+}
+
+// AddServiceAddrsSwitchedCallback adds callbacks which will be called when any leader/follower
+// is changed.
+func (v *sharedServiceDiscovery) AddServiceAddrsSwitchedCallback(callbacks ...func()) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.membersChangedCbs = append(v.membersChangedCbs, callbacks...)
+}
+
+// SetTSOLocalServAddrsUpdatedCallback sets the callback which will be called when the local tso
+// allocator leader list is updated.
+func (v *sharedServiceDiscovery) SetTSOLocalServAddrsUpdatedCallback(callback tsoLocalServAddrsUpdatedFunc) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.tsoLocalAllocLeadersUpdatedCb = callback
+}
+
+// SetTSOGlobalServAddrUpdatedCallback sets the callback which will be called when the global tso
+// allocator leader is updated.
+func (v *sharedServiceDiscovery) SetTSOGlobalServAddrUpdatedCallback(callback tsoGlobalServAddrUpdatedFunc) {
+	addr := v.getLeaderAddr()
+	if len(addr) > 0 {
+		callback(addr)
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.tsoGlobalAllocLeaderUpdatedCb = callback
+}
+
+func (v *sharedServiceDiscovery) getLeaderSwitchedCbs() []func() {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.leaderSwitchedCbs
+}
+
+func (v *sharedServiceDiscovery) getMembersChangedCbs() []func() {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.membersChangedCbs
+}
+
+func (v *sharedServiceDiscovery) getTSOLocalServAddrsUpdatedCb() tsoLocalServAddrsUpdatedFunc {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.tsoLocalAllocLeadersUpdatedCb
+}
+
+func (v *sharedServiceDiscovery) getTSOGlobalServAddrUpdatedCb() tsoGlobalServAddrUpdatedFunc {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.tsoGlobalAllocLeaderUpdatedCb
+}
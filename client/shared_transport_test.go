@@ -0,0 +1,160 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/kvproto/pkg/tsopb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/client/errs"
+)
+
+func TestSharedServiceDiscovery(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	transport := &SharedTransport{
+		ctx:    ctx,
+		cancel: cancel,
+		option: newOption(),
+		views:  make(map[*sharedServiceDiscovery]struct{}),
+	}
+	transport.pdSvcDiscovery = newPDServiceDiscovery(
+		ctx, cancel, &wg, transport.setServiceMode, nil, nullKeyspaceID, []string{"http://127.0.0.1:2379"}, nil, transport.option)
+	transport.setServiceMode(pdpb.ServiceMode_PD_SVC_MODE)
+
+	type viewState struct {
+		modes          []pdpb.ServiceMode
+		leaderSwitched int
+		globalTSOAddrs []string
+	}
+	newView := func(keyspaceID uint32) (*sharedServiceDiscovery, *viewState) {
+		state := &viewState{}
+		view := transport.newServiceDiscovery(func(mode pdpb.ServiceMode) {
+			state.modes = append(state.modes, mode)
+		}, nil, keyspaceID)
+		re.NoError(view.Init())
+		view.AddServingAddrSwitchedCallback(func() { state.leaderSwitched++ })
+		view.SetTSOGlobalServAddrUpdatedCallback(func(addr string) error {
+			state.globalTSOAddrs = append(state.globalTSOAddrs, addr)
+			return nil
+		})
+		return view, state
+	}
+	view1, state1 := newView(1)
+	view2, state2 := newView(2)
+	// The views have their own keyspaces but share the connections.
+	re.Equal(uint32(1), view1.GetKeyspaceID())
+	re.Equal(uint32(2), view2.GetKeyspaceID())
+	view1.SetKeyspaceID(3)
+	re.Equal(uint32(3), view1.GetKeyspaceID())
+	re.Equal(uint32(2), view2.GetKeyspaceID())
+	re.Same(view1.GetClientConns(), view2.GetClientConns())
+	// The current service mode is notified once the view is initialized.
+	re.Equal([]pdpb.ServiceMode{pdpb.ServiceMode_PD_SVC_MODE}, state1.modes)
+	re.Equal([]pdpb.ServiceMode{pdpb.ServiceMode_PD_SVC_MODE}, state2.modes)
+
+	// The events of the shared service discovery are fanned out to the views.
+	transport.setServiceMode(pdpb.ServiceMode_API_SVC_MODE)
+	transport.onLeaderSwitched()
+	re.NoError(transport.onTSOGlobalServAddrUpdated("http://127.0.0.1:2380"))
+	for _, state := range []*viewState{state1, state2} {
+		re.Equal([]pdpb.ServiceMode{pdpb.ServiceMode_PD_SVC_MODE, pdpb.ServiceMode_API_SVC_MODE}, state.modes)
+		re.Equal(1, state.leaderSwitched)
+		re.Equal([]string{"http://127.0.0.1:2380"}, state.globalTSOAddrs)
+	}
+
+	// The closed view does not receive the events anymore.
+	view1.Close()
+	transport.onLeaderSwitched()
+	re.Equal(1, state1.leaderSwitched)
+	re.Equal(2, state2.leaderSwitched)
+	view2.Close()
+	re.Empty(transport.getViews())
+}
+
+func TestSharedTSOClientDetached(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	transport := &SharedTransport{
+		ctx:        ctx,
+		cancel:     cancel,
+		option:     newOption(),
+		views:      make(map[*sharedServiceDiscovery]struct{}),
+		tsoClients: make(map[sharedTSOClientKey]*sharedTSOClient),
+	}
+	transport.pdSvcDiscovery = newPDServiceDiscovery(
+		ctx, cancel, &wg, transport.setServiceMode, nil, nullKeyspaceID, []string{"http://127.0.0.1:2379"}, nil, transport.option)
+
+	// The TSO client of the default keyspace group is shared by the keyspaces 1 and 2.
+	svcDiscovery := newTSOServiceDiscovery(
+		ctx, nil, transport.pdSvcDiscovery, 1, 1, nil, transport.option).(*tsoServiceDiscovery)
+	key := sharedTSOClientKey{serviceMode: pdpb.ServiceMode_API_SVC_MODE, keyspaceGroupID: defaultKeySpaceGroupID}
+	shared := &sharedTSOClient{
+		key:          key,
+		cli:          newTSOClient(ctx, transport.option, svcDiscovery, &tsoTSOStreamBuilderFactory{}),
+		svcDiscovery: svcDiscovery,
+		holders:      make(map[*sharedTSOClientHolder]struct{}),
+	}
+	transport.tsoClients[key] = shared
+	var detached []*sharedTSOClientHolder
+	onDetached := func(holder *sharedTSOClientHolder) { detached = append(detached, holder) }
+	holder1 := &sharedTSOClientHolder{keyspaceID: 1, onDetached: onDetached}
+	holder2 := &sharedTSOClientHolder{keyspaceID: 2, onDetached: onDetached}
+	shared.attach(holder1)
+	shared.attach(holder2)
+
+	// The keyspace group is not changed.
+	transport.checkKeyspaceGroups()
+	re.Empty(detached)
+	re.Same(shared, transport.tsoClients[key])
+
+	// Once the keyspace 1 is moved to another keyspace group, all the holders are detached, and
+	// the TSO client is not shared with the new holders anymore.
+	svcDiscovery.keyspaceGroupSD.group = &tsopb.KeyspaceGroup{Id: 1}
+	transport.checkKeyspaceGroups()
+	re.ElementsMatch([]*sharedTSOClientHolder{holder1, holder2}, detached)
+	re.NotContains(transport.tsoClients, key)
+
+	// The TSO client is closed after the last holder releases it.
+	transport.releaseTSOClient(holder1)
+	re.Nil(holder1.shared)
+	re.NoError(shared.cli.ctx.Err())
+	transport.releaseTSOClient(holder2)
+	re.Error(shared.cli.ctx.Err())
+	// Releasing a released holder is a no-op.
+	transport.releaseTSOClient(holder2)
+}
+
+func TestSharedTransportUpdateOption(t *testing.T) {
+	re := require.New(t)
+	transport := &SharedTransport{option: newOption()}
+	cli := &client{option: newOption(), serviceModeKeeper: serviceModeKeeper{transport: transport}}
+	// The option of the shared TSO clients can't be updated by one of the clients.
+	err := cli.UpdateOption(EnableTSOFollowerProxy, true)
+	re.True(errs.ErrClientUpdateSharedOption.Equal(err))
+	re.False(cli.option.getEnableTSOFollowerProxy())
+	re.False(transport.option.getEnableTSOFollowerProxy())
+	re.NoError(transport.UpdateOption(EnableTSOFollowerProxy, true))
+	re.True(transport.option.getEnableTSOFollowerProxy())
+	re.Error(transport.UpdateOption(EnableTSOFollowerProxy, 1))
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/client/errs"
+	"go.uber.org/zap"
+)
+
+type circuitBreakerState int
+
+const (
+	circuitBreakerClosed circuitBreakerState = iota
+	circuitBreakerOpen
+	circuitBreakerHalfOpen
+)
+
+// tsoCircuitBreaker makes the TSO requests of a dc-location fail fast once the TSO batches
+// fail consecutively for the given times. After the open duration, one request is let
+// through to probe whether the TSO service is recovered, and the breaker is closed again
+// if the probe succeeds. It's nil if the circuit breaker is not enabled.
+type tsoCircuitBreaker struct {
+	dcLocation       string
+	failureThreshold int
+	openDuration     time.Duration
+
+	mu       sync.Mutex
+	state    circuitBreakerState
+	failures int
+	// openedAt is the time the breaker is opened, or the last probe is let through.
+	openedAt time.Time
+}
+
+func newTSOCircuitBreaker(dcLocation string, option *option) *tsoCircuitBreaker {
+	if option.tsoCircuitBreakerThreshold <= 0 {
+		return nil
+	}
+	return &tsoCircuitBreaker{
+		dcLocation:       dcLocation,
+		failureThreshold: option.tsoCircuitBreakerThreshold,
+		openDuration:     option.tsoCircuitBreakerOpenDuration,
+	}
+}
+
+// allowRequest returns an error if the request should fail fast. It lets one request
+// through as the probe if the breaker has been opened for the open duration.
+func (cb *tsoCircuitBreaker) allowRequest() error {
+	if cb == nil {
+		return nil
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case circuitBreakerClosed:
+		return nil
+	case circuitBreakerOpen, circuitBreakerHalfOpen:
+		// Let another probe through in case the last one is lost, e.g, canceled before dispatched.
+		if time.Since(cb.openedAt) >= cb.openDuration {
+			cb.state = circuitBreakerHalfOpen
+			cb.openedAt = time.Now()
+			tsoCircuitBreakerCounter.WithLabelValues("probe").Inc()
+			return nil
+		}
+	}
+	tsoCircuitBreakerCounter.WithLabelValues("reject").Inc()
+	return errs.ErrClientTSOCircuitBreakerOpen.FastGenByArgs(cb.dcLocation)
+}
+
+// failFast returns whether the collected batch should fail without being sent, which
+// happens if the requests are enqueued before the breaker is opened.
+func (cb *tsoCircuitBreaker) failFast() bool {
+	if cb == nil {
+		return false
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state == circuitBreakerOpen && time.Since(cb.openedAt) < cb.openDuration
+}
+
+func (cb *tsoCircuitBreaker) onSuccess() {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state != circuitBreakerClosed {
+		log.Info("[tso] circuit breaker is closed", zap.String("dc-location", cb.dcLocation))
+		tsoCircuitBreakerCounter.WithLabelValues("close").Inc()
+	}
+	cb.state = circuitBreakerClosed
+	cb.failures = 0
+}
+
+func (cb *tsoCircuitBreaker) onFailure() {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.failures++
+	if cb.state == circuitBreakerClosed && cb.failures < cb.failureThreshold {
+		return
+	}
+	if cb.state != circuitBreakerOpen {
+		log.Warn("[tso] circuit breaker is opened",
+			zap.String("dc-location", cb.dcLocation), zap.Int("consecutive-failures", cb.failures))
+		tsoCircuitBreakerCounter.WithLabelValues("open").Inc()
+	}
+	cb.state = circuitBreakerOpen
+	cb.openedAt = time.Now()
+}
+
+// LastKnownTS is the latest timestamp received from the TSO service.
+type LastKnownTS struct {
+	Physical int64
+	Logical  int64
+	// Uncertainty bounds how far the timestamp may lag behind the one allocated by the
+	// TSO service now. It's the time elapsed since the timestamp was requested.
+	Uncertainty time.Duration
+}
+
+type lastKnownTS struct {
+	physical    int64
+	logical     int64
+	requestedAt time.Time
+}
+
+func (c *tsoClient) updateLastKnownTS(dcLocation string, physical, logical int64, requestedAt time.Time) {
+	c.lastKnownTS.Store(dcLocation, &lastKnownTS{
+		physical:    physical,
+		logical:     logical,
+		requestedAt: requestedAt,
+	})
+}
+
+func (c *tsoClient) getLastKnownTS(dcLocation string, maxUncertainty time.Duration) (*LastKnownTS, error) {
+	val, ok := c.lastKnownTS.Load(dcLocation)
+	if !ok {
+		return nil, errs.ErrClientLastKnownTSUnavailable.FastGenByArgs(dcLocation, maxUncertainty)
+	}
+	ts := val.(*lastKnownTS)
+	uncertainty := time.Since(ts.requestedAt)
+	if maxUncertainty > 0 && uncertainty > maxUncertainty {
+		return nil, errs.ErrClientLastKnownTSUnavailable.FastGenByArgs(dcLocation, maxUncertainty)
+	}
+	return &LastKnownTS{
+		Physical:    ts.physical,
+		Logical:     ts.logical,
+		Uncertainty: uncertainty,
+	}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/client/errs"
+)
+
+func TestTSOCircuitBreaker(t *testing.T) {
+	re := require.New(t)
+	// The circuit breaker is disabled by default.
+	cb := newTSOCircuitBreaker(globalDCLocation, newOption())
+	re.Nil(cb)
+	re.NoError(cb.allowRequest())
+	cb.onFailure()
+	re.False(cb.failFast())
+
+	option := newOption()
+	option.tsoCircuitBreakerThreshold = 2
+	option.tsoCircuitBreakerOpenDuration = 100 * time.Millisecond
+	cb = newTSOCircuitBreaker(globalDCLocation, option)
+	// The success resets the consecutive failures.
+	cb.onFailure()
+	cb.onSuccess()
+	cb.onFailure()
+	re.NoError(cb.allowRequest())
+	re.False(cb.failFast())
+	cb.onFailure()
+	err := cb.allowRequest()
+	re.True(errs.ErrClientTSOCircuitBreakerOpen.Equal(errors.WithStack(err)))
+	re.True(cb.failFast())
+
+	// Only one request is let through to probe after the open duration.
+	time.Sleep(option.tsoCircuitBreakerOpenDuration)
+	re.False(cb.failFast())
+	re.NoError(cb.allowRequest())
+	re.Error(cb.allowRequest())
+	re.False(cb.failFast())
+	// The breaker is opened again if the probe fails.
+	cb.onFailure()
+	re.Error(cb.allowRequest())
+	re.True(cb.failFast())
+	time.Sleep(option.tsoCircuitBreakerOpenDuration)
+	re.NoError(cb.allowRequest())
+	cb.onSuccess()
+	re.NoError(cb.allowRequest())
+	re.NoError(cb.allowRequest())
+	re.False(cb.failFast())
+}
+
+func TestLastKnownTS(t *testing.T) {
+	re := require.New(t)
+	c := &tsoClient{}
+	_, err := c.getLastKnownTS(globalDCLocation, 0)
+	re.True(errs.ErrClientLastKnownTSUnavailable.Equal(err))
+
+	c.updateLastKnownTS(globalDCLocation, 10, 1, time.Now().Add(-time.Second))
+	ts, err := c.getLastKnownTS(globalDCLocation, 0)
+	re.NoError(err)
+	re.Equal(int64(10), ts.Physical)
+	re.Equal(int64(1), ts.Logical)
+	re.GreaterOrEqual(ts.Uncertainty, time.Second)
+	_, err = c.getLastKnownTS(globalDCLocation, time.Minute)
+	re.NoError(err)
+	_, err = c.getLastKnownTS(globalDCLocation, time.Millisecond)
+	re.True(errs.ErrClientLastKnownTSUnavailable.Equal(err))
+	_, err = c.getLastKnownTS("dc-1", 0)
+	re.Error(err)
+}
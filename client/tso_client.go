@@ -19,11 +19,13 @@ import (
 	"fmt"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/client/errs"
+	"github.com/tikv/pd/client/tsoutil"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
@@ -44,6 +46,25 @@ type TSOClient interface {
 	GetMinTS(ctx context.Context) (int64, int64, error)
 }
 
+// LastKnownTSClient is an optional interface implemented by the clients which cache the latest
+// timestamps, use a type assertion on `TSOClient` to access it.
+type LastKnownTSClient interface {
+	// GetLastKnownTS returns the latest timestamp of the dc-location received from PD or TSO
+	// microservice without any RPC, along with the bound of how far it may lag behind. It's for
+	// the stale reads which can tolerate the uncertainty, e.g, while the TSO service is unavailable.
+	// An error is returned if the uncertainty exceeds maxUncertainty, 0 means no limit.
+	GetLastKnownTS(dcLocation string, maxUncertainty time.Duration) (*LastKnownTS, error)
+}
+
+// TSOLayoutClient is an optional interface implemented by the clients which support the configurable
+// TSO layout, use a type assertion on `TSOClient` to access it.
+type TSOLayoutClient interface {
+	// GetTSOLayout returns the layout of the TSO negotiated with the server, which should be
+	// used to compose the physical and logical parts into a timestamp. It's always the default
+	// layout unless the client is created with `WithTSOLayoutNegotiation`.
+	GetTSOLayout() tsoutil.Layout
+}
+
 type tsoRequest struct {
 	start      time.Time
 	clientCtx  context.Context
@@ -85,6 +106,10 @@ type tsoClient struct {
 	tsDeadline sync.Map // Same as map[string]chan deadline
 	// dc-location -> *tsoInfo while the tsoInfo is the last TSO info
 	lastTSOInfoMap sync.Map // Same as map[string]*tsoInfo
+	// layout is the TSO layout negotiated with the server.
+	layout atomic.Value // Store as tsoutil.Layout
+	// dc-location -> *lastKnownTS
+	lastKnownTS sync.Map // Same as map[string]*lastKnownTS
 
 	checkTSDeadlineCh         chan struct{}
 	checkTSODispatcherCh      chan struct{}
@@ -107,6 +132,7 @@ func newTSOClient(
 		checkTSODispatcherCh:      make(chan struct{}, 1),
 		updateTSOConnectionCtxsCh: make(chan struct{}, 1),
 	}
+	c.layout.Store(tsoutil.DefaultLayout)
 
 	eventSrc := svcDiscovery.(tsoAllocatorEventSource)
 	eventSrc.SetTSOLocalServAddrsUpdatedCallback(c.updateTSOLocalServAddrs)
@@ -126,6 +152,19 @@ func (c *tsoClient) Setup() {
 	go c.tsCancelLoop()
 }
 
+func (c *tsoClient) getTSOLayout() tsoutil.Layout {
+	return c.layout.Load().(tsoutil.Layout)
+}
+
+// updateTSOLayout updates the TSO layout if it's changed by the server.
+func (c *tsoClient) updateTSOLayout(layout tsoutil.Layout) {
+	if old := c.getTSOLayout(); old != layout {
+		c.layout.Store(layout)
+		log.Info("[tso] the tso layout is changed",
+			zap.Stringer("old-layout", old), zap.Stringer("new-layout", layout))
+	}
+}
+
 // Close closes the TSO client
 func (c *tsoClient) Close() {
 	if c == nil {
@@ -263,8 +302,14 @@ func (c *tsoClient) backupClientConn() (*grpc.ClientConn, string) {
 		cc  *grpc.ClientConn
 		err error
 	)
+	// The backup addresses of the TSO service are sorted by preference, so try them in order.
+	_, ordered := c.svcDiscovery.(*tsoServiceDiscovery)
 	for i := 0; i < len(addrs); i++ {
-		addr := addrs[rand.Intn(len(addrs))]
+		idx := i
+		if !ordered {
+			idx = rand.Intn(len(addrs))
+		}
+		addr := addrs[idx]
 		if cc, err = c.svcDiscovery.GetOrCreateGRPCConn(addr); err != nil {
 			continue
 		}
@@ -41,6 +41,7 @@ import (
 type tsoDispatcher struct {
 	dispatcherCancel   context.CancelFunc
 	tsoBatchController *tsoBatchController
+	circuitBreaker     *tsoCircuitBreaker
 }
 
 type tsoInfo struct {
@@ -82,6 +83,10 @@ func (c *tsoClient) dispatchRequest(dcLocation string, request *tsoRequest) erro
 		return err
 	}
 
+	if err := dispatcher.(*tsoDispatcher).circuitBreaker.allowRequest(); err != nil {
+		return err
+	}
+
 	defer trace.StartRegion(request.requestCtx, "tsoReqEnqueue").End()
 	dispatcher.(*tsoDispatcher).tsoBatchController.tsoRequestCh <- request
 	return nil
@@ -310,6 +315,7 @@ func (c *tsoClient) createTSODispatcher(dcLocation string) {
 		tsoBatchController: newTSOBatchController(
 			make(chan *tsoRequest, defaultMaxTSOBatchSize*2),
 			defaultMaxTSOBatchSize),
+		circuitBreaker: newTSOCircuitBreaker(dcLocation, c.option),
 	}
 
 	if _, ok := c.tsoDispatcher.LoadOrStore(dcLocation, dispatcher); !ok {
@@ -319,7 +325,7 @@ func (c *tsoClient) createTSODispatcher(dcLocation string) {
 		// is that the loopCtx is done, otherwise there is no circumstance
 		// this goroutine should exit.
 		c.wg.Add(1)
-		go c.handleDispatcher(dispatcherCtx, dcLocation, dispatcher.tsoBatchController)
+		go c.handleDispatcher(dispatcherCtx, dcLocation, dispatcher.tsoBatchController, dispatcher.circuitBreaker)
 		log.Info("[tso] tso dispatcher created", zap.String("dc-location", dcLocation))
 	} else {
 		dispatcherCancel()
@@ -329,7 +335,8 @@ func (c *tsoClient) createTSODispatcher(dcLocation string) {
 func (c *tsoClient) handleDispatcher(
 	dispatcherCtx context.Context,
 	dc string,
-	tbc *tsoBatchController) {
+	tbc *tsoBatchController,
+	cb *tsoCircuitBreaker) {
 	var (
 		err        error
 		streamAddr string
@@ -424,6 +431,11 @@ tsoBatchLoop:
 		if maxBatchWaitInterval >= 0 {
 			tbc.adjustBestBatchSize()
 		}
+		// The requests enqueued before the circuit breaker is opened also fail fast.
+		if cb.failFast() {
+			c.finishRequest(tbc.getCollectedRequests(), 0, 0, 0, errs.ErrClientTSOCircuitBreakerOpen.FastGenByArgs(dc))
+			continue tsoBatchLoop
+		}
 		timerutil.SafeResetTimer(streamLoopTimer, c.option.timeout)
 		// Choose a stream to send the TSO gRPC request.
 	streamChoosingLoop:
@@ -448,6 +460,7 @@ tsoBatchLoop:
 					log.Error("[tso] create tso stream error", zap.String("dc-location", dc), errs.ZapError(err))
 					c.svcDiscovery.ScheduleCheckMemberChanged()
 					c.finishRequest(tbc.getCollectedRequests(), 0, 0, 0, errors.WithStack(err))
+					cb.onFailure()
 					timer.Stop()
 					continue tsoBatchLoop
 				case <-timer.C:
@@ -483,6 +496,9 @@ tsoBatchLoop:
 		opts = extractSpanReference(tbc, opts[:0])
 		err = c.processRequests(stream, dc, tbc, opts)
 		close(done)
+		if err == nil {
+			cb.onSuccess()
+		}
 		// If error happens during tso stream handling, reset stream and run the next trial.
 		if err != nil {
 			select {
@@ -490,6 +506,7 @@ tsoBatchLoop:
 				return
 			default:
 			}
+			cb.onFailure()
 			c.svcDiscovery.ScheduleCheckMemberChanged()
 			log.Error("[tso] getTS error after processing requests",
 				zap.String("dc-location", dc),
@@ -756,13 +773,17 @@ func (c *tsoClient) processRequests(
 	}
 	count := int64(len(requests))
 	reqKeyspaceGroupID := c.svcDiscovery.GetKeyspaceGroupID()
+	reqTrace := stream.nextRequestTrace()
+	sendStart := time.Now()
 	respKeyspaceGroupID, physical, logical, suffixBits, err := stream.processRequests(
 		c.svcDiscovery.GetClusterID(), c.svcDiscovery.GetKeyspaceID(), reqKeyspaceGroupID,
 		dcLocation, requests, tbc.batchStartTime)
+	c.traceTSORequests(requests, reqTrace, dcLocation, stream.getServerAddr(), reqKeyspaceGroupID, sendStart, time.Now(), err)
 	if err != nil {
 		c.finishRequest(requests, 0, 0, 0, err)
 		return err
 	}
+	c.updateTSOLayout(stream.getLayout())
 	// `logical` is the largest ts's logical part here, we need to do the subtracting before we finish each TSO request.
 	firstLogical := tsoutil.AddLogical(logical, -count+1, suffixBits)
 	curTSOInfo := &tsoInfo{
@@ -774,6 +795,7 @@ func (c *tsoClient) processRequests(
 		logical:             tsoutil.AddLogical(firstLogical, count-1, suffixBits),
 	}
 	c.compareAndSwapTS(dcLocation, curTSOInfo, physical, firstLogical)
+	c.updateLastKnownTS(dcLocation, curTSOInfo.physical, curTSOInfo.logical, sendStart)
 	c.finishRequest(requests, physical, firstLogical, suffixBits, nil)
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -48,6 +49,9 @@ const (
 	tsoQueryRetryMaxTimes = 10
 	// tsoQueryRetryInterval is the retry interval for querying TSO.
 	tsoQueryRetryInterval = 500 * time.Millisecond
+	// tsoServerRefreshInterval is the interval to refresh the TSO server addresses, which are
+	// ordered by the health and load of the TSO servers.
+	tsoServerRefreshInterval = time.Minute
 )
 
 var _ ServiceDiscovery = (*tsoServiceDiscovery)(nil)
@@ -101,6 +105,8 @@ type tsoServerDiscovery struct {
 	selectIdx int
 	// failureCount counts the consecutive failures for communicating with the tso servers
 	failureCount int
+	// updateTime is the last time the addresses are discovered
+	updateTime time.Time
 }
 
 func (t *tsoServerDiscovery) countFailure() bool {
@@ -116,6 +122,57 @@ func (t *tsoServerDiscovery) resetFailure() {
 	t.failureCount = 0
 }
 
+// needRefresh returns true if the addresses need to be discovered again.
+func (t *tsoServerDiscovery) needRefresh() bool {
+	t.RLock()
+	defer t.RUnlock()
+	return len(t.addrs) == 0 || t.failureCount >= len(t.addrs) ||
+		time.Since(t.updateTime) >= tsoServerRefreshInterval
+}
+
+func (t *tsoServerDiscovery) updateAddrs(addrs []string) {
+	t.Lock()
+	defer t.Unlock()
+	if !reflect.DeepEqual(t.addrs, addrs) {
+		log.Info("update tso server addresses", zap.Strings("addrs", addrs))
+	}
+	t.addrs = addrs
+	t.selectIdx = 0
+	t.failureCount = 0
+	t.updateTime = time.Now()
+}
+
+// nextAddr picks a TSO server in a round-robin way.
+func (t *tsoServerDiscovery) nextAddr() string {
+	t.Lock()
+	defer t.Unlock()
+	addr := t.addrs[t.selectIdx]
+	t.selectIdx++
+	t.selectIdx %= len(t.addrs)
+	return addr
+}
+
+// sortByPreference sorts the given addresses in the order of the discovered TSO server
+// addresses, in which the healthy and less loaded servers come first. The addresses
+// which are not discovered are put at the end.
+func (t *tsoServerDiscovery) sortByPreference(addrs []string) {
+	t.RLock()
+	defer t.RUnlock()
+	rank := make(map[string]int, len(t.addrs))
+	for i, addr := range t.addrs {
+		rank[addr] = i
+	}
+	getRank := func(addr string) int {
+		if r, ok := rank[addr]; ok {
+			return r
+		}
+		return len(t.addrs)
+	}
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return getRank(addrs[i]) < getRank(addrs[j])
+	})
+}
+
 // tsoServiceDiscovery is the service discovery client of the independent TSO service
 
 type tsoServiceDiscovery struct {
@@ -489,6 +546,8 @@ func (c *tsoServiceDiscovery) updateMember() error {
 			secondaryAddrs = append(secondaryAddrs, m.Address)
 		}
 	}
+	// Prefer the healthy and less loaded secondaries when following them.
+	c.tsoServerDiscovery.sortByPreference(secondaryAddrs)
 
 	// If the primary address is not empty, we need to create a grpc connection to it, and do it
 	// out of the critical section of the keyspace group service discovery.
@@ -581,7 +640,7 @@ func (c *tsoServiceDiscovery) getTSOServer(sd ServiceDiscovery) (string, error)
 		err   error
 	)
 	t := c.tsoServerDiscovery
-	if len(t.addrs) == 0 || t.failureCount == len(t.addrs) {
+	if t.needRefresh() {
 		addrs, err = sd.DiscoverMicroservice(tsoService)
 		if err != nil {
 			return "", err
@@ -597,20 +656,9 @@ func (c *tsoServiceDiscovery) getTSOServer(sd ServiceDiscovery) (string, error)
 			// and handle the fallback logic outside of this function.
 			return "", nil
 		}
-
-		log.Info("update tso server addresses", zap.Strings("addrs", addrs))
-
-		t.addrs = addrs
-		t.selectIdx = 0
-		t.failureCount = 0
+		t.updateAddrs(addrs)
 	}
-
-	// Pick a TSO server in a round-robin way.
-	tsoServerAddr := t.addrs[t.selectIdx]
-	t.selectIdx++
-	t.selectIdx %= len(t.addrs)
-
-	return tsoServerAddr, nil
+	return t.nextAddr(), nil
 }
 
 func (c *tsoServiceDiscovery) discoverWithLegacyPath() ([]string, error) {
@@ -0,0 +1,35 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByPreference(t *testing.T) {
+	re := require.New(t)
+	t1 := &tsoServerDiscovery{addrs: []string{"127.0.0.1:3", "127.0.0.1:1", "127.0.0.1:2"}}
+	addrs := []string{"127.0.0.1:4", "127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3"}
+	t1.sortByPreference(addrs)
+	re.Equal([]string{"127.0.0.1:3", "127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:4"}, addrs)
+
+	// Keep the original order if nothing is discovered.
+	t2 := &tsoServerDiscovery{}
+	addrs = []string{"127.0.0.1:2", "127.0.0.1:1"}
+	t2.sortByPreference(addrs)
+	re.Equal([]string{"127.0.0.1:2", "127.0.0.1:1"}, addrs)
+}
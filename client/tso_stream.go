@@ -22,8 +22,12 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/pingcap/kvproto/pkg/tsopb"
+	"github.com/pingcap/log"
 	"github.com/tikv/pd/client/errs"
+	"github.com/tikv/pd/client/tsoutil"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // TSO Stream Builder Factory
@@ -32,16 +36,30 @@ type tsoStreamBuilderFactory interface {
 	makeBuilder(cc *grpc.ClientConn) tsoStreamBuilder
 }
 
-type pdTSOStreamBuilderFactory struct{}
+type pdTSOStreamBuilderFactory struct {
+	negotiateLayout bool
+	// traceSampleInterval is the interval of the requests to be traced, 0 means the tracing is disabled.
+	traceSampleInterval uint64
+}
 
 func (f *pdTSOStreamBuilderFactory) makeBuilder(cc *grpc.ClientConn) tsoStreamBuilder {
-	return &pdTSOStreamBuilder{client: pdpb.NewPDClient(cc), serverAddr: cc.Target()}
+	return &pdTSOStreamBuilder{
+		client: pdpb.NewPDClient(cc), serverAddr: cc.Target(),
+		negotiateLayout: f.negotiateLayout, traceSampleInterval: f.traceSampleInterval,
+	}
 }
 
-type tsoTSOStreamBuilderFactory struct{}
+type tsoTSOStreamBuilderFactory struct {
+	negotiateLayout bool
+	// traceSampleInterval is the interval of the requests to be traced, 0 means the tracing is disabled.
+	traceSampleInterval uint64
+}
 
 func (f *tsoTSOStreamBuilderFactory) makeBuilder(cc *grpc.ClientConn) tsoStreamBuilder {
-	return &tsoTSOStreamBuilder{client: tsopb.NewTSOClient(cc), serverAddr: cc.Target()}
+	return &tsoTSOStreamBuilder{
+		client: tsopb.NewTSOClient(cc), serverAddr: cc.Target(),
+		negotiateLayout: f.negotiateLayout, traceSampleInterval: f.traceSampleInterval,
+	}
 }
 
 // TSO Stream Builder
@@ -51,25 +69,30 @@ type tsoStreamBuilder interface {
 }
 
 type pdTSOStreamBuilder struct {
-	serverAddr string
-	client     pdpb.PDClient
+	serverAddr          string
+	client              pdpb.PDClient
+	negotiateLayout     bool
+	traceSampleInterval uint64
 }
 
 func (b *pdTSOStreamBuilder) build(ctx context.Context, cancel context.CancelFunc, timeout time.Duration) (tsoStream, error) {
 	done := make(chan struct{})
 	// TODO: we need to handle a conner case that this goroutine is timeout while the stream is successfully created.
 	go checkStreamTimeout(ctx, cancel, done, timeout)
-	stream, err := b.client.Tso(ctx)
+	trace := newTSOStreamTraceContext(b.traceSampleInterval)
+	stream, err := b.client.Tso(trace.AppendToOutgoingContext(withTSOLayoutNegotiation(ctx, b.negotiateLayout)))
 	done <- struct{}{}
 	if err == nil {
-		return &pdTSOStream{stream: stream, serverAddr: b.serverAddr}, nil
+		return &pdTSOStream{stream: stream, serverAddr: b.serverAddr, trace: trace}, nil
 	}
 	return nil, err
 }
 
 type tsoTSOStreamBuilder struct {
-	serverAddr string
-	client     tsopb.TSOClient
+	serverAddr          string
+	client              tsopb.TSOClient
+	negotiateLayout     bool
+	traceSampleInterval uint64
 }
 
 func (b *tsoTSOStreamBuilder) build(
@@ -78,14 +101,51 @@ func (b *tsoTSOStreamBuilder) build(
 	done := make(chan struct{})
 	// TODO: we need to handle a conner case that this goroutine is timeout while the stream is successfully created.
 	go checkStreamTimeout(ctx, cancel, done, timeout)
-	stream, err := b.client.Tso(ctx)
+	trace := newTSOStreamTraceContext(b.traceSampleInterval)
+	stream, err := b.client.Tso(trace.AppendToOutgoingContext(withTSOLayoutNegotiation(ctx, b.negotiateLayout)))
 	done <- struct{}{}
 	if err == nil {
-		return &tsoTSOStream{stream: stream, serverAddr: b.serverAddr}, nil
+		return &tsoTSOStream{stream: stream, serverAddr: b.serverAddr, trace: trace}, nil
 	}
 	return nil, err
 }
 
+// withTSOLayoutNegotiation declares the client understands the non-default TSO layout if negotiate is true.
+func withTSOLayoutNegotiation(ctx context.Context, negotiate bool) context.Context {
+	if !negotiate {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, tsoutil.LayoutNegotiationMetadataKey, "true")
+}
+
+// newTSOStreamTraceContext creates the trace context of a new TSO stream, nil is returned if the
+// tracing is disabled.
+func newTSOStreamTraceContext(sampleInterval uint64) *tsoutil.TraceContext {
+	if sampleInterval == 0 {
+		return nil
+	}
+	return tsoutil.NewTraceContext(sampleInterval)
+}
+
+// layoutFromHeader gets the TSO layout from the gRPC header sent by the server.
+// The default layout is returned if the server doesn't send it.
+func layoutFromHeader(stream grpc.ClientStream) tsoutil.Layout {
+	md, err := stream.Header()
+	if err != nil {
+		return tsoutil.DefaultLayout
+	}
+	values := md.Get(tsoutil.LayoutMetadataKey)
+	if len(values) == 0 {
+		return tsoutil.DefaultLayout
+	}
+	layout, err := tsoutil.ParseLayout(values[0])
+	if err != nil {
+		log.Warn("[tso] failed to parse the tso layout, use the default one", zap.String("layout", values[0]), zap.Error(err))
+		return tsoutil.DefaultLayout
+	}
+	return layout
+}
+
 func checkStreamTimeout(ctx context.Context, cancel context.CancelFunc, done chan struct{}, timeout time.Duration) {
 	timer := time.NewTimer(timeout)
 	defer timer.Stop()
@@ -103,6 +163,11 @@ func checkStreamTimeout(ctx context.Context, cancel context.CancelFunc, done cha
 
 type tsoStream interface {
 	getServerAddr() string
+	// getLayout returns the TSO layout of the stream, it's only valid after the first response is received.
+	getLayout() tsoutil.Layout
+	// nextRequestTrace returns the trace context of the next request to be sent, it must be called
+	// once before every call of processRequests.
+	nextRequestTrace() tsoutil.RequestTrace
 	// processRequests processes TSO requests in streaming mode to get timestamps
 	processRequests(
 		clusterID uint64, keyspaceID, keyspaceGroupID uint32, dcLocation string,
@@ -113,12 +178,25 @@ type tsoStream interface {
 type pdTSOStream struct {
 	serverAddr string
 	stream     pdpb.PD_TsoClient
+	layout     *tsoutil.Layout
+	trace      *tsoutil.TraceContext
 }
 
 func (s *pdTSOStream) getServerAddr() string {
 	return s.serverAddr
 }
 
+func (s *pdTSOStream) getLayout() tsoutil.Layout {
+	if s.layout == nil {
+		return tsoutil.DefaultLayout
+	}
+	return *s.layout
+}
+
+func (s *pdTSOStream) nextRequestTrace() tsoutil.RequestTrace {
+	return s.trace.Next()
+}
+
 func (s *pdTSOStream) processRequests(
 	clusterID uint64, _, _ uint32, dcLocation string, requests []*tsoRequest, batchStartTime time.Time,
 ) (respKeyspaceGroupID uint32, physical, logical int64, suffixBits uint32, err error) {
@@ -152,6 +230,11 @@ func (s *pdTSOStream) processRequests(
 	}
 	requestDurationTSO.Observe(time.Since(start).Seconds())
 	tsoBatchSize.Observe(float64(count))
+	// The header must have been received once the first response is received.
+	if s.layout == nil {
+		layout := layoutFromHeader(s.stream)
+		s.layout = &layout
+	}
 
 	if resp.GetCount() != uint32(count) {
 		err = errors.WithStack(errTSOLength)
@@ -167,12 +250,25 @@ func (s *pdTSOStream) processRequests(
 type tsoTSOStream struct {
 	serverAddr string
 	stream     tsopb.TSO_TsoClient
+	layout     *tsoutil.Layout
+	trace      *tsoutil.TraceContext
 }
 
 func (s *tsoTSOStream) getServerAddr() string {
 	return s.serverAddr
 }
 
+func (s *tsoTSOStream) getLayout() tsoutil.Layout {
+	if s.layout == nil {
+		return tsoutil.DefaultLayout
+	}
+	return *s.layout
+}
+
+func (s *tsoTSOStream) nextRequestTrace() tsoutil.RequestTrace {
+	return s.trace.Next()
+}
+
 func (s *tsoTSOStream) processRequests(
 	clusterID uint64, keyspaceID, keyspaceGroupID uint32, dcLocation string,
 	requests []*tsoRequest, batchStartTime time.Time,
@@ -209,6 +305,11 @@ func (s *tsoTSOStream) processRequests(
 	}
 	requestDurationTSO.Observe(time.Since(start).Seconds())
 	tsoBatchSize.Observe(float64(count))
+	// The header must have been received once the first response is received.
+	if s.layout == nil {
+		layout := layoutFromHeader(s.stream)
+		s.layout = &layout
+	}
 
 	if resp.GetCount() != uint32(count) {
 		err = errors.WithStack(errTSOLength)
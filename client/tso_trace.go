@@ -0,0 +1,133 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/tikv/pd/client/tsoutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tsoTracerName is the name of the tracer which reports the TSO spans.
+const tsoTracerName = "github.com/tikv/pd/client"
+
+// The names of the spans reported by the TSO client.
+const (
+	// TSOSpanRequest is the root span which covers the whole TSO request of a batch.
+	TSOSpanRequest = "pdclient.tso.request"
+	// TSOSpanBatchWait covers the time the request waits in the client to be batched and sent.
+	TSOSpanBatchWait = "pdclient.tso.batch-wait"
+	// TSOSpanSend covers the time from the batch being sent to the response being received, which
+	// includes the network round trip and the server-side handling. The server-side spans share the
+	// same trace ID, and can be inspected through the `debug/tso/traces` API of the PD or TSO server.
+	TSOSpanSend = "pdclient.tso.send"
+)
+
+// The attributes of the root span.
+const (
+	TSOAttrSeq             = attribute.Key("tso.seq")
+	TSOAttrBatchSize       = attribute.Key("tso.batch-size")
+	TSOAttrDCLocation      = attribute.Key("tso.dc-location")
+	TSOAttrKeyspaceGroupID = attribute.Key("tso.keyspace-group-id")
+	TSOAttrServerAddress   = attribute.Key("server.address")
+)
+
+// tsoTraceSampleInterval converts the sample rate into the interval of the requests to be sampled,
+// 0 means the tracing is disabled.
+func tsoTraceSampleInterval(rate float64) uint64 {
+	if rate <= 0 {
+		return 0
+	}
+	if rate >= 1 {
+		return 1
+	}
+	return uint64(math.Round(1 / rate))
+}
+
+// getTSOTracer returns the tracer to report the TSO spans, the global tracer provider is used if it's not set.
+func (c *tsoClient) getTSOTracer() trace.Tracer {
+	if c.option.tsoTracerProvider != nil {
+		return c.option.tsoTracerProvider.Tracer(tsoTracerName)
+	}
+	return otel.GetTracerProvider().Tracer(tsoTracerName)
+}
+
+// tsoRemoteSpanContext returns the span context shared with the server for the request. Its trace ID
+// is the one propagated to the server, so the client-side and the server-side spans of the request
+// belong to the same trace. Its span ID is derived from the trace ID, since the server doesn't
+// report a span ID back.
+func tsoRemoteSpanContext(reqTrace tsoutil.RequestTrace) (trace.SpanContext, bool) {
+	traceID, err := trace.TraceIDFromHex(reqTrace.TraceID)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	var spanID trace.SpanID
+	binary.BigEndian.PutUint64(spanID[:], binary.BigEndian.Uint64(traceID[8:]))
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}), true
+}
+
+// traceTSORequests reports the spans of the sampled batch. Since the requests are sent in batches,
+// the root span starts from the oldest request of the batch.
+func (c *tsoClient) traceTSORequests(
+	requests []*tsoRequest, reqTrace tsoutil.RequestTrace, dcLocation, serverAddr string, keyspaceGroupID uint32,
+	sendStart, sendEnd time.Time, err error,
+) {
+	if len(requests) == 0 || !reqTrace.Sampled {
+		return
+	}
+	remote, ok := tsoRemoteSpanContext(reqTrace)
+	if !ok {
+		return
+	}
+	start := requests[0].start
+	for _, req := range requests[1:] {
+		if req.start.Before(start) {
+			start = req.start
+		}
+	}
+	tracer := c.getTSOTracer()
+	ctx, root := tracer.Start(trace.ContextWithRemoteSpanContext(context.Background(), remote), TSOSpanRequest,
+		trace.WithTimestamp(start),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			TSOAttrSeq.Int64(int64(reqTrace.Seq)),
+			TSOAttrBatchSize.Int(len(requests)),
+			TSOAttrDCLocation.String(dcLocation),
+			TSOAttrKeyspaceGroupID.Int64(int64(keyspaceGroupID)),
+			TSOAttrServerAddress.String(serverAddr),
+		))
+	_, wait := tracer.Start(ctx, TSOSpanBatchWait, trace.WithTimestamp(start))
+	wait.End(trace.WithTimestamp(sendStart))
+	_, send := tracer.Start(ctx, TSOSpanSend, trace.WithTimestamp(sendStart))
+	if err != nil {
+		send.RecordError(err, trace.WithTimestamp(sendEnd))
+		send.SetStatus(codes.Error, err.Error())
+		root.SetStatus(codes.Error, err.Error())
+	}
+	send.End(trace.WithTimestamp(sendEnd))
+	root.End(trace.WithTimestamp(sendEnd))
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsoutil
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+)
+
+const (
+	// LayoutMetadataKey is the gRPC header key used by the TSO server to tell the client the layout of the TSO.
+	LayoutMetadataKey = "pd-tso-layout"
+	// LayoutNegotiationMetadataKey is the gRPC metadata key used by the client to declare that it
+	// understands the non-default layout of the TSO.
+	LayoutNegotiationMetadataKey = "pd-tso-layout-negotiation"
+
+	// PhysicalPrecisionMillisecond indicates the physical part of the TSO is in milliseconds.
+	PhysicalPrecisionMillisecond = "ms"
+	// PhysicalPrecisionMicrosecond indicates the physical part of the TSO is in microseconds.
+	PhysicalPrecisionMicrosecond = "us"
+
+	// defaultLogicalBits is the number of bits of the logical part in the default layout.
+	defaultLogicalBits = 18
+	// layoutHeadroom is the minimum time the TSO under a layout should not overflow from now on.
+	layoutHeadroom = 30 * 365 * 24 * time.Hour
+)
+
+// DefaultLayout is the layout of the TSO used by default, i.e, the physical part is in
+// milliseconds and the logical part has 18 bits, which is compatible with all the components.
+var DefaultLayout = Layout{PhysicalPrecision: PhysicalPrecisionMillisecond, LogicalBits: defaultLogicalBits}
+
+// Layout describes how a TSO is split into the physical and the logical parts.
+type Layout struct {
+	// PhysicalPrecision is the granularity of the physical part, "ms" or "us".
+	PhysicalPrecision string `json:"physical-precision"`
+	// LogicalBits is the number of bits of the logical part.
+	LogicalBits int `json:"logical-bits"`
+}
+
+// ParseLayout parses the layout from the string generated by `Layout.String`, e.g, "ms/18".
+func ParseLayout(s string) (Layout, error) {
+	precision, bits, ok := strings.Cut(s, "/")
+	if !ok {
+		return Layout{}, errors.Errorf("invalid tso layout %s", s)
+	}
+	logicalBits, err := strconv.Atoi(bits)
+	if err != nil {
+		return Layout{}, errors.Errorf("invalid tso layout %s", s)
+	}
+	l := Layout{PhysicalPrecision: precision, LogicalBits: logicalBits}
+	return l, l.Validate()
+}
+
+// String implements fmt.Stringer.
+func (l Layout) String() string {
+	return fmt.Sprintf("%s/%d", l.PhysicalPrecision, l.LogicalBits)
+}
+
+// IsDefault returns whether the layout is the default one.
+func (l Layout) IsDefault() bool {
+	return l == DefaultLayout
+}
+
+// Validate checks whether the layout is valid. A valid layout must not overflow within
+// the next decades and must not generate a smaller TSO than the default layout does.
+func (l Layout) Validate() error {
+	if l.PhysicalPrecision != PhysicalPrecisionMillisecond && l.PhysicalPrecision != PhysicalPrecisionMicrosecond {
+		return errors.Errorf("invalid tso physical precision %s", l.PhysicalPrecision)
+	}
+	if l.LogicalBits <= 0 || l.LogicalBits >= 63 {
+		return errors.Errorf("invalid tso logical bits %d", l.LogicalBits)
+	}
+	now := time.Now()
+	if l.Physical(now.Add(layoutHeadroom)) > math.MaxInt64>>l.LogicalBits {
+		return errors.Errorf("tso layout %s will overflow soon", l)
+	}
+	if !l.IsCompatibleWith(DefaultLayout, now) {
+		return errors.Errorf("tso layout %s generates smaller tso than the default layout %s", l, DefaultLayout)
+	}
+	return nil
+}
+
+// PhysicalUnit returns the duration of one unit of the physical part.
+func (l Layout) PhysicalUnit() time.Duration {
+	if l.PhysicalPrecision == PhysicalPrecisionMicrosecond {
+		return time.Microsecond
+	}
+	return time.Millisecond
+}
+
+// MaxLogical returns the upper limit of the logical part.
+func (l Layout) MaxLogical() int64 {
+	return int64(1) << l.LogicalBits
+}
+
+// Physical returns the physical part of the given time.
+func (l Layout) Physical(t time.Time) int64 {
+	return t.UnixNano() / int64(l.PhysicalUnit())
+}
+
+// SubPhysical returns the difference of the physical parts of the two given times.
+func (l Layout) SubPhysical(after, before time.Time) int64 {
+	return l.Physical(after) - l.Physical(before)
+}
+
+// ComposeTS generates an `uint64` TS by passing the physical and logical parts.
+func (l Layout) ComposeTS(physical, logical int64) uint64 {
+	return uint64(physical)<<l.LogicalBits | uint64(logical)&uint64(l.MaxLogical()-1)
+}
+
+// ParseTS parses the ts to (physical, logical).
+func (l Layout) ParseTS(ts uint64) (time.Time, uint64) {
+	logical := ts & uint64(l.MaxLogical()-1)
+	physical := int64(ts >> l.LogicalBits)
+	return time.Unix(0, physical*int64(l.PhysicalUnit())), logical
+}
+
+// ParseTimestamp parses the `pdpb.Timestamp` generated with this layout to (physical, logical).
+func (l Layout) ParseTimestamp(ts pdpb.Timestamp) (time.Time, uint64) {
+	return time.Unix(0, ts.GetPhysical()*int64(l.PhysicalUnit())), uint64(ts.GetLogical())
+}
+
+// IsCompatibleWith returns whether the TSO generated with this layout since the given time
+// is always greater than the one generated with the previous layout before the given time,
+// which guarantees the monotonicity of the TSO when the layout is changed.
+func (l Layout) IsCompatibleWith(prev Layout, since time.Time) bool {
+	return l.ComposeTS(l.Physical(since), 0) >= prev.ComposeTS(prev.Physical(since), 0)
+}
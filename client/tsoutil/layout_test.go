@@ -0,0 +1,66 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsoutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLayout(t *testing.T) {
+	re := require.New(t)
+	layout, err := ParseLayout(DefaultLayout.String())
+	re.NoError(err)
+	re.True(layout.IsDefault())
+
+	layout, err = ParseLayout("us/11")
+	re.NoError(err)
+	re.Equal(Layout{PhysicalPrecision: PhysicalPrecisionMicrosecond, LogicalBits: 11}, layout)
+
+	for _, s := range []string{"", "ms", "ns/18", "ms/x", "ms/0", "ms/63", "us/18", "ms/10"} {
+		_, err = ParseLayout(s)
+		re.Error(err, s)
+	}
+}
+
+func TestLayoutComposeAndParse(t *testing.T) {
+	re := require.New(t)
+	now := time.Now()
+	for _, layout := range []Layout{DefaultLayout, {PhysicalPrecision: PhysicalPrecisionMicrosecond, LogicalBits: 11}} {
+		ts := layout.ComposeTS(layout.Physical(now), 100)
+		physical, logical := layout.ParseTS(ts)
+		re.Equal(uint64(100), logical)
+		re.Equal(layout.Physical(now), layout.Physical(physical))
+		re.Equal(ts+1, layout.ComposeTS(layout.Physical(now), 101))
+	}
+	// The physical part of `pdpb.Timestamp` is in the unit of the layout.
+	microLayout := Layout{PhysicalPrecision: PhysicalPrecisionMicrosecond, LogicalBits: 11}
+	physical, logical := microLayout.ParseTimestamp(pdpb.Timestamp{Physical: microLayout.Physical(now), Logical: 1})
+	re.Equal(uint64(1), logical)
+	re.Equal(now.UnixMicro(), physical.UnixMicro())
+}
+
+func TestLayoutIsCompatibleWith(t *testing.T) {
+	re := require.New(t)
+	now := time.Now()
+	microLayout := Layout{PhysicalPrecision: PhysicalPrecisionMicrosecond, LogicalBits: 11}
+	re.True(microLayout.IsCompatibleWith(DefaultLayout, now))
+	re.True(DefaultLayout.IsCompatibleWith(DefaultLayout, now))
+	// Switching back to the default layout will generate smaller TSO.
+	re.False(DefaultLayout.IsCompatibleWith(microLayout, now))
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsoutil
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand"
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// TraceIDMetadataKey is the gRPC metadata key used by the client to propagate the trace ID of the TSO stream.
+	// Note: keep the same as the one defined on the server side.
+	TraceIDMetadataKey = "pd-tso-trace-id"
+	// TraceSampleIntervalMetadataKey is the gRPC metadata key used by the client to tell the server every
+	// how many requests on the TSO stream one is sampled.
+	// Note: keep the same as the one defined on the server side.
+	TraceSampleIntervalMetadataKey = "pd-tso-trace-sample-interval"
+
+	traceIDLen = 16
+)
+
+// RequestTrace is the trace context of a single TSO request.
+type RequestTrace struct {
+	// TraceID is the hex encoded trace ID of the request, which is shared with the server.
+	TraceID string
+	// Seq is the sequence number of the request on the stream, starting from 1.
+	Seq uint64
+	// Sampled indicates whether the request is sampled.
+	Sampled bool
+}
+
+// TraceContext is the trace context of a TSO stream propagated to the server through the gRPC metadata.
+// Since the requests on a stream are received in the same order as they are sent, both sides count
+// them to derive the same trace ID for each request without changing the protocol.
+type TraceContext struct {
+	streamTraceID  [traceIDLen]byte
+	sampleInterval uint64
+	seq            uint64
+}
+
+// NewTraceContext creates a trace context with a random trace ID for a new TSO stream, one of
+// every sampleInterval requests on the stream is sampled.
+func NewTraceContext(sampleInterval uint64) *TraceContext {
+	if sampleInterval == 0 {
+		sampleInterval = 1
+	}
+	c := &TraceContext{sampleInterval: sampleInterval}
+	binary.BigEndian.PutUint64(c.streamTraceID[:8], rand.Uint64())
+	binary.BigEndian.PutUint64(c.streamTraceID[8:], rand.Uint64())
+	return c
+}
+
+// AppendToOutgoingContext propagates the trace context to the server.
+func (c *TraceContext) AppendToOutgoingContext(ctx context.Context) context.Context {
+	if c == nil {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx,
+		TraceIDMetadataKey, hex.EncodeToString(c.streamTraceID[:]),
+		TraceSampleIntervalMetadataKey, strconv.FormatUint(c.sampleInterval, 10))
+}
+
+// Next returns the trace context of the next request sent on the stream. It must be called once
+// for every request sent.
+func (c *TraceContext) Next() RequestTrace {
+	if c == nil {
+		return RequestTrace{}
+	}
+	c.seq++
+	return RequestTrace{
+		TraceID: RequestTraceID(c.streamTraceID, c.seq),
+		Seq:     c.seq,
+		Sampled: c.seq%c.sampleInterval == 0,
+	}
+}
+
+// RequestTraceID derives the trace ID of the seq-th request on the stream by adding seq to the
+// lower 64 bits of the trace ID of the stream.
+func RequestTraceID(streamTraceID [traceIDLen]byte, seq uint64) string {
+	id := streamTraceID
+	binary.BigEndian.PutUint64(id[8:], binary.BigEndian.Uint64(id[8:])+seq)
+	return hex.EncodeToString(id[:])
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/utils/syncutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/server/cluster"
 	"github.com/tikv/pd/server/config"
@@ -40,6 +41,17 @@ const (
 	milliCores                     = 1000
 )
 
+// The reasons of scaling in the plans.
+const (
+	reasonCPUUsageHigh        = "cpu-usage-high"
+	reasonCPUUsageLow         = "cpu-usage-low"
+	reasonStorageAvailableLow = "storage-available-low"
+	reasonMemoryUsageHigh     = "memory-usage-high"
+	reasonMemoryUsageLow      = "memory-usage-low"
+	reasonQPSHigh             = "qps-high"
+	reasonQPSLow              = "qps-low"
+)
+
 // TODO: adjust the value or make it configurable.
 var (
 	// MetricsTimeDuration is used to get the metrics of a certain time period.
@@ -52,18 +64,46 @@ var (
 	MaxScaleInStep uint64 = 1
 )
 
-func calculate(rc *cluster.RaftCluster, cfg *config.PDServerConfig, strategy *Strategy) []*Plan {
+// clusterInformer provides the cluster information to calculate the plans.
+type clusterInformer interface {
+	storeStatsInformer
+	GetEtcdClient() *clientv3.Client
+}
+
+// calculator calculates the plans and records the last scaling time of each component
+// to apply the cooldowns. The plans emitted last time are returned in the cooldown.
+type calculator struct {
+	syncutil.Mutex
+	lastScaleOut map[ComponentType]time.Time
+	lastScaleIn  map[ComponentType]time.Time
+	lastPlans    map[ComponentType][]*Plan
+}
+
+func newCalculator() *calculator {
+	return &calculator{
+		lastScaleOut: make(map[ComponentType]time.Time),
+		lastScaleIn:  make(map[ComponentType]time.Time),
+		lastPlans:    make(map[ComponentType][]*Plan),
+	}
+}
+
+func (c *calculator) calculate(rc *cluster.RaftCluster, cfg *config.PDServerConfig, strategy *Strategy) []*Plan {
 	var plans []*Plan
 
-	client, err := promClient.NewClient(promClient.Config{
-		Address: cfg.MetricStorage,
-	})
-	if err != nil {
-		log.Error("error initializing Prometheus client", zap.String("metric-storage", cfg.MetricStorage), errs.ZapError(errs.ErrPrometheusCreateClient, err))
-		return nil
+	querier := newQuerier(rc, cfg, strategy)
+
+	now := time.Now()
+	for _, comp := range getComponents(strategy) {
+		if compPlans := c.getPlans(rc, querier, strategy, comp, now); compPlans != nil {
+			plans = append(plans, compPlans...)
+		}
 	}
-	querier := NewPrometheusQuerier(client)
 
+	return plans
+}
+
+// getComponents returns the components to scale by the strategy in a fixed order.
+func getComponents(strategy *Strategy) []ComponentType {
 	components := map[ComponentType]struct{}{}
 	for _, rule := range strategy.Rules {
 		switch rule.Component {
@@ -71,72 +111,303 @@ func calculate(rc *cluster.RaftCluster, cfg *config.PDServerConfig, strategy *St
 			components[TiDB] = struct{}{}
 		case "tikv":
 			components[TiKV] = struct{}{}
+		case "tiflash":
+			components[TiFlash] = struct{}{}
 		}
 	}
-
-	for comp := range components {
-		if compPlans := getPlans(rc, querier, strategy, comp); compPlans != nil {
-			plans = append(plans, compPlans...)
+	result := make([]ComponentType, 0, len(components))
+	for _, comp := range []ComponentType{TiKV, TiDB, TiFlash} {
+		if _, ok := components[comp]; ok {
+			result = append(result, comp)
 		}
 	}
-
-	return plans
+	return result
 }
 
-func getPlans(rc *cluster.RaftCluster, querier Querier, strategy *Strategy, component ComponentType) []*Plan {
-	var instances []instance
-	if component == TiKV {
-		instances = filterTiKVInstances(rc)
-	} else {
-		instances = getTiDBInstances(rc.GetEtcdClient())
+// newQuerier returns the querier which queries Prometheus if the metric storage is configured, and
+// falls back to the store heartbeats for the metrics which are not supported by Prometheus.
+func newQuerier(rc storeStatsInformer, cfg *config.PDServerConfig, strategy *Strategy) Querier {
+	heartbeatQuerier := NewHeartbeatQuerier(rc, strategy)
+	if cfg.MetricStorage == "" {
+		return heartbeatQuerier
+	}
+	client, err := promClient.NewClient(promClient.Config{
+		Address: cfg.MetricStorage,
+	})
+	if err != nil {
+		log.Error("error initializing Prometheus client", zap.String("metric-storage", cfg.MetricStorage), errs.ZapError(errs.ErrPrometheusCreateClient, err))
+		return heartbeatQuerier
 	}
+	return NewMultiQuerier(NewPrometheusQuerier(client), heartbeatQuerier)
+}
 
+func (c *calculator) getPlans(rc clusterInformer, querier Querier, strategy *Strategy, component ComponentType, now time.Time) []*Plan {
+	rule := getRuleByComponent(strategy, component)
+	if rule == nil {
+		return nil
+	}
+	instances := getInstancesByComponent(rc, component)
 	if len(instances) == 0 {
 		return nil
 	}
 
-	now := time.Now()
-	totalCPUUseTime, err := getTotalCPUUseTime(querier, component, instances, now, MetricsTimeDuration)
+	groups, err := getScaledGroupsByComponent(rc, component, instances)
 	if err != nil {
-		log.Error("cannot get total CPU used time", errs.ZapError(err))
+		// TODO: error handling
 		return nil
 	}
+	return c.plan(querier, strategy, rule, component, instances, groups, now)
+}
 
-	currentQuota, err := getTotalCPUQuota(querier, component, instances, now)
-	if err != nil || currentQuota == 0 {
-		log.Error("cannot get total CPU quota", errs.ZapError(err))
-		return nil
+// plan scales the groups of the component by the rule according to the metrics of the instances.
+// The plans emitted last time are returned instead if the scaling is in the cooldown.
+func (c *calculator) plan(querier Querier, strategy *Strategy, rule *Rule, component ComponentType, instances []instance, groups []*Plan, now time.Time) []*Plan {
+	plans := c.scale(querier, strategy, rule, component, instances, groups, now)
+	if plans != nil {
+		c.Lock()
+		defer c.Unlock()
+		c.lastPlans[component] = plans
+	}
+	return plans
+}
+
+func (c *calculator) scale(querier Querier, strategy *Strategy, rule *Rule, component ComponentType, instances []instance, groups []*Plan, now time.Time) []*Plan {
+	counts := make(map[string]uint64, len(groups))
+	for _, group := range groups {
+		counts[group.Labels[groupLabelKey]] = group.Count
+	}
+
+	e := evaluate(querier, rule, component, instances, now)
+	var (
+		plans    []*Plan
+		reasons  []string
+		scaleOut bool
+	)
+	switch {
+	case e.scaleOut():
+		if c.inCooldown(rule, component, true, now) {
+			log.Info("skip scaling out in cooldown", zap.String("component", component.String()), zap.Strings("reasons", e.scaleOutReasons))
+			return c.getLastPlans(component, groups)
+		}
+		if len(groups) == 0 && len(getResourcesByComponent(strategy, component)) == 0 {
+			log.Error("no resource to scale out", zap.String("component", component.String()))
+			return nil
+		}
+		if e.cpuScaleOutQuota > 0 {
+			plans = calculateScaleOutPlan(strategy, component, e.cpuScaleOutQuota, groups)
+		} else {
+			plans = scaleOutGroup(strategy, findBestGroupToScaleOut(strategy, groups, component), MaxScaleOutStep, groups)
+		}
+		reasons, scaleOut = e.scaleOutReasons, true
+	case e.scaleIn():
+		if c.inCooldown(rule, component, false, now) {
+			log.Info("skip scaling in in cooldown", zap.String("component", component.String()), zap.Strings("reasons", e.scaleInReasons))
+			return c.getLastPlans(component, groups)
+		}
+		if e.cpuScaleInQuota > 0 {
+			plans = calculateScaleInPlan(strategy, e.cpuScaleInQuota, groups)
+		} else if len(groups) > 0 {
+			plans = scaleInGroup(findBestGroupToScaleIn(strategy, 0, groups), MaxScaleInStep, groups)
+		}
+		reasons = e.scaleInReasons
+	default:
+		return groups
 	}
 
-	totalCPUTime := float64(currentQuota) / milliCores * MetricsTimeDuration.Seconds()
-	usage := totalCPUUseTime / totalCPUTime
-	maxThreshold, minThreshold := getCPUThresholdByComponent(strategy, component)
+	changed := plans != nil && len(plans) != len(counts)
+	reason := strings.Join(reasons, ",")
+	for _, plan := range plans {
+		if count, ok := counts[plan.Labels[groupLabelKey]]; !ok || count != plan.Count {
+			plan.Reason, plan.Metrics = reason, e.metrics
+			changed = true
+		}
+	}
+	if changed {
+		c.recordScaling(component, scaleOut, now)
+		log.Info("auto scaling plans are changed", zap.String("component", component.String()),
+			zap.Bool("scale-out", scaleOut), zap.String("reason", reason), zap.Any("metrics", e.metrics))
+	}
+	return plans
+}
 
-	groups, err := getScaledGroupsByComponent(rc, component, instances)
+// inCooldown checks whether the component is in the cooldown of the last scaling.
+func (c *calculator) inCooldown(rule *Rule, component ComponentType, scaleOut bool, now time.Time) bool {
+	c.Lock()
+	defer c.Unlock()
+	if scaleOut {
+		return now.Sub(c.lastScaleOut[component]) < rule.getScaleOutCooldown()
+	}
+	cooldown := rule.getScaleInCooldown()
+	return now.Sub(c.lastScaleOut[component]) < cooldown || now.Sub(c.lastScaleIn[component]) < cooldown
+}
+
+// getLastPlans returns the plans emitted last time, or the groups if there is none.
+func (c *calculator) getLastPlans(component ComponentType, groups []*Plan) []*Plan {
+	c.Lock()
+	defer c.Unlock()
+	if plans, ok := c.lastPlans[component]; ok {
+		return plans
+	}
+	return groups
+}
+
+func (c *calculator) recordScaling(component ComponentType, scaleOut bool, now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	if scaleOut {
+		c.lastScaleOut[component] = now
+	} else {
+		c.lastScaleIn[component] = now
+	}
+}
+
+// evaluation is the result of checking the metrics of a component against the rule.
+type evaluation struct {
+	metrics map[string]float64
+	// scaleOutReasons is not empty if any metric is above the max threshold.
+	scaleOutReasons []string
+	// scaleInReasons is not empty if any metric is below the min threshold, and holdIn is
+	// true if any metric does not allow scaling in.
+	scaleInReasons []string
+	holdIn         bool
+	// cpuScaleOutQuota and cpuScaleInQuota are the CPU cores to scale out or in by the CPU rule.
+	cpuScaleOutQuota float64
+	cpuScaleInQuota  float64
+}
+
+func (e *evaluation) scaleOut() bool {
+	return len(e.scaleOutReasons) > 0
+}
+
+// scaleIn returns true only if all the checked metrics allow scaling in.
+func (e *evaluation) scaleIn() bool {
+	return !e.holdIn && len(e.scaleInReasons) > 0
+}
+
+// checkError holds scaling in if the metrics are supported but failed to query.
+func (e *evaluation) checkError(component ComponentType, metric string, err error) {
+	if isUnsupportedError(err) {
+		log.Debug("skip unsupported metrics", zap.String("component", component.String()), zap.String("metrics", metric), errs.ZapError(err))
+		return
+	}
+	log.Warn("cannot get metrics", zap.String("component", component.String()), zap.String("metrics", metric), errs.ZapError(err))
+	e.holdIn = true
+}
+
+// nonZero returns the error of querying the quota or capacity, which is also an error if it is zero.
+func nonZero(err error) error {
 	if err != nil {
-		// TODO: error handling
-		return nil
+		return err
 	}
+	return errors.New("the value is zero")
+}
 
-	// TODO: add metrics to show why it triggers scale in/out.
-	if usage > maxThreshold {
-		scaleOutQuota := (totalCPUUseTime - totalCPUTime*maxThreshold) / MetricsTimeDuration.Seconds()
-		return calculateScaleOutPlan(strategy, component, scaleOutQuota, groups)
+// evaluate checks the metrics of the component against the rule. To avoid flapping, a metric allows
+// scaling in only if it is still below the max threshold after removing an instance.
+func evaluate(querier Querier, rule *Rule, component ComponentType, instances []instance, now time.Time) *evaluation {
+	e := &evaluation{metrics: make(map[string]float64)}
+	// remain is the ratio of the resources left after removing an instance.
+	remain := float64(len(instances)-1) / float64(len(instances))
+	if len(instances) <= 1 {
+		e.holdIn = true
 	}
 
-	if usage < minThreshold {
-		scaleInQuota := (totalCPUTime*minThreshold - totalCPUUseTime) / MetricsTimeDuration.Seconds()
-		return calculateScaleInPlan(strategy, scaleInQuota, groups)
+	if rule.CPURule != nil {
+		totalCPUUseTime, err := getTotalCPUUseTime(querier, component, instances, now, MetricsTimeDuration)
+		if err != nil {
+			e.checkError(component, CPUUsage.String(), err)
+		} else if currentQuota, err := getTotalCPUQuota(querier, component, instances, now); err != nil || currentQuota == 0 {
+			e.checkError(component, CPUQuota.String(), nonZero(err))
+		} else {
+			totalCPUTime := float64(currentQuota) / milliCores * MetricsTimeDuration.Seconds()
+			usage := totalCPUUseTime / totalCPUTime
+			e.metrics["cpu_usage"] = usage
+			e.metrics["cpu_quota"] = float64(currentQuota) / milliCores
+			switch {
+			case usage > rule.CPURule.MaxThreshold:
+				e.scaleOutReasons = append(e.scaleOutReasons, reasonCPUUsageHigh)
+				e.cpuScaleOutQuota = (totalCPUUseTime - totalCPUTime*rule.CPURule.MaxThreshold) / MetricsTimeDuration.Seconds()
+			case usage < rule.CPURule.MinThreshold && usage <= rule.CPURule.MaxThreshold*remain:
+				e.scaleInReasons = append(e.scaleInReasons, reasonCPUUsageLow)
+				e.cpuScaleInQuota = (totalCPUTime*rule.CPURule.MinThreshold - totalCPUUseTime) / MetricsTimeDuration.Seconds()
+			default:
+				e.holdIn = true
+			}
+		}
 	}
 
-	return groups
+	if rule.StorageRule != nil {
+		available, err := getTotalMetrics(querier, component, StorageAvailable, instances, now, 0)
+		if err != nil {
+			e.checkError(component, StorageAvailable.String(), err)
+		} else if capacity, err := getTotalMetrics(querier, component, StorageCapacity, instances, now, 0); err != nil || capacity == 0 {
+			e.checkError(component, StorageCapacity.String(), nonZero(err))
+		} else {
+			ratio := available / capacity
+			e.metrics["storage_available_ratio"] = ratio
+			// The storage never triggers scaling in, but it holds scaling in if the available
+			// storage would be too low after the data of the removed instance is migrated.
+			remainCapacity := capacity * remain
+			if ratio < rule.StorageRule.MinThreshold {
+				e.scaleOutReasons = append(e.scaleOutReasons, reasonStorageAvailableLow)
+			} else if remainCapacity == 0 || (remainCapacity-(capacity-available))/remainCapacity < rule.StorageRule.MinThreshold {
+				e.holdIn = true
+			}
+		}
+	}
+
+	if rule.MemoryRule != nil {
+		used, err := getTotalMetrics(querier, component, MemoryUsage, instances, now, 0)
+		if err != nil {
+			e.checkError(component, MemoryUsage.String(), err)
+		} else if quota, err := getTotalMetrics(querier, component, MemoryQuota, instances, now, 0); err != nil || quota == 0 {
+			e.checkError(component, MemoryQuota.String(), nonZero(err))
+		} else {
+			usage := used / quota
+			e.metrics["memory_usage"] = usage
+			switch {
+			case usage > rule.MemoryRule.MaxThreshold:
+				e.scaleOutReasons = append(e.scaleOutReasons, reasonMemoryUsageHigh)
+			case usage < rule.MemoryRule.MinThreshold && usage <= rule.MemoryRule.MaxThreshold*remain:
+				e.scaleInReasons = append(e.scaleInReasons, reasonMemoryUsageLow)
+			default:
+				e.holdIn = true
+			}
+		}
+	}
+
+	if rule.QPSRule != nil {
+		total, err := getTotalMetrics(querier, component, QPS, instances, now, MetricsTimeDuration)
+		if err != nil {
+			e.checkError(component, QPS.String(), err)
+		} else {
+			qps := total / float64(len(instances))
+			e.metrics["qps_per_instance"] = qps
+			switch {
+			case qps > rule.QPSRule.MaxThreshold:
+				e.scaleOutReasons = append(e.scaleOutReasons, reasonQPSHigh)
+			case qps < rule.QPSRule.MinThreshold && qps <= rule.QPSRule.MaxThreshold*remain:
+				e.scaleInReasons = append(e.scaleInReasons, reasonQPSLow)
+			default:
+				e.holdIn = true
+			}
+		}
+	}
+	return e
+}
+
+func getInstancesByComponent(rc clusterInformer, component ComponentType) []instance {
+	if component == TiDB {
+		return getTiDBInstances(rc.GetEtcdClient())
+	}
+	return filterStoreInstances(rc, component)
 }
 
-func filterTiKVInstances(informer core.StoreSetInformer) []instance {
+func filterStoreInstances(informer core.StoreSetInformer, component ComponentType) []instance {
 	var instances []instance
 	stores := informer.GetStores()
 	for _, store := range stores {
-		if store.IsUp() {
+		if store.IsUp() && store.IsTiFlash() == (component == TiFlash) {
 			instances = append(instances, instance{id: store.GetID(), address: store.GetAddress()})
 		}
 	}
@@ -164,10 +435,9 @@ func getAddresses(instances []instance) []string {
 	return names
 }
 
-// TODO: support other metrics storage
-// get total CPU use time (in seconds) through Prometheus.
-func getTotalCPUUseTime(querier Querier, component ComponentType, instances []instance, timestamp time.Time, duration time.Duration) (float64, error) {
-	result, err := querier.Query(NewQueryOptions(component, CPUUsage, getAddresses(instances), timestamp, duration))
+// get the sum of the metrics of all instances through the querier.
+func getTotalMetrics(querier Querier, component ComponentType, metric MetricType, instances []instance, timestamp time.Time, duration time.Duration) (float64, error) {
+	result, err := querier.Query(NewQueryOptions(component, metric, getAddresses(instances), timestamp, duration))
 	if err != nil {
 		return 0.0, err
 	}
@@ -180,40 +450,44 @@ func getTotalCPUUseTime(querier Querier, component ComponentType, instances []in
 	return sum, nil
 }
 
-// TODO: support other metrics storage
-// get total CPU quota (in milliCores) through Prometheus.
+// get total CPU use time (in seconds) through the querier.
+func getTotalCPUUseTime(querier Querier, component ComponentType, instances []instance, timestamp time.Time, duration time.Duration) (float64, error) {
+	return getTotalMetrics(querier, component, CPUUsage, instances, timestamp, duration)
+}
+
+// get total CPU quota (in milliCores) through the querier.
 func getTotalCPUQuota(querier Querier, component ComponentType, instances []instance, timestamp time.Time) (uint64, error) {
-	result, err := querier.Query(NewQueryOptions(component, CPUQuota, getAddresses(instances), timestamp, 0))
+	sum, err := getTotalMetrics(querier, component, CPUQuota, instances, timestamp, 0)
 	if err != nil {
 		return 0, err
 	}
 
-	sum := 0.0
-	for _, value := range result {
-		sum += value
-	}
-
 	quota := uint64(math.Floor(sum * float64(milliCores)))
 
 	return quota, nil
 }
 
-func getCPUThresholdByComponent(strategy *Strategy, component ComponentType) (maxThreshold float64, minThreshold float64) {
+func getRuleByComponent(strategy *Strategy, component ComponentType) *Rule {
 	for _, rule := range strategy.Rules {
 		if rule.Component == component.String() {
-			return rule.CPURule.MaxThreshold, rule.CPURule.MinThreshold
+			return rule
 		}
 	}
+	return nil
+}
+
+func getCPUThresholdByComponent(strategy *Strategy, component ComponentType) (maxThreshold float64, minThreshold float64) {
+	if rule := getRuleByComponent(strategy, component); rule != nil && rule.CPURule != nil {
+		return rule.CPURule.MaxThreshold, rule.CPURule.MinThreshold
+	}
 	return 0, 0
 }
 
 func getResourcesByComponent(strategy *Strategy, component ComponentType) []*Resource {
 	var resTyp []string
 	var resources []*Resource
-	for _, rule := range strategy.Rules {
-		if rule.Component == component.String() {
-			resTyp = rule.CPURule.ResourceTypes
-		}
+	if rule := getRuleByComponent(strategy, component); rule != nil {
+		resTyp = rule.getResourceTypes()
 	}
 	for _, res := range strategy.Resources {
 		for _, typ := range resTyp {
@@ -233,8 +507,13 @@ func calculateScaleOutPlan(strategy *Strategy, component ComponentType, scaleOut
 		log.Error("resource CPU is zero, exiting calculation")
 		return nil
 	}
-	resCount := getCountByResourceType(strategy, group.ResourceType)
 	scaleOutCount := typeutil.MinUint64(uint64(math.Ceil(scaleOutQuota/resCPU)), MaxScaleOutStep)
+	return scaleOutGroup(strategy, group, scaleOutCount, groups)
+}
+
+// scaleOutGroup adds scaleOutCount instances to the group within the resource count limit.
+func scaleOutGroup(strategy *Strategy, group Plan, scaleOutCount uint64, groups []*Plan) []*Plan {
+	resCount := getCountByResourceType(strategy, group.ResourceType)
 
 	// A new group created
 	if len(groups) == 0 {
@@ -271,6 +550,11 @@ func calculateScaleInPlan(strategy *Strategy, scaleInQuota float64, groups []*Pl
 		return nil
 	}
 	scaleInCount := typeutil.MinUint64(uint64(math.Ceil(scaleInQuota/resCPU)), MaxScaleInStep)
+	return scaleInGroup(group, scaleInCount, groups)
+}
+
+// scaleInGroup removes scaleInCount instances from the group, and the group is removed if it is empty.
+func scaleInGroup(group Plan, scaleInCount uint64, groups []*Plan) []*Plan {
 	for i, g := range groups {
 		if g.ResourceType == group.ResourceType {
 			if group.Count > scaleInCount {
@@ -293,6 +577,15 @@ func getCPUByResourceType(strategy *Strategy, resourceType string) uint64 {
 	return 0
 }
 
+func getResourceByType(strategy *Strategy, resourceType string) *Resource {
+	for _, res := range strategy.Resources {
+		if res.ResourceType == resourceType {
+			return res
+		}
+	}
+	return nil
+}
+
 func getCountByResourceType(strategy *Strategy, resourceType string) *uint64 {
 	var zero uint64 = 0
 	for _, res := range strategy.Resources {
@@ -303,10 +596,10 @@ func getCountByResourceType(strategy *Strategy, resourceType string) *uint64 {
 	return &zero
 }
 
-func getScaledGroupsByComponent(rc *cluster.RaftCluster, component ComponentType, healthyInstances []instance) ([]*Plan, error) {
+func getScaledGroupsByComponent(rc clusterInformer, component ComponentType, healthyInstances []instance) ([]*Plan, error) {
 	switch component {
-	case TiKV:
-		return getScaledTiKVGroups(rc, healthyInstances)
+	case TiKV, TiFlash:
+		return getScaledStoreGroups(rc, component, healthyInstances)
 	case TiDB:
 		return getScaledTiDBGroups(rc.GetEtcdClient(), healthyInstances)
 	default:
@@ -315,6 +608,10 @@ func getScaledGroupsByComponent(rc *cluster.RaftCluster, component ComponentType
 }
 
 func getScaledTiKVGroups(informer core.StoreSetInformer, healthyInstances []instance) ([]*Plan, error) {
+	return getScaledStoreGroups(informer, TiKV, healthyInstances)
+}
+
+func getScaledStoreGroups(informer core.StoreSetInformer, component ComponentType, healthyInstances []instance) ([]*Plan, error) {
 	planMap := make(map[string]map[string]struct{}, len(healthyInstances))
 	resourceTypeMap := make(map[string]string)
 	for _, instance := range healthyInstances {
@@ -341,7 +638,7 @@ func getScaledTiKVGroups(informer core.StoreSetInformer, healthyInstances []inst
 			resourceTypeMap[groupName] = resourceType
 		}
 	}
-	return buildPlans(planMap, resourceTypeMap, TiKV), nil
+	return buildPlans(planMap, resourceTypeMap, component), nil
 }
 
 func getScaledTiDBGroups(etcdClient *clientv3.Client, healthyInstances []instance) ([]*Plan, error) {
@@ -432,8 +729,11 @@ func findBestGroupToScaleOut(strategy *Strategy, groups []*Plan, component Compo
 	}
 
 	// TODO: we can provide different senerios by using options and remove this kind of special judgement.
-	if component == TiKV {
+	switch component {
+	case TiKV:
 		group.Labels[filter.SpecialUseKey] = filter.SpecialUseHotRegion
+	case TiFlash:
+		group.Labels[core.EngineKey] = core.EngineTiFlash
 	}
 
 	return group
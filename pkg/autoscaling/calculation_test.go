@@ -26,6 +26,8 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
 	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+	"go.etcd.io/etcd/clientv3"
 )
 
 func TestGetScaledTiKVGroups(t *testing.T) {
@@ -370,3 +372,95 @@ func TestStrategyChangeCount(t *testing.T) {
 	plans = calculateScaleOutPlan(strategy, TiKV, scaleOutQuota, groups)
 	re.Equal(uint64(1), plans[0].Count)
 }
+
+type mockClusterInformer struct {
+	*mockcluster.Cluster
+}
+
+func (*mockClusterInformer) GetEtcdClient() *clientv3.Client {
+	return nil
+}
+
+func TestGetPlans(t *testing.T) {
+	t.Parallel()
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())
+	tikvGroup := fmt.Sprintf("%s-%s-0", autoScalingGroupLabelKeyPrefix, TiKV.String())
+	for storeID := uint64(1); storeID <= 2; storeID++ {
+		putHeartbeatStore(tc, storeID, map[string]string{groupLabelKey: tikvGroup, resourceTypeLabelKey: "a"})
+		updateHeartbeatStats(tc, storeID, 350, 100, 0.5)
+	}
+	putHeartbeatStore(tc, 3, map[string]string{core.EngineKey: core.EngineTiFlash, resourceTypeLabelKey: "a"})
+	updateHeartbeatStats(tc, 3, 100, 10, 0.1)
+	var count uint64 = 5
+	strategy := &Strategy{
+		Rules: []*Rule{
+			{
+				Component:   "tikv",
+				CPURule:     &CPURule{MaxThreshold: 0.8, MinThreshold: 0.2, ResourceTypes: []string{"a"}},
+				StorageRule: &StorageRule{MinThreshold: 0.2, ResourceTypes: []string{"a"}},
+				QPSRule:     &QPSRule{MaxThreshold: 1000, MinThreshold: 10, ResourceTypes: []string{"a"}},
+				// The cooldowns are disabled unless they are set.
+				ScaleOutCooldown: &typeutil.Duration{Duration: 3 * time.Minute},
+				ScaleInCooldown:  &typeutil.Duration{Duration: 5 * time.Minute},
+			},
+			{
+				Component:   "tiflash",
+				StorageRule: &StorageRule{MinThreshold: 0.2, ResourceTypes: []string{"a"}},
+			},
+		},
+		Resources: []*Resource{{ResourceType: "a", CPU: 4000, Memory: 8, Storage: 1000, Count: &count}},
+	}
+	rc := &mockClusterInformer{tc}
+	querier := NewHeartbeatQuerier(tc, strategy)
+	c := newCalculator()
+	now := time.Now()
+	checkPlan := func(plans []*Plan, component ComponentType, count uint64, reason string) *Plan {
+		re.Len(plans, 1)
+		re.Equal(component.String(), plans[0].Component)
+		re.Equal(count, plans[0].Count)
+		re.Equal(reason, plans[0].Reason)
+		return plans[0]
+	}
+
+	// The TiKV group is scaled out due to the high CPU usage, and the TiFlash store is not counted.
+	plan := checkPlan(c.getPlans(rc, querier, strategy, TiKV, now), TiKV, 3, reasonCPUUsageHigh)
+	re.InDelta(0.875, plan.Metrics["cpu_usage"], 1e-6)
+	re.InDelta(100.0, plan.Metrics["qps_per_instance"], 1e-6)
+	// The group is not scaled out again in the cooldown, and the last plan is returned.
+	checkPlan(c.getPlans(rc, querier, strategy, TiKV, now.Add(time.Minute)), TiKV, 3, reasonCPUUsageHigh)
+	now = now.Add(3 * time.Minute)
+	checkPlan(c.getPlans(rc, querier, strategy, TiKV, now), TiKV, 3, reasonCPUUsageHigh)
+
+	// All metrics must be low to scale in.
+	for storeID := uint64(1); storeID <= 2; storeID++ {
+		updateHeartbeatStats(tc, storeID, 10, 100, 0.8)
+	}
+	now = now.Add(5 * time.Minute)
+	checkPlan(c.getPlans(rc, querier, strategy, TiKV, now), TiKV, 2, "")
+	// The QPS would be above the max threshold after scaling in.
+	strategy.Rules[0].QPSRule.MaxThreshold = 150
+	strategy.Rules[0].QPSRule.MinThreshold = 120
+	checkPlan(c.getPlans(rc, querier, strategy, TiKV, now), TiKV, 2, "")
+	strategy.Rules[0].QPSRule.MaxThreshold = 1000
+	strategy.Rules[0].QPSRule.MinThreshold = 10
+	// The available storage would be too low after scaling in.
+	for storeID := uint64(1); storeID <= 2; storeID++ {
+		updateHeartbeatStats(tc, storeID, 10, 1, 0.5)
+	}
+	checkPlan(c.getPlans(rc, querier, strategy, TiKV, now), TiKV, 2, "")
+	for storeID := uint64(1); storeID <= 2; storeID++ {
+		updateHeartbeatStats(tc, storeID, 10, 1, 0.8)
+	}
+	// The scaling in is not allowed soon after scaling out.
+	checkPlan(c.getPlans(rc, querier, strategy, TiKV, now.Add(-time.Minute)), TiKV, 2, "")
+	plan = checkPlan(c.getPlans(rc, querier, strategy, TiKV, now), TiKV, 1, reasonCPUUsageLow+","+reasonQPSLow)
+	re.InDelta(0.8, plan.Metrics["storage_available_ratio"], 1e-6)
+
+	// A new TiFlash group is created due to the low available storage.
+	plan = checkPlan(c.getPlans(rc, querier, strategy, TiFlash, now), TiFlash, 1, reasonStorageAvailableLow)
+	re.Equal(core.EngineTiFlash, plan.Labels[core.EngineKey])
+	re.Equal("a", plan.ResourceType)
+}
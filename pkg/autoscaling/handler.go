@@ -57,6 +57,8 @@ func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	plan := calculate(rc, h.svr.GetPDServerConfig(), &strategy)
+	// The request is served by a new calculator, so it neither records the scaling nor is
+	// affected by the cooldowns.
+	plan := newCalculator().calculate(rc, h.svr.GetPDServerConfig(), &strategy)
 	h.rd.JSON(w, http.StatusOK, plan)
 }
@@ -0,0 +1,101 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaling
+
+import (
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/statistics/utils"
+)
+
+// storeStatsInformer provides the store heartbeat statistics.
+type storeStatsInformer interface {
+	core.StoreSetInformer
+	GetStoresLoads() map[uint64][]float64
+}
+
+// HeartbeatQuerier queries metrics of TiKV and TiFlash from the store heartbeats
+// collected by PD, so that no external metrics storage is required.
+// The stores do not report their CPU and memory quota, which are derived from the
+// resources of the strategy by the resource type label of each store.
+type HeartbeatQuerier struct {
+	cluster  storeStatsInformer
+	strategy *Strategy
+}
+
+// NewHeartbeatQuerier returns a HeartbeatQuerier
+func NewHeartbeatQuerier(cluster storeStatsInformer, strategy *Strategy) *HeartbeatQuerier {
+	return &HeartbeatQuerier{
+		cluster:  cluster,
+		strategy: strategy,
+	}
+}
+
+// Query returns the metric value of each store in the options
+func (q *HeartbeatQuerier) Query(options *QueryOptions) (QueryResult, error) {
+	if options.component != TiKV && options.component != TiFlash {
+		return nil, errs.ErrUnsupportedComponentType.FastGenByArgs(options.component)
+	}
+	if options.metric == MemoryUsage {
+		// The memory usage is not reported by the store heartbeats.
+		return nil, errs.ErrUnsupportedMetricsType.FastGenByArgs(options.metric)
+	}
+
+	stores := make(map[string]*core.StoreInfo, len(options.addresses))
+	for _, store := range q.cluster.GetStores() {
+		stores[store.GetAddress()] = store
+	}
+	var loads map[uint64][]float64
+	if options.metric == CPUUsage || options.metric == QPS {
+		loads = q.cluster.GetStoresLoads()
+	}
+
+	result := make(QueryResult, len(options.addresses))
+	for _, addr := range options.addresses {
+		store, ok := stores[addr]
+		if !ok {
+			continue
+		}
+		switch options.metric {
+		case CPUUsage:
+			// The CPU usage is reported in percentage, which is converted to the
+			// used CPU time in seconds during the duration.
+			if load, ok := loads[store.GetID()]; ok {
+				result[addr] = load[utils.StoreCPUUsage] / 100 * options.duration.Seconds()
+			}
+		case QPS:
+			if load, ok := loads[store.GetID()]; ok {
+				result[addr] = load[utils.StoreReadQuery] + load[utils.StoreWriteQuery]
+			}
+		case StorageAvailable:
+			result[addr] = float64(store.GetAvailable())
+		case StorageCapacity:
+			result[addr] = float64(store.GetCapacity())
+		case CPUQuota, MemoryQuota:
+			resource := getResourceByType(q.strategy, store.GetLabelValue(resourceTypeLabelKey))
+			if resource == nil {
+				return nil, errs.ErrMissingResourceType.FastGenByArgs(store.GetID())
+			}
+			if options.metric == CPUQuota {
+				result[addr] = float64(resource.CPU) / milliCores
+			} else {
+				result[addr] = float64(resource.Memory)
+			}
+		default:
+			return nil, errs.ErrUnsupportedMetricsType.FastGenByArgs(options.metric)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+)
+
+// putHeartbeatStore puts a store whose address is its ID with the labels.
+func putHeartbeatStore(tc *mockcluster.Cluster, storeID uint64, labels map[string]string) {
+	tc.AddLabelsStore(storeID, 0, labels)
+	tc.PutStore(tc.GetStore(storeID).Clone(core.SetStoreAddress(fmt.Sprint(storeID), "", "")))
+}
+
+// updateHeartbeatStats updates the store heartbeat stats, where cpu is the CPU usage in percentage.
+func updateHeartbeatStats(tc *mockcluster.Cluster, storeID, cpu, qps uint64, availableRatio float64) {
+	stats := &pdpb.StoreStats{
+		StoreId:    storeID,
+		Capacity:   100 * units.GiB,
+		Available:  uint64(availableRatio * 100 * units.GiB),
+		CpuUsages:  []*pdpb.RecordPair{{Key: "grpc", Value: cpu}},
+		QueryStats: &pdpb.QueryStats{Get: qps * 10},
+		Interval:   &pdpb.TimeInterval{StartTimestamp: 0, EndTimestamp: 10},
+	}
+	tc.PutStore(tc.GetStore(storeID).Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(time.Now())))
+	tc.Set(storeID, stats)
+}
+
+func TestHeartbeatQuerier(t *testing.T) {
+	t.Parallel()
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())
+	for storeID := uint64(1); storeID <= 2; storeID++ {
+		putHeartbeatStore(tc, storeID, map[string]string{resourceTypeLabelKey: "a"})
+		updateHeartbeatStats(tc, storeID, 200, 100, 0.4)
+	}
+	putHeartbeatStore(tc, 3, map[string]string{})
+	updateHeartbeatStats(tc, 3, 100, 10, 0.5)
+	strategy := &Strategy{
+		Resources: []*Resource{{ResourceType: "a", CPU: 4000, Memory: 8 * units.GiB}},
+	}
+	querier := NewHeartbeatQuerier(tc, strategy)
+	query := func(metric MetricType, addresses ...string) (QueryResult, error) {
+		return querier.Query(NewQueryOptions(TiKV, metric, addresses, time.Now(), time.Minute))
+	}
+
+	testCases := []struct {
+		metric   MetricType
+		expected float64
+	}{
+		{CPUUsage, 120},
+		{CPUQuota, 4},
+		{MemoryQuota, 8 * units.GiB},
+		{StorageAvailable, 40 * units.GiB},
+		{StorageCapacity, 100 * units.GiB},
+		{QPS, 100},
+	}
+	for _, testCase := range testCases {
+		result, err := query(testCase.metric, "1", "2", "4")
+		re.NoError(err, testCase.metric.String())
+		re.Len(result, 2, testCase.metric.String())
+		re.InDelta(testCase.expected, result["1"], 1e-6, testCase.metric.String())
+		re.InDelta(testCase.expected, result["2"], 1e-6, testCase.metric.String())
+	}
+
+	// The quota is unknown if the store has no resource type.
+	_, err := query(CPUQuota, "1", "3")
+	re.True(errs.ErrMissingResourceType.Equal(err))
+	result, err := query(CPUUsage, "3")
+	re.NoError(err)
+	re.InDelta(60.0, result["3"], 1e-6)
+	_, err = query(MemoryUsage, "1")
+	re.True(errs.ErrUnsupportedMetricsType.Equal(err))
+	_, err = querier.Query(NewQueryOptions(TiDB, CPUUsage, []string{"1"}, time.Now(), time.Minute))
+	re.True(errs.ErrUnsupportedComponentType.Equal(err))
+
+	// The unsupported metrics fall back to the next querier.
+	multiQuerier := NewMultiQuerier(querier, &mockQuerier{})
+	result, err = multiQuerier.Query(NewQueryOptions(TiKV, MemoryUsage, []string{"1"}, time.Now(), 0))
+	re.NoError(err)
+	re.Equal(mockResultValue, result["1"])
+	result, err = multiQuerier.Query(NewQueryOptions(TiKV, CPUQuota, []string{"1"}, time.Now(), 0))
+	re.NoError(err)
+	re.InDelta(4.0, result["1"], 1e-6)
+	_, err = NewMultiQuerier(querier).Query(NewQueryOptions(TiKV, MemoryUsage, []string{"1"}, time.Now(), 0))
+	re.True(errs.ErrUnsupportedMetricsType.Equal(err))
+	_, err = NewMultiQuerier(querier, &mockQuerier{}).Query(NewQueryOptions(TiKV, CPUQuota, []string{"3"}, time.Now(), 0))
+	re.True(errs.ErrMissingResourceType.Equal(err))
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaling
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/server"
+	"go.uber.org/zap"
+)
+
+var (
+	// EvaluationInterval is the interval to evaluate the stored strategy and record the plans.
+	EvaluationInterval = time.Minute
+	// PlanHistoryRetention is how long the recorded plans are kept.
+	PlanHistoryRetention = 7 * 24 * time.Hour
+)
+
+// planGCBatchSize is the max number of the expired plan records removed at a time.
+const planGCBatchSize = 128
+
+// PlanRecord is the plans evaluated by the stored strategy at a time.
+type PlanRecord struct {
+	Timestamp time.Time `json:"timestamp"`
+	Plans     []*Plan   `json:"plans"`
+}
+
+// SaveStrategy stores the strategy which is evaluated periodically.
+func SaveStrategy(storage endpoint.AutoScalingStorage, strategy *Strategy) error {
+	return storage.SaveAutoScalingStrategy(strategy)
+}
+
+// LoadStrategy loads the stored strategy. It returns nil if there is no stored strategy.
+func LoadStrategy(storage endpoint.AutoScalingStorage) (*Strategy, error) {
+	strategy := &Strategy{}
+	ok, err := storage.LoadAutoScalingStrategy(strategy)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return strategy, nil
+}
+
+// RemoveStrategy removes the stored strategy to stop the periodic evaluation.
+func RemoveStrategy(storage endpoint.AutoScalingStorage) error {
+	return storage.RemoveAutoScalingStrategy()
+}
+
+// LoadPlanHistory loads the plans recorded in [start, end) in the order of the time.
+// The limit is the max number of the records, and 0 means no limit.
+func LoadPlanHistory(storage endpoint.AutoScalingStorage, start, end time.Time, limit int) ([]*PlanRecord, error) {
+	var (
+		records []*PlanRecord
+		err     error
+	)
+	if loadErr := storage.LoadAutoScalingPlans(start.UnixNano(), end.UnixNano(), limit, func(_, v string) {
+		record := &PlanRecord{}
+		if e := json.Unmarshal([]byte(v), record); e != nil {
+			err = errs.ErrJSONUnmarshal.Wrap(e).GenWithStackByCause()
+			return
+		}
+		records = append(records, record)
+	}); loadErr != nil {
+		return nil, loadErr
+	}
+	return records, err
+}
+
+// planRecorder evaluates the stored strategy periodically and records the plans.
+// The cooldowns of the rules only take effect on the recorded plans.
+type planRecorder struct {
+	svr        *server.Server
+	calculator *calculator
+}
+
+func newPlanRecorder(svr *server.Server) *planRecorder {
+	return &planRecorder{
+		svr:        svr,
+		calculator: newCalculator(),
+	}
+}
+
+// run evaluates the strategy until the context is canceled, e.g. the leadership is lost.
+func (r *planRecorder) run(ctx context.Context) {
+	defer logutil.LogPanic()
+
+	ticker := time.NewTicker(EvaluationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("auto scaling plan recorder is stopped")
+			return
+		case <-ticker.C:
+			if err := r.evaluate(time.Now()); err != nil {
+				log.Warn("failed to record auto scaling plans", errs.ZapError(err))
+			}
+		}
+	}
+}
+
+func (r *planRecorder) evaluate(now time.Time) error {
+	rc := r.svr.GetRaftCluster()
+	if rc == nil {
+		return nil
+	}
+	storage := r.svr.GetStorage()
+	strategy, err := LoadStrategy(storage)
+	if err != nil || strategy == nil {
+		return err
+	}
+	record := &PlanRecord{
+		Timestamp: now,
+		Plans:     r.calculator.calculate(rc, r.svr.GetPDServerConfig(), strategy),
+	}
+	if err := storage.SaveAutoScalingPlans(now.UnixNano(), record); err != nil {
+		return err
+	}
+	return gcPlanHistory(storage, now.Add(-PlanHistoryRetention))
+}
+
+// gcPlanHistory removes the plans recorded before the safe point.
+func gcPlanHistory(storage endpoint.AutoScalingStorage, safePoint time.Time) error {
+	var expired []int64
+	if err := storage.LoadAutoScalingPlans(0, safePoint.UnixNano(), planGCBatchSize, func(k, _ string) {
+		if ts, err := strconv.ParseInt(path.Base(k), 10, 64); err == nil {
+			expired = append(expired, ts)
+		}
+	}); err != nil {
+		return err
+	}
+	for _, ts := range expired {
+		if err := storage.RemoveAutoScalingPlans(ts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
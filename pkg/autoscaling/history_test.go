@@ -0,0 +1,67 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func TestPlanHistory(t *testing.T) {
+	re := require.New(t)
+	storage := storage.NewStorageWithMemoryBackend()
+
+	strategy, err := LoadStrategy(storage)
+	re.NoError(err)
+	re.Nil(strategy)
+	re.NoError(SaveStrategy(storage, &Strategy{Rules: []*Rule{{Component: "tikv"}}}))
+	strategy, err = LoadStrategy(storage)
+	re.NoError(err)
+	re.Len(strategy.Rules, 1)
+	re.NoError(RemoveStrategy(storage))
+	strategy, err = LoadStrategy(storage)
+	re.NoError(err)
+	re.Nil(strategy)
+
+	start := time.Unix(1000, 0)
+	for i := 0; i < 5; i++ {
+		ts := start.Add(time.Duration(i) * time.Minute)
+		record := &PlanRecord{Timestamp: ts, Plans: []*Plan{{Component: "tikv", Count: uint64(i)}}}
+		re.NoError(storage.SaveAutoScalingPlans(ts.UnixNano(), record))
+	}
+	records, err := LoadPlanHistory(storage, start, start.Add(time.Hour), 0)
+	re.NoError(err)
+	re.Len(records, 5)
+	for i, record := range records {
+		re.True(start.Add(time.Duration(i) * time.Minute).Equal(record.Timestamp))
+		re.Equal(uint64(i), record.Plans[0].Count)
+	}
+	records, err = LoadPlanHistory(storage, start.Add(time.Minute), start.Add(3*time.Minute), 0)
+	re.NoError(err)
+	re.Len(records, 2)
+	records, err = LoadPlanHistory(storage, start, start.Add(time.Hour), 3)
+	re.NoError(err)
+	re.Len(records, 3)
+
+	// The records before the safe point are removed.
+	re.NoError(gcPlanHistory(storage, start.Add(2*time.Minute)))
+	records, err = LoadPlanHistory(storage, start, start.Add(time.Hour), 0)
+	re.NoError(err)
+	re.Len(records, 3)
+	re.Equal(uint64(2), records[0].Plans[0].Count)
+}
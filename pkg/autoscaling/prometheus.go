@@ -36,9 +36,24 @@ const (
 	tidbSumCPUUsageMetricsPattern = `sum(increase(process_cpu_seconds_total{component="tidb"}[%s])) by (instance, kubernetes_namespace)`
 	tikvCPUQuotaMetricsPattern    = `tikv_server_cpu_cores_quota`
 	tidbCPUQuotaMetricsPattern    = `tidb_server_maxprocs`
-	instanceLabelName             = "instance"
-	namespaceLabelName            = "kubernetes_namespace"
-	addressFormat                 = "pod-name.peer-svc.namespace.svc:port"
+
+	tiflashSumCPUUsageMetricsPattern = `sum(increase(process_cpu_seconds_total{component="tiflash"}[%s])) by (instance, kubernetes_namespace)`
+	tiflashCPUQuotaMetricsPattern    = `tiflash_proxy_tikv_server_cpu_cores_quota`
+
+	sumMemoryUsageMetricsPattern = `sum(process_resident_memory_bytes{component="%s"}) by (instance, kubernetes_namespace)`
+
+	tikvSumQPSMetricsPattern    = `sum(rate(tikv_grpc_msg_duration_seconds_count{type!="kv_gc"}[%s])) by (instance, kubernetes_namespace)`
+	tidbSumQPSMetricsPattern    = `sum(rate(tidb_server_query_total[%s])) by (instance, kubernetes_namespace)`
+	tiflashSumQPSMetricsPattern = `sum(rate(tiflash_coprocessor_request_count[%s])) by (instance, kubernetes_namespace)`
+
+	tikvStorageAvailableMetricsPattern    = `sum(tikv_store_size_bytes{type="available"}) by (instance, kubernetes_namespace)`
+	tikvStorageCapacityMetricsPattern     = `sum(tikv_store_size_bytes{type="capacity"}) by (instance, kubernetes_namespace)`
+	tiflashStorageAvailableMetricsPattern = `sum(tiflash_system_current_metric_StoreSizeAvailable) by (instance, kubernetes_namespace)`
+	tiflashStorageCapacityMetricsPattern  = `sum(tiflash_system_current_metric_StoreSizeCapacity) by (instance, kubernetes_namespace)`
+
+	instanceLabelName  = "instance"
+	namespaceLabelName = "kubernetes_namespace"
+	addressFormat      = "pod-name.peer-svc.namespace.svc:port"
 
 	httpRequestTimeout = 5 * time.Second
 )
@@ -58,8 +73,12 @@ func NewPrometheusQuerier(client promClient.Client) *PrometheusQuerier {
 type promQLBuilderFn func(*QueryOptions) (string, error)
 
 var queryBuilderFnMap = map[MetricType]promQLBuilderFn{
-	CPUQuota: buildCPUQuotaPromQL,
-	CPUUsage: buildCPUUsagePromQL,
+	CPUQuota:         buildCPUQuotaPromQL,
+	CPUUsage:         buildCPUUsagePromQL,
+	MemoryUsage:      buildMemoryUsagePromQL,
+	QPS:              buildQPSPromQL,
+	StorageAvailable: buildStorageAvailablePromQL,
+	StorageCapacity:  buildStorageCapacityPromQL,
 }
 
 // Query do the real query on Prometheus and returns metric value for each instance
@@ -155,13 +174,31 @@ func extractInstancesFromResponse(resp promModel.Value, addresses []string) (Que
 }
 
 var cpuUsagePromQLTemplate = map[ComponentType]string{
-	TiDB: tidbSumCPUUsageMetricsPattern,
-	TiKV: tikvSumCPUUsageMetricsPattern,
+	TiDB:    tidbSumCPUUsageMetricsPattern,
+	TiKV:    tikvSumCPUUsageMetricsPattern,
+	TiFlash: tiflashSumCPUUsageMetricsPattern,
 }
 
 var cpuQuotaPromQLTemplate = map[ComponentType]string{
-	TiDB: tidbCPUQuotaMetricsPattern,
-	TiKV: tikvCPUQuotaMetricsPattern,
+	TiDB:    tidbCPUQuotaMetricsPattern,
+	TiKV:    tikvCPUQuotaMetricsPattern,
+	TiFlash: tiflashCPUQuotaMetricsPattern,
+}
+
+var qpsPromQLTemplate = map[ComponentType]string{
+	TiDB:    tidbSumQPSMetricsPattern,
+	TiKV:    tikvSumQPSMetricsPattern,
+	TiFlash: tiflashSumQPSMetricsPattern,
+}
+
+var storageAvailablePromQLTemplate = map[ComponentType]string{
+	TiKV:    tikvStorageAvailableMetricsPattern,
+	TiFlash: tiflashStorageAvailableMetricsPattern,
+}
+
+var storageCapacityPromQLTemplate = map[ComponentType]string{
+	TiKV:    tikvStorageCapacityMetricsPattern,
+	TiFlash: tiflashStorageCapacityMetricsPattern,
 }
 
 func buildCPUQuotaPromQL(options *QueryOptions) (string, error) {
@@ -184,6 +221,41 @@ func buildCPUUsagePromQL(options *QueryOptions) (string, error) {
 	return query, nil
 }
 
+func buildMemoryUsagePromQL(options *QueryOptions) (string, error) {
+	switch options.component {
+	case TiDB, TiKV, TiFlash:
+		return fmt.Sprintf(sumMemoryUsageMetricsPattern, options.component.String()), nil
+	default:
+		return "", errs.ErrUnsupportedComponentType.FastGenByArgs(options.component)
+	}
+}
+
+func buildQPSPromQL(options *QueryOptions) (string, error) {
+	pattern, ok := qpsPromQLTemplate[options.component]
+	if !ok {
+		return "", errs.ErrUnsupportedComponentType.FastGenByArgs(options.component)
+	}
+
+	query := fmt.Sprintf(pattern, getDurationExpression(options.duration))
+	return query, nil
+}
+
+func buildStorageAvailablePromQL(options *QueryOptions) (string, error) {
+	query, ok := storageAvailablePromQLTemplate[options.component]
+	if !ok {
+		return "", errs.ErrUnsupportedComponentType.FastGenByArgs(options.component)
+	}
+	return query, nil
+}
+
+func buildStorageCapacityPromQL(options *QueryOptions) (string, error) {
+	query, ok := storageCapacityPromQLTemplate[options.component]
+	if !ok {
+		return "", errs.ErrUnsupportedComponentType.FastGenByArgs(options.component)
+	}
+	return query, nil
+}
+
 // this function assumes that addr is already a valid resolvable address
 // returns in format "podname_namespace"
 func getInstanceNameFromAddress(addr string) (string, error) {
@@ -14,7 +14,11 @@
 
 package autoscaling
 
-import "time"
+import (
+	"time"
+
+	"github.com/tikv/pd/pkg/errs"
+)
 
 // QueryResult stores metrics value for each instance
 type QueryResult map[string]float64
@@ -47,3 +51,34 @@ func NewQueryOptions(component ComponentType, metric MetricType, addresses []str
 		duration,
 	}
 }
+
+// MultiQuerier queries metrics from a list of queriers in order. The next querier is
+// tried only if the metrics or the component is not supported by the previous ones.
+type MultiQuerier struct {
+	queriers []Querier
+}
+
+// NewMultiQuerier returns a MultiQuerier
+func NewMultiQuerier(queriers ...Querier) *MultiQuerier {
+	return &MultiQuerier{queriers: queriers}
+}
+
+// Query returns the result of the first querier which supports the options
+func (q *MultiQuerier) Query(options *QueryOptions) (QueryResult, error) {
+	err := errs.ErrUnsupportedMetricsType.FastGenByArgs(options.metric)
+	for _, querier := range q.queriers {
+		var result QueryResult
+		result, err = querier.Query(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isUnsupportedError(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func isUnsupportedError(err error) bool {
+	return errs.ErrUnsupportedMetricsType.Equal(err) || errs.ErrUnsupportedComponentType.Equal(err)
+}
@@ -42,9 +42,16 @@ func NewHandler(_ context.Context, svr *server.Server) (http.Handler, apiutil.AP
 	rd := render.New(render.Options{
 		IndentJSON: true,
 	})
+	httpHandler := NewHTTPHandler(svr, rd)
 	autoScalingHandler.Handle(autoScalingPrefix, negroni.New(
 		serverapi.NewRedirector(svr),
-		negroni.Wrap(NewHTTPHandler(svr, rd))),
+		negroni.Wrap(httpHandler)),
 	)
+	// The stored strategy is evaluated periodically by the leader.
+	recorder := newPlanRecorder(svr)
+	svr.AddServiceReadyCallback(func(ctx context.Context) error {
+		go recorder.run(ctx)
+		return nil
+	})
 	return autoScalingHandler, autoscalingServiceGroup, nil
 }
@@ -0,0 +1,139 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaling
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/tikv/pd/pkg/errs"
+)
+
+// Snapshot is a caller-supplied snapshot of the instances and their metrics, which is used to
+// simulate a strategy without querying the cluster.
+type Snapshot struct {
+	Instances []*InstanceSnapshot `json:"instances"`
+}
+
+// InstanceSnapshot is the snapshot of an instance. The metrics are keyed by the metric types,
+// e.g. "cpu_usage" and "memory_quota". Note that "cpu_usage" is the number of the used CPU cores
+// rather than the used CPU time.
+type InstanceSnapshot struct {
+	Component string             `json:"component"`
+	Address   string             `json:"address"`
+	Labels    map[string]string  `json:"labels"`
+	Metrics   map[string]float64 `json:"metrics"`
+}
+
+func (i *InstanceSnapshot) getLabelValue(key string) string {
+	for k, v := range i.Labels {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+// SimulationInput is the input of the simulation.
+type SimulationInput struct {
+	Strategy *Strategy `json:"strategy"`
+	Snapshot *Snapshot `json:"snapshot"`
+}
+
+// Simulate calculates the plans of the strategy against the snapshot. The simulation is
+// stateless, so the cooldowns never take effect.
+func Simulate(strategy *Strategy, snapshot *Snapshot) ([]*Plan, error) {
+	var plans []*Plan
+	c := newCalculator()
+	querier := &snapshotQuerier{snapshot: snapshot}
+	now := time.Now()
+	for _, comp := range getComponents(strategy) {
+		instances := snapshot.getInstances(comp)
+		if len(instances) == 0 {
+			continue
+		}
+		groups, err := snapshot.getScaledGroups(comp, instances)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, c.plan(querier, strategy, getRuleByComponent(strategy, comp), comp, instances, groups, now)...)
+	}
+	return plans, nil
+}
+
+func (s *Snapshot) getInstances(component ComponentType) []instance {
+	var instances []instance
+	for i, inst := range s.Instances {
+		if inst.Component == component.String() {
+			instances = append(instances, instance{id: uint64(i + 1), address: inst.Address})
+		}
+	}
+	return instances
+}
+
+func (s *Snapshot) getScaledGroups(component ComponentType, instances []instance) ([]*Plan, error) {
+	planMap := make(map[string]map[string]struct{}, len(instances))
+	resourceTypeMap := make(map[string]string)
+	for _, instance := range instances {
+		inst := s.Instances[instance.id-1]
+		groupName := inst.getLabelValue(groupLabelKey)
+		if !isAutoScaledGroup(groupName) {
+			continue
+		}
+
+		buildPlanMap(planMap, groupName, instance.address)
+		if _, ok := resourceTypeMap[groupName]; !ok {
+			resourceType := inst.getLabelValue(resourceTypeLabelKey)
+			if resourceType == "" {
+				return nil, errors.Errorf("instance %s is in auto-scaled group but has no resource type label", instance.address)
+			}
+			resourceTypeMap[groupName] = resourceType
+		}
+	}
+	return buildPlans(planMap, resourceTypeMap, component), nil
+}
+
+// snapshotQuerier queries the metrics from the snapshot.
+type snapshotQuerier struct {
+	snapshot *Snapshot
+}
+
+// Query returns the metrics of the instances in the snapshot. The metrics type is
+// unsupported if no instance has it.
+func (q *snapshotQuerier) Query(options *QueryOptions) (QueryResult, error) {
+	addresses := make(map[string]struct{}, len(options.addresses))
+	for _, addr := range options.addresses {
+		addresses[addr] = struct{}{}
+	}
+	result := make(QueryResult)
+	for _, inst := range q.snapshot.Instances {
+		if _, ok := addresses[inst.Address]; !ok || inst.Component != options.component.String() {
+			continue
+		}
+		value, ok := inst.Metrics[options.metric.String()]
+		if !ok {
+			continue
+		}
+		if options.metric == CPUUsage {
+			value *= options.duration.Seconds()
+		}
+		result[inst.Address] = value
+	}
+	if len(result) == 0 {
+		return nil, errs.ErrUnsupportedMetricsType.FastGenByArgs(options.metric)
+	}
+	return result, nil
+}
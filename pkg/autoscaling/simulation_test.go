@@ -0,0 +1,90 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaling
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	re := require.New(t)
+	tidbGroup := fmt.Sprintf("%s-%s-0", autoScalingGroupLabelKeyPrefix, TiDB.String())
+	snapshot := &Snapshot{}
+	for i := 0; i < 2; i++ {
+		snapshot.Instances = append(snapshot.Instances,
+			&InstanceSnapshot{
+				Component: TiDB.String(),
+				Address:   fmt.Sprintf("tidb-%d", i),
+				Labels:    map[string]string{groupLabelKey: tidbGroup, resourceTypeLabelKey: "a"},
+				Metrics:   map[string]float64{CPUUsage.String(): 3.5, CPUQuota.String(): 4},
+			},
+			&InstanceSnapshot{
+				Component: TiKV.String(),
+				Address:   fmt.Sprintf("tikv-%d", i),
+				Metrics:   map[string]float64{MemoryUsage.String(): 1, MemoryQuota.String(): 8},
+			},
+		)
+	}
+	var count uint64 = 5
+	strategy := &Strategy{
+		Rules: []*Rule{
+			{
+				Component: "tidb",
+				CPURule:   &CPURule{MaxThreshold: 0.8, MinThreshold: 0.2, ResourceTypes: []string{"a"}},
+			},
+			{
+				Component:  "tikv",
+				MemoryRule: &MemoryRule{MaxThreshold: 0.8, MinThreshold: 0.2, ResourceTypes: []string{"a"}},
+			},
+		},
+		Resources: []*Resource{{ResourceType: "a", CPU: 4000, Memory: 8, Storage: 1000, Count: &count}},
+	}
+
+	plans, err := Simulate(strategy, snapshot)
+	re.NoError(err)
+	re.Len(plans, 1)
+	re.Equal(TiDB.String(), plans[0].Component)
+	re.Equal(uint64(3), plans[0].Count)
+	re.Equal(reasonCPUUsageHigh, plans[0].Reason)
+	re.InDelta(0.875, plans[0].Metrics["cpu_usage"], 1e-6)
+
+	// The simulation is stateless, so the cooldown does not take effect.
+	plans, err = Simulate(strategy, snapshot)
+	re.NoError(err)
+	re.Len(plans, 1)
+	re.Equal(uint64(3), plans[0].Count)
+
+	// A new TiKV group is created due to the high memory usage.
+	for _, inst := range snapshot.Instances {
+		if inst.Component == TiKV.String() {
+			inst.Metrics[MemoryUsage.String()] = 7
+		}
+	}
+	plans, err = Simulate(strategy, snapshot)
+	re.NoError(err)
+	re.Len(plans, 2)
+	re.Equal(TiKV.String(), plans[0].Component)
+	re.Equal(uint64(1), plans[0].Count)
+	re.Equal(reasonMemoryUsageHigh, plans[0].Reason)
+	re.Equal(TiDB.String(), plans[1].Component)
+
+	// The instance in an auto-scaled group must have the resource type label.
+	delete(snapshot.Instances[0].Labels, resourceTypeLabelKey)
+	_, err = Simulate(strategy, snapshot)
+	re.Error(err)
+}
@@ -19,8 +19,10 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/tikv/pd/pkg/utils/etcdutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
 	"go.etcd.io/etcd/clientv3"
 )
 
@@ -35,6 +37,43 @@ type Rule struct {
 	Component   string       `json:"component"`
 	CPURule     *CPURule     `json:"cpu_rule,omitempty"`
 	StorageRule *StorageRule `json:"storage_rule,omitempty"`
+	MemoryRule  *MemoryRule  `json:"memory_rule,omitempty"`
+	QPSRule     *QPSRule     `json:"qps_rule,omitempty"`
+	// ScaleOutCooldown is the min interval between the scale out and the next scale out.
+	// There is no cooldown if it is not set, and so is ScaleInCooldown.
+	ScaleOutCooldown *typeutil.Duration `json:"scale_out_cooldown,omitempty"`
+	// ScaleInCooldown is the min interval between any scaling and the next scale in.
+	ScaleInCooldown *typeutil.Duration `json:"scale_in_cooldown,omitempty"`
+}
+
+// getResourceTypes returns the resource types which can be used to scale the component.
+func (r *Rule) getResourceTypes() []string {
+	switch {
+	case r.CPURule != nil:
+		return r.CPURule.ResourceTypes
+	case r.MemoryRule != nil:
+		return r.MemoryRule.ResourceTypes
+	case r.QPSRule != nil:
+		return r.QPSRule.ResourceTypes
+	case r.StorageRule != nil:
+		return r.StorageRule.ResourceTypes
+	default:
+		return nil
+	}
+}
+
+func (r *Rule) getScaleOutCooldown() time.Duration {
+	if r.ScaleOutCooldown == nil {
+		return 0
+	}
+	return r.ScaleOutCooldown.Duration
+}
+
+func (r *Rule) getScaleInCooldown() time.Duration {
+	if r.ScaleInCooldown == nil {
+		return 0
+	}
+	return r.ScaleInCooldown.Duration
 }
 
 // CPURule is the constraints about CPU.
@@ -44,12 +83,27 @@ type CPURule struct {
 	ResourceTypes []string `json:"resource_types"`
 }
 
-// StorageRule is the constraints about storage.
+// StorageRule is the constraints about storage. MinThreshold is the min ratio of the
+// available storage, below which the component is scaled out.
 type StorageRule struct {
 	MinThreshold  float64  `json:"min_threshold"`
 	ResourceTypes []string `json:"resource_types"`
 }
 
+// MemoryRule is the constraints about the ratio of the used memory to the memory quota.
+type MemoryRule struct {
+	MaxThreshold  float64  `json:"max_threshold"`
+	MinThreshold  float64  `json:"min_threshold"`
+	ResourceTypes []string `json:"resource_types"`
+}
+
+// QPSRule is the constraints about the average QPS of each instance.
+type QPSRule struct {
+	MaxThreshold  float64  `json:"max_threshold"`
+	MinThreshold  float64  `json:"min_threshold"`
+	ResourceTypes []string `json:"resource_types"`
+}
+
 // Resource represents a kind of resource set including CPU, memory, storage.
 type Resource struct {
 	ResourceType string `json:"resource_type"`
@@ -68,6 +122,9 @@ type Plan struct {
 	Count        uint64            `json:"count"`
 	ResourceType string            `json:"resource_type"`
 	Labels       map[string]string `json:"labels"`
+	// Reason and Metrics explain why the group is scaled. They are empty if the group is not changed.
+	Reason  string             `json:"reason,omitempty"`
+	Metrics map[string]float64 `json:"metrics,omitempty"`
 }
 
 // ComponentType distinguishes different kinds of components.
@@ -78,6 +135,8 @@ const (
 	TiKV ComponentType = iota
 	// TiDB indicates the TiDB component
 	TiDB
+	// TiFlash indicates the TiFlash component
+	TiFlash
 )
 
 func (c ComponentType) String() string {
@@ -86,6 +145,8 @@ func (c ComponentType) String() string {
 		return "tikv"
 	case TiDB:
 		return "tidb"
+	case TiFlash:
+		return "tiflash"
 	default:
 		return "unknown"
 	}
@@ -99,6 +160,16 @@ const (
 	CPUUsage MetricType = iota
 	// CPUQuota is cpu cores quota for each instance
 	CPUQuota
+	// StorageAvailable is the available storage in bytes for each instance
+	StorageAvailable
+	// StorageCapacity is the storage capacity in bytes for each instance
+	StorageCapacity
+	// MemoryUsage is the used memory in bytes for each instance
+	MemoryUsage
+	// MemoryQuota is the memory quota in bytes for each instance
+	MemoryQuota
+	// QPS is the queries per second for each instance
+	QPS
 )
 
 func (c MetricType) String() string {
@@ -107,6 +178,16 @@ func (c MetricType) String() string {
 		return "cpu_usage"
 	case CPUQuota:
 		return "cpu_quota"
+	case StorageAvailable:
+		return "storage_available"
+	case StorageCapacity:
+		return "storage_capacity"
+	case MemoryUsage:
+		return "memory_usage"
+	case MemoryQuota:
+		return "memory_quota"
+	case QPS:
+		return "qps"
 	default:
 		return "unknown"
 	}
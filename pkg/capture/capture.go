@@ -0,0 +1,199 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package capture implements a compact binary format to record the region and
+// store heartbeats received by PD, so they can be replayed later.
+//
+// A capture starts with an 8-byte magic and the start time in unix nanoseconds,
+// followed by the records. Each record is a type byte, the uvarint offset in
+// microseconds since the start time, the uvarint length of the payload and the
+// protobuf-encoded heartbeat request.
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+)
+
+const magic = "PDHBCAP1"
+
+// maxPayloadSize is the max size of a record payload, to avoid allocating too
+// much memory for a corrupted capture.
+const maxPayloadSize = 64 << 20
+
+// RecordType is the type of a record.
+type RecordType byte
+
+// The types of the records.
+const (
+	RegionHeartbeat RecordType = iota + 1
+	StoreHeartbeat
+)
+
+// Record is a heartbeat in the capture.
+type Record struct {
+	Type RecordType
+	// Offset is the duration since the start of the capture.
+	Offset          time.Duration
+	RegionHeartbeat *pdpb.RegionHeartbeatRequest
+	StoreHeartbeat  *pdpb.StoreHeartbeatRequest
+}
+
+// Writer writes the heartbeats into a capture. It is not thread-safe.
+type Writer struct {
+	w     *bufio.Writer
+	start time.Time
+	size  int64
+	buf   []byte
+}
+
+// NewWriter creates a Writer and writes the header of the capture.
+func NewWriter(w io.Writer, start time.Time) (*Writer, error) {
+	cw := &Writer{
+		w:     bufio.NewWriter(w),
+		start: start,
+		buf:   make([]byte, 0, 2*binary.MaxVarintLen64+1),
+	}
+	header := make([]byte, 0, len(magic)+8)
+	header = append(header, magic...)
+	header = binary.BigEndian.AppendUint64(header, uint64(start.UnixNano()))
+	if _, err := cw.w.Write(header); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	cw.size = int64(len(header))
+	return cw, nil
+}
+
+// WriteRegionHeartbeat writes a region heartbeat received at the given time.
+func (w *Writer) WriteRegionHeartbeat(t time.Time, req *pdpb.RegionHeartbeatRequest) error {
+	return w.write(RegionHeartbeat, t, req)
+}
+
+// WriteStoreHeartbeat writes a store heartbeat received at the given time.
+func (w *Writer) WriteStoreHeartbeat(t time.Time, req *pdpb.StoreHeartbeatRequest) error {
+	return w.write(StoreHeartbeat, t, req)
+}
+
+func (w *Writer) write(typ RecordType, t time.Time, msg proto.Message) error {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	offset := t.Sub(w.start)
+	if offset < 0 {
+		offset = 0
+	}
+	w.buf = append(w.buf[:0], byte(typ))
+	w.buf = binary.AppendUvarint(w.buf, uint64(offset/time.Microsecond))
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(payload)))
+	if _, err := w.w.Write(w.buf); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := w.w.Write(payload); err != nil {
+		return errors.WithStack(err)
+	}
+	w.size += int64(len(w.buf) + len(payload))
+	return nil
+}
+
+// Size returns the bytes written into the capture.
+func (w *Writer) Size() int64 {
+	return w.size
+}
+
+// Flush writes the buffered data into the underlying writer.
+func (w *Writer) Flush() error {
+	return errors.WithStack(w.w.Flush())
+}
+
+// Reader reads the heartbeats from a capture.
+type Reader struct {
+	r     *bufio.Reader
+	start time.Time
+}
+
+// NewReader creates a Reader and reads the header of the capture.
+func NewReader(r io.Reader) (*Reader, error) {
+	cr := &Reader{r: bufio.NewReader(r)}
+	header := make([]byte, len(magic)+8)
+	if _, err := io.ReadFull(cr.r, header); err != nil {
+		return nil, errors.Annotate(err, "failed to read the capture header")
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, errors.New("invalid capture header")
+	}
+	cr.start = time.Unix(0, int64(binary.BigEndian.Uint64(header[len(magic):])))
+	return cr, nil
+}
+
+// StartTime returns the start time of the capture.
+func (r *Reader) StartTime() time.Time {
+	return r.start
+}
+
+// Next returns the next record. It returns io.EOF if there is no more record.
+func (r *Reader) Next() (*Record, error) {
+	typ, err := r.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	offset, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, errors.Annotate(unexpectedEOF(err), "failed to read the record offset")
+	}
+	length, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, errors.Annotate(unexpectedEOF(err), "failed to read the record length")
+	}
+	if length > maxPayloadSize {
+		return nil, errors.Errorf("record payload is too large, size %d", length)
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		return nil, errors.Annotate(unexpectedEOF(err), "failed to read the record payload")
+	}
+	record := &Record{
+		Type:   RecordType(typ),
+		Offset: time.Duration(offset) * time.Microsecond,
+	}
+	switch record.Type {
+	case RegionHeartbeat:
+		record.RegionHeartbeat = &pdpb.RegionHeartbeatRequest{}
+		err = proto.Unmarshal(payload, record.RegionHeartbeat)
+	case StoreHeartbeat:
+		record.StoreHeartbeat = &pdpb.StoreHeartbeatRequest{}
+		err = proto.Unmarshal(payload, record.StoreHeartbeat)
+	default:
+		return nil, errors.Errorf("unknown record type %d", typ)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return record, nil
+}
+
+// unexpectedEOF converts io.EOF to io.ErrUnexpectedEOF, since the record is
+// truncated if the capture ends in the middle of it.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
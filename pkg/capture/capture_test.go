@@ -0,0 +1,72 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapture(t *testing.T) {
+	re := require.New(t)
+	start := time.Unix(1700000000, 0)
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, start)
+	re.NoError(err)
+	regionHeartbeat := &pdpb.RegionHeartbeatRequest{
+		Region: &metapb.Region{Id: 2, StartKey: []byte("a"), EndKey: []byte("b")},
+		Leader: &metapb.Peer{Id: 3, StoreId: 1},
+	}
+	storeHeartbeat := &pdpb.StoreHeartbeatRequest{Stats: &pdpb.StoreStats{StoreId: 1, RegionCount: 10}}
+	re.NoError(w.WriteRegionHeartbeat(start.Add(time.Second), regionHeartbeat))
+	re.NoError(w.WriteStoreHeartbeat(start.Add(2*time.Second), storeHeartbeat))
+	re.NoError(w.Flush())
+	re.Equal(int64(buf.Len()), w.Size())
+
+	data := buf.Bytes()
+	r, err := NewReader(bytes.NewReader(data))
+	re.NoError(err)
+	re.True(start.Equal(r.StartTime()))
+	record, err := r.Next()
+	re.NoError(err)
+	re.Equal(RegionHeartbeat, record.Type)
+	re.Equal(time.Second, record.Offset)
+	re.Equal(regionHeartbeat.String(), record.RegionHeartbeat.String())
+	record, err = r.Next()
+	re.NoError(err)
+	re.Equal(StoreHeartbeat, record.Type)
+	re.Equal(2*time.Second, record.Offset)
+	re.Equal(storeHeartbeat.String(), record.StoreHeartbeat.String())
+	_, err = r.Next()
+	re.Equal(io.EOF, err)
+
+	// A truncated capture.
+	r, err = NewReader(bytes.NewReader(data[:len(data)-1]))
+	re.NoError(err)
+	_, err = r.Next()
+	re.NoError(err)
+	_, err = r.Next()
+	re.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	// An invalid capture.
+	_, err = NewReader(bytes.NewReader([]byte("not a capture file")))
+	re.Error(err)
+}
@@ -0,0 +1,385 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+	"go.uber.org/zap"
+)
+
+// The defaults and limits of a recording.
+const (
+	DefaultDuration = 10 * time.Minute
+	MaxDuration     = 24 * time.Hour
+	DefaultMaxSize  = 256 * units.MiB
+	MaxSizeLimit    = 16 * units.GiB
+)
+
+// The reasons why a recording is stopped.
+const (
+	StopReasonFinished  = "finished"
+	StopReasonSizeLimit = "size-limit"
+	StopReasonStopped   = "stopped"
+	StopReasonError     = "error"
+)
+
+// sampleBase is the base to sample the regions by their IDs.
+const sampleBase = 10000
+
+// recordQueueSize is the number of the heartbeats which are waiting to be written. The
+// heartbeats are dropped once the queue is full, so the heartbeat handlers are never blocked.
+const recordQueueSize = 4096
+
+// captureFilePattern is the pattern of the capture files in the directory.
+const captureFilePattern = "heartbeat-*.cap"
+
+// RecordConfig is the config of a recording.
+type RecordConfig struct {
+	// Duration is the window of the recording.
+	Duration typeutil.Duration `json:"duration"`
+	// SampleRate is the ratio of the regions whose heartbeats are recorded. The
+	// regions are sampled by their IDs, so all the heartbeats of a sampled region
+	// are recorded. The store heartbeats are always recorded.
+	SampleRate float64 `json:"sample-rate"`
+	// MaxSize is the size cap of the capture file.
+	MaxSize typeutil.ByteSize `json:"max-size"`
+}
+
+// Adjust fills the default values and checks the config.
+func (c *RecordConfig) Adjust() error {
+	if c.Duration.Duration == 0 {
+		c.Duration.Duration = DefaultDuration
+	}
+	if c.SampleRate == 0 {
+		c.SampleRate = 1
+	}
+	if c.MaxSize == 0 {
+		c.MaxSize = DefaultMaxSize
+	}
+	if c.Duration.Duration < 0 || c.Duration.Duration > MaxDuration {
+		return errs.ErrCaptureInvalidConfig.FastGenByArgs(fmt.Sprintf("duration should be in (0, %s]", MaxDuration))
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return errs.ErrCaptureInvalidConfig.FastGenByArgs("sample-rate should be in (0, 1]")
+	}
+	if c.MaxSize > MaxSizeLimit {
+		return errs.ErrCaptureInvalidConfig.FastGenByArgs(fmt.Sprintf("max-size should be no more than %s", units.BytesSize(MaxSizeLimit)))
+	}
+	return nil
+}
+
+// RecordStatus is the status of the current or the last recording.
+type RecordStatus struct {
+	Recording         bool          `json:"recording"`
+	File              string        `json:"file,omitempty"`
+	Config            *RecordConfig `json:"config,omitempty"`
+	StartTime         time.Time     `json:"start-time,omitempty"`
+	StopTime          time.Time     `json:"stop-time,omitempty"`
+	StopReason        string        `json:"stop-reason,omitempty"`
+	Error             string        `json:"error,omitempty"`
+	Size              int64         `json:"size"`
+	RegionHeartbeats  uint64        `json:"region-heartbeats"`
+	StoreHeartbeats   uint64        `json:"store-heartbeats"`
+	DroppedHeartbeats uint64        `json:"dropped-heartbeats"`
+}
+
+// Recorder records the heartbeats received by PD into a capture file in the
+// given directory. Only one recording can be running at the same time.
+type Recorder struct {
+	dir          string
+	maxTotalSize int64
+	queueSize    int
+	// active is the recording which accepts the heartbeats. It's checked without
+	// locking, so the heartbeats are not blocked by the recorder.
+	active atomic.Pointer[recording]
+
+	mu syncutil.Mutex
+	// current is the recording which is not finished yet, it's still set after the
+	// recording is requested to stop until the queued heartbeats are written.
+	current *recording
+	status  RecordStatus
+}
+
+// recording is a running recording, whose heartbeats are written by a single goroutine.
+type recording struct {
+	cfg    RecordConfig
+	file   *os.File
+	writer *Writer
+	queue  chan *queuedRecord
+	timer  *time.Timer
+
+	stopOnce   sync.Once
+	stopCh     chan struct{}
+	stopReason string
+	done       chan struct{}
+
+	size             atomic.Int64
+	regionHeartbeats atomic.Uint64
+	storeHeartbeats  atomic.Uint64
+	dropped          atomic.Uint64
+}
+
+type queuedRecord struct {
+	typ  RecordType
+	time time.Time
+	msg  proto.Message
+}
+
+// NewRecorder creates a Recorder. The oldest capture files in the directory are removed
+// to keep the total size of the capture files under maxTotalSize.
+func NewRecorder(dir string, maxTotalSize int64) *Recorder {
+	return &Recorder{dir: dir, maxTotalSize: maxTotalSize, queueSize: recordQueueSize}
+}
+
+// Start starts a recording.
+func (r *Recorder) Start(cfg RecordConfig) (*RecordStatus, error) {
+	if err := cfg.Adjust(); err != nil {
+		return nil, err
+	}
+	if int64(cfg.MaxSize) > r.maxTotalSize {
+		return nil, errs.ErrCaptureInvalidConfig.FastGenByArgs(
+			fmt.Sprintf("max-size should be no more than the max total size %s", units.BytesSize(float64(r.maxTotalSize))))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current != nil {
+		return nil, errs.ErrCaptureIsRunning.FastGenByArgs()
+	}
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return nil, errs.ErrCaptureInvalidConfig.FastGenByArgs(err.Error())
+	}
+	if err := r.removeOldCaptures(int64(cfg.MaxSize)); err != nil {
+		return nil, errs.ErrCaptureInvalidConfig.FastGenByArgs(err.Error())
+	}
+	now := time.Now()
+	path := filepath.Join(r.dir, fmt.Sprintf("heartbeat-%s.cap", now.Format("20060102-150405.000000")))
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, errs.ErrCaptureInvalidConfig.FastGenByArgs(err.Error())
+	}
+	writer, err := NewWriter(file, now)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	rec := &recording{
+		cfg:    cfg,
+		file:   file,
+		writer: writer,
+		queue:  make(chan *queuedRecord, r.queueSize),
+		stopCh: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	rec.size.Store(writer.Size())
+	r.current = rec
+	r.status = RecordStatus{
+		Recording: true,
+		File:      path,
+		Config:    &rec.cfg,
+		StartTime: now,
+	}
+	rec.timer = time.AfterFunc(cfg.Duration.Duration, func() {
+		r.stop(rec, StopReasonFinished)
+	})
+	r.active.Store(rec)
+	go r.run(rec)
+	log.Info("heartbeat capture is started", zap.String("file", path),
+		zap.Duration("duration", cfg.Duration.Duration), zap.Float64("sample-rate", cfg.SampleRate),
+		zap.Uint64("max-size", uint64(cfg.MaxSize)))
+	return r.statusLocked(), nil
+}
+
+// removeOldCaptures removes the oldest capture files until there is room for a new
+// recording of the given size.
+func (r *Recorder) removeOldCaptures(size int64) error {
+	paths, err := filepath.Glob(filepath.Join(r.dir, captureFilePattern))
+	if err != nil {
+		return err
+	}
+	// The file names are ordered by the start time of the recordings.
+	sort.Strings(paths)
+	sizes := make([]int64, len(paths))
+	total := size
+	for i, path := range paths {
+		stat, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		sizes[i] = stat.Size()
+		total += sizes[i]
+	}
+	for i := 0; i < len(paths) && total > r.maxTotalSize; i++ {
+		if err := os.Remove(paths[i]); err != nil {
+			return err
+		}
+		total -= sizes[i]
+		log.Info("old heartbeat capture is removed", zap.String("file", paths[i]), zap.Int64("size", sizes[i]))
+	}
+	return nil
+}
+
+// Stop stops the current recording, and returns the status of it once the queued
+// heartbeats are written.
+func (r *Recorder) Stop() *RecordStatus {
+	r.mu.Lock()
+	rec := r.current
+	r.mu.Unlock()
+	if rec != nil {
+		r.stop(rec, StopReasonStopped)
+		<-rec.done
+	}
+	return r.Status()
+}
+
+// stop requests the recording to stop, the queued heartbeats are still written.
+func (r *Recorder) stop(rec *recording, reason string) {
+	rec.stopOnce.Do(func() {
+		r.active.CompareAndSwap(rec, nil)
+		rec.stopReason = reason
+		close(rec.stopCh)
+	})
+}
+
+// Status returns the status of the current or the last recording.
+func (r *Recorder) Status() *RecordStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.statusLocked()
+}
+
+func (r *Recorder) statusLocked() *RecordStatus {
+	status := r.status
+	if rec := r.current; rec != nil {
+		rec.fillStatus(&status)
+	}
+	return &status
+}
+
+func (rec *recording) fillStatus(status *RecordStatus) {
+	status.Size = rec.size.Load()
+	status.RegionHeartbeats = rec.regionHeartbeats.Load()
+	status.StoreHeartbeats = rec.storeHeartbeats.Load()
+	status.DroppedHeartbeats = rec.dropped.Load()
+}
+
+// RecordRegionHeartbeat records a region heartbeat if it is sampled.
+func (r *Recorder) RecordRegionHeartbeat(req *pdpb.RegionHeartbeatRequest) {
+	rec := r.active.Load()
+	if rec == nil || float64(req.GetRegion().GetId()%sampleBase) >= rec.cfg.SampleRate*sampleBase {
+		return
+	}
+	rec.enqueue(RegionHeartbeat, req)
+}
+
+// RecordStoreHeartbeat records a store heartbeat.
+func (r *Recorder) RecordStoreHeartbeat(req *pdpb.StoreHeartbeatRequest) {
+	if rec := r.active.Load(); rec != nil {
+		rec.enqueue(StoreHeartbeat, req)
+	}
+}
+
+func (rec *recording) enqueue(typ RecordType, msg proto.Message) {
+	select {
+	case rec.queue <- &queuedRecord{typ: typ, time: time.Now(), msg: msg}:
+	default:
+		rec.dropped.Add(1)
+	}
+}
+
+// run writes the queued heartbeats until the recording is stopped or reaches the size cap.
+func (r *Recorder) run(rec *recording) {
+	defer close(rec.done)
+	for {
+		select {
+		case record := <-rec.queue:
+			if reason, err := rec.write(record); reason != "" {
+				r.stop(rec, reason)
+				r.finish(rec, reason, err)
+				return
+			}
+		case <-rec.stopCh:
+			// Write the heartbeats queued before stopping.
+			for {
+				select {
+				case record := <-rec.queue:
+					if reason, err := rec.write(record); reason != "" {
+						r.finish(rec, reason, err)
+						return
+					}
+				default:
+					r.finish(rec, rec.stopReason, nil)
+					return
+				}
+			}
+		}
+	}
+}
+
+// write writes a queued heartbeat, and returns the reason if the recording should stop.
+func (rec *recording) write(record *queuedRecord) (string, error) {
+	if err := rec.writer.write(record.typ, record.time, record.msg); err != nil {
+		return StopReasonError, err
+	}
+	if record.typ == RegionHeartbeat {
+		rec.regionHeartbeats.Add(1)
+	} else {
+		rec.storeHeartbeats.Add(1)
+	}
+	rec.size.Store(rec.writer.Size())
+	if rec.writer.Size() >= int64(rec.cfg.MaxSize) {
+		return StopReasonSizeLimit, nil
+	}
+	return "", nil
+}
+
+// finish closes the capture file and records the final status of the recording.
+func (r *Recorder) finish(rec *recording, reason string, err error) {
+	rec.timer.Stop()
+	if flushErr := rec.writer.Flush(); flushErr != nil && err == nil {
+		reason, err = StopReasonError, flushErr
+	}
+	if closeErr := rec.file.Close(); closeErr != nil && err == nil {
+		reason, err = StopReasonError, closeErr
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec.size.Store(rec.writer.Size())
+	rec.fillStatus(&r.status)
+	r.status.Recording = false
+	r.status.StopTime = time.Now()
+	r.status.StopReason = reason
+	if err != nil {
+		r.status.Error = err.Error()
+	}
+	r.current = nil
+	log.Info("heartbeat capture is stopped", zap.String("file", r.status.File), zap.String("reason", reason),
+		zap.Int64("size", r.status.Size), zap.Uint64("region-heartbeats", r.status.RegionHeartbeats),
+		zap.Uint64("store-heartbeats", r.status.StoreHeartbeats),
+		zap.Uint64("dropped-heartbeats", r.status.DroppedHeartbeats), errs.ZapError(err))
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+func newRegionHeartbeat(regionID uint64) *pdpb.RegionHeartbeatRequest {
+	return &pdpb.RegionHeartbeatRequest{
+		Region: &metapb.Region{Id: regionID},
+		Leader: &metapb.Peer{Id: regionID + 1, StoreId: 1},
+	}
+}
+
+func readRecords(re *require.Assertions, path string) []*Record {
+	f, err := os.Open(path)
+	re.NoError(err)
+	defer f.Close()
+	r, err := NewReader(f)
+	re.NoError(err)
+	var records []*Record
+	for {
+		record, err := r.Next()
+		if err == io.EOF {
+			return records
+		}
+		re.NoError(err)
+		records = append(records, record)
+	}
+}
+
+func TestRecordConfig(t *testing.T) {
+	re := require.New(t)
+	cfg := RecordConfig{}
+	re.NoError(cfg.Adjust())
+	re.Equal(DefaultDuration, cfg.Duration.Duration)
+	re.Equal(1.0, cfg.SampleRate)
+	re.Equal(typeutil.ByteSize(DefaultMaxSize), cfg.MaxSize)
+	for _, cfg := range []RecordConfig{
+		{Duration: typeutil.NewDuration(-time.Second)},
+		{Duration: typeutil.NewDuration(MaxDuration + time.Second)},
+		{SampleRate: 1.5},
+		{MaxSize: MaxSizeLimit + 1},
+	} {
+		re.Error(cfg.Adjust())
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	re := require.New(t)
+	recorder := NewRecorder(t.TempDir(), MaxSizeLimit)
+	// Nothing is recorded before starting.
+	recorder.RecordRegionHeartbeat(newRegionHeartbeat(1))
+	re.False(recorder.Status().Recording)
+
+	status, err := recorder.Start(RecordConfig{SampleRate: 0.5})
+	re.NoError(err)
+	re.True(status.Recording)
+	_, err = recorder.Start(RecordConfig{})
+	re.Error(err)
+	for id := uint64(0); id < sampleBase; id += 1000 {
+		recorder.RecordRegionHeartbeat(newRegionHeartbeat(id))
+	}
+	recorder.RecordStoreHeartbeat(&pdpb.StoreHeartbeatRequest{Stats: &pdpb.StoreStats{StoreId: 1}})
+	status = recorder.Stop()
+	re.False(status.Recording)
+	re.Equal(StopReasonStopped, status.StopReason)
+	re.Equal(uint64(5), status.RegionHeartbeats)
+	re.Equal(uint64(1), status.StoreHeartbeats)
+	records := readRecords(re, status.File)
+	re.Len(records, 6)
+	for _, record := range records[:5] {
+		re.Less(record.RegionHeartbeat.GetRegion().GetId(), uint64(sampleBase/2))
+	}
+	re.Equal(uint64(1), records[5].StoreHeartbeat.GetStats().GetStoreId())
+	stat, err := os.Stat(status.File)
+	re.NoError(err)
+	re.Equal(stat.Size(), status.Size)
+}
+
+func TestRecorderLimits(t *testing.T) {
+	re := require.New(t)
+	recorder := NewRecorder(t.TempDir(), MaxSizeLimit)
+	_, err := recorder.Start(RecordConfig{MaxSize: 100})
+	re.NoError(err)
+	for id := uint64(1); id <= 100; id++ {
+		recorder.RecordRegionHeartbeat(newRegionHeartbeat(id))
+	}
+	re.Eventually(func() bool {
+		return !recorder.Status().Recording
+	}, 5*time.Second, 50*time.Millisecond)
+	status := recorder.Status()
+	re.Equal(StopReasonSizeLimit, status.StopReason)
+	re.Less(status.RegionHeartbeats, uint64(100))
+	re.Len(readRecords(re, status.File), int(status.RegionHeartbeats))
+
+	_, err = recorder.Start(RecordConfig{Duration: typeutil.NewDuration(100 * time.Millisecond)})
+	re.NoError(err)
+	re.Eventually(func() bool {
+		return !recorder.Status().Recording
+	}, 5*time.Second, 50*time.Millisecond)
+	status = recorder.Status()
+	re.Equal(StopReasonFinished, status.StopReason)
+	recorder.RecordRegionHeartbeat(newRegionHeartbeat(1))
+	re.Zero(recorder.Status().RegionHeartbeats)
+}
+
+func TestRecorderQueueOverflow(t *testing.T) {
+	re := require.New(t)
+	recorder := NewRecorder(t.TempDir(), MaxSizeLimit)
+	recorder.queueSize = 1
+	_, err := recorder.Start(RecordConfig{})
+	re.NoError(err)
+	const count = 1000
+	for id := uint64(0); id < count; id++ {
+		recorder.RecordRegionHeartbeat(newRegionHeartbeat(id))
+	}
+	// The heartbeats are either written or dropped.
+	status := recorder.Stop()
+	re.Equal(uint64(count), status.RegionHeartbeats+status.DroppedHeartbeats)
+	re.Len(readRecords(re, status.File), int(status.RegionHeartbeats))
+}
+
+func TestRecorderRetention(t *testing.T) {
+	re := require.New(t)
+	dir := t.TempDir()
+	recorder := NewRecorder(dir, 300)
+	_, err := recorder.Start(RecordConfig{MaxSize: 400})
+	re.Error(err)
+
+	var files []string
+	for i := 0; i < 3; i++ {
+		_, err := recorder.Start(RecordConfig{MaxSize: 100})
+		re.NoError(err)
+		for id := uint64(1); id <= 10; id++ {
+			recorder.RecordRegionHeartbeat(newRegionHeartbeat(id))
+		}
+		re.Eventually(func() bool {
+			return !recorder.Status().Recording
+		}, 5*time.Second, 50*time.Millisecond)
+		files = append(files, recorder.Status().File)
+	}
+	// The oldest capture is removed to make room for the last one.
+	_, err = os.Stat(files[0])
+	re.True(os.IsNotExist(err))
+	for _, file := range files[1:] {
+		_, err = os.Stat(file)
+		re.NoError(err)
+	}
+}
@@ -836,6 +836,7 @@ type RegionsInfo struct {
 	learners     map[uint64]*regionTree // storeID -> sub regionTree
 	witnesses    map[uint64]*regionTree // storeID -> sub regionTree
 	pendingPeers map[uint64]*regionTree // storeID -> sub regionTree
+	subscribers  regionSubscriptions
 }
 
 // NewRegionsInfo creates RegionsInfo with tree, regions, leaders and followers
@@ -994,6 +995,7 @@ func (r *RegionsInfo) setRegionLocked(region *RegionInfo, withOverlaps bool, ol
 			r.tree.updateStat(origin, region)
 			// Update the RegionInfo in the regionItem.
 			item.RegionInfo = region
+			r.notifyRegionChanged(region, origin, rangeChanged)
 			return origin, nil, rangeChanged
 		}
 	} else {
@@ -1009,6 +1011,13 @@ func (r *RegionsInfo) setRegionLocked(region *RegionInfo, withOverlaps bool, ol
 			delete(r.regions, old.GetID())
 		}
 	}
+	// The subscribers are notified with the tree lock held, so the changes are pushed in the order
+	// they are applied, even if they carry the same epoch, e.g, the leader changes.
+	// The regions dropped by a merge or overlapped by the region are removed first.
+	for _, old := range overlaps {
+		r.notifyRegionRemoved(old)
+	}
+	r.notifyRegionChanged(region, origin, rangeChanged)
 	// return rangeChanged to prevent duplicated calculation
 	return origin, overlaps, rangeChanged
 }
@@ -1129,10 +1138,11 @@ func (r *RegionsInfo) GetOverlaps(region *RegionInfo) []*regionItem {
 // RemoveRegion removes RegionInfo from regionTree and regionMap
 func (r *RegionsInfo) RemoveRegion(region *RegionInfo) {
 	r.t.Lock()
-	defer r.t.Unlock()
 	// Remove from tree and regions.
 	r.tree.remove(region)
 	delete(r.regions, region.GetID())
+	r.notifyRegionRemoved(region)
+	r.t.Unlock()
 }
 
 // ResetRegionCache resets the regions info.
@@ -1140,6 +1150,7 @@ func (r *RegionsInfo) ResetRegionCache() {
 	r.t.Lock()
 	r.tree = newRegionTree()
 	r.regions = make(map[uint64]*regionItem)
+	r.notifyAllRegionsRemoved()
 	r.t.Unlock()
 	r.st.Lock()
 	defer r.st.Unlock()
@@ -0,0 +1,139 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// RegionChange is a change of the region pushed to the subscribers.
+type RegionChange struct {
+	Region *RegionInfo
+	// Removed indicates the region is removed from the cache, e.g, it's tombstone or dropped.
+	Removed bool
+}
+
+// RegionSubscription receives the changes of the regions overlapping with a key range.
+type RegionSubscription struct {
+	id       uint64
+	startKey []byte
+	endKey   []byte
+	ch       chan *RegionChange
+	// overflow is closed when the subscriber cannot keep up with the changes,
+	// which means some changes are dropped and the subscriber needs to resync.
+	overflow     chan struct{}
+	overflowOnce sync.Once
+}
+
+// ID returns the ID of the subscription.
+func (s *RegionSubscription) ID() uint64 {
+	return s.id
+}
+
+// Changes returns the channel of the region changes.
+func (s *RegionSubscription) Changes() <-chan *RegionChange {
+	return s.ch
+}
+
+// Overflow returns a channel which is closed once some changes are dropped.
+func (s *RegionSubscription) Overflow() <-chan struct{} {
+	return s.overflow
+}
+
+func (s *RegionSubscription) overlaps(region *RegionInfo) bool {
+	return (len(s.endKey) == 0 || bytes.Compare(region.GetStartKey(), s.endKey) < 0) &&
+		(len(region.GetEndKey()) == 0 || bytes.Compare(s.startKey, region.GetEndKey()) < 0)
+}
+
+type regionSubscriptions struct {
+	syncutil.RWMutex
+	nextID uint64
+	subs   map[uint64]*RegionSubscription
+}
+
+// SubscribeRegionChanges subscribes the epoch and leader changes of the regions
+// overlapping with [startKey, endKey). An empty endKey means the end of the key space.
+// The subscription must be released by UnsubscribeRegionChanges.
+func (r *RegionsInfo) SubscribeRegionChanges(startKey, endKey []byte, bufferSize int) *RegionSubscription {
+	r.subscribers.Lock()
+	defer r.subscribers.Unlock()
+	if r.subscribers.subs == nil {
+		r.subscribers.subs = make(map[uint64]*RegionSubscription)
+	}
+	r.subscribers.nextID++
+	sub := &RegionSubscription{
+		id:       r.subscribers.nextID,
+		startKey: startKey,
+		endKey:   endKey,
+		ch:       make(chan *RegionChange, bufferSize),
+		overflow: make(chan struct{}),
+	}
+	r.subscribers.subs[sub.id] = sub
+	return sub
+}
+
+// UnsubscribeRegionChanges releases the subscription with the given ID.
+func (r *RegionsInfo) UnsubscribeRegionChanges(id uint64) {
+	r.subscribers.Lock()
+	defer r.subscribers.Unlock()
+	delete(r.subscribers.subs, id)
+}
+
+// notifyRegionChanged pushes the region to the subscribers if its range, epoch or leader is changed.
+// It must be called with the tree lock held to keep the changes in order.
+func (r *RegionsInfo) notifyRegionChanged(region, origin *RegionInfo, rangeChanged bool) {
+	if origin != nil && !rangeChanged &&
+		region.GetRegionEpoch().GetVersion() == origin.GetRegionEpoch().GetVersion() &&
+		region.GetRegionEpoch().GetConfVer() == origin.GetRegionEpoch().GetConfVer() &&
+		region.GetLeader().GetId() == origin.GetLeader().GetId() {
+		return
+	}
+	r.pushRegionChange(&RegionChange{Region: region})
+}
+
+// notifyRegionRemoved pushes the removal of the region to the subscribers.
+func (r *RegionsInfo) notifyRegionRemoved(region *RegionInfo) {
+	r.pushRegionChange(&RegionChange{Region: region, Removed: true})
+}
+
+// notifyAllRegionsRemoved asks all the subscribers to resync since all the regions are dropped.
+func (r *RegionsInfo) notifyAllRegionsRemoved() {
+	r.subscribers.RLock()
+	defer r.subscribers.RUnlock()
+	for _, sub := range r.subscribers.subs {
+		sub.overflowOnce.Do(func() { close(sub.overflow) })
+	}
+}
+
+func (r *RegionsInfo) pushRegionChange(change *RegionChange) {
+	r.subscribers.RLock()
+	defer r.subscribers.RUnlock()
+	for _, sub := range r.subscribers.subs {
+		if !sub.overlaps(change.Region) {
+			continue
+		}
+		select {
+		case <-sub.overflow:
+			// The subscriber has to resync anyway.
+		case sub.ch <- change:
+		default:
+			// Never block the region update path.
+			sub.overflowOnce.Do(func() { close(sub.overflow) })
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionSubscription(t *testing.T) {
+	re := require.New(t)
+	regions := NewRegionsInfo()
+	sub := regions.SubscribeRegionChanges([]byte("b"), []byte("d"), 2)
+	all := regions.SubscribeRegionChanges(nil, nil, 1)
+
+	checkChanged := func(sub *RegionSubscription, regionID uint64, removed bool) {
+		select {
+		case change := <-sub.Changes():
+			re.Equal(regionID, change.Region.GetID())
+			re.Equal(removed, change.Removed)
+		default:
+			re.FailNow("no region change")
+		}
+	}
+	checkNoChange := func(sub *RegionSubscription) {
+		select {
+		case change := <-sub.Changes():
+			re.FailNow("unexpected region change", "region %d", change.Region.GetID())
+		default:
+		}
+	}
+
+	// The new regions are notified if they overlap with the range.
+	regions.CheckAndPutRegion(NewTestRegionInfo(1, 1, []byte("a"), []byte("b")))
+	checkNoChange(sub)
+	checkChanged(all, 1, false)
+	region := NewTestRegionInfo(2, 1, []byte("b"), []byte("c"))
+	regions.CheckAndPutRegion(region)
+	checkChanged(sub, 2, false)
+	checkChanged(all, 2, false)
+
+	// The flow changes are not notified.
+	region = region.Clone(SetWrittenBytes(100))
+	regions.CheckAndPutRegion(region)
+	checkNoChange(sub)
+	checkNoChange(all)
+
+	// The epoch and leader changes are notified.
+	region = region.Clone(WithIncConfVer())
+	regions.CheckAndPutRegion(region)
+	checkChanged(sub, 2, false)
+	checkChanged(all, 2, false)
+	region = region.Clone(WithIncVersion(), WithEndKey([]byte("e")))
+	regions.CheckAndPutRegion(region)
+	checkChanged(sub, 2, false)
+	checkChanged(all, 2, false)
+	peer := &metapb.Peer{Id: 100, StoreId: 2}
+	region = region.Clone(WithAddPeer(peer), WithLeader(peer))
+	regions.CheckAndPutRegion(region)
+	checkChanged(sub, 2, false)
+	checkChanged(all, 2, false)
+
+	// The removed regions are notified.
+	regions.RemoveRegionIfExist(2)
+	checkChanged(sub, 2, true)
+	checkChanged(all, 2, true)
+	regions.CheckAndPutRegion(region)
+	checkChanged(sub, 2, false)
+	checkChanged(all, 2, false)
+
+	// The overflowed subscription is notified to resync.
+	regions.CheckAndPutRegion(NewTestRegionInfo(3, 1, []byte("e"), []byte("f")))
+	checkNoChange(sub)
+	regions.CheckAndPutRegion(NewTestRegionInfo(4, 1, []byte("f"), []byte("g")))
+	select {
+	case <-all.Overflow():
+	default:
+		re.FailNow("subscription should be overflowed")
+	}
+	select {
+	case <-sub.Overflow():
+		re.FailNow("subscription should not be overflowed")
+	default:
+	}
+
+	// All the subscriptions are notified to resync once the regions are reset.
+	regions.ResetRegionCache()
+	select {
+	case <-sub.Overflow():
+	default:
+		re.FailNow("subscription should be overflowed")
+	}
+
+	// The released subscription is not notified anymore.
+	regions.UnsubscribeRegionChanges(sub.ID())
+	regions.CheckAndPutRegion(region.Clone(WithIncVersion()))
+	checkNoChange(sub)
+}
+
+func TestRegionSubscriptionMerge(t *testing.T) {
+	re := require.New(t)
+	regions := NewRegionsInfo()
+	sub := regions.SubscribeRegionChanges(nil, nil, 4)
+	left := NewTestRegionInfo(1, 1, []byte("a"), []byte("b"))
+	right := NewTestRegionInfo(2, 1, []byte("b"), []byte("c"))
+	regions.CheckAndPutRegion(left)
+	regions.CheckAndPutRegion(right)
+	re.Equal(uint64(1), (<-sub.Changes()).Region.GetID())
+	re.Equal(uint64(2), (<-sub.Changes()).Region.GetID())
+
+	// The merged region is removed before the region which it is merged into is notified.
+	right = right.Clone(WithIncVersion(), WithStartKey([]byte("a")))
+	regions.CheckAndPutRegion(right)
+	change := <-sub.Changes()
+	re.Equal(uint64(1), change.Region.GetID())
+	re.True(change.Removed)
+	change = <-sub.Changes()
+	re.Equal(uint64(2), change.Region.GetID())
+	re.False(change.Removed)
+	re.Equal([]byte("a"), change.Region.GetStartKey())
+
+	// The changes with the same epoch are pushed in the order they are applied.
+	for i := uint64(10); i < 14; i++ {
+		peer := &metapb.Peer{Id: i, StoreId: i}
+		right = right.Clone(WithAddPeer(peer), WithLeader(peer))
+		regions.CheckAndPutRegion(right)
+	}
+	for i := uint64(10); i < 14; i++ {
+		re.Equal(i, (<-sub.Changes()).Region.GetLeader().GetId())
+	}
+}
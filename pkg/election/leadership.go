@@ -208,6 +208,14 @@ func (ls *Leadership) Check() bool {
 	return ls != nil && ls.getLease() != nil && !ls.getLease().IsExpired()
 }
 
+// LeaseRemaining returns how long the leadership is still available without renewing the lease.
+func (ls *Leadership) LeaseRemaining() time.Duration {
+	if ls == nil {
+		return 0
+	}
+	return ls.getLease().Remaining()
+}
+
 // LeaderTxn returns txn() with a leader comparison to guarantee that
 // the transaction can be executed only if the server is leader.
 func (ls *Leadership) LeaderTxn(cs ...clientv3.Cmp) clientv3.Txn {
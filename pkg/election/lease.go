@@ -98,6 +98,17 @@ func (l *lease) IsExpired() bool {
 	return time.Now().After(l.expireTime.Load().(time.Time))
 }
 
+// Remaining returns how long the lease is still valid, which is 0 if the lease is expired.
+func (l *lease) Remaining() time.Duration {
+	if l == nil || l.expireTime.Load() == nil {
+		return 0
+	}
+	if remaining := time.Until(l.expireTime.Load().(time.Time)); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
 // KeepAlive auto renews the lease and update expireTime.
 func (l *lease) KeepAlive(ctx context.Context) {
 	defer logutil.LogPanic()
@@ -42,6 +42,7 @@ func TestLease(t *testing.T) {
 	}
 	re.True(lease1.IsExpired())
 	re.True(lease2.IsExpired())
+	re.Zero(lease1.Remaining())
 	re.NoError(lease1.Close())
 	re.NoError(lease2.Close())
 
@@ -50,11 +51,14 @@ func TestLease(t *testing.T) {
 	re.NoError(lease2.Grant(defaultLeaseTimeout))
 	re.False(lease1.IsExpired())
 	re.False(lease2.IsExpired())
+	re.Greater(lease1.Remaining(), time.Duration(0))
+	re.LessOrEqual(lease1.Remaining(), defaultLeaseTimeout*time.Second)
 
 	// Wait for a while to make both two leases timeout.
 	time.Sleep((defaultLeaseTimeout + 1) * time.Second)
 	re.True(lease1.IsExpired())
 	re.True(lease2.IsExpired())
+	re.Zero(lease1.Remaining())
 
 	// Grant the two leases with different timeouts.
 	re.NoError(lease1.Grant(defaultLeaseTimeout))
@@ -58,13 +58,18 @@ var (
 	ErrKeyspaceNotAssigned              = errors.Normalize("the keyspace %d isn't assigned to any keyspace group", errors.RFCCodeText("PD:tso:ErrKeyspaceNotAssigned"))
 	ErrGetMinTS                         = errors.Normalize("get min ts failed, %s", errors.RFCCodeText("PD:tso:ErrGetMinTS"))
 	ErrKeyspaceGroupIsMerging           = errors.Normalize("the keyspace group %d is merging", errors.RFCCodeText("PD:tso:ErrKeyspaceGroupIsMerging"))
+	ErrIncompatibleTSOLayout            = errors.Normalize("the tso layout %s is incompatible with the persisted layout %s", errors.RFCCodeText("PD:tso:ErrIncompatibleTSOLayout"))
+	ErrTSOLayoutChanged                 = errors.Normalize("the tso layout %s is different from the layout %s negotiated with the client", errors.RFCCodeText("PD:tso:ErrTSOLayoutChanged"))
+	ErrTSOLayoutNotNegotiated           = errors.Normalize("the tso layout %s is not the default one, the client should negotiate it first", errors.RFCCodeText("PD:tso:ErrTSOLayoutNotNegotiated"))
 )
 
 // member errors
 var (
-	ErrEtcdLeaderNotFound = errors.Normalize("etcd leader not found", errors.RFCCodeText("PD:member:ErrEtcdLeaderNotFound"))
-	ErrMarshalLeader      = errors.Normalize("marshal leader failed", errors.RFCCodeText("PD:member:ErrMarshalLeader"))
-	ErrCheckCampaign      = errors.Normalize("check campaign failed", errors.RFCCodeText("PD:member:ErrCheckCampaign"))
+	ErrEtcdLeaderNotFound          = errors.Normalize("etcd leader not found", errors.RFCCodeText("PD:member:ErrEtcdLeaderNotFound"))
+	ErrMarshalLeader               = errors.Normalize("marshal leader failed", errors.RFCCodeText("PD:member:ErrMarshalLeader"))
+	ErrCheckCampaign               = errors.Normalize("check campaign failed", errors.RFCCodeText("PD:member:ErrCheckCampaign"))
+	ErrLeaderHandoff               = errors.Normalize("failed to hand off the leadership, %s", errors.RFCCodeText("PD:member:ErrLeaderHandoff"))
+	ErrLeaderHandoffMemberNotFound = errors.Normalize("failed to hand off the leadership, member %s is not found", errors.RFCCodeText("PD:member:ErrLeaderHandoffMemberNotFound"))
 )
 
 // core errors
@@ -122,6 +127,9 @@ var (
 	ErrUnknownOperatorStep      = errors.Normalize("unknown operator step found", errors.RFCCodeText("PD:schedule:ErrUnknownOperatorStep"))
 	ErrMergeOperator            = errors.Normalize("merge operator error, %s", errors.RFCCodeText("PD:schedule:ErrMergeOperator"))
 	ErrCreateOperator           = errors.Normalize("unable to create operator, %s", errors.RFCCodeText("PD:schedule:ErrCreateOperator"))
+	ErrScatterJobNotFound       = errors.Normalize("scatter job %d not found", errors.RFCCodeText("PD:schedule:ErrScatterJobNotFound"))
+	ErrScatterJobNotRunning     = errors.Normalize("scatter job %d is %s", errors.RFCCodeText("PD:schedule:ErrScatterJobNotRunning"))
+	ErrInvalidScatterJobRange   = errors.Normalize("invalid scatter job range [%s, %s)", errors.RFCCodeText("PD:schedule:ErrInvalidScatterJobRange"))
 )
 
 // scheduler errors
@@ -141,6 +149,8 @@ var (
 var (
 	ErrCheckerNotFound   = errors.Normalize("checker not found", errors.RFCCodeText("PD:checker:ErrCheckerNotFound"))
 	ErrCheckerMergeAgain = errors.Normalize("region will be merged again, %s", errors.RFCCodeText("PD:checker:ErrCheckerMergeAgain"))
+	ErrBulkMergeRunning  = errors.Normalize("the bulk merge is running", errors.RFCCodeText("PD:checker:ErrBulkMergeRunning"))
+	ErrBulkMergeNotFound = errors.Normalize("the bulk merge is not found", errors.RFCCodeText("PD:checker:ErrBulkMergeNotFound"))
 )
 
 // diagnostic errors
@@ -186,6 +196,16 @@ var (
 	ErrTypeConversion           = errors.Normalize("type conversion error", errors.RFCCodeText("PD:autoscaling:ErrTypeConversion"))
 	ErrEmptyMetricsResponse     = errors.Normalize("metrics response from Prometheus is empty", errors.RFCCodeText("PD:autoscaling:ErrEmptyMetricsResponse"))
 	ErrEmptyMetricsResult       = errors.Normalize("result from Prometheus is empty, %s", errors.RFCCodeText("PD:autoscaling:ErrEmptyMetricsResult"))
+	ErrMissingResourceType      = errors.Normalize("store %d has no resource type in the strategy", errors.RFCCodeText("PD:autoscaling:ErrMissingResourceType"))
+)
+
+// gc errors
+var (
+	ErrServiceGCSafePointDenied       = errors.Normalize("service %s is not allowed to update the service safe point", errors.RFCCodeText("PD:gc:ErrServiceGCSafePointDenied"))
+	ErrServiceGCSafePointExpired      = errors.Normalize("the service safe point of %s is force expired, only the safe point newer than %d can be registered", errors.RFCCodeText("PD:gc:ErrServiceGCSafePointExpired"))
+	ErrServiceGCSafePointLagTooLarge  = errors.Normalize("the service safe point of %s lags behind the current TSO by %v, which exceeds the limit %v", errors.RFCCodeText("PD:gc:ErrServiceGCSafePointLagTooLarge"))
+	ErrServiceGCSafePointNotFound     = errors.Normalize("the service safe point of %s is not found", errors.RFCCodeText("PD:gc:ErrServiceGCSafePointNotFound"))
+	ErrRemoveGCWorkerServiceSafePoint = errors.Normalize("cannot remove service safe point of gc_worker", errors.RFCCodeText("PD:gc:ErrRemoveGCWorkerServiceSafePoint"))
 )
 
 // apiutil errors
@@ -210,6 +230,9 @@ var (
 	ErrServerNotStarted       = errors.Normalize("server not started", errors.RFCCodeText("PD:server:ErrServerNotStarted"))
 	ErrRateLimitExceeded      = errors.Normalize("rate limit exceeded", errors.RFCCodeText("PD:server:ErrRateLimitExceeded"))
 	ErrLeaderFrequentlyChange = errors.Normalize("leader %s frequently changed, leader-key is [%s]", errors.RFCCodeText("PD:server:ErrLeaderFrequentlyChange"))
+	ErrFollowerReadDisabled   = errors.Normalize("follower read is disabled", errors.RFCCodeText("PD:server:ErrFollowerReadDisabled"))
+	ErrFollowerReadNotSynced  = errors.Normalize("follower has not caught up with the leader", errors.RFCCodeText("PD:server:ErrFollowerReadNotSynced"))
+	ErrFollowerReadTooStale   = errors.Normalize("follower staleness %s exceeds the max staleness %s", errors.RFCCodeText("PD:server:ErrFollowerReadTooStale"))
 )
 
 // logutil errors
@@ -392,6 +415,12 @@ var (
 	ErrUnsafeRecoveryInvalidInput = errors.Normalize("invalid input %s", errors.RFCCodeText("PD:unsaferecovery:ErrUnsafeRecoveryInvalidInput"))
 )
 
+// heartbeat capture errors
+var (
+	ErrCaptureIsRunning     = errors.Normalize("heartbeat capture is running", errors.RFCCodeText("PD:capture:ErrCaptureIsRunning"))
+	ErrCaptureInvalidConfig = errors.Normalize("invalid heartbeat capture config, %s", errors.RFCCodeText("PD:capture:ErrCaptureInvalidConfig"))
+)
+
 // progress errors
 var (
 	ErrProgressWrongStatus = errors.Normalize("progress status is wrong", errors.RFCCodeText("PD:progress:ErrProgressWrongStatus"))
@@ -403,4 +432,5 @@ var (
 	ErrResourceGroupNotExists = errors.Normalize("the %s resource group does not exist", errors.RFCCodeText("PD:resourcemanager:ErrGroupNotExists"))
 	ErrDeleteReservedGroup    = errors.Normalize("cannot delete reserved group", errors.RFCCodeText("PD:resourcemanager:ErrDeleteReservedGroup"))
 	ErrInvalidGroup           = errors.Normalize("invalid group settings, please check the group name, priority and the number of resources", errors.RFCCodeText("PD:resourcemanager:ErrInvalidGroup"))
+	ErrInvalidRunawayPolicy   = errors.Normalize("invalid runaway policy, %s", errors.RFCCodeText("PD:resourcemanager:ErrInvalidRunawayPolicy"))
 )
@@ -24,8 +24,26 @@ var (
 			Name:      "gc_safepoint",
 			Help:      "The ts of gc safepoint",
 		}, []string{"type"})
+
+	gcBlockedDurationGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "gc",
+			Name:      "blocked_duration_seconds",
+			Help:      "How long GC has been blocked by the service which holds the min service safe point",
+		})
+
+	serviceGCSafePointRejectedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "gc",
+			Name:      "service_safepoint_rejected_total",
+			Help:      "Counter of the rejected service safe point updates",
+		}, []string{"reason"})
 )
 
 func init() {
 	prometheus.MustRegister(gcSafePointGauge)
+	prometheus.MustRegister(gcBlockedDurationGauge)
+	prometheus.MustRegister(serviceGCSafePointRejectedCounter)
 }
@@ -16,12 +16,17 @@ package gc
 
 import (
 	"math"
+	"path"
 	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
 	"github.com/tikv/pd/server/config"
+	"go.uber.org/zap"
 )
 
 var blockGCSafePointErrmsg = "don't allow update gc safe point v1."
@@ -32,12 +37,23 @@ type SafePointManager struct {
 	gcLock        syncutil.Mutex
 	serviceGCLock syncutil.Mutex
 	store         endpoint.GCSafePointStorage
-	cfg           config.PDServerConfig
+	cfg           ConfigProvider
+	layout        func() tsoutil.Layout
 }
 
-// NewSafePointManager creates a SafePointManager of GC and services.
-func NewSafePointManager(store endpoint.GCSafePointStorage, cfg config.PDServerConfig) *SafePointManager {
-	return &SafePointManager{store: store, cfg: cfg}
+// ConfigProvider provides the live PD server configuration, so that the changes
+// made at runtime take effect without restarting.
+type ConfigProvider interface {
+	GetPDServerConfig() *config.PDServerConfig
+}
+
+// NewSafePointManager creates a SafePointManager of GC and services. The layout is used to
+// parse the service safe points, the default layout is used if it's nil.
+func NewSafePointManager(store endpoint.GCSafePointStorage, cfg ConfigProvider, layout func() tsoutil.Layout) *SafePointManager {
+	if layout == nil {
+		layout = func() tsoutil.Layout { return tsoutil.DefaultLayout }
+	}
+	return &SafePointManager{store: store, cfg: cfg, layout: layout}
 }
 
 // LoadGCSafePoint loads current GC safe point from storage.
@@ -55,7 +71,7 @@ func (manager *SafePointManager) UpdateGCSafePoint(newSafePoint uint64) (oldSafe
 	if err != nil {
 		return
 	}
-	if manager.cfg.BlockSafePointV1 {
+	if manager.cfg.GetPDServerConfig().BlockSafePointV1 {
 		err = errors.Errorf(blockGCSafePointErrmsg)
 		return
 	}
@@ -72,15 +88,49 @@ func (manager *SafePointManager) UpdateGCSafePoint(newSafePoint uint64) (oldSafe
 
 // UpdateServiceGCSafePoint update the safepoint for a specific service.
 func (manager *SafePointManager) UpdateServiceGCSafePoint(serviceID string, newSafePoint uint64, ttl int64, now time.Time) (minServiceSafePoint *endpoint.ServiceSafePoint, updated bool, err error) {
-	if manager.cfg.BlockSafePointV1 {
+	cfg := manager.cfg.GetPDServerConfig()
+	if cfg.BlockSafePointV1 {
 		return nil, false, errors.Errorf(blockServiceSafepointErrmsg)
 	}
+	layout := manager.layout()
+	if ttl > 0 {
+		if reason, err := checkServiceGCSafePointPolicy(cfg, layout, serviceID, newSafePoint, now); err != nil {
+			serviceGCSafePointRejectedCounter.WithLabelValues(reason).Inc()
+			return nil, false, err
+		}
+	}
 	manager.serviceGCLock.Lock()
 	defer manager.serviceGCLock.Unlock()
+	if ttl > 0 && serviceID != endpoint.GCWorkerServiceSafePointID {
+		expired, err := manager.store.LoadExpiredServiceGCSafePoint(serviceID)
+		if err != nil {
+			return nil, false, err
+		}
+		if expired != nil {
+			if newSafePoint <= expired.SafePoint {
+				serviceGCSafePointRejectedCounter.WithLabelValues(rejectReasonExpired).Inc()
+				return nil, false, errs.ErrServiceGCSafePointExpired.FastGenByArgs(serviceID, expired.SafePoint)
+			}
+			if err := manager.store.RemoveExpiredServiceGCSafePoint(serviceID); err != nil {
+				return nil, false, err
+			}
+		}
+	}
 	minServiceSafePoint, err = manager.store.LoadMinServiceGCSafePoint(now)
 	if err != nil || ttl <= 0 || newSafePoint < minServiceSafePoint.SafePoint {
+		if err == nil {
+			updateGCBlockedMetrics(layout, minServiceSafePoint, now)
+		}
 		return minServiceSafePoint, false, err
 	}
+	if maxTTL := int64(cfg.MaxServiceGCSafePointTTL.Seconds()); maxTTL > 0 && ttl > maxTTL &&
+		serviceID != endpoint.GCWorkerServiceSafePointID {
+		log.Warn("the ttl of service GC safe point exceeds the limit, cap it",
+			zap.String("service-id", serviceID),
+			zap.Int64("ttl", ttl),
+			zap.Int64("max-ttl", maxTTL))
+		ttl = maxTTL
+	}
 
 	ssp := &endpoint.ServiceSafePoint{
 		ServiceID: serviceID,
@@ -98,5 +148,101 @@ func (manager *SafePointManager) UpdateServiceGCSafePoint(serviceID string, newS
 	if serviceID == minServiceSafePoint.ServiceID {
 		minServiceSafePoint, err = manager.store.LoadMinServiceGCSafePoint(now)
 	}
+	if err == nil {
+		updateGCBlockedMetrics(layout, minServiceSafePoint, now)
+	}
 	return minServiceSafePoint, true, err
 }
+
+// ExpireServiceGCSafePoint forces the service safe point of the given service to expire on behalf
+// of the caller, it returns the expired service safe point. To prevent the service from undoing the
+// expiry on its next update, it can only register again with a safe point newer than the expired one.
+// The expired safe point is persisted, so the restriction survives the PD leader changes, and it's
+// removed once the service registers again with a newer safe point.
+func (manager *SafePointManager) ExpireServiceGCSafePoint(serviceID, caller string, now time.Time) (*endpoint.ServiceSafePoint, error) {
+	if serviceID == endpoint.GCWorkerServiceSafePointID {
+		return nil, errs.ErrRemoveGCWorkerServiceSafePoint.FastGenByArgs()
+	}
+	manager.serviceGCLock.Lock()
+	defer manager.serviceGCLock.Unlock()
+	ssps, err := manager.store.LoadAllServiceGCSafePoints()
+	if err != nil {
+		return nil, err
+	}
+	var expired *endpoint.ServiceSafePoint
+	for _, ssp := range ssps {
+		if ssp.ServiceID == serviceID {
+			expired = ssp
+			break
+		}
+	}
+	if expired == nil {
+		return nil, errs.ErrServiceGCSafePointNotFound.FastGenByArgs(serviceID)
+	}
+	// Persist the expired safe point before removing it, so the service can't register the
+	// expired safe point again once it's removed.
+	if err := manager.store.SaveExpiredServiceGCSafePoint(expired); err != nil {
+		return nil, err
+	}
+	if err := manager.store.RemoveServiceGCSafePoint(serviceID); err != nil {
+		return nil, err
+	}
+	log.Warn("service GC safe point is force expired",
+		zap.String("service-id", serviceID),
+		zap.String("caller", caller),
+		zap.Uint64("safepoint", expired.SafePoint),
+		zap.Int64("expired-at", expired.ExpiredAt),
+		zap.Time("now", now))
+	if min, err := manager.store.LoadMinServiceGCSafePoint(now); err == nil {
+		updateGCBlockedMetrics(manager.layout(), min, now)
+	}
+	return expired, nil
+}
+
+// The reasons of the rejected service safe point updates, which are used as the metric labels
+// instead of the service IDs to bound the cardinality.
+const (
+	rejectReasonDenied  = "denied"
+	rejectReasonLag     = "lag_too_large"
+	rejectReasonExpired = "expired"
+)
+
+// checkServiceGCSafePointPolicy checks whether the service is allowed to update its service safe point
+// to the given value, it returns the reason if not. The service safe point of gc_worker is never restricted.
+func checkServiceGCSafePointPolicy(cfg *config.PDServerConfig, layout tsoutil.Layout, serviceID string, newSafePoint uint64, now time.Time) (string, error) {
+	if serviceID == endpoint.GCWorkerServiceSafePointID {
+		return "", nil
+	}
+	if matchServiceID(cfg.ServiceGCSafePointDenyList, serviceID) {
+		return rejectReasonDenied, errs.ErrServiceGCSafePointDenied.FastGenByArgs(serviceID)
+	}
+	if len(cfg.ServiceGCSafePointAllowList) > 0 && !matchServiceID(cfg.ServiceGCSafePointAllowList, serviceID) {
+		return rejectReasonDenied, errs.ErrServiceGCSafePointDenied.FastGenByArgs(serviceID)
+	}
+	if maxLag := cfg.MaxServiceGCSafePointLag.Duration; maxLag > 0 {
+		physical, _ := layout.ParseTS(newSafePoint)
+		if lag := now.Sub(physical); lag > maxLag {
+			return rejectReasonLag, errs.ErrServiceGCSafePointLagTooLarge.FastGenByArgs(serviceID, lag, maxLag)
+		}
+	}
+	return "", nil
+}
+
+func matchServiceID(patterns []string, serviceID string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, serviceID); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// updateGCBlockedMetrics records how long GC has been blocked by the service which holds the min service safe point.
+func updateGCBlockedMetrics(layout tsoutil.Layout, min *endpoint.ServiceSafePoint, now time.Time) {
+	if min.SafePoint == 0 {
+		gcBlockedDurationGauge.Set(0)
+		return
+	}
+	physical, _ := layout.ParseTS(min.SafePoint)
+	gcBlockedDurationGauge.Set(now.Sub(physical).Seconds())
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/server/config"
 )
 
@@ -30,8 +32,12 @@ func newGCStorage() endpoint.GCSafePointStorage {
 	return endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
 }
 
+func newTestConfig(cfg config.PDServerConfig) *config.PersistOptions {
+	return config.NewPersistOptions(&config.Config{PDServerCfg: cfg})
+}
+
 func TestGCSafePointUpdateSequentially(t *testing.T) {
-	gcSafePointManager := NewSafePointManager(newGCStorage(), config.PDServerConfig{})
+	gcSafePointManager := NewSafePointManager(newGCStorage(), newTestConfig(config.PDServerConfig{}), nil)
 	re := require.New(t)
 	curSafePoint := uint64(0)
 	// update gc safePoint with asc value.
@@ -60,7 +66,7 @@ func TestGCSafePointUpdateSequentially(t *testing.T) {
 }
 
 func TestGCSafePointUpdateCurrently(t *testing.T) {
-	gcSafePointManager := NewSafePointManager(newGCStorage(), config.PDServerConfig{})
+	gcSafePointManager := NewSafePointManager(newGCStorage(), newTestConfig(config.PDServerConfig{}), nil)
 	maxSafePoint := uint64(1000)
 	wg := sync.WaitGroup{}
 	re := require.New(t)
@@ -84,7 +90,7 @@ func TestGCSafePointUpdateCurrently(t *testing.T) {
 
 func TestServiceGCSafePointUpdate(t *testing.T) {
 	re := require.New(t)
-	manager := NewSafePointManager(newGCStorage(), config.PDServerConfig{})
+	manager := NewSafePointManager(newGCStorage(), newTestConfig(config.PDServerConfig{}), nil)
 	gcworkerServiceID := "gc_worker"
 	cdcServiceID := "cdc"
 	brServiceID := "br"
@@ -166,7 +172,7 @@ func TestServiceGCSafePointUpdate(t *testing.T) {
 
 func TestBlockUpdateSafePointV1(t *testing.T) {
 	re := require.New(t)
-	manager := NewSafePointManager(newGCStorage(), config.PDServerConfig{BlockSafePointV1: true})
+	manager := NewSafePointManager(newGCStorage(), newTestConfig(config.PDServerConfig{BlockSafePointV1: true}), nil)
 	gcworkerServiceID := "gc_worker"
 	gcWorkerSafePoint := uint64(8)
 
@@ -182,3 +188,128 @@ func TestBlockUpdateSafePointV1(t *testing.T) {
 
 	re.Equal(uint64(0), oldSafePoint)
 }
+
+func TestServiceGCSafePointPolicy(t *testing.T) {
+	re := require.New(t)
+	cfg := config.PDServerConfig{
+		MaxServiceGCSafePointLag:    typeutil.NewDuration(time.Hour),
+		MaxServiceGCSafePointTTL:    typeutil.NewDuration(time.Minute),
+		ServiceGCSafePointAllowList: typeutil.StringSlice{"cdc-*", "br-*"},
+		ServiceGCSafePointDenyList:  typeutil.StringSlice{"br-legacy-*"},
+	}
+	manager := NewSafePointManager(newGCStorage(), newTestConfig(cfg), nil)
+	now := time.Now()
+	safePoint := tsoutil.GenerateTS(tsoutil.GenerateTimestamp(now.Add(-time.Minute), 0))
+
+	// gc_worker is never restricted.
+	_, updated, err := manager.UpdateServiceGCSafePoint(endpoint.GCWorkerServiceSafePointID, 0, math.MaxInt64, now)
+	re.NoError(err)
+	re.True(updated)
+	// not in the allow list.
+	_, updated, err = manager.UpdateServiceGCSafePoint("lightning", safePoint, 10, now)
+	re.ErrorContains(err, "not allowed")
+	re.False(updated)
+	// in the deny list.
+	_, updated, err = manager.UpdateServiceGCSafePoint("br-legacy-1", safePoint, 10, now)
+	re.ErrorContains(err, "not allowed")
+	re.False(updated)
+	// lags behind too much.
+	oldSafePoint := tsoutil.GenerateTS(tsoutil.GenerateTimestamp(now.Add(-2*time.Hour), 0))
+	_, updated, err = manager.UpdateServiceGCSafePoint("cdc-1", oldSafePoint, 10, now)
+	re.ErrorContains(err, "exceeds the limit")
+	re.False(updated)
+	// the ttl is capped.
+	_, updated, err = manager.UpdateServiceGCSafePoint("cdc-1", safePoint, 3600, now)
+	re.NoError(err)
+	re.True(updated)
+	ssps, err := manager.store.LoadAllServiceGCSafePoints()
+	re.NoError(err)
+	re.Len(ssps, 2)
+	for _, ssp := range ssps {
+		if ssp.ServiceID == "cdc-1" {
+			re.Equal(now.Unix()+60, ssp.ExpiredAt)
+		}
+	}
+}
+
+func TestExpireServiceGCSafePoint(t *testing.T) {
+	re := require.New(t)
+	manager := NewSafePointManager(newGCStorage(), newTestConfig(config.PDServerConfig{}), nil)
+	now := time.Now()
+	_, updated, err := manager.UpdateServiceGCSafePoint("cdc", 10, 3600, now)
+	re.NoError(err)
+	re.True(updated)
+
+	ssp, err := manager.ExpireServiceGCSafePoint("cdc", "pd-ctl", now)
+	re.NoError(err)
+	re.Equal("cdc", ssp.ServiceID)
+	re.Equal(uint64(10), ssp.SafePoint)
+	_, err = manager.ExpireServiceGCSafePoint("cdc", "pd-ctl", now)
+	re.ErrorContains(err, "not found")
+	// gc_worker can not be expired.
+	_, err = manager.ExpireServiceGCSafePoint(endpoint.GCWorkerServiceSafePointID, "pd-ctl", now)
+	re.Error(err)
+
+	min, err := manager.store.LoadMinServiceGCSafePoint(now)
+	re.NoError(err)
+	re.Equal(endpoint.GCWorkerServiceSafePointID, min.ServiceID)
+
+	// The expired service can't register again with the expired safe point.
+	_, updated, err = manager.UpdateServiceGCSafePoint("cdc", 10, 3600, now)
+	re.ErrorContains(err, "force expired")
+	re.False(updated)
+	// The expiry is persisted, so it still takes effect after the PD leader changes.
+	manager = NewSafePointManager(manager.store, newTestConfig(config.PDServerConfig{}), nil)
+	_, updated, err = manager.UpdateServiceGCSafePoint("cdc", 10, 3600, now)
+	re.ErrorContains(err, "force expired")
+	re.False(updated)
+	// Querying the min service safe point is not affected.
+	min, updated, err = manager.UpdateServiceGCSafePoint("cdc", 10, 0, now)
+	re.NoError(err)
+	re.False(updated)
+	re.Equal(endpoint.GCWorkerServiceSafePointID, min.ServiceID)
+	// The service registers again once a newer safe point arrives.
+	_, updated, err = manager.UpdateServiceGCSafePoint("cdc", 11, 3600, now)
+	re.NoError(err)
+	re.True(updated)
+	_, updated, err = manager.UpdateServiceGCSafePoint("cdc", 11, 3600, now)
+	re.NoError(err)
+	re.True(updated)
+}
+
+func TestServiceGCSafePointPolicyHotReload(t *testing.T) {
+	re := require.New(t)
+	opt := newTestConfig(config.PDServerConfig{})
+	manager := NewSafePointManager(newGCStorage(), opt, nil)
+	now := time.Now()
+	_, updated, err := manager.UpdateServiceGCSafePoint("lightning", 10, 10, now)
+	re.NoError(err)
+	re.True(updated)
+
+	cfg := opt.GetPDServerConfig().Clone()
+	cfg.ServiceGCSafePointDenyList = typeutil.StringSlice{"lightning"}
+	opt.SetPDServerConfig(cfg)
+	_, updated, err = manager.UpdateServiceGCSafePoint("lightning", 20, 10, now)
+	re.ErrorContains(err, "not allowed")
+	re.False(updated)
+
+	_, err = manager.ExpireServiceGCSafePoint(endpoint.GCWorkerServiceSafePointID, "pd-ctl", now)
+	re.ErrorContains(err, "cannot remove")
+}
+
+func TestServiceGCSafePointPolicyWithTSOLayout(t *testing.T) {
+	re := require.New(t)
+	cfg := config.PDServerConfig{MaxServiceGCSafePointLag: typeutil.NewDuration(time.Hour)}
+	layout := tsoutil.Layout{PhysicalPrecision: tsoutil.PhysicalPrecisionMicrosecond, LogicalBits: 11}
+	manager := NewSafePointManager(newGCStorage(), newTestConfig(cfg), func() tsoutil.Layout { return layout })
+	now := time.Now()
+	// The safe point is parsed with the layout of the TSO rather than the default one.
+	safePoint := layout.ComposeTS(layout.Physical(now.Add(-time.Minute)), 0)
+	_, updated, err := manager.UpdateServiceGCSafePoint("cdc", safePoint, 10, now)
+	re.NoError(err)
+	re.True(updated)
+	oldSafePoint := layout.ComposeTS(layout.Physical(now.Add(-2*time.Hour)), 0)
+	_, updated, err = manager.UpdateServiceGCSafePoint("br", oldSafePoint, 10, now)
+	re.ErrorContains(err, "exceeds the limit")
+	re.False(updated)
+}
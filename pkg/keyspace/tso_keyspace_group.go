@@ -75,6 +75,11 @@ type GroupManager struct {
 	// serviceRegistryMap stores the mapping from the service registry key to the service address.
 	// Note: it is only used in tsoNodesWatcher.
 	serviceRegistryMap map[string]string
+	// tsoNodes stores the latest registry entries of the tso nodes, keyed by the service address.
+	tsoNodes struct {
+		syncutil.RWMutex
+		entries map[string]*discovery.ServiceRegistryEntry
+	}
 	// tsoNodesWatcher is the watcher for the registered tso servers.
 	tsoNodesWatcher *etcdutil.LoopWatcher
 }
@@ -101,6 +106,7 @@ func NewKeyspaceGroupManager(
 		nodesBalancer:      balancer.GenByPolicy[string](defaultBalancerPolicy),
 		serviceRegistryMap: make(map[string]string),
 	}
+	m.tsoNodes.entries = make(map[string]*discovery.ServiceRegistryEntry)
 
 	// If the etcd client is not nil, start the watch loop for the registered tso servers.
 	// The PD(TSO) Client relies on this info to discover tso servers.
@@ -227,6 +233,9 @@ func (m *GroupManager) initTSONodesWatcher(client *clientv3.Client, clusterID ui
 		}
 		m.nodesBalancer.Put(s.ServiceAddr)
 		m.serviceRegistryMap[string(kv.Key)] = s.ServiceAddr
+		m.tsoNodes.Lock()
+		m.tsoNodes.entries[s.ServiceAddr] = s
+		m.tsoNodes.Unlock()
 		return nil
 	}
 	deleteFn := func(kv *mvccpb.KeyValue) error {
@@ -234,6 +243,9 @@ func (m *GroupManager) initTSONodesWatcher(client *clientv3.Client, clusterID ui
 		if serviceAddr, ok := m.serviceRegistryMap[key]; ok {
 			delete(m.serviceRegistryMap, key)
 			m.nodesBalancer.Delete(serviceAddr)
+			m.tsoNodes.Lock()
+			delete(m.tsoNodes.entries, serviceAddr)
+			m.tsoNodes.Unlock()
 			return nil
 		}
 		return errors.Errorf("failed to find the service address for key %s", key)
@@ -268,12 +280,28 @@ func (m *GroupManager) CreateKeyspaceGroups(keyspaceGroups []*endpoint.KeyspaceG
 	return nil
 }
 
-// GetTSOServiceAddrs gets all TSO service addresses.
+// GetTSOServiceAddrs gets all TSO service addresses. The healthy and less loaded
+// nodes come first so that the clients can prefer them.
 func (m *GroupManager) GetTSOServiceAddrs() []string {
 	if m == nil || m.nodesBalancer == nil {
 		return nil
 	}
-	return m.nodesBalancer.GetAll()
+	addrs := m.nodesBalancer.GetAll()
+	entries := make([]*discovery.ServiceRegistryEntry, 0, len(addrs))
+	m.tsoNodes.RLock()
+	for _, addr := range addrs {
+		if entry, ok := m.tsoNodes.entries[addr]; ok {
+			entries = append(entries, entry)
+		} else {
+			entries = append(entries, &discovery.ServiceRegistryEntry{ServiceAddr: addr})
+		}
+	}
+	m.tsoNodes.RUnlock()
+	discovery.SortEntries(entries, discovery.DefaultStaleTimeout)
+	for i, entry := range entries {
+		addrs[i] = entry.ServiceAddr
+	}
+	return addrs
 }
 
 // GetKeyspaceGroups gets keyspace groups from the start ID with limit.
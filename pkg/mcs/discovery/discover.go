@@ -15,6 +15,9 @@
 package discovery
 
 import (
+	"sort"
+	"time"
+
 	"github.com/tikv/pd/pkg/utils/etcdutil"
 	"go.etcd.io/etcd/clientv3"
 )
@@ -35,3 +38,73 @@ func Discover(cli *clientv3.Client, clusterID, serviceName string) ([]string, er
 	}
 	return values, nil
 }
+
+// EntryFilter is used to filter the service registry entries.
+type EntryFilter func(entry *ServiceRegistryEntry) bool
+
+// WithVersion returns a filter which only keeps the entries with the given version.
+func WithVersion(version string) EntryFilter {
+	return func(entry *ServiceRegistryEntry) bool {
+		return entry.Version == version
+	}
+}
+
+// WithLabels returns a filter which only keeps the entries with all the given labels.
+func WithLabels(labels map[string]string) EntryFilter {
+	return func(entry *ServiceRegistryEntry) bool {
+		return entry.MatchLabels(labels)
+	}
+}
+
+// WithHealthy returns a filter which only keeps the healthy entries.
+func WithHealthy(staleTimeout time.Duration) EntryFilter {
+	now := time.Now()
+	return func(entry *ServiceRegistryEntry) bool {
+		return entry.IsHealthy(now, staleTimeout)
+	}
+}
+
+// DiscoverEntries is used to get the registry entries of the specified service name
+// which pass all the given filters.
+func DiscoverEntries(cli *clientv3.Client, clusterID, serviceName string, filters ...EntryFilter) ([]*ServiceRegistryEntry, error) {
+	values, err := Discover(cli, clusterID, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*ServiceRegistryEntry, 0, len(values))
+	for _, value := range values {
+		entry := &ServiceRegistryEntry{}
+		if err := entry.Deserialize([]byte(value)); err != nil {
+			continue
+		}
+		if matchFilters(entry, filters) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func matchFilters(entry *ServiceRegistryEntry, filters []EntryFilter) bool {
+	for _, filter := range filters {
+		if !filter(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// SortEntries sorts the entries so that the healthy ones come first, and the less
+// loaded ones come first among the entries with the same health status.
+func SortEntries(entries []*ServiceRegistryEntry, staleTimeout time.Duration) {
+	now := time.Now()
+	sort.SliceStable(entries, func(i, j int) bool {
+		hi, hj := entries[i].IsHealthy(now, staleTimeout), entries[j].IsHealthy(now, staleTimeout)
+		if hi != hj {
+			return hi
+		}
+		if entries[i].Load != entries[j].Load {
+			return entries[i].Load < entries[j].Load
+		}
+		return entries[i].ServiceAddr < entries[j].ServiceAddr
+	})
+}
@@ -82,3 +82,58 @@ func TestServiceRegistryEntry(t *testing.T) {
 	re.NoError(err)
 	re.Empty(endpoints)
 }
+
+func TestDiscoverEntries(t *testing.T) {
+	re := require.New(t)
+	_, client, clean := etcdutil.NewTestEtcdCluster(t, 1)
+	defer clean()
+	entries := []*ServiceRegistryEntry{
+		{ServiceAddr: "127.0.0.1:1", Version: "v7.1.0", Labels: map[string]string{"zone": "z1"}},
+		{ServiceAddr: "127.0.0.1:2", Version: "v7.1.0", Labels: map[string]string{"zone": "z2"}, Status: StatusUnhealthy},
+		{ServiceAddr: "127.0.0.1:3", Version: "v7.2.0", Labels: map[string]string{"zone": "z1"}},
+	}
+	for _, entry := range entries {
+		value, err := entry.Serialize()
+		re.NoError(err)
+		sr := NewServiceRegister(context.Background(), client, "12345", "test_service", entry.ServiceAddr, value, 1)
+		re.NoError(sr.Register())
+		defer sr.cancel()
+	}
+
+	result, err := DiscoverEntries(client, "12345", "test_service")
+	re.NoError(err)
+	re.Len(result, 3)
+	result, err = DiscoverEntries(client, "12345", "test_service", WithVersion("v7.1.0"))
+	re.NoError(err)
+	re.Len(result, 2)
+	result, err = DiscoverEntries(client, "12345", "test_service", WithLabels(map[string]string{"zone": "z1"}))
+	re.NoError(err)
+	re.Len(result, 2)
+	re.Equal("127.0.0.1:1", result[0].ServiceAddr)
+	re.Equal("127.0.0.1:3", result[1].ServiceAddr)
+	result, err = DiscoverEntries(client, "12345", "test_service",
+		WithVersion("v7.1.0"), WithHealthy(DefaultStaleTimeout))
+	re.NoError(err)
+	re.Len(result, 1)
+	re.Equal("127.0.0.1:1", result[0].ServiceAddr)
+}
+
+func TestSortEntries(t *testing.T) {
+	re := require.New(t)
+	now := time.Now()
+	entries := []*ServiceRegistryEntry{
+		{ServiceAddr: "127.0.0.1:1", Load: 10, Status: StatusHealthy, UpdateTime: now.Unix()},
+		{ServiceAddr: "127.0.0.1:2", Load: 1, Status: StatusUnhealthy, UpdateTime: now.Unix()},
+		{ServiceAddr: "127.0.0.1:3", Load: 5, Status: StatusHealthy, UpdateTime: now.Unix()},
+		// The stale report is treated as unhealthy.
+		{ServiceAddr: "127.0.0.1:4", Load: 0, Status: StatusHealthy, UpdateTime: now.Add(-time.Minute).Unix()},
+		// The entry without any report is treated as healthy.
+		{ServiceAddr: "127.0.0.1:5"},
+	}
+	SortEntries(entries, DefaultStaleTimeout)
+	addrs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		addrs = append(addrs, entry.ServiceAddr)
+	}
+	re.Equal([]string{"127.0.0.1:5", "127.0.0.1:3", "127.0.0.1:1", "127.0.0.1:4", "127.0.0.1:2"}, addrs)
+}
@@ -17,6 +17,7 @@ package discovery
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/pingcap/log"
@@ -35,22 +36,28 @@ type ServiceRegister struct {
 	cancel context.CancelFunc
 	cli    *clientv3.Client
 	key    string
-	value  string
 	ttl    int64
+
+	mu struct {
+		sync.RWMutex
+		value   string
+		leaseID clientv3.LeaseID
+	}
 }
 
 // NewServiceRegister creates a new ServiceRegister.
 func NewServiceRegister(ctx context.Context, cli *clientv3.Client, clusterID, serviceName, serviceAddr, serializedValue string, ttl int64) *ServiceRegister {
 	cctx, cancel := context.WithCancel(ctx)
 	serviceKey := RegistryPath(clusterID, serviceName, serviceAddr)
-	return &ServiceRegister{
+	sr := &ServiceRegister{
 		ctx:    cctx,
 		cancel: cancel,
 		cli:    cli,
 		key:    serviceKey,
-		value:  serializedValue,
 		ttl:    ttl,
 	}
+	sr.mu.value = serializedValue
+	return sr
 }
 
 // Register registers the service to etcd.
@@ -111,7 +118,67 @@ func (sr *ServiceRegister) renewKeepalive() <-chan *clientv3.LeaseKeepAliveRespo
 func (sr *ServiceRegister) putWithTTL() (clientv3.LeaseID, error) {
 	ctx, cancel := context.WithTimeout(sr.ctx, etcdutil.DefaultRequestTimeout)
 	defer cancel()
-	return etcdutil.EtcdKVPutWithTTL(ctx, sr.cli, sr.key, sr.value, sr.ttl)
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	id, err := etcdutil.EtcdKVPutWithTTL(ctx, sr.cli, sr.key, sr.mu.value, sr.ttl)
+	if err != nil {
+		return id, err
+	}
+	sr.mu.leaseID = id
+	return id, nil
+}
+
+// UpdateValue updates the registered value of the service without changing its lease.
+// If the service has not been registered yet, the value will be used by the next registration.
+func (sr *ServiceRegister) UpdateValue(value string) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.mu.value = value
+	if sr.mu.leaseID == clientv3.NoLease {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(sr.ctx, etcdutil.DefaultRequestTimeout)
+	defer cancel()
+	_, err := sr.cli.Put(ctx, sr.key, value, clientv3.WithLease(sr.mu.leaseID))
+	return err
+}
+
+// StartReporting starts a loop to periodically collect the load and health status with the
+// stats. To avoid rewriting the registry entry on every interval, the entry is only updated
+// when the status changes, the load changes by more than DefaultLoadChangeRatio, or the last
+// report is about to be stale.
+func (sr *ServiceRegister) StartReporting(entry *ServiceRegistryEntry, stats *ServiceStats, interval time.Duration) {
+	go func() {
+		defer logutil.LogPanic()
+		// Copy the entry to avoid racing with the other readers of it.
+		reported := *entry
+		refreshInterval := DefaultStaleTimeout - interval
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sr.ctx.Done():
+				log.Info("exit report process", zap.String("key", sr.key))
+				return
+			case now := <-ticker.C:
+				load, status := stats.Report(interval)
+				if !needReport(&reported, load, status, now, refreshInterval) {
+					continue
+				}
+				next := reported
+				next.Load, next.Status, next.UpdateTime = load, status, now.Unix()
+				value, err := next.Serialize()
+				if err != nil {
+					continue
+				}
+				if err := sr.UpdateValue(value); err != nil {
+					log.Warn("failed to report the service status", zap.String("key", sr.key), zap.Error(err))
+					continue
+				}
+				reported = next
+			}
+		}
+	}()
 }
 
 // Deregister deregisters the service from etcd.
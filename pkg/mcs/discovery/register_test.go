@@ -82,3 +82,67 @@ func getKeyAfterLeaseExpired(re *require.Assertions, client *clientv3.Client, ke
 	}
 	return string(resp.Kvs[0].Value)
 }
+
+func TestUpdateValue(t *testing.T) {
+	re := require.New(t)
+	_, client, clean := etcdutil.NewTestEtcdCluster(t, 1)
+	defer clean()
+
+	sr := NewServiceRegister(context.Background(), client, "12345", "test_service", "127.0.0.1:1", "v1", 1)
+	// The value is used by the registration if the service is not registered yet.
+	re.NoError(sr.UpdateValue("v2"))
+	re.NoError(sr.Register())
+	resp, err := client.Get(context.Background(), sr.key)
+	re.NoError(err)
+	re.Equal("v2", string(resp.Kvs[0].Value))
+
+	// The updated value is still bound to the lease.
+	re.NoError(sr.UpdateValue("v3"))
+	re.Equal("v3", getKeyAfterLeaseExpired(re, client, sr.key))
+	sr.cancel()
+	re.Empty(getKeyAfterLeaseExpired(re, client, sr.key))
+}
+
+func TestServiceStats(t *testing.T) {
+	re := require.New(t)
+	stats := NewServiceStats(10 * time.Millisecond)
+	load, status := stats.Report(time.Second)
+	re.Zero(load)
+	re.Equal(StatusHealthy, status)
+
+	for i := 0; i < 10; i++ {
+		stats.Observe(time.Millisecond)
+	}
+	load, status = stats.Report(time.Second)
+	re.Equal(10.0, load)
+	re.Equal(StatusHealthy, status)
+
+	stats.Observe(20 * time.Millisecond)
+	stats.Observe(20 * time.Millisecond)
+	load, status = stats.Report(2 * time.Second)
+	re.Equal(1.0, load)
+	re.Equal(StatusUnhealthy, status)
+}
+
+func TestNeedReport(t *testing.T) {
+	re := require.New(t)
+	now := time.Now()
+	refreshInterval := 10 * time.Second
+	// The entry without any report is always reported.
+	re.True(needReport(&ServiceRegistryEntry{}, 0, StatusHealthy, now, refreshInterval))
+
+	reported := &ServiceRegistryEntry{Load: 100, Status: StatusHealthy, UpdateTime: now.Unix()}
+	re.False(needReport(reported, 100, StatusHealthy, now, refreshInterval))
+	// The small load changes are not reported.
+	re.False(needReport(reported, 110, StatusHealthy, now, refreshInterval))
+	re.False(needReport(reported, 90, StatusHealthy, now, refreshInterval))
+	// The large load changes are reported.
+	re.True(needReport(reported, 130, StatusHealthy, now, refreshInterval))
+	re.True(needReport(reported, 70, StatusHealthy, now, refreshInterval))
+	re.True(needReport(reported, 0, StatusHealthy, now, refreshInterval))
+	// The status changes are always reported.
+	re.True(needReport(reported, 100, StatusUnhealthy, now, refreshInterval))
+	// The entry is reported again before it becomes stale.
+	re.False(needReport(reported, 100, StatusHealthy, now.Add(refreshInterval-time.Second), refreshInterval))
+	re.True(needReport(reported, 100, StatusHealthy, now.Add(refreshInterval), refreshInterval))
+}
@@ -16,14 +16,53 @@ package discovery
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/pingcap/log"
 	"go.uber.org/zap"
 )
 
+const (
+	// StatusHealthy means the service instance is serving requests normally.
+	StatusHealthy = "healthy"
+	// StatusUnhealthy means the service instance is overloaded or serving requests slowly.
+	StatusUnhealthy = "unhealthy"
+)
+
 // ServiceRegistryEntry is the registry entry of a service
 type ServiceRegistryEntry struct {
-	ServiceAddr string `json:"service-addr"`
+	ServiceAddr    string            `json:"service-addr"`
+	Version        string            `json:"version,omitempty"`
+	GitHash        string            `json:"git-hash,omitempty"`
+	StartTimestamp int64             `json:"start-timestamp,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	// Load, Status and UpdateTime are reported periodically by the service instance itself.
+	// An entry without any report is treated as healthy for compatibility.
+	Load       float64 `json:"load,omitempty"`
+	Status     string  `json:"status,omitempty"`
+	UpdateTime int64   `json:"update-time,omitempty"`
+}
+
+// IsHealthy returns whether the service instance is healthy. An instance is considered
+// unhealthy if it reports so or its report is older than the given stale timeout.
+func (e *ServiceRegistryEntry) IsHealthy(now time.Time, staleTimeout time.Duration) bool {
+	if e.Status == StatusUnhealthy {
+		return false
+	}
+	if e.UpdateTime > 0 && staleTimeout > 0 && now.Sub(time.Unix(e.UpdateTime, 0)) > staleTimeout {
+		return false
+	}
+	return true
+}
+
+// MatchLabels returns whether the entry has all the given labels.
+func (e *ServiceRegistryEntry) MatchLabels(labels map[string]string) bool {
+	for k, v := range labels {
+		if e.Labels[k] != v {
+			return false
+		}
+	}
+	return true
 }
 
 // Serialize this service registry entry
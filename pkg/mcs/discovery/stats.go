@@ -0,0 +1,82 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultReportInterval is the default interval to report the load and health status.
+	DefaultReportInterval = 5 * time.Second
+	// DefaultStaleTimeout is the default timeout after which a reported status is stale.
+	DefaultStaleTimeout = 3 * DefaultReportInterval
+	// DefaultSlowThreshold is the default average latency above which a service is unhealthy.
+	DefaultSlowThreshold = 100 * time.Millisecond
+	// DefaultLoadChangeRatio is the default ratio of the load change above which the load is reported.
+	DefaultLoadChangeRatio = 0.2
+)
+
+// ServiceStats collects the request statistics of a service instance, which are
+// used to report its load and health status.
+type ServiceStats struct {
+	slowThreshold time.Duration
+	count         atomic.Int64
+	totalDuration atomic.Int64
+}
+
+// NewServiceStats creates a new ServiceStats.
+func NewServiceStats(slowThreshold time.Duration) *ServiceStats {
+	return &ServiceStats{slowThreshold: slowThreshold}
+}
+
+// Observe records a handled request with its duration.
+func (s *ServiceStats) Observe(d time.Duration) {
+	s.count.Add(1)
+	s.totalDuration.Add(int64(d))
+}
+
+// Report returns the load in requests per second and the health status during the
+// given interval, and resets the statistics.
+func (s *ServiceStats) Report(interval time.Duration) (load float64, status string) {
+	count := s.count.Swap(0)
+	total := s.totalDuration.Swap(0)
+	status = StatusHealthy
+	if count == 0 {
+		return 0, status
+	}
+	if s.slowThreshold > 0 && time.Duration(total/count) > s.slowThreshold {
+		status = StatusUnhealthy
+	}
+	if interval > 0 {
+		load = float64(count) / interval.Seconds()
+	}
+	return load, status
+}
+
+// needReport returns whether the load and status should be reported to replace the reported
+// entry, which is true if the status changes, the load changes by more than the ratio, or the
+// reported entry is about to be stale after the refresh interval.
+func needReport(reported *ServiceRegistryEntry, load float64, status string, now time.Time, refreshInterval time.Duration) bool {
+	if reported.Status != status {
+		return true
+	}
+	if math.Abs(load-reported.Load) > DefaultLoadChangeRatio*math.Max(load, reported.Load) {
+		return true
+	}
+	return now.Sub(time.Unix(reported.UpdateTime, 0)) >= refreshInterval
+}
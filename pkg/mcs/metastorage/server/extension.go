@@ -0,0 +1,92 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExtensionServiceName is the name of the gRPC service serving the meta storage requests which are
+// not defined by the meta storage protocol. Since the messages are not defined by the protocol
+// either, they are encoded in JSON and carried by BytesValue.
+// Note: keep the same as the one defined on the client side.
+const ExtensionServiceName = "pd.metastorage.MetaStorageExtension"
+
+// extensionServer is the interface of the extension service.
+type extensionServer interface {
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Txn(context.Context, *TxnRequest) (*TxnResponse, error)
+	LeaseGrant(context.Context, *LeaseGrantRequest) (*LeaseGrantResponse, error)
+	LeaseKeepAlive(context.Context, *LeaseKeepAliveRequest) (*LeaseKeepAliveResponse, error)
+	LeaseRevoke(context.Context, *LeaseRevokeRequest) (*LeaseRevokeResponse, error)
+}
+
+var _ extensionServer = (*Service)(nil)
+
+// ExtensionServiceDesc is the gRPC service descriptor of the extension service.
+var ExtensionServiceDesc = grpc.ServiceDesc{
+	ServiceName: ExtensionServiceName,
+	HandlerType: (*extensionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Delete", Handler: extensionHandler("Delete", extensionServer.Delete)},
+		{MethodName: "Txn", Handler: extensionHandler("Txn", extensionServer.Txn)},
+		{MethodName: "LeaseGrant", Handler: extensionHandler("LeaseGrant", extensionServer.LeaseGrant)},
+		{MethodName: "LeaseKeepAlive", Handler: extensionHandler("LeaseKeepAlive", extensionServer.LeaseKeepAlive)},
+		{MethodName: "LeaseRevoke", Handler: extensionHandler("LeaseRevoke", extensionServer.LeaseRevoke)},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+type methodHandler = func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error)
+
+func extensionHandler[Req, Resp any](method string, call func(extensionServer, context.Context, *Req) (*Resp, error)) methodHandler {
+	handle := func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+		r := new(Req)
+		if err := json.Unmarshal(req.(*types.BytesValue).GetValue(), r); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s request: %v", method, err)
+		}
+		resp, err := call(srv.(extensionServer), ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(resp)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid %s response: %v", method, err)
+		}
+		return &types.BytesValue{Value: value}, nil
+	}
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		req := &types.BytesValue{}
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return handle(srv, ctx, req)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: "/" + ExtensionServiceName + "/" + method,
+		}
+		return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return handle(srv, ctx, req)
+		})
+	}
+}
@@ -66,6 +66,7 @@ func NewService[T ClusterIDProvider](svr bs.Server) registry.RegistrableService
 // RegisterGRPCService registers the service to gRPC server.
 func (s *Service) RegisterGRPCService(g *grpc.Server) {
 	meta_storagepb.RegisterMetaStorageServer(g, s)
+	g.RegisterService(&ExtensionServiceDesc, s)
 }
 
 // RegisterRESTHandler registers the service to REST server.
@@ -198,6 +199,9 @@ func (s *Service) Put(ctx context.Context, req *meta_storagepb.PutRequest) (*met
 	}
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	if err := checkKeyRange(req.GetKey(), nil); err != nil {
+		return &meta_storagepb.PutResponse{Header: s.wrapErrorAndRevision(0, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+	}
 	options := []clientv3.OpOption{}
 	key := string(req.GetKey())
 	value := string(req.GetValue())
@@ -0,0 +1,301 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pingcap/kvproto/pkg/meta_storagepb"
+	"go.etcd.io/etcd/clientv3"
+	"go.etcd.io/etcd/mvcc/mvccpb"
+)
+
+// The meta storage protocol only defines Watch, Get and Put, the requests and responses below
+// are served by the extension gRPC service of the meta storage, and follow the semantics of etcd.
+// They share the same key space with Watch, Get and Put. For all of them, the keys owned by PD and
+// the other microservices are read-only, i.e, they can be read, e.g, to discover the primary of a
+// microservice, but the writes to them are rejected by checkKeyRange.
+
+// reservedPrefixes are the prefixes of the keys owned by PD and the other microservices, e.g, the
+// leader keys attached to the leases, which can't be written through the meta storage.
+// Note: keep the same as the root paths of PD and the microservices.
+var reservedPrefixes = []string{"/pd/", "/ms/"}
+
+// DeleteRequest is the request to delete the key or the keys in the range.
+type DeleteRequest struct {
+	Key      []byte `json:"key"`
+	RangeEnd []byte `json:"range_end,omitempty"`
+	PrevKv   bool   `json:"prev_kv,omitempty"`
+}
+
+// DeleteResponse is the response of the DeleteRequest.
+type DeleteResponse struct {
+	Header  *meta_storagepb.ResponseHeader `json:"header"`
+	Deleted int64                          `json:"deleted"`
+	PrevKvs []*meta_storagepb.KeyValue     `json:"prev_kvs,omitempty"`
+}
+
+// CompareTarget is the target of the comparison in the transaction.
+type CompareTarget string
+
+// The targets of the comparison.
+const (
+	CompareValue       CompareTarget = "value"
+	CompareModRevision CompareTarget = "mod_revision"
+)
+
+// CompareResult is the expected result of the comparison in the transaction.
+type CompareResult string
+
+// The results of the comparison.
+const (
+	CompareEqual    CompareResult = "equal"
+	CompareNotEqual CompareResult = "not_equal"
+	CompareGreater  CompareResult = "greater"
+	CompareLess     CompareResult = "less"
+)
+
+var compareOperators = map[CompareResult]string{
+	CompareEqual:    "=",
+	CompareNotEqual: "!=",
+	CompareGreater:  ">",
+	CompareLess:     "<",
+}
+
+// Compare is the condition of the transaction. Note that the mod revision of a key which
+// does not exist is 0, so comparing it with 0 checks whether the key exists.
+type Compare struct {
+	Key         []byte        `json:"key"`
+	Target      CompareTarget `json:"target"`
+	Result      CompareResult `json:"result"`
+	Value       []byte        `json:"value,omitempty"`
+	ModRevision int64         `json:"mod_revision,omitempty"`
+}
+
+// TxnOpType is the type of the operation in the transaction.
+type TxnOpType string
+
+// The types of the operation in the transaction.
+const (
+	TxnOpGet    TxnOpType = "get"
+	TxnOpPut    TxnOpType = "put"
+	TxnOpDelete TxnOpType = "delete"
+)
+
+// TxnOp is the operation in the transaction.
+type TxnOp struct {
+	Type     TxnOpType `json:"type"`
+	Key      []byte    `json:"key"`
+	RangeEnd []byte    `json:"range_end,omitempty"`
+	Value    []byte    `json:"value,omitempty"`
+	Lease    int64     `json:"lease,omitempty"`
+}
+
+// TxnRequest is the request to execute the success operations if all the comparisons succeed,
+// otherwise execute the failure operations, atomically.
+type TxnRequest struct {
+	Compare []*Compare `json:"compare"`
+	Success []*TxnOp   `json:"success"`
+	Failure []*TxnOp   `json:"failure"`
+}
+
+// TxnOpResponse is the response of the operation in the transaction.
+type TxnOpResponse struct {
+	Kvs     []*meta_storagepb.KeyValue `json:"kvs,omitempty"`
+	Deleted int64                      `json:"deleted,omitempty"`
+}
+
+// TxnResponse is the response of the TxnRequest.
+type TxnResponse struct {
+	Header    *meta_storagepb.ResponseHeader `json:"header"`
+	Succeeded bool                           `json:"succeeded"`
+	Responses []*TxnOpResponse               `json:"responses"`
+}
+
+// Delete deletes the key or the keys in the range from meta storage.
+func (s *Service) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	if err := s.checkServing(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err := checkKeyRange(req.Key, req.RangeEnd); err != nil {
+		return &DeleteResponse{Header: s.wrapErrorAndRevision(0, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+	}
+	options := []clientv3.OpOption{}
+	key := string(req.Key)
+	if endKey := req.RangeEnd; endKey != nil {
+		options = append(options, clientv3.WithRange(string(endKey)))
+	}
+	if req.PrevKv {
+		options = append(options, clientv3.WithPrevKV())
+	}
+	cli := s.manager.GetClient()
+	res, err := cli.Delete(ctx, key, options...)
+	var revision int64
+	if res != nil {
+		revision = res.Header.GetRevision()
+	}
+	if err != nil {
+		return &DeleteResponse{Header: s.wrapErrorAndRevision(revision, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+	}
+	resp := &DeleteResponse{
+		Header:  &meta_storagepb.ResponseHeader{ClusterId: s.manager.ClusterID(), Revision: revision},
+		Deleted: res.Deleted,
+	}
+	for _, kv := range res.PrevKvs {
+		resp.PrevKvs = append(resp.PrevKvs, toKeyValue(kv))
+	}
+	return resp, nil
+}
+
+// Txn executes the operations in the transaction atomically.
+func (s *Service) Txn(ctx context.Context, req *TxnRequest) (*TxnResponse, error) {
+	if err := s.checkServing(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cmps := make([]clientv3.Cmp, 0, len(req.Compare))
+	for _, c := range req.Compare {
+		cmp, err := toCmp(c)
+		if err != nil {
+			return &TxnResponse{Header: s.wrapErrorAndRevision(0, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+		}
+		cmps = append(cmps, cmp)
+	}
+	success, err := toOps(req.Success)
+	if err != nil {
+		return &TxnResponse{Header: s.wrapErrorAndRevision(0, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+	}
+	failure, err := toOps(req.Failure)
+	if err != nil {
+		return &TxnResponse{Header: s.wrapErrorAndRevision(0, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+	}
+
+	cli := s.manager.GetClient()
+	res, err := cli.Txn(ctx).If(cmps...).Then(success...).Else(failure...).Commit()
+	var revision int64
+	if res != nil {
+		revision = res.Header.GetRevision()
+	}
+	if err != nil {
+		return &TxnResponse{Header: s.wrapErrorAndRevision(revision, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+	}
+	resp := &TxnResponse{
+		Header:    &meta_storagepb.ResponseHeader{ClusterId: s.manager.ClusterID(), Revision: revision},
+		Succeeded: res.Succeeded,
+		Responses: make([]*TxnOpResponse, 0, len(res.Responses)),
+	}
+	for _, r := range res.Responses {
+		opResp := &TxnOpResponse{}
+		if rangeResp := r.GetResponseRange(); rangeResp != nil {
+			for _, kv := range rangeResp.Kvs {
+				opResp.Kvs = append(opResp.Kvs, toKeyValue(kv))
+			}
+		}
+		if deleteResp := r.GetResponseDeleteRange(); deleteResp != nil {
+			opResp.Deleted = deleteResp.Deleted
+		}
+		resp.Responses = append(resp.Responses, opResp)
+	}
+	return resp, nil
+}
+
+func toCmp(c *Compare) (clientv3.Cmp, error) {
+	op, ok := compareOperators[c.Result]
+	if !ok {
+		return clientv3.Cmp{}, fmt.Errorf("unknown compare result %q", c.Result)
+	}
+	key := string(c.Key)
+	switch c.Target {
+	case CompareValue:
+		return clientv3.Compare(clientv3.Value(key), op, string(c.Value)), nil
+	case CompareModRevision:
+		return clientv3.Compare(clientv3.ModRevision(key), op, c.ModRevision), nil
+	default:
+		return clientv3.Cmp{}, fmt.Errorf("unknown compare target %q", c.Target)
+	}
+}
+
+func toOps(txnOps []*TxnOp) ([]clientv3.Op, error) {
+	ops := make([]clientv3.Op, 0, len(txnOps))
+	for _, op := range txnOps {
+		if op.Type != TxnOpGet {
+			if err := checkKeyRange(op.Key, op.RangeEnd); err != nil {
+				return nil, err
+			}
+		}
+		key := string(op.Key)
+		options := []clientv3.OpOption{}
+		if endKey := op.RangeEnd; endKey != nil {
+			options = append(options, clientv3.WithRange(string(endKey)))
+		}
+		switch op.Type {
+		case TxnOpGet:
+			ops = append(ops, clientv3.OpGet(key, options...))
+		case TxnOpPut:
+			if op.RangeEnd != nil {
+				return nil, fmt.Errorf("range end is not allowed in the put operation")
+			}
+			if lease := clientv3.LeaseID(op.Lease); lease != 0 {
+				options = append(options, clientv3.WithLease(lease))
+			}
+			ops = append(ops, clientv3.OpPut(key, string(op.Value), options...))
+		case TxnOpDelete:
+			ops = append(ops, clientv3.OpDelete(key, options...))
+		default:
+			return nil, fmt.Errorf("unknown txn operation type %q", op.Type)
+		}
+	}
+	return ops, nil
+}
+
+// checkKeyRange checks that the key, or the keys in the range if the range end is given, to be
+// written don't overlap with the reserved prefixes. The range end `\x00` means all the keys not less than the key.
+func checkKeyRange(key, rangeEnd []byte) error {
+	if len(key) == 0 {
+		return fmt.Errorf("key should not be empty")
+	}
+	for _, prefix := range reservedPrefixes {
+		start, end := []byte(prefix), []byte(clientv3.GetPrefixRangeEnd(prefix))
+		var overlapped bool
+		switch {
+		case rangeEnd == nil:
+			overlapped = bytes.HasPrefix(key, start)
+		case bytes.Equal(rangeEnd, []byte{0}):
+			overlapped = bytes.Compare(key, end) < 0
+		default:
+			overlapped = bytes.Compare(key, end) < 0 && bytes.Compare(start, rangeEnd) < 0
+		}
+		if overlapped {
+			return fmt.Errorf("key %q is reserved by PD", key)
+		}
+	}
+	return nil
+}
+
+func toKeyValue(kv *mvccpb.KeyValue) *meta_storagepb.KeyValue {
+	return &meta_storagepb.KeyValue{
+		Key:            kv.Key,
+		Value:          kv.Value,
+		ModRevision:    kv.ModRevision,
+		CreateRevision: kv.CreateRevision,
+		Version:        kv.Version,
+		Lease:          kv.Lease,
+	}
+}
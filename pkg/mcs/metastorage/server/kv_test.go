@@ -0,0 +1,55 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckKeyRange(t *testing.T) {
+	re := require.New(t)
+	re.NoError(checkKeyRange([]byte("resource_group/settings"), nil))
+	re.NoError(checkKeyRange([]byte("a"), []byte("b")))
+	re.NoError(checkKeyRange([]byte("/pe"), []byte{0}))
+	re.NoError(checkKeyRange([]byte("/pa"), []byte("/pd/")))
+	re.NoError(checkKeyRange([]byte("/pd"), nil))
+
+	re.Error(checkKeyRange(nil, nil))
+	re.Error(checkKeyRange([]byte("/pd/1/leader"), nil))
+	re.Error(checkKeyRange([]byte("/ms/1/tso"), nil))
+	// The ranges overlapping with the reserved prefixes are rejected.
+	re.Error(checkKeyRange([]byte("/"), []byte("0")))
+	re.Error(checkKeyRange([]byte("/pa"), []byte("/pd/1")))
+	re.Error(checkKeyRange([]byte("/pd/1/"), []byte("/pd/2/")))
+	re.Error(checkKeyRange([]byte("/a"), []byte{0}))
+}
+
+func TestToOps(t *testing.T) {
+	re := require.New(t)
+	// The reserved keys can be read but not written.
+	_, err := toOps([]*TxnOp{{Type: TxnOpGet, Key: []byte("/pd/1/leader")}})
+	re.NoError(err)
+	_, err = toCmp(&Compare{Key: []byte("/ms/1/tso"), Target: CompareModRevision, Result: CompareEqual})
+	re.NoError(err)
+	_, err = toOps([]*TxnOp{{Type: TxnOpPut, Key: []byte("/pd/1/leader")}})
+	re.Error(err)
+	_, err = toOps([]*TxnOp{{Type: TxnOpDelete, Key: []byte("/"), RangeEnd: []byte{0}}})
+	re.Error(err)
+	ops, err := toOps([]*TxnOp{{Type: TxnOpPut, Key: []byte("a")}, {Type: TxnOpDelete, Key: []byte("a"), RangeEnd: []byte("b")}})
+	re.NoError(err)
+	re.Len(ops, 2)
+}
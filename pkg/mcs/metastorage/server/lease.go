@@ -0,0 +1,153 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/kvproto/pkg/meta_storagepb"
+	"go.etcd.io/etcd/clientv3"
+)
+
+const (
+	// minLeaseTTL and maxLeaseTTL bound the TTL of the lease in seconds, a lease lasting too
+	// long keeps the keys attached to it after the owner is gone.
+	minLeaseTTL = 1
+	maxLeaseTTL = 3600
+)
+
+// LeaseGrantRequest is the request to grant a lease with the TTL in seconds.
+type LeaseGrantRequest struct {
+	TTL int64 `json:"ttl"`
+}
+
+// LeaseGrantResponse is the response of the LeaseGrantRequest.
+type LeaseGrantResponse struct {
+	Header *meta_storagepb.ResponseHeader `json:"header"`
+	ID     int64                          `json:"id"`
+	TTL    int64                          `json:"ttl"`
+}
+
+// LeaseKeepAliveRequest is the request to refresh the lease once.
+type LeaseKeepAliveRequest struct {
+	ID int64 `json:"id"`
+}
+
+// LeaseKeepAliveResponse is the response of the LeaseKeepAliveRequest.
+type LeaseKeepAliveResponse struct {
+	Header *meta_storagepb.ResponseHeader `json:"header"`
+	ID     int64                          `json:"id"`
+	// TTL is the remaining TTL in seconds after refreshing.
+	TTL int64 `json:"ttl"`
+}
+
+// LeaseRevokeRequest is the request to revoke the lease, the keys attached to it will be deleted.
+type LeaseRevokeRequest struct {
+	ID int64 `json:"id"`
+}
+
+// LeaseRevokeResponse is the response of the LeaseRevokeRequest.
+type LeaseRevokeResponse struct {
+	Header *meta_storagepb.ResponseHeader `json:"header"`
+}
+
+// LeaseGrant grants a lease which can be attached to the keys.
+func (s *Service) LeaseGrant(ctx context.Context, req *LeaseGrantRequest) (*LeaseGrantResponse, error) {
+	if err := s.checkServing(); err != nil {
+		return nil, err
+	}
+	if req.TTL < minLeaseTTL || req.TTL > maxLeaseTTL {
+		return &LeaseGrantResponse{Header: s.wrapErrorAndRevision(0, meta_storagepb.ErrorType_UNKNOWN,
+			fmt.Sprintf("lease ttl %d is out of range [%d, %d]", req.TTL, minLeaseTTL, maxLeaseTTL))}, nil
+	}
+	cli := s.manager.GetClient()
+	res, err := cli.Grant(ctx, req.TTL)
+	var revision int64
+	if res != nil {
+		revision = res.ResponseHeader.GetRevision()
+	}
+	if err != nil {
+		return &LeaseGrantResponse{Header: s.wrapErrorAndRevision(revision, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+	}
+	return &LeaseGrantResponse{
+		Header: &meta_storagepb.ResponseHeader{ClusterId: s.manager.ClusterID(), Revision: revision},
+		ID:     int64(res.ID),
+		TTL:    res.TTL,
+	}, nil
+}
+
+// LeaseKeepAlive refreshes the lease once. The caller should refresh the lease periodically
+// before it expires to keep it alive.
+func (s *Service) LeaseKeepAlive(ctx context.Context, req *LeaseKeepAliveRequest) (*LeaseKeepAliveResponse, error) {
+	if err := s.checkServing(); err != nil {
+		return nil, err
+	}
+	cli := s.manager.GetClient()
+	if err := checkLeaseOwnership(ctx, cli, clientv3.LeaseID(req.ID)); err != nil {
+		return &LeaseKeepAliveResponse{Header: s.wrapErrorAndRevision(0, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+	}
+	res, err := cli.KeepAliveOnce(ctx, clientv3.LeaseID(req.ID))
+	var revision int64
+	if res != nil {
+		revision = res.ResponseHeader.GetRevision()
+	}
+	if err != nil {
+		return &LeaseKeepAliveResponse{Header: s.wrapErrorAndRevision(revision, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+	}
+	return &LeaseKeepAliveResponse{
+		Header: &meta_storagepb.ResponseHeader{ClusterId: s.manager.ClusterID(), Revision: revision},
+		ID:     int64(res.ID),
+		TTL:    res.TTL,
+	}, nil
+}
+
+// LeaseRevoke revokes the lease and deletes the keys attached to it.
+func (s *Service) LeaseRevoke(ctx context.Context, req *LeaseRevokeRequest) (*LeaseRevokeResponse, error) {
+	if err := s.checkServing(); err != nil {
+		return nil, err
+	}
+	cli := s.manager.GetClient()
+	if err := checkLeaseOwnership(ctx, cli, clientv3.LeaseID(req.ID)); err != nil {
+		return &LeaseRevokeResponse{Header: s.wrapErrorAndRevision(0, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+	}
+	res, err := cli.Revoke(ctx, clientv3.LeaseID(req.ID))
+	var revision int64
+	if res != nil {
+		revision = res.Header.GetRevision()
+	}
+	if err != nil {
+		return &LeaseRevokeResponse{Header: s.wrapErrorAndRevision(revision, meta_storagepb.ErrorType_UNKNOWN, err.Error())}, nil
+	}
+	return &LeaseRevokeResponse{
+		Header: &meta_storagepb.ResponseHeader{ClusterId: s.manager.ClusterID(), Revision: revision},
+	}, nil
+}
+
+// checkLeaseOwnership checks that none of the keys attached to the lease is reserved, so the
+// leases of PD and the other microservices, e.g, the one of the PD leader, can't be refreshed
+// or revoked by the meta storage requests.
+func checkLeaseOwnership(ctx context.Context, cli *clientv3.Client, id clientv3.LeaseID) error {
+	res, err := cli.TimeToLive(ctx, id, clientv3.WithAttachedKeys())
+	if err != nil {
+		return err
+	}
+	for _, key := range res.Keys {
+		if err := checkKeyRange(key, nil); err != nil {
+			return fmt.Errorf("lease %d is attached to the reserved key %q", id, key)
+		}
+	}
+	return nil
+}
@@ -111,6 +111,11 @@ func (s *Service) RegisterRouter() {
 	configEndpoint.DELETE("/group/:name", s.deleteResourceGroup)
 	configEndpoint.GET("/controller", s.getControllerConfig)
 	configEndpoint.POST("/controller", s.setControllerConfig)
+	configEndpoint.GET("/group/:name/runaway-policy", s.getRunawayPolicy)
+	configEndpoint.PUT("/group/:name/runaway-policy", s.putRunawayPolicy)
+	configEndpoint.DELETE("/group/:name/runaway-policy", s.deleteRunawayPolicy)
+	runawayEndpoint := s.root.Group("/runaway")
+	runawayEndpoint.GET("/violations/:name", s.getRunawayViolations)
 }
 
 func (s *Service) handler() http.Handler {
@@ -261,3 +266,75 @@ func (s *Service) setControllerConfig(c *gin.Context) {
 	}
 	c.String(http.StatusOK, "Success!")
 }
+
+// getRunawayPolicy
+//
+//	@Tags		ResourceManager
+//	@Summary	Get the runaway policy of the resource group.
+//	@Param		name	path		string	true	"groupName"
+//	@Success	200		{object}	rmserver.RunawayPolicy
+//	@Failure	404		{string}	error
+//	@Router		/config/group/{name}/runaway-policy [GET]
+func (s *Service) getRunawayPolicy(c *gin.Context) {
+	policy := s.manager.GetRunawayPolicy(c.Param("name"))
+	if policy == nil {
+		c.String(http.StatusNotFound, errors.New("runaway policy not found").Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, policy)
+}
+
+// putRunawayPolicy
+//
+//	@Tags		ResourceManager
+//	@Summary	Set the runaway policy of the resource group.
+//	@Param		name	path		string	true	"groupName"
+//	@Param		policy	body		object	true	"json params, rmserver.RunawayPolicy"
+//	@Success	200		{string}	string	"Success!"
+//	@Failure	400		{string}	error
+//	@Router		/config/group/{name}/runaway-policy [PUT]
+func (s *Service) putRunawayPolicy(c *gin.Context) {
+	var policy rmserver.RunawayPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.manager.SetRunawayPolicy(c.Param("name"), &policy); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	c.String(http.StatusOK, "Success!")
+}
+
+// deleteRunawayPolicy
+//
+//	@Tags		ResourceManager
+//	@Summary	Delete the runaway policy of the resource group.
+//	@Param		name	path		string	true	"groupName"
+//	@Success	200		{string}	string	"Success!"
+//	@Failure	500		{string}	error
+//	@Router		/config/group/{name}/runaway-policy [DELETE]
+func (s *Service) deleteRunawayPolicy(c *gin.Context) {
+	if err := s.manager.DeleteRunawayPolicy(c.Param("name")); err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.String(http.StatusOK, "Success!")
+}
+
+// getRunawayViolations
+//
+//	@Tags		ResourceManager
+//	@Summary	Get the runaway violations of the resource group aggregated from all the clients.
+//	@Param		name	path		string	true	"groupName"
+//	@Success	200		{object}	rmserver.RunawayViolationStats
+//	@Failure	404		{string}	error
+//	@Router		/runaway/violations/{name} [GET]
+func (s *Service) getRunawayViolations(c *gin.Context) {
+	stats, err := s.manager.GetRunawayViolationStats(c.Param("name"))
+	if err != nil {
+		c.String(http.StatusNotFound, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, stats)
+}
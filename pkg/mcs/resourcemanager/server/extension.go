@@ -0,0 +1,105 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExtensionServiceName is the name of the gRPC service serving the resource manager requests which
+// are not defined by the resource manager protocol. Since the messages are not defined by the
+// protocol either, they are encoded in JSON and carried by BytesValue.
+// Note: keep the same as the one defined on the client side.
+const ExtensionServiceName = "pd.resourcemanager.ResourceManagerExtension"
+
+// ReportRunawayViolationsRequest is the request to report the runaway violations detected by a
+// client since its last report.
+type ReportRunawayViolationsRequest struct {
+	Violations []*RunawayViolationStats `json:"violations"`
+}
+
+// ReportRunawayViolationsResponse is the response of the ReportRunawayViolationsRequest.
+type ReportRunawayViolationsResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// extensionServer is the interface of the extension service.
+type extensionServer interface {
+	ReportRunawayViolations(context.Context, *ReportRunawayViolationsRequest) (*ReportRunawayViolationsResponse, error)
+}
+
+var _ extensionServer = (*Service)(nil)
+
+// ExtensionServiceDesc is the gRPC service descriptor of the extension service.
+var ExtensionServiceDesc = grpc.ServiceDesc{
+	ServiceName: ExtensionServiceName,
+	HandlerType: (*extensionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ReportRunawayViolations", Handler: reportRunawayViolationsHandler},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func reportRunawayViolationsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := &types.BytesValue{}
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	handle := func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := &ReportRunawayViolationsRequest{}
+		if err := json.Unmarshal(req.(*types.BytesValue).GetValue(), r); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ReportRunawayViolations request: %v", err)
+		}
+		resp, err := srv.(extensionServer).ReportRunawayViolations(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(resp)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid ReportRunawayViolations response: %v", err)
+		}
+		return &types.BytesValue{Value: value}, nil
+	}
+	if interceptor == nil {
+		return handle(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + ExtensionServiceName + "/ReportRunawayViolations",
+	}
+	return interceptor(ctx, req, info, handle)
+}
+
+// ReportRunawayViolations aggregates the runaway violations reported by a client. The reports of
+// the resource groups which no longer exist are ignored.
+func (s *Service) ReportRunawayViolations(_ context.Context, req *ReportRunawayViolationsRequest) (*ReportRunawayViolationsResponse, error) {
+	if err := s.checkServing(); err != nil {
+		return nil, err
+	}
+	for _, report := range req.Violations {
+		if err := s.manager.ReportRunawayViolations(report); err != nil {
+			log.Debug("ignore the runaway violations", zap.String("name", report.Name), zap.Error(err))
+		}
+	}
+	return &ReportRunawayViolationsResponse{}, nil
+}
@@ -73,6 +73,7 @@ func NewService[T ConfigProvider](svr bs.Server) registry.RegistrableService {
 // RegisterGRPCService registers the service to gRPC server.
 func (s *Service) RegisterGRPCService(g *grpc.Server) {
 	rmpb.RegisterResourceManagerServer(g, s)
+	g.RegisterService(&ExtensionServiceDesc, s)
 }
 
 // RegisterRESTHandler registers the service to REST server.
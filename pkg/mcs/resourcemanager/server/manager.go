@@ -57,6 +57,10 @@ type Manager struct {
 	controllerConfig *ControllerConfig
 	groups           map[string]*ResourceGroup
 	storage          endpoint.ResourceGroupStorage
+	// runawayViolations is the runaway violations aggregated from the reports of the clients,
+	// the changed ones since the last persistence are marked in dirtyRunawayViolations.
+	runawayViolations      map[string]*RunawayViolationStats
+	dirtyRunawayViolations map[string]struct{}
 	// consumptionChan is used to send the consumption
 	// info to the background metrics flusher.
 	consumptionDispatcher chan struct {
@@ -84,8 +88,10 @@ type ConfigProvider interface {
 // which should implement the `ConfigProvider` interface.
 func NewManager[T ConfigProvider](srv bs.Server) *Manager {
 	m := &Manager{
-		controllerConfig: srv.(T).GetControllerConfig(),
-		groups:           make(map[string]*ResourceGroup),
+		controllerConfig:       srv.(T).GetControllerConfig(),
+		groups:                 make(map[string]*ResourceGroup),
+		runawayViolations:      make(map[string]*RunawayViolationStats),
+		dirtyRunawayViolations: make(map[string]struct{}),
 		consumptionDispatcher: make(chan struct {
 			resourceGroupName string
 			*rmpb.Consumption
@@ -157,6 +163,9 @@ func (m *Manager) Init(ctx context.Context) error {
 	if err := m.storage.LoadResourceGroupStates(tokenHandler); err != nil {
 		return err
 	}
+	if err := m.loadRunawayViolations(); err != nil {
+		return err
+	}
 
 	// Add default group if it's not inited.
 	if _, ok := m.groups[reservedDefaultGroupName]; !ok {
@@ -278,12 +287,19 @@ func (m *Manager) DeleteResourceGroup(name string) error {
 	if name == reservedDefaultGroupName {
 		return errs.ErrDeleteReservedGroup
 	}
+	m.Lock()
+	defer m.Unlock()
+	// Delete the group, with its runaway policy, and the runaway violations with the lock held,
+	// so the policy is not set and the violations are not persisted again after the deletion.
 	if err := m.storage.DeleteResourceGroupSetting(name); err != nil {
 		return err
 	}
-	m.Lock()
+	if err := m.storage.DeleteRunawayViolations(name); err != nil {
+		return err
+	}
 	delete(m.groups, name)
-	m.Unlock()
+	delete(m.runawayViolations, name)
+	delete(m.dirtyRunawayViolations, name)
 	return nil
 }
 
@@ -334,6 +350,7 @@ func (m *Manager) persistLoop(ctx context.Context) {
 			return
 		case <-ticker.C:
 			m.persistResourceGroupRunningState()
+			m.persistRunawayViolations()
 		}
 	}
 }
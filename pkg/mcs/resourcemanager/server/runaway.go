@@ -0,0 +1,237 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/errs"
+	"go.uber.org/zap"
+)
+
+// RunawayPolicy is the policy to detect the runaway requests of a resource group, which is
+// evaluated by the resource group controller on the client side when the response is received.
+// A request is runaway if it exceeds any of the thresholds. The policy is stored as the runaway
+// settings of the resource group.
+type RunawayPolicy struct {
+	// MaxRUPerRequest is the max RU consumed by a request, 0 means no limit.
+	MaxRUPerRequest int64 `json:"max_ru_per_request,omitempty"`
+	// MaxExecElapsedTimeMs is the max execution time of a request, 0 means no limit.
+	MaxExecElapsedTimeMs uint64 `json:"max_exec_elapsed_time_ms,omitempty"`
+	// Action is the name of the runaway action, e.g, "Kill".
+	Action string `json:"action"`
+	// SwitchGroup is the resource group to switch to, only used by the SwitchGroup action.
+	SwitchGroup string `json:"switch_group,omitempty"`
+}
+
+// runawayPolicyFromSettings converts the runaway settings of the resource group to the policy,
+// nil if no threshold is set.
+func runawayPolicyFromSettings(settings *rmpb.RunawaySettings) *RunawayPolicy {
+	rule := settings.GetRule()
+	if rule.GetRequestUnit() == 0 && rule.GetExecElapsedTimeMs() == 0 {
+		return nil
+	}
+	return &RunawayPolicy{
+		MaxRUPerRequest:      rule.GetRequestUnit(),
+		MaxExecElapsedTimeMs: rule.GetExecElapsedTimeMs(),
+		Action:               settings.GetAction().String(),
+		SwitchGroup:          settings.GetSwitchGroupName(),
+	}
+}
+
+// applyTo returns the runaway settings with the policy applied, the other settings are kept.
+func (p *RunawayPolicy) applyTo(settings *rmpb.RunawaySettings) *rmpb.RunawaySettings {
+	newSettings := &rmpb.RunawaySettings{}
+	if settings != nil {
+		newSettings = proto.Clone(settings).(*rmpb.RunawaySettings)
+	}
+	if newSettings.Rule == nil {
+		newSettings.Rule = &rmpb.RunawayRule{}
+	}
+	newSettings.Rule.RequestUnit = p.MaxRUPerRequest
+	newSettings.Rule.ExecElapsedTimeMs = p.MaxExecElapsedTimeMs
+	newSettings.Action = rmpb.RunawayAction(rmpb.RunawayAction_value[p.Action])
+	newSettings.SwitchGroupName = p.SwitchGroup
+	return newSettings
+}
+
+// RunawayViolationStats is the statistics of the runaway violations of a resource group. Each
+// client reports the violations since its last report, which are aggregated per resource group.
+type RunawayViolationStats struct {
+	Name string `json:"name"`
+	// Count is the total number of the violations.
+	Count uint64 `json:"count"`
+	// ActionCount is the number of the violations by the name of the action taken.
+	ActionCount map[string]uint64 `json:"action_count,omitempty"`
+	// RUExceededCount is the number of the violations exceeding MaxRUPerRequest.
+	RUExceededCount uint64 `json:"ru_exceeded_count"`
+	// ExecElapsedTimeExceededCount is the number of the violations exceeding MaxExecElapsedTimeMs.
+	ExecElapsedTimeExceededCount uint64    `json:"exec_elapsed_time_exceeded_count"`
+	MaxRU                        float64   `json:"max_ru"`
+	MaxExecElapsedTimeMs         uint64    `json:"max_exec_elapsed_time_ms"`
+	LastViolationTime            time.Time `json:"last_violation_time"`
+}
+
+func (s *RunawayViolationStats) merge(other *RunawayViolationStats) {
+	s.Count += other.Count
+	for action, count := range other.ActionCount {
+		if s.ActionCount == nil {
+			s.ActionCount = make(map[string]uint64)
+		}
+		s.ActionCount[action] += count
+	}
+	s.RUExceededCount += other.RUExceededCount
+	s.ExecElapsedTimeExceededCount += other.ExecElapsedTimeExceededCount
+	if other.MaxRU > s.MaxRU {
+		s.MaxRU = other.MaxRU
+	}
+	if other.MaxExecElapsedTimeMs > s.MaxExecElapsedTimeMs {
+		s.MaxExecElapsedTimeMs = other.MaxExecElapsedTimeMs
+	}
+	if other.LastViolationTime.After(s.LastViolationTime) {
+		s.LastViolationTime = other.LastViolationTime
+	}
+}
+
+// SetRunawayPolicy sets the runaway policy of the resource group, which is saved with the group.
+func (m *Manager) SetRunawayPolicy(name string, policy *RunawayPolicy) error {
+	if policy.MaxRUPerRequest < 0 {
+		return errs.ErrInvalidRunawayPolicy.FastGenByArgs("the max RU per request should not be negative")
+	}
+	if policy.MaxRUPerRequest == 0 && policy.MaxExecElapsedTimeMs == 0 {
+		return errs.ErrInvalidRunawayPolicy.FastGenByArgs("at least one threshold should be set")
+	}
+	action, ok := rmpb.RunawayAction_value[policy.Action]
+	switch {
+	case !ok || rmpb.RunawayAction(action) == rmpb.RunawayAction_NoneAction:
+		return errs.ErrInvalidRunawayPolicy.FastGenByArgs("unknown action " + policy.Action)
+	case rmpb.RunawayAction(action) != rmpb.RunawayAction_SwitchGroup:
+		if len(policy.SwitchGroup) > 0 {
+			return errs.ErrInvalidRunawayPolicy.FastGenByArgs("the switch group is only used by the SwitchGroup action")
+		}
+	case policy.SwitchGroup == name:
+		return errs.ErrInvalidRunawayPolicy.FastGenByArgs("the switch group should not be the group itself")
+	case m.GetResourceGroup(policy.SwitchGroup) == nil:
+		return errs.ErrResourceGroupNotExists.FastGenByArgs(policy.SwitchGroup)
+	}
+	if err := m.updateRunawaySettings(name, policy.applyTo); err != nil {
+		return err
+	}
+	log.Info("set runaway policy", zap.String("name", name), zap.Reflect("policy", policy))
+	return nil
+}
+
+// GetRunawayPolicy returns the runaway policy of the resource group, nil if it's not set.
+func (m *Manager) GetRunawayPolicy(name string) *RunawayPolicy {
+	group := m.GetResourceGroup(name)
+	if group == nil {
+		return nil
+	}
+	return runawayPolicyFromSettings(group.Runaway)
+}
+
+// DeleteRunawayPolicy deletes the runaway policy of the resource group, i.e, its runaway settings.
+func (m *Manager) DeleteRunawayPolicy(name string) error {
+	return m.updateRunawaySettings(name, func(*rmpb.RunawaySettings) *rmpb.RunawaySettings {
+		return nil
+	})
+}
+
+// updateRunawaySettings updates the runaway settings of the resource group and persists the group.
+// The manager lock is held, so the group can't be deleted concurrently.
+func (m *Manager) updateRunawaySettings(name string, update func(*rmpb.RunawaySettings) *rmpb.RunawaySettings) error {
+	m.Lock()
+	defer m.Unlock()
+	group, ok := m.groups[name]
+	if !ok {
+		return errs.ErrResourceGroupNotExists.FastGenByArgs(name)
+	}
+	metaGroup := group.IntoProtoResourceGroup()
+	metaGroup.RunawaySettings = update(metaGroup.GetRunawaySettings())
+	if err := m.storage.SaveResourceGroupSetting(name, metaGroup); err != nil {
+		return err
+	}
+	group.Lock()
+	group.Runaway = metaGroup.RunawaySettings
+	group.Unlock()
+	return nil
+}
+
+// ReportRunawayViolations merges the runaway violations reported by a client since its last report
+// into the aggregated ones of the resource group, which are persisted periodically.
+func (m *Manager) ReportRunawayViolations(report *RunawayViolationStats) error {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.groups[report.Name]; !ok {
+		return errs.ErrResourceGroupNotExists.FastGenByArgs(report.Name)
+	}
+	stats, ok := m.runawayViolations[report.Name]
+	if !ok {
+		stats = &RunawayViolationStats{Name: report.Name}
+		m.runawayViolations[report.Name] = stats
+	}
+	stats.merge(report)
+	m.dirtyRunawayViolations[report.Name] = struct{}{}
+	return nil
+}
+
+// GetRunawayViolationStats returns the runaway violations of the resource group aggregated
+// from the reports of all the clients.
+func (m *Manager) GetRunawayViolationStats(name string) (*RunawayViolationStats, error) {
+	m.RLock()
+	defer m.RUnlock()
+	if _, ok := m.groups[name]; !ok {
+		return nil, errs.ErrResourceGroupNotExists.FastGenByArgs(name)
+	}
+	stats := &RunawayViolationStats{Name: name}
+	if aggregated, ok := m.runawayViolations[name]; ok {
+		stats.merge(aggregated)
+	}
+	return stats, nil
+}
+
+func (m *Manager) loadRunawayViolations() error {
+	m.Lock()
+	defer m.Unlock()
+	m.runawayViolations = make(map[string]*RunawayViolationStats)
+	m.dirtyRunawayViolations = make(map[string]struct{})
+	return m.storage.LoadRunawayViolations(func(k, v string) {
+		stats := &RunawayViolationStats{}
+		if err := json.Unmarshal([]byte(v), stats); err != nil {
+			log.Error("failed to parse the runaway violations", zap.Error(err), zap.String("k", k), zap.String("v", v))
+			return
+		}
+		m.runawayViolations[k] = stats
+	})
+}
+
+// persistRunawayViolations persists the aggregated runaway violations changed since the last time.
+func (m *Manager) persistRunawayViolations() {
+	m.Lock()
+	defer m.Unlock()
+	for name := range m.dirtyRunawayViolations {
+		if stats, ok := m.runawayViolations[name]; ok {
+			if err := m.storage.SaveRunawayViolations(name, stats); err != nil {
+				log.Warn("failed to persist the runaway violations", zap.String("name", name), zap.Error(err))
+				continue
+			}
+		}
+		delete(m.dirtyRunawayViolations, name)
+	}
+}
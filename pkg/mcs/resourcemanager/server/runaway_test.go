@@ -0,0 +1,157 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+func TestRunawayPolicy(t *testing.T) {
+	re := require.New(t)
+	base := kv.NewMemoryKV()
+	m := &Manager{
+		groups: map[string]*ResourceGroup{
+			"test":  FromProtoResourceGroup(&rmpb.ResourceGroup{Name: "test", Mode: rmpb.GroupMode_RUMode}),
+			"other": FromProtoResourceGroup(&rmpb.ResourceGroup{Name: "other", Mode: rmpb.GroupMode_RUMode}),
+		},
+		storage: endpoint.NewStorageEndpoint(base, nil),
+	}
+
+	invalidPolicies := []*RunawayPolicy{
+		{Action: "Kill"},
+		{MaxRUPerRequest: -1, Action: "Kill"},
+		{MaxRUPerRequest: 100, Action: "unknown"},
+		{MaxRUPerRequest: 100, Action: "NoneAction"},
+		{MaxRUPerRequest: 100, Action: "Kill", SwitchGroup: "other"},
+		{MaxRUPerRequest: 100, Action: "SwitchGroup", SwitchGroup: "test"},
+		{MaxRUPerRequest: 100, Action: "SwitchGroup", SwitchGroup: "none"},
+	}
+	for _, policy := range invalidPolicies {
+		re.Error(m.SetRunawayPolicy("test", policy))
+	}
+	re.Error(m.SetRunawayPolicy("none", &RunawayPolicy{MaxRUPerRequest: 100, Action: "Kill"}))
+	re.Nil(m.GetRunawayPolicy("test"))
+
+	loadSettings := func() *rmpb.RunawaySettings {
+		var settings *rmpb.RunawaySettings
+		re.NoError(m.storage.LoadResourceGroupSettings(func(k, v string) {
+			group := &rmpb.ResourceGroup{}
+			re.NoError(proto.Unmarshal([]byte(v), group))
+			if k == "test" {
+				settings = group.GetRunawaySettings()
+			}
+		}))
+		return settings
+	}
+	// The policy is saved as the runaway settings of the group, and the other settings are kept.
+	m.groups["test"].Runaway = &rmpb.RunawaySettings{Watch: &rmpb.RunawayWatch{LastingDurationMs: 100}}
+	policy := &RunawayPolicy{MaxRUPerRequest: 100, MaxExecElapsedTimeMs: 1000, Action: "SwitchGroup", SwitchGroup: "other"}
+	re.NoError(m.SetRunawayPolicy("test", policy))
+	re.Equal(policy, m.GetRunawayPolicy("test"))
+	settings := loadSettings()
+	re.Equal(int64(100), settings.GetRule().GetRequestUnit())
+	re.Equal(uint64(1000), settings.GetRule().GetExecElapsedTimeMs())
+	re.Equal(rmpb.RunawayAction_SwitchGroup, settings.GetAction())
+	re.Equal("other", settings.GetSwitchGroupName())
+	re.Equal(int64(100), settings.GetWatch().GetLastingDurationMs())
+	re.Equal(policy, runawayPolicyFromSettings(settings))
+
+	re.NoError(m.DeleteRunawayPolicy("test"))
+	re.Nil(m.GetRunawayPolicy("test"))
+	re.Nil(loadSettings())
+	re.Error(m.DeleteRunawayPolicy("none"))
+
+	// The policy is deleted with the group.
+	re.NoError(m.SetRunawayPolicy("test", &RunawayPolicy{MaxRUPerRequest: 100, Action: "Kill"}))
+	re.NoError(m.DeleteResourceGroup("test"))
+	re.Nil(m.GetRunawayPolicy("test"))
+	re.Nil(loadSettings())
+}
+
+func TestRunawayViolationStats(t *testing.T) {
+	re := require.New(t)
+	base := kv.NewMemoryKV()
+	m := &Manager{
+		groups:                 map[string]*ResourceGroup{"test": {Name: "test"}, "other": {Name: "other"}},
+		runawayViolations:      make(map[string]*RunawayViolationStats),
+		dirtyRunawayViolations: make(map[string]struct{}),
+		storage:                endpoint.NewStorageEndpoint(base, nil),
+	}
+
+	now := time.Now().Truncate(time.Second)
+	reports := []*RunawayViolationStats{
+		{
+			Name:                 "test",
+			Count:                3,
+			ActionCount:          map[string]uint64{"Kill": 3},
+			RUExceededCount:      3,
+			MaxRU:                300,
+			MaxExecElapsedTimeMs: 10,
+			LastViolationTime:    now.Add(-time.Minute),
+		},
+		{
+			Name:                         "test",
+			Count:                        2,
+			ActionCount:                  map[string]uint64{"Kill": 1, "CoolDown": 1},
+			ExecElapsedTimeExceededCount: 2,
+			MaxRU:                        100,
+			MaxExecElapsedTimeMs:         2000,
+			LastViolationTime:            now,
+		},
+	}
+	for _, report := range reports {
+		re.NoError(m.ReportRunawayViolations(report))
+	}
+	re.Error(m.ReportRunawayViolations(&RunawayViolationStats{Name: "none", Count: 1}))
+
+	check := func() {
+		stats, err := m.GetRunawayViolationStats("test")
+		re.NoError(err)
+		re.Equal(uint64(5), stats.Count)
+		re.Equal(uint64(4), stats.ActionCount["Kill"])
+		re.Equal(uint64(1), stats.ActionCount["CoolDown"])
+		re.Equal(uint64(3), stats.RUExceededCount)
+		re.Equal(uint64(2), stats.ExecElapsedTimeExceededCount)
+		re.Equal(300.0, stats.MaxRU)
+		re.Equal(uint64(2000), stats.MaxExecElapsedTimeMs)
+		re.True(now.Equal(stats.LastViolationTime))
+	}
+	check()
+	stats, err := m.GetRunawayViolationStats("other")
+	re.NoError(err)
+	re.Zero(stats.Count)
+	_, err = m.GetRunawayViolationStats("none")
+	re.Error(err)
+
+	// The aggregated violations are persisted and reloaded, one key per resource group.
+	m.persistRunawayViolations()
+	re.Empty(m.dirtyRunawayViolations)
+	keys, _, err := base.LoadRange("runaway_violations/", "runaway_violations0", 0)
+	re.NoError(err)
+	re.Equal([]string{"runaway_violations/test"}, keys)
+	re.NoError(m.loadRunawayViolations())
+	check()
+	// The broken violations are skipped.
+	re.NoError(base.Save("runaway_violations/other", "{"))
+	re.NoError(m.loadRunawayViolations())
+	check()
+}
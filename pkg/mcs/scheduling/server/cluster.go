@@ -14,8 +14,10 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/mcs/scheduling/server/config"
+	"github.com/tikv/pd/pkg/progress"
 	"github.com/tikv/pd/pkg/schedule"
 	sc "github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/schedule/handoff"
 	"github.com/tikv/pd/pkg/schedule/hbstream"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
@@ -26,6 +28,7 @@ import (
 	"github.com/tikv/pd/pkg/statistics/buckets"
 	"github.com/tikv/pd/pkg/statistics/utils"
 	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/logutil"
 	"go.uber.org/zap"
 )
@@ -43,17 +46,24 @@ type Cluster struct {
 	labelStats        *statistics.LabelStatistics
 	hotStat           *statistics.HotStat
 	storage           storage.Storage
+	scatterJobStorage endpoint.ScatterJobStorage
 	coordinator       *schedule.Coordinator
+	progressManager   *progress.Manager
 	checkMembershipCh chan struct{}
 	apiServerLeader   atomic.Value
 	clusterID         uint64
 	running           atomic.Bool
+	// acquireSchedulingFn takes over the scheduling ownership before the coordinator runs.
+	acquireSchedulingFn func(ctx context.Context) (*handoff.Record, error)
+	// handoffState is the running state handed off by the previous scheduling owner,
+	// which is resumed once the schedulers are created.
+	handoffState atomic.Pointer[handoff.RunningState]
 }
 
 const regionLabelGCInterval = time.Hour
 
 // NewCluster creates a new cluster.
-func NewCluster(parentCtx context.Context, persistConfig *config.PersistConfig, storage storage.Storage, basicCluster *core.BasicCluster, hbStreams *hbstream.HeartbeatStreams, clusterID uint64, checkMembershipCh chan struct{}) (*Cluster, error) {
+func NewCluster(parentCtx context.Context, persistConfig *config.PersistConfig, storage storage.Storage, scatterJobStorage endpoint.ScatterJobStorage, basicCluster *core.BasicCluster, hbStreams *hbstream.HeartbeatStreams, clusterID uint64, checkMembershipCh chan struct{}) (*Cluster, error) {
 	ctx, cancel := context.WithCancel(parentCtx)
 	labelerManager, err := labeler.NewRegionLabeler(ctx, storage, regionLabelGCInterval)
 	if err != nil {
@@ -72,6 +82,8 @@ func NewCluster(parentCtx context.Context, persistConfig *config.PersistConfig,
 		labelStats:        statistics.NewLabelStatistics(),
 		regionStats:       statistics.NewRegionStatistics(basicCluster, persistConfig, ruleManager),
 		storage:           storage,
+		scatterJobStorage: scatterJobStorage,
+		progressManager:   progress.NewManager(),
 		clusterID:         clusterID,
 		checkMembershipCh: checkMembershipCh,
 	}
@@ -84,6 +96,12 @@ func NewCluster(parentCtx context.Context, persistConfig *config.PersistConfig,
 	return c, nil
 }
 
+// GetScatterJobStorage returns the storage of the scatter jobs, which persists them to etcd
+// since the storage of the cluster is in memory.
+func (c *Cluster) GetScatterJobStorage() endpoint.ScatterJobStorage {
+	return c.scatterJobStorage
+}
+
 // GetCoordinator returns the coordinator
 func (c *Cluster) GetCoordinator() *schedule.Coordinator {
 	return c.coordinator
@@ -125,6 +143,11 @@ func (c *Cluster) GetRuleManager() *placement.RuleManager {
 	return c.ruleManager
 }
 
+// GetProgressManager returns the progress manager.
+func (c *Cluster) GetProgressManager() *progress.Manager {
+	return c.progressManager
+}
+
 // GetRegionLabeler returns the region labeler.
 func (c *Cluster) GetRegionLabeler() *labeler.RegionLabeler {
 	return c.labelerManager
@@ -310,6 +333,10 @@ func (c *Cluster) updateScheduler() {
 			log.Info("remove scheduler successfully",
 				zap.String("scheduler-name", name))
 		}
+		// Resume the handed off running state once the schedulers are created.
+		if state := c.handoffState.Swap(nil); state != nil {
+			state.Resume(c.coordinator)
+		}
 	}
 }
 
@@ -427,9 +454,28 @@ func (c *Cluster) runUpdateStoreStats() {
 func (c *Cluster) runCoordinator() {
 	defer logutil.LogPanic()
 	defer c.wg.Done()
+	c.acquireScheduling()
 	c.coordinator.RunUntilStop()
 }
 
+// acquireScheduling takes over the scheduling ownership before running the coordinator. Acquiring
+// only fails if the primary is lost or etcd is unavailable, in which case the coordinator still
+// runs until the primary steps down, without being recorded as the owner.
+func (c *Cluster) acquireScheduling() {
+	if c.acquireSchedulingFn == nil {
+		return
+	}
+	prev, err := c.acquireSchedulingFn(c.ctx)
+	if err != nil {
+		log.Warn("failed to acquire the scheduling ownership", errs.ZapError(err))
+		return
+	}
+	if prev != nil && prev.RunningState != nil {
+		// The state is resumed by updateScheduler after the coordinator creates the schedulers.
+		c.handoffState.Store(prev.RunningState)
+	}
+}
+
 func (c *Cluster) runMetricsCollectionJob() {
 	defer logutil.LogPanic()
 	defer c.wg.Done()
@@ -492,6 +538,12 @@ func (c *Cluster) resetClusterMetrics() {
 	c.hotStat.ResetMetrics()
 }
 
+// SetSchedulingAcquirer sets the function to take over the scheduling ownership, which is
+// called before the coordinator runs. It should be called before the background jobs start.
+func (c *Cluster) SetSchedulingAcquirer(acquire func(ctx context.Context) (*handoff.Record, error)) {
+	c.acquireSchedulingFn = acquire
+}
+
 // StartBackgroundJobs starts background jobs.
 func (c *Cluster) StartBackgroundJobs() {
 	c.wg.Add(4)
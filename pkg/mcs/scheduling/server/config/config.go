@@ -382,6 +382,11 @@ func (o *PersistConfig) IsCrossTableMergeEnabled() bool {
 	return o.GetScheduleConfig().EnableCrossTableMerge
 }
 
+// IsLoadSplitEnabled returns if the load split is enabled for the given keyspace.
+func (o *PersistConfig) IsLoadSplitEnabled(keyspaceID uint32) bool {
+	return slice.Contains(o.GetScheduleConfig().LoadSplitKeyspaces, keyspaceID)
+}
+
 // IsOneWayMergeEnabled returns if the one way merge is enabled.
 func (o *PersistConfig) IsOneWayMergeEnabled() bool {
 	return o.GetScheduleConfig().EnableOneWayMerge
@@ -98,7 +98,8 @@ func NewWatcher(
 	return cw, nil
 }
 
-// SetSchedulersController sets the schedulers controller.
+// SetSchedulersController sets the schedulers controller. It's set to nil once the server
+// is no longer the primary.
 func (cw *Watcher) SetSchedulersController(sc *schedulers.Controller) {
 	cw.schedulersController.Store(sc)
 }
@@ -46,6 +46,7 @@ import (
 	"github.com/tikv/pd/pkg/mcs/utils"
 	"github.com/tikv/pd/pkg/member"
 	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/handoff"
 	"github.com/tikv/pd/pkg/schedule/hbstream"
 	"github.com/tikv/pd/pkg/schedule/schedulers"
 	"github.com/tikv/pd/pkg/storage/endpoint"
@@ -103,6 +104,8 @@ type Server struct {
 	cluster   *Cluster
 	hbStreams *hbstream.HeartbeatStreams
 	storage   *endpoint.StorageEndpoint
+	// schedulingOwner identifies this server as the scheduling owner when it becomes the primary.
+	schedulingOwner *handoff.Record
 
 	// for watching the PD API server meta info updates that are related to the scheduling.
 	configWatcher *config.Watcher
@@ -320,6 +323,7 @@ func (s *Server) Close() {
 	s.GetListener().Close()
 	s.serverLoopCancel()
 	s.serverLoopWg.Wait()
+	s.stopConfigWatcher()
 
 	if s.GetClient() != nil {
 		if err := s.GetClient().Close(); err != nil {
@@ -421,6 +425,18 @@ func (s *Server) startServer() (err error) {
 		ListenUrls: []string{s.cfg.AdvertiseListenAddr},
 	}
 	s.participant.InitInfo(p, endpoint.SchedulingSvcRootPath(s.clusterID), utils.PrimaryKey, "primary election")
+	s.schedulingOwner = &handoff.Record{
+		Owner: handoff.OwnerSchedulingService,
+		Name:  uniqueName,
+		Addr:  s.cfg.AdvertiseListenAddr,
+	}
+	// The config and rules are watched during the whole lifetime of the server rather than
+	// the primary term, so they are hot-reloaded independently of the scheduling ownership
+	// and already up to date once the server takes over the scheduling.
+	s.storage = endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	if err := s.startConfigWatcher(); err != nil {
+		return err
+	}
 
 	s.service = &Service{Server: s}
 	s.AddServiceReadyCallback(s.startCluster)
@@ -460,31 +476,49 @@ func (s *Server) startServer() (err error) {
 
 func (s *Server) startCluster(context.Context) error {
 	s.basicCluster = core.NewBasicCluster()
-	s.storage = endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
-	err := s.startWatcher()
+	var err error
+	s.metaWatcher, err = meta.NewWatcher(s.Context(), s.GetClient(), s.clusterID, s.basicCluster)
 	if err != nil {
 		return err
 	}
 	s.hbStreams = hbstream.NewHeartbeatStreams(s.Context(), s.clusterID, utils.SchedulingServiceName, s.basicCluster)
-	s.cluster, err = NewCluster(s.Context(), s.persistConfig, s.storage, s.basicCluster, s.hbStreams, s.clusterID, s.checkMembershipCh)
+	// The scatter jobs are persisted to the same path as PD, so that they are continued
+	// wherever the scheduling is served.
+	scatterJobStorage := endpoint.NewStorageEndpoint(kv.NewEtcdKVBase(s.GetClient(), endpoint.PDRootPath(s.clusterID)), nil)
+	s.cluster, err = NewCluster(s.Context(), s.persistConfig, s.storage, scatterJobStorage, s.basicCluster, s.hbStreams, s.clusterID, s.checkMembershipCh)
 	if err != nil {
 		return err
 	}
 	s.configWatcher.SetSchedulersController(s.cluster.GetCoordinator().GetSchedulersController())
+	// The coordinator waits for the previous owner to hand off the scheduling before running,
+	// which doesn't block the primary from serving.
+	s.cluster.SetSchedulingAcquirer(func(ctx context.Context) (*handoff.Record, error) {
+		return handoff.Acquire(ctx, s.GetClient(), s.clusterID, s.participant.GetLeadership(), s.schedulingOwner, handoff.DefaultWaitTimeout)
+	})
 	s.cluster.StartBackgroundJobs()
 	return nil
 }
 
 func (s *Server) stopCluster() {
+	// Drain the in-flight operators while the primary lease is still valid, so that the
+	// operators could keep receiving the region heartbeats. Note that the exit callback
+	// usually runs after the lease has lapsed, e.g, it fails to be kept alive, in which case
+	// the operators are still drained for the minimum timeout since the heartbeats may be
+	// forwarded to this server until the new primary is elected, but the release can't be
+	// recorded without the lease, so the new owner takes over after its wait timeout. No new
+	// operator is created while draining, so it's safe to overlap with the new owner.
+	leadership := s.participant.GetLeadership()
+	drainTimeout := handoff.BoundDrainTimeout(leadership.LeaseRemaining())
+	if _, err := handoff.Release(s.Context(), s.GetClient(), s.clusterID, leadership, s.schedulingOwner,
+		s.cluster.GetCoordinator(), drainTimeout); err != nil {
+		log.Warn("failed to release the scheduling ownership", errs.ZapError(err))
+	}
+	s.configWatcher.SetSchedulersController(nil)
 	s.cluster.StopBackgroundJobs()
-	s.stopWatcher()
+	s.metaWatcher.Close()
 }
 
-func (s *Server) startWatcher() (err error) {
-	s.metaWatcher, err = meta.NewWatcher(s.Context(), s.GetClient(), s.clusterID, s.basicCluster)
-	if err != nil {
-		return err
-	}
+func (s *Server) startConfigWatcher() (err error) {
 	s.configWatcher, err = config.NewWatcher(s.Context(), s.GetClient(), s.clusterID, s.persistConfig, s.storage)
 	if err != nil {
 		return err
@@ -493,10 +527,9 @@ func (s *Server) startWatcher() (err error) {
 	return err
 }
 
-func (s *Server) stopWatcher() {
+func (s *Server) stopConfigWatcher() {
 	s.ruleWatcher.Close()
 	s.configWatcher.Close()
-	s.metaWatcher.Close()
 }
 
 // GetPersistConfig returns the persist config.
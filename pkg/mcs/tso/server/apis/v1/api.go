@@ -101,6 +101,7 @@ func NewService(srv *tsoserver.Service) *Service {
 	}
 	s.RegisterAdminRouter()
 	s.RegisterKeyspaceGroupRouter()
+	s.RegisterDebugRouter()
 	return s
 }
 
@@ -117,6 +118,14 @@ func (s *Service) RegisterKeyspaceGroupRouter() {
 	router.GET("/members", GetKeyspaceGroupMembers)
 }
 
+// RegisterDebugRouter registers the router of the TSO debug handler.
+func (s *Service) RegisterDebugRouter() {
+	router := s.root.Group("debug/tso")
+	router.GET("/traces", GetTraces)
+	router.GET("/traces/config", GetTraceConfig)
+	router.POST("/traces/config", SetTraceConfig)
+}
+
 func changeLogLevel(c *gin.Context) {
 	svr := c.MustGet(multiservicesapi.ServiceContextKey).(*tsoserver.Service)
 	var level string
@@ -224,3 +233,62 @@ func GetKeyspaceGroupMembers(c *gin.Context) {
 	}
 	c.IndentedJSON(http.StatusOK, members)
 }
+
+// GetTraces gets the sampled traces of the TSO requests, the newest first.
+// @Tags     tso
+// @Summary  Get the sampled traces of the TSO requests.
+// @Param    client    query  string   false  "The address of the client to filter the traces"
+// @Param    trace_id  query  string   false  "The trace ID propagated by the client to filter the traces"
+// @Param    limit     query  integer  false  "The max number of the traces"
+// @Produce  json
+// @Success  200  {array}   tso.Trace
+// @Failure  400  {string}  string  "The input is invalid."
+// @Router   /debug/tso/traces [get]
+func GetTraces(c *gin.Context) {
+	svr := c.MustGet(multiservicesapi.ServiceContextKey).(*tsoserver.Service)
+	var (
+		limit int
+		err   error
+	)
+	if limitStr := c.Query("limit"); len(limitStr) > 0 {
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit < 0 {
+			c.String(http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+	c.IndentedJSON(http.StatusOK, svr.GetTSOTracer().GetTraces(c.Query("client"), c.Query("trace_id"), limit))
+}
+
+// GetTraceConfig gets the config of the TSO request tracing.
+// @Tags     tso
+// @Summary  Get the config of the TSO request tracing.
+// @Produce  json
+// @Success  200  {object}  tso.TracerConfig
+// @Router   /debug/tso/traces/config [get]
+func GetTraceConfig(c *gin.Context) {
+	svr := c.MustGet(multiservicesapi.ServiceContextKey).(*tsoserver.Service)
+	c.IndentedJSON(http.StatusOK, svr.GetTSOTracer().GetConfig())
+}
+
+// SetTraceConfig sets the config of the TSO request tracing, the tracing is disabled if the sample rate is 0.
+// @Tags     tso
+// @Summary  Set the config of the TSO request tracing.
+// @Accept   json
+// @Param    body  body  tso.TracerConfig  true  "json params"
+// @Produce  json
+// @Success  200  {string}  string  "The tracing config is updated."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Router   /debug/tso/traces/config [post]
+func SetTraceConfig(c *gin.Context) {
+	svr := c.MustGet(multiservicesapi.ServiceContextKey).(*tsoserver.Service)
+	cfg := svr.GetTSOTracer().GetConfig()
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := svr.GetTSOTracer().SetConfig(cfg); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	c.String(http.StatusOK, "The tracing config is updated.")
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/tikv/pd/pkg/utils/configutil"
 	"github.com/tikv/pd/pkg/utils/grpcutil"
 	"github.com/tikv/pd/pkg/utils/metricutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"go.uber.org/zap"
 )
@@ -59,6 +60,9 @@ type Config struct {
 	DataDir           string `toml:"data-dir" json:"data-dir"`
 	EnableGRPCGateway bool   `json:"enable-grpc-gateway"`
 
+	// Labels are the labels of the TSO node, which are reported with its registry entry.
+	Labels map[string]string `toml:"labels" json:"labels"`
+
 	// LeaderLease defines the time within which a TSO primary/leader must update its TTL
 	// in etcd, otherwise etcd will expire the leader key and other servers can campaign
 	// the primary/leader again. Etcd only supports seconds TTL, so here is second too.
@@ -80,6 +84,14 @@ type Config struct {
 	// be automatically clamped to the range.
 	TSOUpdatePhysicalInterval typeutil.Duration `toml:"tso-update-physical-interval" json:"tso-update-physical-interval"`
 
+	// TSOPhysicalPrecision is the precision of the physical part of timestamp, "ms" or "us".
+	// TSOLogicalBits is the number of bits of the logical part of timestamp.
+	// Only the clients which negotiate the layout with the TSO service can get the timestamp with the non-default layout.
+	// The layout can only be changed in the direction that keeps the timestamp monotonic, e.g, increasing the
+	// logical bits, otherwise the TSO service will refuse to provide the TSO.
+	TSOPhysicalPrecision string `toml:"tso-physical-precision" json:"tso-physical-precision"`
+	TSOLogicalBits       int    `toml:"tso-logical-bits" json:"tso-logical-bits"`
+
 	// MaxResetTSGap is the max gap to reset the TSO.
 	MaxResetTSGap typeutil.Duration `toml:"max-gap-reset-ts" json:"max-gap-reset-ts"`
 
@@ -147,6 +159,11 @@ func (c *Config) GetMaxResetTSGap() time.Duration {
 	return c.MaxResetTSGap.Duration
 }
 
+// GetTSOLayout returns the layout of the TSO.
+func (c *Config) GetTSOLayout() tsoutil.Layout {
+	return tsoutil.Layout{PhysicalPrecision: c.TSOPhysicalPrecision, LogicalBits: c.TSOLogicalBits}
+}
+
 // GetTLSConfig returns the TLS config.
 func (c *Config) GetTLSConfig() *grpcutil.TLSConfig {
 	return &c.Security.TLSConfig
@@ -219,6 +236,18 @@ func (c *Config) Adjust(meta *toml.MetaData, reloading bool) error {
 			zap.Duration("update-physical-interval", c.TSOUpdatePhysicalInterval.Duration))
 	}
 
+	configutil.AdjustString(&c.TSOPhysicalPrecision, tsoutil.DefaultLayout.PhysicalPrecision)
+	configutil.AdjustInt(&c.TSOLogicalBits, tsoutil.DefaultLayout.LogicalBits)
+	if layout := c.GetTSOLayout(); !layout.IsDefault() {
+		if err := layout.Validate(); err != nil {
+			return err
+		}
+		if c.EnableLocalTSO {
+			return errors.New("the non-default tso layout is not supported when local tso is enabled")
+		}
+		log.Warn("tso layout is non-default", zap.Stringer("layout", layout))
+	}
+
 	if !configMetaData.IsDefined("enable-grpc-gateway") {
 		c.EnableGRPCGateway = utils.DefaultEnableGRPCGateway
 	}
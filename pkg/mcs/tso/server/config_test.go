@@ -22,6 +22,7 @@ import (
 	"github.com/BurntSushi/toml"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/pd/pkg/mcs/utils"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
 )
 
 func TestConfigBasic(t *testing.T) {
@@ -41,6 +42,7 @@ func TestConfigBasic(t *testing.T) {
 	re.Equal(defaultTSOSaveInterval, cfg.TSOSaveInterval.Duration)
 	re.Equal(defaultTSOUpdatePhysicalInterval, cfg.TSOUpdatePhysicalInterval.Duration)
 	re.Equal(defaultMaxResetTSGap, cfg.MaxResetTSGap.Duration)
+	re.True(cfg.GetTSOLayout().IsDefault())
 
 	// Test setting values.
 	cfg.Name = "test-name"
@@ -97,3 +99,37 @@ max-gap-reset-ts = "1h"
 	re.Equal(time.Duration(100)*time.Millisecond, cfg.TSOUpdatePhysicalInterval.Duration)
 	re.Equal(time.Duration(1)*time.Hour, cfg.MaxResetTSGap.Duration)
 }
+
+func TestLoadTSOLayoutFromConfig(t *testing.T) {
+	re := require.New(t)
+	cfgData := `
+tso-physical-precision = "us"
+tso-logical-bits = 11
+`
+	cfg := NewConfig()
+	meta, err := toml.Decode(cfgData, &cfg)
+	re.NoError(err)
+	re.NoError(cfg.Adjust(&meta, false))
+	re.Equal(tsoutil.Layout{PhysicalPrecision: tsoutil.PhysicalPrecisionMicrosecond, LogicalBits: 11}, cfg.GetTSOLayout())
+
+	// The layout generating smaller TSO than the default one is invalid.
+	cfgData = `
+tso-physical-precision = "ms"
+tso-logical-bits = 10
+`
+	cfg = NewConfig()
+	meta, err = toml.Decode(cfgData, &cfg)
+	re.NoError(err)
+	re.Error(cfg.Adjust(&meta, false))
+
+	// The non-default layout is not supported with the local TSO.
+	cfgData = `
+enable-local-tso = true
+tso-physical-precision = "us"
+tso-logical-bits = 11
+`
+	cfg = NewConfig()
+	meta, err = toml.Decode(cfgData, &cfg)
+	re.NoError(err)
+	re.Error(cfg.Adjust(&meta, false))
+}
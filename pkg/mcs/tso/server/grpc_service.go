@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/log"
 	bs "github.com/tikv/pd/pkg/basicserver"
 	"github.com/tikv/pd/pkg/mcs/registry"
+	"github.com/tikv/pd/pkg/tso"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/pkg/utils/grpcutil"
 	"github.com/tikv/pd/pkg/utils/tsoutil"
@@ -94,6 +95,10 @@ func (s *Service) Tso(stream tsopb.TSO_TsoServer) error {
 	)
 	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
+	streamTrace := tsoutil.TraceContextFromIncomingContext(stream.Context())
+	// The layout is negotiated right before the first TSO is sent, so that the forwarded stream
+	// negotiates with the layout of the TSO server which actually generates the TSO.
+	negotiator := tsoutil.NewLayoutNegotiator(stream)
 	for {
 		// Prevent unnecessary performance overhead of the channel.
 		if errCh != nil {
@@ -110,6 +115,7 @@ func (s *Service) Tso(stream tsopb.TSO_TsoServer) error {
 		if err != nil {
 			return errors.WithStack(err)
 		}
+		reqTrace := streamTrace.Next()
 
 		streamCtx := stream.Context()
 		forwardedHost := grpcutil.GetForwardedHost(streamCtx)
@@ -126,7 +132,7 @@ func (s *Service) Tso(stream tsopb.TSO_TsoServer) error {
 			}
 
 			tsoProtoFactory := s.tsoProtoFactory
-			tsoRequest := tsoutil.NewTSOProtoRequest(forwardedHost, clientConn, request, stream)
+			tsoRequest := tsoutil.NewTSOProtoRequest(forwardedHost, clientConn, request, stream, negotiator)
 			s.tsoDispatcher.DispatchRequest(ctx, tsoRequest, tsoProtoFactory)
 			continue
 		}
@@ -143,25 +149,37 @@ func (s *Service) Tso(stream tsopb.TSO_TsoServer) error {
 				codes.FailedPrecondition, "mismatch cluster id, need %d but got %d",
 				s.clusterID, clusterID)
 		}
+		if err := negotiator.Negotiate(s.GetConfig().GetTSOLayout()); err != nil {
+			return err
+		}
 		keyspaceID := header.GetKeyspaceId()
 		keyspaceGroupID := header.GetKeyspaceGroupId()
 		dcLocation := request.GetDcLocation()
 		count := request.GetCount()
+		tsoTrace := s.tsoTracer.StartTrace(reqTrace, grpcutil.GetPeerAddr(streamCtx), keyspaceGroupID, dcLocation, count, start)
+		tsoTrace.AddSpan(tso.SpanReceive, start, time.Since(start))
 		ts, keyspaceGroupBelongTo, err := s.keyspaceGroupManager.HandleTSORequest(
-			ctx,
+			tso.WithTrace(ctx, tsoTrace),
 			keyspaceID, keyspaceGroupID,
 			dcLocation, count)
 		if err != nil {
+			s.tsoTracer.FinishTrace(tsoTrace, err)
 			return status.Errorf(codes.Unknown, err.Error())
 		}
 		keyspaceGroupIDStr := strconv.FormatUint(uint64(keyspaceGroupID), 10)
-		tsoHandleDuration.WithLabelValues(keyspaceGroupIDStr).Observe(time.Since(start).Seconds())
+		handleDuration := time.Since(start)
+		tsoHandleDuration.WithLabelValues(keyspaceGroupIDStr).Observe(handleDuration.Seconds())
+		s.serviceStats.Observe(handleDuration)
 		response := &tsopb.TsoResponse{
 			Header:    s.header(keyspaceGroupBelongTo),
 			Timestamp: &ts,
 			Count:     count,
 		}
-		if err := stream.Send(response); err != nil {
+		finishSpan := tsoTrace.StartSpan(tso.SpanRespond)
+		err = stream.Send(response)
+		finishSpan()
+		s.tsoTracer.FinishTrace(tsoTrace, err)
+		if err != nil {
 			return errors.WithStack(err)
 		}
 	}
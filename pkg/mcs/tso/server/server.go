@@ -84,10 +84,13 @@ type Server struct {
 	// tsoProtoFactory is the abstract factory for creating tso
 	// related data structures defined in the tso grpc protocol
 	tsoProtoFactory *tsoutil.TSOProtoFactory
+	// tsoTracer samples the TSO requests to break down their latency.
+	tsoTracer *tso.Tracer
 
 	// for service registry
 	serviceID       *discovery.ServiceRegistryEntry
 	serviceRegister *discovery.ServiceRegister
+	serviceStats    *discovery.ServiceStats
 }
 
 // Implement the following methods defined in bs.Server
@@ -272,6 +275,11 @@ func (s *Server) GetKeyspaceGroupManager() *tso.KeyspaceGroupManager {
 	return s.keyspaceGroupManager
 }
 
+// GetTSOTracer returns the tracer of the TSO requests.
+func (s *Server) GetTSOTracer() *tso.Tracer {
+	return s.tsoTracer
+}
+
 // GetTSOAllocatorManager returns the manager of TSO Allocator.
 func (s *Server) GetTSOAllocatorManager(keyspaceGroupID uint32) (*tso.AllocatorManager, error) {
 	return s.keyspaceGroupManager.GetAllocatorManager(keyspaceGroupID)
@@ -368,7 +376,14 @@ func (s *Server) startServer() (err error) {
 	s.serverLoopCtx, s.serverLoopCancel = context.WithCancel(s.Context())
 	legacySvcRootPath := endpoint.LegacyRootPath(s.clusterID)
 	tsoSvcRootPath := endpoint.TSOSvcRootPath(s.clusterID)
-	s.serviceID = &discovery.ServiceRegistryEntry{ServiceAddr: s.cfg.AdvertiseListenAddr}
+	s.serviceID = &discovery.ServiceRegistryEntry{
+		ServiceAddr:    s.cfg.AdvertiseListenAddr,
+		Version:        versioninfo.PDReleaseVersion,
+		GitHash:        versioninfo.PDGitHash,
+		StartTimestamp: s.StartTimestamp(),
+		Labels:         s.cfg.Labels,
+	}
+	s.serviceStats = discovery.NewServiceStats(discovery.DefaultSlowThreshold)
 	s.keyspaceGroupManager = tso.NewKeyspaceGroupManager(
 		s.serverLoopCtx, s.serviceID, s.GetClient(), s.GetHTTPClient(), s.cfg.AdvertiseListenAddr,
 		discovery.TSOPath(s.clusterID), legacySvcRootPath, tsoSvcRootPath, s.cfg)
@@ -377,6 +392,7 @@ func (s *Server) startServer() (err error) {
 	}
 
 	s.tsoProtoFactory = &tsoutil.TSOProtoFactory{}
+	s.tsoTracer = tso.NewTracer(0)
 	s.service = &Service{Server: s}
 
 	if err := s.InitListener(s.GetTLSConfig(), s.cfg.ListenAddr); err != nil {
@@ -406,6 +422,7 @@ func (s *Server) startServer() (err error) {
 		log.Error("failed to register the service", zap.String("service-name", utils.TSOServiceName), errs.ZapError(err))
 		return err
 	}
+	s.serviceRegister.StartReporting(s.serviceID, s.serviceStats, discovery.DefaultReportInterval)
 
 	atomic.StoreInt64(&s.isRunning, 1)
 	return nil
@@ -0,0 +1,192 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+	"go.etcd.io/etcd/clientv3"
+	"go.uber.org/zap"
+)
+
+const (
+	leaderHandoffPath = "leader_handoff"
+	// minHandoffLease is the min remaining lease of the leader to start a handoff, which makes
+	// sure the leader could finish the handoff before its lease expires.
+	minHandoffLease = time.Second
+)
+
+// LeaderHandoff is announced by the PD leader before it steps down, so that only the designated
+// member campaigns for the leadership until the handoff is done or expires. The handoff is attached
+// to an etcd lease, so it expires with the lease regardless of the clocks of the members.
+type LeaderHandoff struct {
+	From   string `json:"from"`
+	FromID uint64 `json:"from_id"`
+	To     string `json:"to"`
+	ToID   uint64 `json:"to_id"`
+	// TSO is the last timestamp allocated by the previous leader before it steps down. The
+	// designated member starts allocating right after it instead of the end of the time window
+	// saved by the previous leader.
+	TSO       uint64    `json:"tso,omitempty"`
+	StartTime time.Time `json:"start_time"`
+	// Deadline is only informational, it's computed by the previous leader when the lease of the
+	// handoff is granted.
+	Deadline time.Time        `json:"deadline"`
+	LeaseID  clientv3.LeaseID `json:"lease_id"`
+
+	// revision is the mod revision of the handoff in etcd, which is used to make sure the handoff
+	// is not changed when it's updated or removed.
+	revision int64
+}
+
+// getLeaderHandoffPath returns the path of the leader handoff.
+func (m *EmbeddedEtcdMember) getLeaderHandoffPath() string {
+	return path.Join(m.rootPath, leaderHandoffPath)
+}
+
+// AnnounceLeaderHandoff announces the handoff of the PD leadership to the given member. The handoff
+// expires after the timeout, which should be longer than the time to step down and re-campaign.
+func (m *EmbeddedEtcdMember) AnnounceLeaderHandoff(ctx context.Context, nextLeader string, timeout time.Duration) (*LeaderHandoff, error) {
+	if !m.IsLeader() {
+		return nil, errs.ErrLeaderHandoff.FastGenByArgs("not the leader")
+	}
+	if remaining := m.leadership.LeaseRemaining(); remaining < minHandoffLease {
+		return nil, errs.ErrLeaderHandoff.FastGenByArgs(fmt.Sprintf("the lease is about to expire in %v", remaining))
+	}
+	res, err := etcdutil.ListEtcdMembers(ctx, m.client)
+	if err != nil {
+		return nil, err
+	}
+	var nextLeaderID uint64
+	for _, member := range res.Members {
+		if member.Name == nextLeader {
+			nextLeaderID = member.GetID()
+			break
+		}
+	}
+	if nextLeaderID == 0 {
+		return nil, errs.ErrLeaderHandoffMemberNotFound.FastGenByArgs(nextLeader)
+	}
+	if nextLeaderID == m.ID() {
+		return nil, errs.ErrLeaderHandoff.FastGenByArgs(fmt.Sprintf("member %s is already the leader", nextLeader))
+	}
+	ttl := int64(math.Ceil(timeout.Seconds()))
+	if ttl < 1 {
+		ttl = 1
+	}
+	lease, err := m.client.Grant(ctx, ttl)
+	if err != nil {
+		return nil, errs.ErrEtcdGrantLease.Wrap(err).GenWithStackByCause()
+	}
+	now := time.Now()
+	handoff := &LeaderHandoff{
+		From:      m.Name(),
+		FromID:    m.ID(),
+		To:        nextLeader,
+		ToID:      nextLeaderID,
+		StartTime: now,
+		Deadline:  now.Add(time.Duration(ttl) * time.Second),
+		LeaseID:   lease.ID,
+	}
+	if err := m.saveLeaderHandoff(handoff); err != nil {
+		m.revokeLeaderHandoffLease(lease.ID)
+		return nil, err
+	}
+	log.Info("announce the leader handoff", zap.String("from", handoff.From), zap.String("to", handoff.To), zap.Time("deadline", handoff.Deadline))
+	return handoff, nil
+}
+
+// UpdateLeaderHandoff updates the announced handoff, e.g. to record the TSO at the handoff point.
+// It only succeeds before the leader steps down and the handoff is neither changed nor expired.
+func (m *EmbeddedEtcdMember) UpdateLeaderHandoff(handoff *LeaderHandoff) error {
+	return m.saveLeaderHandoff(handoff, clientv3.Compare(clientv3.ModRevision(m.getLeaderHandoffPath()), "=", handoff.revision))
+}
+
+func (m *EmbeddedEtcdMember) saveLeaderHandoff(handoff *LeaderHandoff, cs ...clientv3.Cmp) error {
+	value, err := json.Marshal(handoff)
+	if err != nil {
+		return errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	res, err := m.leadership.LeaderTxn(cs...).
+		Then(clientv3.OpPut(m.getLeaderHandoffPath(), string(value), clientv3.WithLease(handoff.LeaseID))).
+		Commit()
+	if err != nil {
+		return errs.ErrEtcdTxnInternal.Wrap(err).GenWithStackByCause()
+	}
+	if !res.Succeeded {
+		return errs.ErrEtcdTxnConflict.FastGenByArgs()
+	}
+	handoff.revision = res.Header.GetRevision()
+	return nil
+}
+
+func (m *EmbeddedEtcdMember) revokeLeaderHandoffLease(id clientv3.LeaseID) {
+	ctx, cancel := context.WithTimeout(m.client.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+	if _, err := m.client.Revoke(ctx, id); err != nil {
+		log.Warn("failed to revoke the lease of the leader handoff", zap.Int64("lease-id", int64(id)), errs.ZapError(err))
+	}
+}
+
+// GetLeaderHandoff returns the ongoing leader handoff. It returns nil if there is no handoff, which
+// includes the case that the lease of the handoff is expired.
+func (m *EmbeddedEtcdMember) GetLeaderHandoff() (*LeaderHandoff, error) {
+	resp, err := etcdutil.EtcdKVGet(m.client, m.getLeaderHandoffPath())
+	if err != nil || len(resp.Kvs) == 0 {
+		return nil, err
+	}
+	handoff := &LeaderHandoff{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, handoff); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	handoff.revision = resp.Kvs[0].ModRevision
+	return handoff, nil
+}
+
+// ShouldCampaign returns false if there is an ongoing handoff to another member.
+func (m *EmbeddedEtcdMember) ShouldCampaign() bool {
+	handoff, err := m.GetLeaderHandoff()
+	if err != nil {
+		log.Warn("failed to load the leader handoff", errs.ZapError(err))
+		return true
+	}
+	return handoff == nil || handoff.ToID == m.ID()
+}
+
+// FinishLeaderHandoff removes the handoff after the new leader is elected or the handoff fails.
+// It only succeeds if the member is the leader and the handoff is not changed since it's loaded
+// or saved, so a stale member can't remove a newer handoff.
+func (m *EmbeddedEtcdMember) FinishLeaderHandoff(handoff *LeaderHandoff) error {
+	handoffPath := m.getLeaderHandoffPath()
+	res, err := m.leadership.LeaderTxn(clientv3.Compare(clientv3.ModRevision(handoffPath), "=", handoff.revision)).
+		Then(clientv3.OpDelete(handoffPath)).
+		Commit()
+	if err != nil {
+		return errs.ErrEtcdTxnInternal.Wrap(err).GenWithStackByCause()
+	}
+	if !res.Succeeded {
+		return errs.ErrEtcdTxnConflict.FastGenByArgs()
+	}
+	m.revokeLeaderHandoffLease(handoff.LeaseID)
+	return nil
+}
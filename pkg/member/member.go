@@ -299,7 +299,16 @@ func (m *EmbeddedEtcdMember) CheckPriority(ctx context.Context) {
 		return
 	}
 	if myPriority > leaderPriority {
-		err := m.MoveEtcdLeader(ctx, etcdLeader, m.ID())
+		// The leader preference by labels takes precedence over the priority.
+		preferred, err := m.isPreferredOver(etcdLeader, m.ID())
+		if err != nil {
+			log.Error("failed to check leader preference", errs.ZapError(err))
+			return
+		}
+		if preferred {
+			return
+		}
+		err = m.MoveEtcdLeader(ctx, etcdLeader, m.ID())
 		if err != nil {
 			log.Error("failed to transfer etcd leader", errs.ZapError(err))
 		} else {
@@ -0,0 +1,188 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+	"go.etcd.io/etcd/clientv3"
+)
+
+const leaderPreferencePath = "member/leader_preference"
+
+// LabelPreference matches the members with the label.
+type LabelPreference struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// LeaderPreference is the placement policy of the PD leader. The members are ranked by the
+// first label preference they match, e.g. the preferences [dc=dc1, dc=dc2] place the leader
+// in dc1 and fall back to dc2 if there is no healthy member in dc1.
+type LeaderPreference struct {
+	Preferences []LabelPreference `json:"preferences"`
+}
+
+// Rank returns the rank of the member with the labels. A smaller rank means a higher
+// preference, and the member matching no preference has the lowest preference.
+func (p *LeaderPreference) Rank(labels map[string]string) int {
+	if p == nil {
+		return 0
+	}
+	for i, pref := range p.Preferences {
+		for k, v := range labels {
+			if strings.EqualFold(k, pref.Key) && v == pref.Value {
+				return i
+			}
+		}
+	}
+	return len(p.Preferences)
+}
+
+// LeaderCandidate is a member ranked by the leader preference.
+type LeaderCandidate struct {
+	Name     string            `json:"name"`
+	MemberID uint64            `json:"member_id"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Rank     int               `json:"rank"`
+}
+
+func (m *EmbeddedEtcdMember) getMemberLabelsPath(id uint64) string {
+	return path.Join(m.rootPath, fmt.Sprintf("member/%d/labels", id))
+}
+
+// GetMemberLabels loads a member's labels. It returns nil if the labels are not set.
+func (m *EmbeddedEtcdMember) GetMemberLabels(id uint64) (map[string]string, error) {
+	value, err := etcdutil.GetValue(m.client, m.getMemberLabelsPath(id))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	labels := make(map[string]string)
+	if err := json.Unmarshal(value, &labels); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return labels, nil
+}
+
+// SetMemberLabels saves a member's labels, which are registered with the member info.
+func (m *EmbeddedEtcdMember) SetMemberLabels(id uint64, labels map[string]string) error {
+	value, err := json.Marshal(labels)
+	if err != nil {
+		return errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	key := m.getMemberLabelsPath(id)
+	txn := kv.NewSlowLogTxn(m.client)
+	res, err := txn.Then(clientv3.OpPut(key, string(value))).Commit()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !res.Succeeded {
+		return errors.New("failed to save labels")
+	}
+	return nil
+}
+
+// GetLeaderPreference loads the leader preference. It returns nil if the preference is not set.
+func (m *EmbeddedEtcdMember) GetLeaderPreference() (*LeaderPreference, error) {
+	value, err := etcdutil.GetValue(m.client, path.Join(m.rootPath, leaderPreferencePath))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	preference := &LeaderPreference{}
+	if err := json.Unmarshal(value, preference); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return preference, nil
+}
+
+// SetLeaderPreference saves the leader preference.
+func (m *EmbeddedEtcdMember) SetLeaderPreference(preference *LeaderPreference) error {
+	value, err := json.Marshal(preference)
+	if err != nil {
+		return errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	res, err := m.leadership.LeaderTxn().Then(clientv3.OpPut(path.Join(m.rootPath, leaderPreferencePath), string(value))).Commit()
+	if err != nil {
+		return errs.ErrEtcdTxnInternal.Wrap(err).GenWithStackByCause()
+	}
+	if !res.Succeeded {
+		return errs.ErrEtcdTxnConflict.FastGenByArgs()
+	}
+	return nil
+}
+
+// DeleteLeaderPreference removes the leader preference.
+func (m *EmbeddedEtcdMember) DeleteLeaderPreference() error {
+	res, err := m.leadership.LeaderTxn().Then(clientv3.OpDelete(path.Join(m.rootPath, leaderPreferencePath))).Commit()
+	if err != nil {
+		return errs.ErrEtcdTxnInternal.Wrap(err).GenWithStackByCause()
+	}
+	if !res.Succeeded {
+		return errs.ErrEtcdTxnConflict.FastGenByArgs()
+	}
+	return nil
+}
+
+// GetLeaderCandidates ranks the members by the leader preference. The candidates are sorted by
+// the rank, and then by the name to make the order stable.
+func (m *EmbeddedEtcdMember) GetLeaderCandidates(preference *LeaderPreference, members []*pdpb.Member) ([]*LeaderCandidate, error) {
+	candidates := make([]*LeaderCandidate, 0, len(members))
+	for _, member := range members {
+		labels, err := m.GetMemberLabels(member.GetMemberId())
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, &LeaderCandidate{
+			Name:     member.GetName(),
+			MemberID: member.GetMemberId(),
+			Labels:   labels,
+			Rank:     preference.Rank(labels),
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Rank != candidates[j].Rank {
+			return candidates[i].Rank < candidates[j].Rank
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates, nil
+}
+
+// isPreferredOver returns true if the member a has a higher leader preference than the member b.
+// It returns false if there is no leader preference.
+func (m *EmbeddedEtcdMember) isPreferredOver(a, b uint64) (bool, error) {
+	preference, err := m.GetLeaderPreference()
+	if err != nil || preference == nil {
+		return false, err
+	}
+	labelsA, err := m.GetMemberLabels(a)
+	if err != nil {
+		return false, err
+	}
+	labelsB, err := m.GetMemberLabels(b)
+	if err != nil {
+		return false, err
+	}
+	return preference.Rank(labelsA) < preference.Rank(labelsB), nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+)
+
+func TestLeaderPreferenceRank(t *testing.T) {
+	re := require.New(t)
+	// All the members have the same rank if there is no preference.
+	var preference *LeaderPreference
+	re.Equal(0, preference.Rank(map[string]string{"dc": "dc1"}))
+	re.Equal(0, preference.Rank(nil))
+
+	preference = &LeaderPreference{Preferences: []LabelPreference{
+		{Key: "dc", Value: "dc1"},
+		{Key: "dc", Value: "dc2"},
+		{Key: "zone", Value: "z1"},
+	}}
+	re.Equal(0, preference.Rank(map[string]string{"dc": "dc1"}))
+	re.Equal(1, preference.Rank(map[string]string{"dc": "dc2"}))
+	// The first matched preference decides the rank.
+	re.Equal(1, preference.Rank(map[string]string{"dc": "dc2", "zone": "z1"}))
+	re.Equal(2, preference.Rank(map[string]string{"dc": "dc3", "zone": "z1"}))
+	// The key is case-insensitive, but the value is not.
+	re.Equal(0, preference.Rank(map[string]string{"DC": "dc1"}))
+	re.Equal(3, preference.Rank(map[string]string{"dc": "DC1"}))
+	// The member matching no preference has the lowest preference.
+	re.Equal(3, preference.Rank(map[string]string{"dc": "dc3"}))
+	re.Equal(3, preference.Rank(nil))
+}
+
+func TestGetLeaderCandidates(t *testing.T) {
+	re := require.New(t)
+	servers, client, clean := etcdutil.NewTestEtcdCluster(t, 1)
+	defer clean()
+	m := NewMember(servers[0], client, uint64(servers[0].Server.ID()))
+	m.InitMemberInfo(servers[0].Config().AdvertiseClientUrls[0].String(), servers[0].Config().AdvertisePeerUrls[0].String(), "pd1", "/pd/0")
+
+	re.NoError(m.SetMemberLabels(1, map[string]string{"dc": "dc2"}))
+	re.NoError(m.SetMemberLabels(2, map[string]string{"dc": "dc1"}))
+	re.NoError(m.SetMemberLabels(4, map[string]string{"dc": "dc2"}))
+	labels, err := m.GetMemberLabels(1)
+	re.NoError(err)
+	re.Equal(map[string]string{"dc": "dc2"}, labels)
+	// The labels of member 3 are not set.
+	labels, err = m.GetMemberLabels(3)
+	re.NoError(err)
+	re.Nil(labels)
+
+	members := []*pdpb.Member{
+		{Name: "pd4", MemberId: 4},
+		{Name: "pd3", MemberId: 3},
+		{Name: "pd2", MemberId: 2},
+		{Name: "pd1", MemberId: 1},
+	}
+	checkCandidates := func(candidates []*LeaderCandidate, names []string, ranks []int) {
+		re.Len(candidates, len(names))
+		for i := range candidates {
+			re.Equal(names[i], candidates[i].Name)
+			re.Equal(ranks[i], candidates[i].Rank)
+		}
+	}
+	// The candidates are sorted by the name if there is no preference.
+	candidates, err := m.GetLeaderCandidates(nil, members)
+	re.NoError(err)
+	checkCandidates(candidates, []string{"pd1", "pd2", "pd3", "pd4"}, []int{0, 0, 0, 0})
+	re.Equal(map[string]string{"dc": "dc1"}, candidates[1].Labels)
+	re.Nil(candidates[2].Labels)
+
+	preference := &LeaderPreference{Preferences: []LabelPreference{
+		{Key: "dc", Value: "dc1"},
+		{Key: "dc", Value: "dc2"},
+	}}
+	candidates, err = m.GetLeaderCandidates(preference, members)
+	re.NoError(err)
+	checkCandidates(candidates, []string{"pd2", "pd1", "pd4", "pd3"}, []int{0, 1, 1, 2})
+	re.Equal(uint64(2), candidates[0].MemberID)
+}
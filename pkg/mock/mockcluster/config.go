@@ -42,6 +42,16 @@ func (mc *Cluster) SetEnableOneWayMerge(v bool) {
 	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.EnableOneWayMerge = v })
 }
 
+// SetEnableCrossTableMerge updates the EnableCrossTableMerge configuration.
+func (mc *Cluster) SetEnableCrossTableMerge(v bool) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.EnableCrossTableMerge = v })
+}
+
+// SetLoadSplitKeyspaces updates the LoadSplitKeyspaces configuration.
+func (mc *Cluster) SetLoadSplitKeyspaces(v ...uint32) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.LoadSplitKeyspaces = v })
+}
+
 // SetMaxSnapshotCount updates the MaxSnapshotCount configuration.
 func (mc *Cluster) SetMaxSnapshotCount(v int) {
 	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.MaxSnapshotCount = uint64(v) })
@@ -29,6 +29,7 @@ import (
 	"github.com/tikv/pd/pkg/core/storelimit"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/mock/mockid"
+	"github.com/tikv/pd/pkg/progress"
 	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/placement"
@@ -59,20 +60,22 @@ type Cluster struct {
 	suspectRegions map[uint64]struct{}
 	*buckets.HotBucketCache
 	storage.Storage
+	progressManager *progress.Manager
 }
 
 // NewCluster creates a new Cluster
 func NewCluster(ctx context.Context, opts *config.PersistOptions) *Cluster {
 	bc := core.NewBasicCluster()
 	c := &Cluster{
-		ctx:            ctx,
-		BasicCluster:   bc,
-		IDAllocator:    mockid.NewIDAllocator(),
-		HotStat:        statistics.NewHotStat(ctx, bc),
-		HotBucketCache: buckets.NewBucketsCache(ctx),
-		PersistOptions: opts,
-		suspectRegions: map[uint64]struct{}{},
-		Storage:        storage.NewStorageWithMemoryBackend(),
+		ctx:             ctx,
+		BasicCluster:    bc,
+		IDAllocator:     mockid.NewIDAllocator(),
+		HotStat:         statistics.NewHotStat(ctx, bc),
+		HotBucketCache:  buckets.NewBucketsCache(ctx),
+		PersistOptions:  opts,
+		suspectRegions:  map[uint64]struct{}{},
+		Storage:         storage.NewStorageWithMemoryBackend(),
+		progressManager: progress.NewManager(),
 	}
 	if c.PersistOptions.GetReplicationConfig().EnablePlacementRules {
 		c.initRuleManager()
@@ -83,6 +86,11 @@ func NewCluster(ctx context.Context, opts *config.PersistOptions) *Cluster {
 	return c
 }
 
+// GetProgressManager returns the progress manager.
+func (mc *Cluster) GetProgressManager() *progress.Manager {
+	return mc.progressManager
+}
+
 // GetStoreConfig returns the store config.
 func (mc *Cluster) GetStoreConfig() sc.StoreConfigProvider {
 	return mc.PersistOptions.GetStoreConfig()
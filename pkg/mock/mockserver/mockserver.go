@@ -86,3 +86,8 @@ func (*MockServer) GetTLSConfig() *grpcutil.TLSConfig {
 func (s *MockServer) GetBasicCluster() *core.BasicCluster {
 	return s.bc
 }
+
+// IsFollowerReadEnabled returns whether the follower read is enabled.
+func (*MockServer) IsFollowerReadEnabled() bool {
+	return false
+}
@@ -0,0 +1,400 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/ratelimit"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultBulkMergeStoreRate is the default max number of the regions which have a peer on
+	// the same store to be merged per minute in the bulk merge mode.
+	DefaultBulkMergeStoreRate = 30
+	bulkMergeProgress         = "bulk-merge"
+	bulkMergeInterval         = 5 * time.Second
+	bulkMergeScanLimit        = 4096
+)
+
+var (
+	bulkMergeCheckerCounter      = checkerCounter.WithLabelValues(mergeCheckerName, "bulk-check")
+	bulkMergeNewOpCounter        = checkerCounter.WithLabelValues(mergeCheckerName, "bulk-new-operator")
+	bulkMergeStoreLimitCounter   = checkerCounter.WithLabelValues(mergeCheckerName, "bulk-store-rate-limit")
+	bulkMergeCreateOpFailCounter = checkerCounter.WithLabelValues(mergeCheckerName, "bulk-create-operator-err")
+)
+
+// BulkMergeStatus is the status of the bulk merge.
+type BulkMergeStatus struct {
+	StartKey   string    `json:"start-key"`
+	EndKey     string    `json:"end-key"`
+	CrossTable bool      `json:"cross-table"`
+	StoreRate  float64   `json:"store-rate"`
+	StartTime  time.Time `json:"start-time"`
+	Finished   bool      `json:"finished"`
+	// Progress is the ratio of the small regions merged since the bulk merge started.
+	Progress     float64 `json:"progress"`
+	LeftSeconds  float64 `json:"left-seconds"`
+	CurrentSpeed float64 `json:"current-speed"`
+	// Operators is the number of the merge operators created by the bulk merge.
+	Operators int `json:"operators"`
+}
+
+// bulkMergeJob coalesces the small regions in a key range by planning the merge chains,
+// which are the runs of the adjacent regions that can be merged into one region. The
+// chains are merged pair by pair concurrently, and the empty regions are merged first.
+type bulkMergeJob struct {
+	startKey   []byte
+	endKey     []byte
+	crossTable bool
+	storeRate  float64
+	startTime  time.Time
+	finished   bool
+	operators  int
+	// storeLimiters limits the rate of the regions merged on each store.
+	storeLimiters map[uint64]*ratelimit.RateLimiter
+
+	// cursor is where the next round of the planning starts from.
+	cursor []byte
+	// lastPlanTime is the time of the last round of the planning.
+	lastPlanTime time.Time
+	// smallRegions is the number of the small regions found in the current pass of the range.
+	smallRegions int
+	// pendingChains is the number of the merge chains found or blocked by the running operators
+	// in the current pass, the bulk merge is finished if there is none after a pass.
+	pendingChains int
+}
+
+// StartBulkMerge starts to coalesce the small regions in [startKey, endKey). If crossTable
+// is true, the empty regions are allowed to be merged across the table boundaries.
+// storeRate limits the number of the regions which have a peer on the same store to be
+// merged per minute. The progress is tracked by the progress manager of the cluster.
+func (m *MergeChecker) StartBulkMerge(startKey, endKey []byte, crossTable bool, storeRate float64) error {
+	if m.isBulkMergeRunning() {
+		return errs.ErrBulkMergeRunning.FastGenByArgs()
+	}
+	// Count the regions outside the lock since the range may be large.
+	regions, total := m.countSmallRegions(startKey, endKey)
+
+	m.bulkMu.Lock()
+	defer m.bulkMu.Unlock()
+	if m.bulkJob != nil && !m.bulkJob.finished {
+		return errs.ErrBulkMergeRunning.FastGenByArgs()
+	}
+	if storeRate <= 0 {
+		storeRate = DefaultBulkMergeStoreRate
+	}
+	job := &bulkMergeJob{
+		startKey:      startKey,
+		endKey:        endKey,
+		crossTable:    crossTable,
+		storeRate:     storeRate,
+		startTime:     time.Now(),
+		cursor:        startKey,
+		storeLimiters: make(map[uint64]*ratelimit.RateLimiter),
+	}
+	// The progress is updated once per pass of the range, which takes a round for every
+	// bulkMergeScanLimit regions.
+	updateInterval := bulkMergeInterval * time.Duration(regions/bulkMergeScanLimit+1)
+	// The progress of the finished bulk merge is replaced.
+	progressManager := m.cluster.GetProgressManager()
+	progressManager.RemoveProgress(bulkMergeProgress)
+	// Avoid the zero total which makes the progress invalid.
+	progressManager.AddProgress(bulkMergeProgress, float64(total), math.Max(float64(total), 1), updateInterval)
+	m.bulkJob = job
+	log.Info("bulk merge is started",
+		zap.String("start-key", hex.EncodeToString(startKey)),
+		zap.String("end-key", hex.EncodeToString(endKey)),
+		zap.Bool("cross-table", crossTable),
+		zap.Float64("store-rate", storeRate),
+		zap.Int("regions", regions),
+		zap.Int("small-regions", total))
+	return nil
+}
+
+func (m *MergeChecker) isBulkMergeRunning() bool {
+	m.bulkMu.RLock()
+	defer m.bulkMu.RUnlock()
+	return m.bulkJob != nil && !m.bulkJob.finished
+}
+
+// countSmallRegions scans the regions in [startKey, endKey) in batches and returns the number
+// of the regions and the small regions.
+func (m *MergeChecker) countSmallRegions(startKey, endKey []byte) (regions, small int) {
+	cursor := startKey
+	for {
+		batch := m.cluster.ScanRegions(cursor, endKey, bulkMergeScanLimit)
+		regions += len(batch)
+		for _, region := range batch {
+			if m.isSmallRegion(region) {
+				small++
+			}
+		}
+		if len(batch) < bulkMergeScanLimit {
+			return
+		}
+		cursor = batch[len(batch)-1].GetEndKey()
+		if len(cursor) == 0 {
+			return
+		}
+	}
+}
+
+// StopBulkMerge stops the bulk merge.
+func (m *MergeChecker) StopBulkMerge() error {
+	m.bulkMu.Lock()
+	defer m.bulkMu.Unlock()
+	if m.bulkJob == nil {
+		return errs.ErrBulkMergeNotFound.FastGenByArgs()
+	}
+	m.bulkJob = nil
+	m.cluster.GetProgressManager().RemoveProgress(bulkMergeProgress)
+	log.Info("bulk merge is stopped")
+	return nil
+}
+
+// GetBulkMergeStatus returns the status of the bulk merge.
+func (m *MergeChecker) GetBulkMergeStatus() (*BulkMergeStatus, error) {
+	m.bulkMu.RLock()
+	defer m.bulkMu.RUnlock()
+	job := m.bulkJob
+	if job == nil {
+		return nil, errs.ErrBulkMergeNotFound.FastGenByArgs()
+	}
+	status := &BulkMergeStatus{
+		StartKey:   hex.EncodeToString(job.startKey),
+		EndKey:     hex.EncodeToString(job.endKey),
+		CrossTable: job.crossTable,
+		StoreRate:  job.storeRate,
+		StartTime:  job.startTime,
+		Finished:   job.finished,
+		Operators:  job.operators,
+	}
+	var err error
+	status.Progress, status.LeftSeconds, status.CurrentSpeed, err = m.cluster.GetProgressManager().Status(bulkMergeProgress)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (m *MergeChecker) isSmallRegion(region *core.RegionInfo) bool {
+	return region.NeedMerge(int64(m.conf.GetMaxMergeRegionSize()), int64(m.conf.GetMaxMergeRegionKeys()))
+}
+
+func isEmptyRegion(region *core.RegionInfo) bool {
+	return region.GetApproximateSize() <= core.EmptyRegionApproximateSize && region.GetApproximateKeys() == 0
+}
+
+// CheckBulkMerge plans the next round of the bulk merge and returns the merge operators in pairs.
+func (m *MergeChecker) CheckBulkMerge(hasOperator func(regionID uint64) bool, opLimit int) [][]*operator.Operator {
+	m.bulkMu.Lock()
+	defer m.bulkMu.Unlock()
+	job := m.bulkJob
+	if job == nil || job.finished || m.IsPaused() || time.Since(job.lastPlanTime) < bulkMergeInterval {
+		return nil
+	}
+	job.lastPlanTime = time.Now()
+	bulkMergeCheckerCounter.Inc()
+
+	regions := m.cluster.ScanRegions(job.cursor, job.endKey, bulkMergeScanLimit)
+	for _, region := range regions {
+		if m.isSmallRegion(region) {
+			job.smallRegions++
+		}
+	}
+	chains, busy := m.planMergeChains(regions, job.crossTable, hasOperator)
+	job.pendingChains += len(chains) + busy
+	if len(regions) < bulkMergeScanLimit {
+		progressManager := m.cluster.GetProgressManager()
+		// The pass of the range is finished, start over from the beginning.
+		job.cursor = job.startKey
+		if job.pendingChains == 0 {
+			// The left small regions can't be merged any more.
+			progressManager.UpdateProgress(bulkMergeProgress, 0, 0, false)
+			job.finished = true
+			log.Info("bulk merge is finished", zap.Int("operators", job.operators), zap.Int("small-regions", job.smallRegions))
+			return nil
+		}
+		progressManager.UpdateProgress(bulkMergeProgress, float64(job.smallRegions), float64(job.smallRegions), false)
+		job.smallRegions, job.pendingChains = 0, 0
+	} else {
+		job.cursor = regions[len(regions)-1].GetEndKey()
+	}
+
+	var ops [][]*operator.Operator
+	for _, chain := range chains {
+		for _, pair := range m.planMergePairs(chain) {
+			if opLimit <= 0 {
+				return ops
+			}
+			source, target := pair[0], pair[1]
+			stores := storeRegionCounts(source, target)
+			if !job.availableOnStores(stores) {
+				bulkMergeStoreLimitCounter.Inc()
+				continue
+			}
+			pairOps, err := operator.CreateMergeRegionOperator("bulk-merge-region", m.cluster, source, target, operator.OpMerge)
+			if err != nil {
+				bulkMergeCreateOpFailCounter.Inc()
+				log.Debug("create bulk merge operator failed", errs.ZapError(err))
+				continue
+			}
+			job.takeOnStores(stores)
+			bulkMergeNewOpCounter.Inc()
+			job.operators++
+			opLimit--
+			ops = append(ops, pairOps)
+		}
+	}
+	return ops
+}
+
+// storeRegionCounts returns the number of the regions which have a peer on each store.
+func storeRegionCounts(regions ...*core.RegionInfo) map[uint64]int {
+	stores := make(map[uint64]int)
+	for _, region := range regions {
+		for storeID := range region.GetStoreIDs() {
+			stores[storeID]++
+		}
+	}
+	return stores
+}
+
+// storeLimiter returns the rate limiter of the store. The burst is the regions of one minute, and
+// at least a pair of regions since both the source and target regions usually have a peer on it.
+func (job *bulkMergeJob) storeLimiter(storeID uint64) *ratelimit.RateLimiter {
+	limiter, ok := job.storeLimiters[storeID]
+	if !ok {
+		limiter = ratelimit.NewRateLimiter(job.storeRate/time.Minute.Seconds(), int(math.Max(math.Ceil(job.storeRate), 2)))
+		job.storeLimiters[storeID] = limiter
+	}
+	return limiter
+}
+
+// availableOnStores checks whether the regions can be merged on all the stores without exceeding the rate.
+func (job *bulkMergeJob) availableOnStores(stores map[uint64]int) bool {
+	for storeID, count := range stores {
+		if !job.storeLimiter(storeID).Available(count) {
+			return false
+		}
+	}
+	return true
+}
+
+// takeOnStores takes the tokens of the merged regions from the rate limiters of the stores.
+func (job *bulkMergeJob) takeOnStores(stores map[uint64]int) {
+	for storeID, count := range stores {
+		job.storeLimiter(storeID).AllowN(count)
+	}
+}
+
+// planMergeChains splits the sorted regions into the chains, each of which can be merged
+// into one region without exceeding the region size and keys limit. It also returns the
+// number of the small regions which are skipped due to the running operators.
+func (m *MergeChecker) planMergeChains(regions []*core.RegionInfo, crossTable bool, hasOperator func(regionID uint64) bool) (chains [][]*core.RegionInfo, busy int) {
+	var (
+		chain       []*core.RegionInfo
+		size, keys  int64
+		storeConfig = m.cluster.GetStoreConfig()
+	)
+	flush := func() {
+		if len(chain) > 1 {
+			chains = append(chains, chain)
+		}
+		chain, size, keys = nil, 0, 0
+	}
+	for _, region := range regions {
+		if !m.isBulkMergeCandidate(region) {
+			flush()
+			continue
+		}
+		if hasOperator(region.GetID()) {
+			busy++
+			flush()
+			continue
+		}
+		if len(chain) > 0 {
+			prev := chain[len(chain)-1]
+			if !bytes.Equal(prev.GetEndKey(), region.GetStartKey()) ||
+				!m.allowBulkMerge(prev, region, crossTable) ||
+				storeConfig.CheckRegionSize(uint64(size+region.GetApproximateSize()), m.conf.GetMaxMergeRegionSize()) != nil ||
+				storeConfig.CheckRegionKeys(uint64(keys+region.GetApproximateKeys()), m.conf.GetMaxMergeRegionKeys()) != nil {
+				flush()
+			}
+		}
+		chain = append(chain, region)
+		size += region.GetApproximateSize()
+		keys += region.GetApproximateKeys()
+	}
+	flush()
+	return chains, busy
+}
+
+// planMergePairs picks the disjoint pairs of the adjacent regions in the chain to merge
+// concurrently. The pairs with the smaller size are picked first, so that the empty
+// regions are merged first. It returns the pairs of the source and target regions.
+func (m *MergeChecker) planMergePairs(chain []*core.RegionInfo) [][2]*core.RegionInfo {
+	indexes := make([]int, 0, len(chain)-1)
+	for i := 0; i+1 < len(chain); i++ {
+		indexes = append(indexes, i)
+	}
+	pairSize := func(i int) int64 {
+		return chain[i].GetApproximateSize() + chain[i+1].GetApproximateSize()
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return pairSize(indexes[i]) < pairSize(indexes[j])
+	})
+	used := make([]bool, len(chain))
+	var pairs [][2]*core.RegionInfo
+	for _, i := range indexes {
+		if used[i] || used[i+1] {
+			continue
+		}
+		used[i], used[i+1] = true, true
+		// Merge the left region into the right one as the merge checker does in one-way mode.
+		source, target := chain[i], chain[i+1]
+		if !m.conf.IsOneWayMergeEnabled() && source.GetApproximateSize() > target.GetApproximateSize() {
+			source, target = target, source
+		}
+		pairs = append(pairs, [2]*core.RegionInfo{source, target})
+	}
+	return pairs
+}
+
+func (m *MergeChecker) isBulkMergeCandidate(region *core.RegionInfo) bool {
+	return region.GetLeader() != nil &&
+		m.isSmallRegion(region) &&
+		filter.IsRegionHealthy(region) &&
+		filter.IsRegionReplicated(m.cluster, region) &&
+		!m.cluster.IsRegionHot(region)
+}
+
+// allowBulkMerge checks whether the adjacent regions can be merged in the bulk merge mode,
+// which allows the empty regions to be merged across the table boundaries if crossTable is set.
+func (m *MergeChecker) allowBulkMerge(region, adjacent *core.RegionInfo, crossTable bool) bool {
+	crossTable = crossTable && isEmptyRegion(region) && isEmptyRegion(adjacent)
+	return allowMerge(m.cluster, region, adjacent, crossTable) && checkPeerStore(m.cluster, region, adjacent)
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/codec"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/ratelimit"
+	"github.com/tikv/pd/pkg/schedule/operator"
+)
+
+func tableKey(tableID int64) string {
+	return string(codec.EncodeBytes(codec.GenerateTableKey(tableID)))
+}
+
+func newBulkMergeTestCluster(ctx context.Context) *mockcluster.Cluster {
+	tc := mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())
+	tc.SetMaxMergeRegionSize(20)
+	tc.SetMaxMergeRegionKeys(200000)
+	tc.SetSplitMergeInterval(0)
+	for storeID := uint64(1); storeID <= 3; storeID++ {
+		tc.AddRegionStore(storeID, 0)
+	}
+	return tc
+}
+
+func newBulkMergeRegion(id uint64, startKey, endKey string, size int64) *core.RegionInfo {
+	return newRegionInfo(id, startKey, endKey, size, size, []uint64{id*10 + 1, 1},
+		[]uint64{id*10 + 1, 1}, []uint64{id*10 + 2, 2}, []uint64{id*10 + 3, 3})
+}
+
+func checkBulkMergePairs(re *require.Assertions, ops [][]*operator.Operator, pairs ...[2]uint64) {
+	re.Len(ops, len(pairs))
+	for i, pair := range pairs {
+		re.Len(ops[i], 2)
+		re.Equal(pair[0], ops[i][0].RegionID())
+		re.Equal(pair[1], ops[i][1].RegionID())
+	}
+}
+
+func TestBulkMerge(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := newBulkMergeTestCluster(ctx)
+	tc.PutRegion(newBulkMergeRegion(1, "", tableKey(1), 1))
+	tc.PutRegion(newBulkMergeRegion(2, tableKey(1), tableKey(2), 0))
+	tc.PutRegion(newBulkMergeRegion(3, tableKey(2), tableKey(3), 5))
+	tc.PutRegion(newBulkMergeRegion(4, tableKey(3), tableKey(4), 0))
+	tc.PutRegion(newBulkMergeRegion(5, tableKey(4), "", 100))
+	mc := NewMergeChecker(ctx, tc, tc.GetCheckerConfig())
+	noOperator := func(uint64) bool { return false }
+	replan := func() {
+		mc.bulkJob.lastPlanTime = time.Time{}
+	}
+
+	_, err := mc.GetBulkMergeStatus()
+	re.True(errs.ErrBulkMergeNotFound.Equal(err))
+	re.Nil(mc.CheckBulkMerge(noOperator, 10))
+	re.NoError(mc.StartBulkMerge(nil, nil, false, 2))
+	re.True(errs.ErrBulkMergeRunning.Equal(mc.StartBulkMerge(nil, nil, false, 2)))
+	status, err := mc.GetBulkMergeStatus()
+	re.NoError(err)
+	re.False(status.Finished)
+	re.Equal(2.0, status.StoreRate)
+	// The progress is tracked by the progress manager of the cluster.
+	re.Equal([]string{bulkMergeProgress}, tc.GetProgressManager().GetProgresses(func(string) bool { return true }))
+
+	// The empty region is merged first, and the store rate allows only one pair per minute.
+	checkBulkMergePairs(re, mc.CheckBulkMerge(noOperator, 10), [2]uint64{2, 1})
+	// The planning is throttled.
+	re.Nil(mc.CheckBulkMerge(noOperator, 10))
+	// The store rate is exhausted.
+	replan()
+	re.Nil(mc.CheckBulkMerge(noOperator, 10))
+	replan()
+	mc.bulkJob.storeRate = DefaultBulkMergeStoreRate
+	mc.bulkJob.storeLimiters = make(map[uint64]*ratelimit.RateLimiter)
+	checkBulkMergePairs(re, mc.CheckBulkMerge(noOperator, 10), [2]uint64{2, 1}, [2]uint64{4, 3})
+	// The operator limit is respected.
+	replan()
+	mc.bulkJob.storeLimiters = make(map[uint64]*ratelimit.RateLimiter)
+	checkBulkMergePairs(re, mc.CheckBulkMerge(noOperator, 1), [2]uint64{2, 1})
+	// The regions with the running operators are skipped.
+	replan()
+	mc.bulkJob.storeLimiters = make(map[uint64]*ratelimit.RateLimiter)
+	hasOperator := func(regionID uint64) bool { return regionID == 1 || regionID == 2 }
+	checkBulkMergePairs(re, mc.CheckBulkMerge(hasOperator, 10), [2]uint64{4, 3})
+	re.Equal(5, mc.bulkJob.operators)
+
+	// The bulk merge is finished once there is nothing to merge.
+	tc.PutRegion(newBulkMergeRegion(6, "", tableKey(4), 6).Clone(core.SetRegionVersion(10)))
+	replan()
+	re.Nil(mc.CheckBulkMerge(noOperator, 10))
+	status, err = mc.GetBulkMergeStatus()
+	re.NoError(err)
+	re.True(status.Finished)
+	re.Equal(1.0, status.Progress)
+	re.Equal(5, status.Operators)
+	re.NoError(mc.StartBulkMerge(nil, nil, false, 0))
+	re.NoError(mc.StopBulkMerge())
+	re.True(errs.ErrBulkMergeNotFound.Equal(mc.StopBulkMerge()))
+	re.Empty(tc.GetProgressManager().GetProgresses(func(string) bool { return true }))
+}
+
+func TestBulkMergeCrossTable(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := newBulkMergeTestCluster(ctx)
+	tc.SetEnableCrossTableMerge(false)
+	tc.PutRegion(newBulkMergeRegion(1, tableKey(1), tableKey(2), 0))
+	tc.PutRegion(newBulkMergeRegion(2, tableKey(2), tableKey(3), 0))
+	tc.PutRegion(newBulkMergeRegion(3, tableKey(3), tableKey(4), 1))
+	tc.PutRegion(newBulkMergeRegion(4, tableKey(4), tableKey(5), 1))
+	mc := NewMergeChecker(ctx, tc, tc.GetCheckerConfig())
+	noOperator := func(uint64) bool { return false }
+
+	// The regions of the different tables are not merged.
+	re.NoError(mc.StartBulkMerge(nil, nil, false, 0))
+	re.Nil(mc.CheckBulkMerge(noOperator, 10))
+	status, err := mc.GetBulkMergeStatus()
+	re.NoError(err)
+	re.True(status.Finished)
+
+	// Only the empty regions are merged across the tables.
+	re.NoError(mc.StartBulkMerge(nil, nil, true, 0))
+	checkBulkMergePairs(re, mc.CheckBulkMerge(noOperator, 10), [2]uint64{1, 2})
+}
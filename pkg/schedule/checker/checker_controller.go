@@ -44,6 +44,7 @@ type Controller struct {
 	replicaChecker    *ReplicaChecker
 	ruleChecker       *RuleChecker
 	splitChecker      *SplitChecker
+	loadSplitChecker  *LoadSplitChecker
 	mergeChecker      *MergeChecker
 	jointStateChecker *JointStateChecker
 	priorityInspector *PriorityInspector
@@ -63,6 +64,7 @@ func NewController(ctx context.Context, cluster sche.CheckerCluster, conf config
 		replicaChecker:    NewReplicaChecker(cluster, conf, regionWaitingList),
 		ruleChecker:       NewRuleChecker(ctx, cluster, ruleManager, regionWaitingList),
 		splitChecker:      NewSplitChecker(cluster, ruleManager, labeler),
+		loadSplitChecker:  NewLoadSplitChecker(cluster, labeler),
 		mergeChecker:      NewMergeChecker(ctx, cluster, conf),
 		jointStateChecker: NewJointStateChecker(cluster),
 		priorityInspector: NewPriorityInspector(cluster, conf),
@@ -130,6 +132,10 @@ func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
 		}
 	}
 
+	if op := c.loadSplitChecker.Check(region); op != nil {
+		return []*operator.Operator{op}
+	}
+
 	if c.mergeChecker != nil {
 		allowed := opController.OperatorCount(operator.OpMerge) < c.conf.GetMergeScheduleLimit()
 		if !allowed {
@@ -142,6 +148,27 @@ func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
 	return nil
 }
 
+// RefreshHotBuckets refreshes the hot buckets used by the load split checker, it's called
+// at the beginning of every patrol round.
+func (c *Controller) RefreshHotBuckets() {
+	c.loadSplitChecker.RefreshHotBuckets()
+}
+
+// CheckBulkMerge plans the next round of the bulk merge and returns the merge operators in pairs.
+func (c *Controller) CheckBulkMerge() [][]*operator.Operator {
+	if c.mergeChecker == nil {
+		return nil
+	}
+	opLimit := int(c.conf.GetMergeScheduleLimit()) - int(c.opController.OperatorCount(operator.OpMerge))
+	if opLimit <= 0 {
+		return nil
+	}
+	hasOperator := func(regionID uint64) bool {
+		return c.opController.GetOperator(regionID) != nil
+	}
+	return c.mergeChecker.CheckBulkMerge(hasOperator, opLimit)
+}
+
 // GetMergeChecker returns the merge checker.
 func (c *Controller) GetMergeChecker() *MergeChecker {
 	return c.mergeChecker
@@ -227,6 +254,9 @@ func (c *Controller) IsPendingRegion(regionID uint64) bool {
 	return exist
 }
 
+// PausableCheckers are the names of the checkers which could be paused.
+var PausableCheckers = []string{"learner", "replica", "rule", "split", "load-split", "merge", "joint-state"}
+
 // GetPauseController returns pause controller of the checker
 func (c *Controller) GetPauseController(name string) (*PauseController, error) {
 	switch name {
@@ -238,6 +268,8 @@ func (c *Controller) GetPauseController(name string) (*PauseController, error) {
 		return &c.ruleChecker.PauseController, nil
 	case "split":
 		return &c.splitChecker.PauseController, nil
+	case "load-split":
+		return &c.loadSplitChecker.PauseController, nil
 	case "merge":
 		return &c.mergeChecker.PauseController, nil
 	case "joint-state":
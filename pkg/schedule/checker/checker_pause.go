@@ -35,3 +35,8 @@ func (c *PauseController) PauseOrResume(t int64) {
 	delayUntil := time.Now().Unix() + t
 	atomic.StoreInt64(&c.delayUntil, delayUntil)
 }
+
+// GetDelayUntil returns the unix time until which the checker is paused.
+func (c *PauseController) GetDelayUntil() int64 {
+	return atomic.LoadInt64(&c.delayUntil)
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/codec"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mcs/utils"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/statistics/buckets"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	loadSplitCheckerName = "load_split_checker"
+	// loadSplitHotDegree is the min hot degree of the buckets to be isolated.
+	loadSplitHotDegree = 3
+
+	// loadSplitLabel is the region label to allow or deny the load split of the key range
+	// regardless of the keyspace config.
+	loadSplitLabel      = "load-split"
+	loadSplitValueAllow = "allow"
+	loadSplitValueDeny  = "deny"
+)
+
+var (
+	// WithLabelValues is a heavy operation, define variable to avoid call it every time.
+	loadSplitCheckerCounter         = checkerCounter.WithLabelValues(loadSplitCheckerName, "check")
+	loadSplitCheckerPausedCounter   = checkerCounter.WithLabelValues(loadSplitCheckerName, "paused")
+	loadSplitCheckerDisabledCounter = checkerCounter.WithLabelValues(loadSplitCheckerName, "disabled")
+	loadSplitCheckerTooSmallCounter = checkerCounter.WithLabelValues(loadSplitCheckerName, "region-too-small")
+	loadSplitCheckerNoHotCounter    = checkerCounter.WithLabelValues(loadSplitCheckerName, "no-hot-bucket")
+	loadSplitCheckerRefreshFailed   = checkerCounter.WithLabelValues(loadSplitCheckerName, "refresh-hot-buckets-fail")
+	loadSplitCheckerNoKeysCounter   = checkerCounter.WithLabelValues(loadSplitCheckerName, "no-split-keys")
+	loadSplitCheckerCreateOpFailed  = checkerCounter.WithLabelValues(loadSplitCheckerName, "create-operator-fail")
+	loadSplitCheckerNewOpCounter    = checkerCounter.WithLabelValues(loadSplitCheckerName, "new-operator")
+)
+
+// LoadSplitChecker splits the regions at the bucket boundaries to isolate the hot key spans,
+// so that the hot spans can be scheduled without moving the whole regions.
+type LoadSplitChecker struct {
+	PauseController
+	cluster sche.CheckerCluster
+	labeler *labeler.RegionLabeler
+
+	mu syncutil.RWMutex
+	// hotBuckets is the snapshot of the hot buckets of all the regions, which is refreshed
+	// once per patrol round, since every collection of the buckets is a round trip to the
+	// hot bucket cache and may fail if the task queue of the cache is full.
+	hotBuckets map[uint64][]*buckets.BucketStat
+}
+
+// NewLoadSplitChecker creates a new LoadSplitChecker.
+func NewLoadSplitChecker(cluster sche.CheckerCluster, labeler *labeler.RegionLabeler) *LoadSplitChecker {
+	return &LoadSplitChecker{
+		cluster: cluster,
+		labeler: labeler,
+	}
+}
+
+// GetType returns the checker type.
+func (c *LoadSplitChecker) GetType() string {
+	return "load-split-checker"
+}
+
+// RefreshHotBuckets takes a new snapshot of the hot buckets, which are used to check the regions
+// until the next refresh. The regions are not split if the snapshot can't be taken.
+func (c *LoadSplitChecker) RefreshHotBuckets() {
+	var hotBuckets map[uint64][]*buckets.BucketStat
+	if !c.IsPaused() {
+		hotBuckets = c.cluster.BucketsStats(loadSplitHotDegree)
+		if hotBuckets == nil {
+			loadSplitCheckerRefreshFailed.Inc()
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hotBuckets = hotBuckets
+}
+
+func (c *LoadSplitChecker) getHotBuckets(regionID uint64) []*buckets.BucketStat {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.hotBuckets[regionID]
+}
+
+// Check checks whether the region has the hot key spans to isolate and returns the split operator.
+func (c *LoadSplitChecker) Check(region *core.RegionInfo) *operator.Operator {
+	loadSplitCheckerCounter.Inc()
+
+	if c.IsPaused() {
+		loadSplitCheckerPausedCounter.Inc()
+		return nil
+	}
+	if !c.isEnabled(region) {
+		loadSplitCheckerDisabledCounter.Inc()
+		return nil
+	}
+	conf := c.cluster.GetCheckerConfig()
+	// The split regions would be merged back by the merge checker if they are too small.
+	if region.NeedMerge(int64(conf.GetMaxMergeRegionSize()), int64(conf.GetMaxMergeRegionKeys())) {
+		loadSplitCheckerTooSmallCounter.Inc()
+		return nil
+	}
+	stats := c.getHotBuckets(region.GetID())
+	if len(stats) == 0 {
+		loadSplitCheckerNoHotCounter.Inc()
+		return nil
+	}
+
+	keys, hotDegree := hotSpanSplitKeys(region, stats)
+	if len(keys) == 0 {
+		loadSplitCheckerNoKeysCounter.Inc()
+		return nil
+	}
+	op, err := operator.CreateSplitRegionOperator("load-split-region", region, operator.OpSplit, pdpb.CheckPolicy_USEKEY, keys)
+	if err != nil {
+		loadSplitCheckerCreateOpFailed.Inc()
+		log.Debug("create load split region operator failed", errs.ZapError(err))
+		return nil
+	}
+	loadSplitCheckerNewOpCounter.Inc()
+	op.AdditionalInfos["hot-degree"] = strconv.Itoa(hotDegree)
+	return op
+}
+
+// isEnabled checks whether the load split is enabled for the region. The region label takes
+// precedence over the keyspace config.
+func (c *LoadSplitChecker) isEnabled(region *core.RegionInfo) bool {
+	if c.labeler != nil {
+		switch c.labeler.GetRegionLabel(region, loadSplitLabel) {
+		case loadSplitValueAllow:
+			return true
+		case loadSplitValueDeny:
+			return false
+		}
+	}
+	return c.cluster.GetCheckerConfig().IsLoadSplitEnabled(keyspaceIDOfKey(region.GetStartKey()))
+}
+
+// hotSpanSplitKeys merges the adjacent hot buckets into the hot spans, and returns the
+// boundaries of the spans inside the region as the split keys together with the max
+// hot degree of the buckets.
+func hotSpanSplitKeys(region *core.RegionInfo, stats []*buckets.BucketStat) ([][]byte, int) {
+	startKey, endKey := region.GetStartKey(), region.GetEndKey()
+	var (
+		keys      [][]byte
+		hotDegree int
+		spanEnd   []byte
+		inSpan    bool
+	)
+	addKey := func(key []byte) {
+		if bytes.Compare(key, startKey) <= 0 || (len(endKey) != 0 && bytes.Compare(key, endKey) >= 0) {
+			return
+		}
+		if len(keys) == 0 || !bytes.Equal(keys[len(keys)-1], key) {
+			keys = append(keys, key)
+		}
+	}
+	for _, stat := range stats {
+		// The stale bucket may be reported before the region is split or merged.
+		if bytes.Compare(stat.StartKey, startKey) < 0 ||
+			(len(endKey) != 0 && (len(stat.EndKey) == 0 || bytes.Compare(stat.EndKey, endKey) > 0)) {
+			continue
+		}
+		if stat.HotDegree > hotDegree {
+			hotDegree = stat.HotDegree
+		}
+		if inSpan && bytes.Equal(spanEnd, stat.StartKey) {
+			spanEnd = stat.EndKey
+			continue
+		}
+		if inSpan {
+			addKey(spanEnd)
+		}
+		addKey(stat.StartKey)
+		spanEnd, inSpan = stat.EndKey, true
+	}
+	if inSpan {
+		addKey(spanEnd)
+	}
+	return keys, hotDegree
+}
+
+// keyspaceIDOfKey returns the ID of the keyspace which the encoded region key belongs to.
+// The keys outside of any keyspace belong to the default keyspace.
+func keyspaceIDOfKey(key []byte) uint32 {
+	_, decoded, err := codec.DecodeBytes(key)
+	if err != nil || len(decoded) < 4 || (decoded[0] != 'r' && decoded[0] != 'x') {
+		return utils.DefaultKeyspaceID
+	}
+	return uint32(decoded[1])<<16 | uint32(decoded[2])<<8 | uint32(decoded[3])
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/codec"
+	"github.com/tikv/pd/pkg/keyspace"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/statistics/buckets"
+)
+
+func TestHotSpanSplitKeys(t *testing.T) {
+	re := require.New(t)
+	region := newRegionInfo(1, "b", "z", 100, 100, []uint64{101, 1}, []uint64{101, 1})
+	newStat := func(startKey, endKey string, degree int) *buckets.BucketStat {
+		return &buckets.BucketStat{RegionID: 1, StartKey: []byte(startKey), EndKey: []byte(endKey), HotDegree: degree}
+	}
+	checkKeys := func(stats []*buckets.BucketStat, expectDegree int, expectKeys ...string) {
+		keys, degree := hotSpanSplitKeys(region, stats)
+		re.Len(keys, len(expectKeys))
+		for i, key := range expectKeys {
+			re.Equal(key, string(keys[i]))
+		}
+		re.Equal(expectDegree, degree)
+	}
+
+	// The hot span is isolated from the cold part of the region.
+	checkKeys([]*buckets.BucketStat{newStat("d", "f", 3)}, 3, "d", "f")
+	// The adjacent hot buckets are merged into one span.
+	checkKeys([]*buckets.BucketStat{newStat("d", "f", 3), newStat("f", "h", 5), newStat("k", "m", 4)}, 5, "d", "h", "k", "m")
+	// The region boundaries are not split keys.
+	checkKeys([]*buckets.BucketStat{newStat("b", "f", 3), newStat("m", "z", 3)}, 3, "f", "m")
+	checkKeys([]*buckets.BucketStat{newStat("b", "f", 3), newStat("f", "z", 3)}, 3)
+	// The stale buckets out of the region are skipped.
+	checkKeys([]*buckets.BucketStat{newStat("a", "c", 3), newStat("x", "", 3)}, 0)
+}
+
+func TestKeyspaceIDOfKey(t *testing.T) {
+	re := require.New(t)
+	bound := keyspace.MakeRegionBound(0x123456)
+	re.Equal(uint32(0x123456), keyspaceIDOfKey(bound.RawLeftBound))
+	re.Equal(uint32(0x123456), keyspaceIDOfKey(bound.TxnLeftBound))
+	re.Equal(uint32(0x123457), keyspaceIDOfKey(bound.TxnRightBound))
+	re.Equal(uint32(0), keyspaceIDOfKey(nil))
+	re.Equal(uint32(0), keyspaceIDOfKey(codec.EncodeBytes(codec.GenerateTableKey(1))))
+	re.Equal(uint32(0), keyspaceIDOfKey([]byte("not encoded")))
+}
+
+func TestLoadSplitCheckerEnabled(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())
+	checker := NewLoadSplitChecker(tc, tc.GetRegionLabeler())
+	bound := keyspace.MakeRegionBound(1)
+	region := newRegionInfo(1, string(bound.TxnLeftBound), string(bound.TxnRightBound), 100, 100, []uint64{101, 1}, []uint64{101, 1})
+
+	re.False(checker.isEnabled(region))
+	tc.SetLoadSplitKeyspaces(2)
+	re.False(checker.isEnabled(region))
+	tc.SetLoadSplitKeyspaces(1, 2)
+	re.True(checker.isEnabled(region))
+
+	// The region label takes precedence over the keyspace config.
+	tc.GetRegionLabeler().SetLabelRule(&labeler.LabelRule{
+		ID:       "test",
+		Labels:   []labeler.RegionLabel{{Key: loadSplitLabel, Value: loadSplitValueDeny}},
+		RuleType: labeler.KeyRange,
+		Data:     makeKeyRanges(hex.EncodeToString(bound.TxnLeftBound), hex.EncodeToString(bound.TxnRightBound)),
+	})
+	re.False(checker.isEnabled(region))
+	tc.SetLoadSplitKeyspaces()
+	tc.GetRegionLabeler().SetLabelRule(&labeler.LabelRule{
+		ID:       "test",
+		Labels:   []labeler.RegionLabel{{Key: loadSplitLabel, Value: loadSplitValueAllow}},
+		RuleType: labeler.KeyRange,
+		Data:     makeKeyRanges(hex.EncodeToString(bound.TxnLeftBound), hex.EncodeToString(bound.TxnRightBound)),
+	})
+	re.True(checker.isEnabled(region))
+	// No operator is created without the hot buckets.
+	re.Nil(checker.Check(region))
+}
+
+type hotBucketsCluster struct {
+	*mockcluster.Cluster
+	collections int
+	hotBuckets  map[uint64][]*buckets.BucketStat
+}
+
+func (c *hotBucketsCluster) BucketsStats(int, ...uint64) map[uint64][]*buckets.BucketStat {
+	c.collections++
+	return c.hotBuckets
+}
+
+func TestLoadSplitCheckerHotBuckets(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := &hotBucketsCluster{Cluster: mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())}
+	tc.SetLoadSplitKeyspaces(0)
+	checker := NewLoadSplitChecker(tc, tc.GetRegionLabeler())
+	region := newRegionInfo(1, "b", "z", 100, 100, []uint64{101, 1}, []uint64{101, 1})
+	tc.hotBuckets = map[uint64][]*buckets.BucketStat{
+		1: {{RegionID: 1, StartKey: []byte("d"), EndKey: []byte("f"), HotDegree: 3}},
+	}
+
+	// The hot buckets are not collected by the check.
+	re.Nil(checker.Check(region))
+	re.Zero(tc.collections)
+	// The hot buckets are collected once per refresh.
+	checker.RefreshHotBuckets()
+	re.Equal(1, tc.collections)
+	for i := 0; i < 3; i++ {
+		op := checker.Check(region)
+		re.NotNil(op)
+		re.Equal("3", op.AdditionalInfos["hot-degree"])
+	}
+	re.Equal(1, tc.collections)
+	// The region is not split if the hot buckets can't be collected.
+	tc.hotBuckets = nil
+	checker.RefreshHotBuckets()
+	re.Nil(checker.Check(region))
+	re.Equal(2, tc.collections)
+}
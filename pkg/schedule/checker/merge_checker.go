@@ -32,6 +32,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
 )
 
 const (
@@ -82,6 +83,9 @@ type MergeChecker struct {
 	conf       config.CheckerConfigProvider
 	splitCache *cache.TTLUint64
 	startTime  time.Time // it's used to judge whether server recently start.
+
+	bulkMu  syncutil.RWMutex
+	bulkJob *bulkMergeJob
 }
 
 // NewMergeChecker creates a merge checker.
@@ -256,6 +260,11 @@ func (m *MergeChecker) checkTarget(region, adjacent *core.RegionInfo) bool {
 
 // AllowMerge returns true if two regions can be merged according to the key type.
 func AllowMerge(cluster sche.SharedCluster, region, adjacent *core.RegionInfo) bool {
+	return allowMerge(cluster, region, adjacent, false)
+}
+
+// allowMerge is the same as AllowMerge, except that the table boundary is ignored if crossTable is true.
+func allowMerge(cluster sche.SharedCluster, region, adjacent *core.RegionInfo, crossTable bool) bool {
 	var start, end []byte
 	if bytes.Equal(region.GetEndKey(), adjacent.GetStartKey()) && len(region.GetEndKey()) != 0 {
 		start, end = region.GetStartKey(), adjacent.GetEndKey()
@@ -288,6 +297,9 @@ func AllowMerge(cluster sche.SharedCluster, region, adjacent *core.RegionInfo) b
 		}
 	}
 
+	if crossTable {
+		return true
+	}
 	policy := cluster.GetSharedConfig().GetKeyType()
 	switch policy {
 	case constant.Table:
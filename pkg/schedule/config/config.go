@@ -144,6 +144,10 @@ type ScheduleConfig struct {
 	// EnableCrossTableMerge is the option to enable cross table merge. This means two Regions can be merged with different table IDs.
 	// This option only works when key type is "table".
 	EnableCrossTableMerge bool `toml:"enable-cross-table-merge" json:"enable-cross-table-merge,string"`
+	// LoadSplitKeyspaces is the IDs of the keyspaces whose regions are split at the bucket
+	// boundaries to isolate the hot key spans. The keys outside of any keyspace belong to
+	// the default keyspace.
+	LoadSplitKeyspaces []uint32 `toml:"load-split-keyspaces" json:"load-split-keyspaces"`
 	// PatrolRegionInterval is the interval for scanning region during patrol.
 	PatrolRegionInterval typeutil.Duration `toml:"patrol-region-interval" json:"patrol-region-interval"`
 	// MaxStoreDownTime is the max duration after which
@@ -284,6 +288,7 @@ func (c *ScheduleConfig) Clone() *ScheduleConfig {
 	cfg := *c
 	cfg.StoreLimit = storeLimit
 	cfg.Schedulers = schedulers
+	cfg.LoadSplitKeyspaces = append(c.LoadSplitKeyspaces[:0:0], c.LoadSplitKeyspaces...)
 	cfg.SchedulersPayload = nil
 	return &cfg
 }
@@ -91,6 +91,7 @@ type CheckerConfigProvider interface {
 	GetMaxMergeRegionSize() uint64
 	GetMaxMergeRegionKeys() uint64
 	GetReplicaScheduleLimit() uint64
+	IsLoadSplitEnabled(keyspaceID uint32) bool
 }
 
 // SharedConfigProvider is the interface for shared configurations.
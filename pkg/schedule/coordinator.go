@@ -38,6 +38,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/splitter"
 	"github.com/tikv/pd/pkg/statistics"
 	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/logutil"
 	"github.com/tikv/pd/pkg/utils/syncutil"
 	"go.uber.org/zap"
@@ -51,6 +52,8 @@ const (
 	maxLoadConfigRetries       = 10
 	// pushOperatorTickInterval is the interval try to push the operator.
 	pushOperatorTickInterval = 500 * time.Millisecond
+	// scatterJobTickInterval is the interval to make progress on the scatter jobs.
+	scatterJobTickInterval = time.Second
 
 	patrolScanRegionLimit = 128 // It takes about 14 minutes to iterate 1 million regions.
 	// PluginLoad means action for load plugin
@@ -80,6 +83,7 @@ type Coordinator struct {
 	prepareChecker    *prepareChecker
 	checkers          *checker.Controller
 	regionScatterer   *scatter.RegionScatterer
+	scatterJobManager *scatter.JobManager
 	regionSplitter    *splitter.RegionSplitter
 	schedulers        *schedulers.Controller
 	opController      *operator.Controller
@@ -94,6 +98,11 @@ func NewCoordinator(ctx context.Context, cluster sche.ClusterInformer, hbStreams
 	opController := operator.NewController(ctx, cluster.GetBasicCluster(), cluster.GetSharedConfig(), hbStreams)
 	schedulers := schedulers.NewController(ctx, cluster, cluster.GetStorage(), opController)
 	checkers := checker.NewController(ctx, cluster, cluster.GetCheckerConfig(), cluster.GetRuleManager(), cluster.GetRegionLabeler(), opController)
+	regionScatterer := scatter.NewRegionScatterer(ctx, cluster, opController, checkers.AddSuspectRegions)
+	var scatterJobStorage endpoint.ScatterJobStorage = cluster.GetStorage()
+	if provider, ok := cluster.(sche.ScatterJobStorageProvider); ok {
+		scatterJobStorage = provider.GetScatterJobStorage()
+	}
 	return &Coordinator{
 		ctx:                   ctx,
 		cancel:                cancel,
@@ -101,7 +110,8 @@ func NewCoordinator(ctx context.Context, cluster sche.ClusterInformer, hbStreams
 		cluster:               cluster,
 		prepareChecker:        newPrepareChecker(),
 		checkers:              checkers,
-		regionScatterer:       scatter.NewRegionScatterer(ctx, cluster, opController, checkers.AddSuspectRegions),
+		regionScatterer:       regionScatterer,
+		scatterJobManager:     scatter.NewJobManager(cluster, scatterJobStorage, regionScatterer, opController),
 		regionSplitter:        splitter.NewRegionSplitter(cluster, splitter.NewSplitRegionsHandler(cluster, opController), checkers.AddSuspectRegions),
 		schedulers:            schedulers,
 		opController:          opController,
@@ -188,7 +198,13 @@ func (c *Coordinator) PatrolRegions() {
 		c.checkSuspectRegions()
 		// Check regions in the waiting list
 		c.checkWaitingRegions()
+		// Plan the bulk merge if it's running.
+		c.checkBulkMerge()
 
+		if len(key) == 0 {
+			// A new round of patrol is started.
+			c.checkers.RefreshHotBuckets()
+		}
 		key, regions = c.checkRegions(key)
 		if len(regions) == 0 {
 			continue
@@ -222,6 +238,12 @@ func (c *Coordinator) checkRegions(startKey []byte) (key []byte, regions []*core
 	return
 }
 
+func (c *Coordinator) checkBulkMerge() {
+	for _, ops := range c.checkers.CheckBulkMerge() {
+		c.opController.AddWaitingOperator(ops...)
+	}
+}
+
 func (c *Coordinator) checkSuspectRegions() {
 	for _, id := range c.checkers.GetSuspectRegions() {
 		region := c.cluster.GetRegion(id)
@@ -347,6 +369,27 @@ func (c *Coordinator) drivePushOperator() {
 	}
 }
 
+// driveScatterJobs makes progress on the running scatter jobs periodically.
+func (c *Coordinator) driveScatterJobs() {
+	defer logutil.LogPanic()
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(scatterJobTickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			log.Info("drive scatter jobs has been stopped")
+			return
+		case <-ticker.C:
+			if c.cluster.IsSchedulingHalted() {
+				continue
+			}
+			c.scatterJobManager.Run()
+		}
+	}
+}
+
 // driveSlowNodeScheduler is used to enable slow node scheduler when using `raft-kv2`.
 func (c *Coordinator) driveSlowNodeScheduler() {
 	defer logutil.LogPanic()
@@ -416,7 +459,11 @@ func (c *Coordinator) Run() {
 	log.Info("coordinator starts to run schedulers")
 	c.InitSchedulers(true)
 
-	c.wg.Add(4)
+	if err := c.scatterJobManager.Load(); err != nil {
+		log.Error("cannot load scatter jobs", errs.ZapError(err))
+	}
+
+	c.wg.Add(5)
 	// Starts to patrol regions.
 	go c.PatrolRegions()
 	// Checks suspect key ranges
@@ -424,6 +471,8 @@ func (c *Coordinator) Run() {
 	go c.drivePushOperator()
 	// Checks whether to create evict-slow-trend scheduler.
 	go c.driveSlowNodeScheduler()
+	// Makes progress on the scatter jobs.
+	go c.driveScatterJobs()
 }
 
 // InitSchedulers initializes schedulers.
@@ -765,11 +814,30 @@ func (c *Coordinator) IsCheckerPaused(name string) (bool, error) {
 	return p.IsPaused(), nil
 }
 
+// GetPausedCheckerDelayUntil returns the unix time until which a checker is paused.
+func (c *Coordinator) GetPausedCheckerDelayUntil(name string) (int64, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if c.cluster == nil {
+		return -1, errs.ErrNotBootstrapped.FastGenByArgs()
+	}
+	p, err := c.checkers.GetPauseController(name)
+	if err != nil {
+		return -1, err
+	}
+	return p.GetDelayUntil(), nil
+}
+
 // GetRegionScatterer returns the region scatterer.
 func (c *Coordinator) GetRegionScatterer() *scatter.RegionScatterer {
 	return c.regionScatterer
 }
 
+// GetScatterJobManager returns the scatter job manager.
+func (c *Coordinator) GetScatterJobManager() *scatter.JobManager {
+	return c.scatterJobManager
+}
+
 // GetRegionSplitter returns the region splitter.
 func (c *Coordinator) GetRegionSplitter() *splitter.RegionSplitter {
 	return c.regionSplitter
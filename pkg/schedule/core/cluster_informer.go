@@ -16,12 +16,14 @@ package core
 
 import (
 	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/progress"
 	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/statistics"
 	"github.com/tikv/pd/pkg/statistics/buckets"
 	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/storage/endpoint"
 )
 
 // ClusterInformer provides the necessary information of a cluster.
@@ -33,6 +35,12 @@ type ClusterInformer interface {
 	UpdateRegionsLabelLevelStats(regions []*core.RegionInfo)
 }
 
+// ScatterJobStorageProvider is implemented by the cluster whose storage is not persistent,
+// e.g. the scheduling microservice, to persist the scatter jobs elsewhere.
+type ScatterJobStorageProvider interface {
+	GetScatterJobStorage() endpoint.ScatterJobStorage
+}
+
 // SchedulerCluster is an aggregate interface that wraps multiple interfaces
 type SchedulerCluster interface {
 	SharedCluster
@@ -49,9 +57,11 @@ type SchedulerCluster interface {
 // CheckerCluster is an aggregate interface that wraps multiple interfaces
 type CheckerCluster interface {
 	SharedCluster
+	buckets.BucketStatInformer
 
 	GetCheckerConfig() sc.CheckerConfigProvider
 	GetStoreConfig() sc.StoreConfigProvider
+	GetProgressManager() *progress.Manager
 }
 
 // SharedCluster is an aggregate interface that wraps multiple interfaces
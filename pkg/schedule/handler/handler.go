@@ -28,6 +28,7 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/checker"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/filter"
 	"github.com/tikv/pd/pkg/schedule/operator"
@@ -765,6 +766,69 @@ func (h *Handler) GetCheckerStatus(name string) (map[string]bool, error) {
 	}, nil
 }
 
+// StartBulkMerge starts to merge the small regions in the given range in bulk.
+func (h *Handler) StartBulkMerge(startKey, endKey []byte, crossTable bool, storeRate float64) error {
+	co := h.GetCoordinator()
+	if co == nil {
+		return errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	return co.GetCheckerController().GetMergeChecker().StartBulkMerge(startKey, endKey, crossTable, storeRate)
+}
+
+// StopBulkMerge stops the running bulk merge.
+func (h *Handler) StopBulkMerge() error {
+	co := h.GetCoordinator()
+	if co == nil {
+		return errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	return co.GetCheckerController().GetMergeChecker().StopBulkMerge()
+}
+
+// GetBulkMergeStatus returns the status of the bulk merge.
+func (h *Handler) GetBulkMergeStatus() (*checker.BulkMergeStatus, error) {
+	co := h.GetCoordinator()
+	if co == nil {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	return co.GetCheckerController().GetMergeChecker().GetBulkMergeStatus()
+}
+
+// CreateScatterJob creates a job to scatter the regions in [startKey, endKey) within the group.
+func (h *Handler) CreateScatterJob(startKey, endKey []byte, group string, scatterPeer bool) (*scatter.Job, error) {
+	co := h.GetCoordinator()
+	if co == nil {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	return co.GetScatterJobManager().CreateJob(startKey, endKey, group, scatterPeer)
+}
+
+// GetScatterJobs returns all scatter jobs.
+func (h *Handler) GetScatterJobs() ([]*scatter.Job, error) {
+	co := h.GetCoordinator()
+	if co == nil {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	return co.GetScatterJobManager().GetJobs(), nil
+}
+
+// GetScatterJob returns the scatter job with the given ID.
+func (h *Handler) GetScatterJob(id uint64) (*scatter.Job, error) {
+	co := h.GetCoordinator()
+	if co == nil {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	return co.GetScatterJobManager().GetJob(id)
+}
+
+// CancelScatterJob cancels the running scatter job with the given ID.
+func (h *Handler) CancelScatterJob(id uint64) error {
+	co := h.GetCoordinator()
+	if co == nil {
+		return errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	return co.GetScatterJobManager().CancelJob(id)
+}
+
 // GetSchedulerNames returns all names of schedulers.
 func (h *Handler) GetSchedulerNames() ([]string, error) {
 	co := h.GetCoordinator()
@@ -0,0 +1,280 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handoff
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/election"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/checker"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+	"go.etcd.io/etcd/clientv3"
+	"go.uber.org/zap"
+)
+
+const (
+	// OwnerPD means the scheduling is owned by the PD leader.
+	OwnerPD = "pd"
+	// OwnerSchedulingService means the scheduling is owned by the primary of the scheduling service.
+	OwnerSchedulingService = "scheduling-service"
+
+	// StateServing means the owner is running the scheduling.
+	StateServing = "serving"
+	// StateReleased means the owner has drained its operators and released the scheduling.
+	StateReleased = "released"
+
+	// DefaultDrainTimeout is the default timeout to wait for the in-flight operators to finish.
+	DefaultDrainTimeout = 10 * time.Second
+	// MinDrainTimeout is the minimum timeout to wait for the in-flight operators to finish
+	// when the owner steps down after its lease has lapsed.
+	MinDrainTimeout = 3 * time.Second
+	// DefaultWaitTimeout is the default timeout to wait for the previous owner to release the scheduling.
+	DefaultWaitTimeout = 15 * time.Second
+
+	waitCheckInterval = 200 * time.Millisecond
+)
+
+// RunningState is the running state transferred from the previous owner to the next one. It
+// only carries the pauses of the checkers and schedulers, which are kept in memory, while the
+// configs of the schedulers are persisted and the other state is rebuilt from the heartbeats.
+type RunningState struct {
+	// PausedCheckers maps the paused checkers to the unix time until which they are paused.
+	PausedCheckers map[string]int64 `json:"paused-checkers,omitempty"`
+	// PausedSchedulers maps the paused schedulers to the unix time until which they are paused.
+	PausedSchedulers map[string]int64 `json:"paused-schedulers,omitempty"`
+}
+
+// Record records who owns the scheduling and when the ownership is switched.
+type Record struct {
+	Owner      string    `json:"owner"`
+	Name       string    `json:"name"`
+	Addr       string    `json:"addr"`
+	State      string    `json:"state"`
+	SwitchTime time.Time `json:"switch-time"`
+	PrevOwner  string    `json:"prev-owner,omitempty"`
+	PrevName   string    `json:"prev-name,omitempty"`
+	// ReleaseTime, CanceledOperators and RunningState are set when the owner releases the scheduling.
+	ReleaseTime       time.Time     `json:"release-time,omitempty"`
+	CanceledOperators int           `json:"canceled-operators,omitempty"`
+	RunningState      *RunningState `json:"running-state,omitempty"`
+
+	// revision is the mod revision of the record in etcd when it's loaded, which is used to
+	// make sure the record is not changed by others when it's replaced.
+	revision int64
+}
+
+// IsSameOwner returns whether the record is owned by the given owner.
+func (r *Record) IsSameOwner(other *Record) bool {
+	return r.Owner == other.Owner && r.Name == other.Name
+}
+
+// Load loads the scheduling owner record. It returns nil if there is no record.
+func Load(cli *clientv3.Client, clusterID uint64) (*Record, error) {
+	resp, err := etcdutil.EtcdKVGet(cli, endpoint.SchedulingOwnerPath(clusterID))
+	if err != nil || len(resp.Kvs) == 0 {
+		return nil, err
+	}
+	record := &Record{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, record); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	record.revision = resp.Kvs[0].ModRevision
+	return record, nil
+}
+
+// replace replaces the previous record with the new one, it only succeeds if the previous
+// record is not changed since it's loaded and the owner still holds the leadership.
+func replace(leadership *election.Leadership, clusterID uint64, prev, record *Record) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	key := endpoint.SchedulingOwnerPath(clusterID)
+	var cmp clientv3.Cmp
+	if prev == nil {
+		cmp = clientv3.Compare(clientv3.CreateRevision(key), "=", 0)
+	} else {
+		cmp = clientv3.Compare(clientv3.ModRevision(key), "=", prev.revision)
+	}
+	resp, err := leadership.LeaderTxn(cmp).Then(clientv3.OpPut(key, string(value))).Commit()
+	if err != nil {
+		return errs.ErrEtcdTxnInternal.Wrap(err).GenWithStackByCause()
+	}
+	if !resp.Succeeded {
+		return errs.ErrEtcdTxnConflict.FastGenByArgs()
+	}
+	record.revision = resp.Header.GetRevision()
+	return nil
+}
+
+// Acquire takes over the scheduling as the given owner, which holds the leadership. If the
+// scheduling is served by another owner, it waits for the previous owner to release the
+// scheduling until the timeout. It's also the case for the owner of the same kind, since the
+// previous one only drains its operators and releases the scheduling after losing the election.
+// The record is replaced only if it's not changed since it's loaded and the owner still holds
+// the leadership, otherwise it's loaded again and retried until the owner loses the leadership.
+// It returns the previous record, whose running state should be resumed.
+func Acquire(ctx context.Context, cli *clientv3.Client, clusterID uint64, leadership *election.Leadership, owner *Record, waitTimeout time.Duration) (*Record, error) {
+	ticker := time.NewTicker(waitCheckInterval)
+	defer ticker.Stop()
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		prev, err := Load(cli, clusterID)
+		if err != nil {
+			return nil, err
+		}
+		// The record served by the same owner is left by its previous term, which has already
+		// been released or failed to, so there is nothing to wait for.
+		for prev != nil && prev.State == StateServing && !prev.IsSameOwner(owner) && time.Now().Before(deadline) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-ticker.C:
+			}
+			if prev, err = Load(cli, clusterID); err != nil {
+				return nil, err
+			}
+		}
+		if prev != nil && prev.State == StateServing && !prev.IsSameOwner(owner) {
+			log.Warn("the previous scheduling owner does not release in time, take over it",
+				zap.String("prev-owner", prev.Owner), zap.String("prev-name", prev.Name))
+		}
+
+		record := &Record{
+			Owner:      owner.Owner,
+			Name:       owner.Name,
+			Addr:       owner.Addr,
+			State:      StateServing,
+			SwitchTime: time.Now(),
+		}
+		if prev != nil {
+			record.PrevOwner, record.PrevName = prev.Owner, prev.Name
+		}
+		err = replace(leadership, clusterID, prev, record)
+		if err == nil {
+			log.Info("acquire the scheduling ownership", zap.String("owner", record.Owner),
+				zap.String("name", record.Name), zap.String("prev-owner", record.PrevOwner), zap.String("prev-name", record.PrevName))
+			return prev, nil
+		}
+		if !leadership.Check() {
+			return nil, err
+		}
+		log.Warn("failed to acquire the scheduling ownership, retry", zap.String("owner", record.Owner),
+			zap.String("name", record.Name), errs.ZapError(err))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Release drains the in-flight operators of the coordinator within the timeout, captures
+// the paused checkers and schedulers, and records that the scheduling is
+// released by the given owner. The record is replaced only if it's not changed since it's
+// loaded and the owner still holds the leadership, so the release is not recorded if the
+// leadership has been lost, and the next owner takes over after its wait timeout.
+func Release(ctx context.Context, cli *clientv3.Client, clusterID uint64, leadership *election.Leadership, owner *Record, co *schedule.Coordinator, drainTimeout time.Duration) (*Record, error) {
+	current, err := Load(cli, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil || !current.IsSameOwner(owner) || current.State == StateReleased {
+		// The scheduling has already been released or taken over by another owner.
+		return current, nil
+	}
+	canceled := co.GetOperatorController().Drain(ctx, drainTimeout)
+	released := *current
+	released.State = StateReleased
+	released.ReleaseTime = time.Now()
+	released.CanceledOperators = canceled
+	released.RunningState = CaptureRunningState(co)
+	if err := replace(leadership, clusterID, current, &released); err != nil {
+		return nil, err
+	}
+	log.Info("release the scheduling ownership", zap.String("owner", released.Owner),
+		zap.String("name", released.Name), zap.Int("canceled-operators", canceled))
+	return &released, nil
+}
+
+// BoundDrainTimeout bounds the timeout to drain the in-flight operators by the remaining
+// time of the lease, which is clamped to [MinDrainTimeout, DefaultDrainTimeout].
+func BoundDrainTimeout(leaseRemaining time.Duration) time.Duration {
+	if leaseRemaining < MinDrainTimeout {
+		return MinDrainTimeout
+	}
+	if leaseRemaining > DefaultDrainTimeout {
+		return DefaultDrainTimeout
+	}
+	return leaseRemaining
+}
+
+// CaptureRunningState captures the paused checkers and schedulers.
+func CaptureRunningState(co *schedule.Coordinator) *RunningState {
+	state := &RunningState{
+		PausedCheckers:   make(map[string]int64),
+		PausedSchedulers: make(map[string]int64),
+	}
+	for _, name := range checker.PausableCheckers {
+		if paused, _ := co.IsCheckerPaused(name); !paused {
+			continue
+		}
+		if delayUntil, err := co.GetPausedCheckerDelayUntil(name); err == nil {
+			state.PausedCheckers[name] = delayUntil
+		}
+	}
+	sc := co.GetSchedulersController()
+	for _, name := range sc.GetSchedulerNames() {
+		if paused, _ := sc.IsSchedulerPaused(name); !paused {
+			continue
+		}
+		if delayUntil, err := sc.GetPausedSchedulerDelayUntil(name); err == nil {
+			state.PausedSchedulers[name] = delayUntil
+		}
+	}
+	return state
+}
+
+// Resume pauses the checkers and schedulers on the coordinator until the same time as
+// before, and the missing ones are skipped.
+func (s *RunningState) Resume(co *schedule.Coordinator) {
+	if s == nil {
+		return
+	}
+	now := time.Now().Unix()
+	for name, delayUntil := range s.PausedCheckers {
+		if delayUntil <= now {
+			continue
+		}
+		if err := co.PauseOrResumeChecker(name, delayUntil-now); err != nil {
+			log.Warn("failed to resume the paused checker", zap.String("checker", name), errs.ZapError(err))
+		}
+	}
+	sc := co.GetSchedulersController()
+	for name, delayUntil := range s.PausedSchedulers {
+		if delayUntil <= now {
+			continue
+		}
+		if err := sc.PauseOrResumeScheduler(name, delayUntil-now); err != nil {
+			log.Warn("failed to resume the paused scheduler", zap.String("scheduler", name), errs.ZapError(err))
+		}
+	}
+}
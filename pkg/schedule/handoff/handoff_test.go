@@ -0,0 +1,145 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handoff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/election"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/hbstream"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+)
+
+func newTestCoordinator(ctx context.Context) *schedule.Coordinator {
+	cluster := mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())
+	return schedule.NewCoordinator(ctx, cluster, hbstream.NewTestHeartbeatStreams(ctx, cluster.ID, cluster, true))
+}
+
+func TestHandoff(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, client, clean := etcdutil.NewTestEtcdCluster(t, 1)
+	defer clean()
+	const clusterID = 1
+
+	record, err := Load(client, clusterID)
+	re.NoError(err)
+	re.Nil(record)
+
+	newLeadership := func(leaderKey, name string) *election.Leadership {
+		leadership := election.NewLeadership(client, leaderKey, name)
+		re.NoError(leadership.Campaign(10, name))
+		return leadership
+	}
+
+	// The PD leader takes over the scheduling for the first time.
+	pd := &Record{Owner: OwnerPD, Name: "pd-1", Addr: "http://127.0.0.1:2379"}
+	pdLeadership := newLeadership("/pd/leader", pd.Name)
+	prev, err := Acquire(ctx, client, clusterID, pdLeadership, pd, time.Second)
+	re.NoError(err)
+	re.Nil(prev)
+	record, err = Load(client, clusterID)
+	re.NoError(err)
+	re.Equal(OwnerPD, record.Owner)
+	re.Equal(StateServing, record.State)
+	re.False(record.SwitchTime.IsZero())
+
+	// The PD leader releases the scheduling with the paused checker.
+	co := newTestCoordinator(ctx)
+	re.NoError(co.PauseOrResumeChecker("merge", 100))
+	record, err = Release(ctx, client, clusterID, pdLeadership, pd, co, 0)
+	re.NoError(err)
+	re.Equal(StateReleased, record.State)
+	re.Contains(record.RunningState.PausedCheckers, "merge")
+	re.True(co.GetOperatorController().IsDraining())
+	// Releasing it again is a no-op.
+	released, err := Release(ctx, client, clusterID, pdLeadership, pd, newTestCoordinator(ctx), 0)
+	re.NoError(err)
+	re.Equal(record.ReleaseTime.Unix(), released.ReleaseTime.Unix())
+	re.Contains(released.RunningState.PausedCheckers, "merge")
+
+	// The scheduling service takes over the scheduling and resumes the running state.
+	scheduling := &Record{Owner: OwnerSchedulingService, Name: "scheduling-1", Addr: "http://127.0.0.1:3379"}
+	prev, err = Acquire(ctx, client, clusterID, newLeadership("/scheduling/primary", scheduling.Name), scheduling, time.Minute)
+	re.NoError(err)
+	re.Equal(StateReleased, prev.State)
+	co = newTestCoordinator(ctx)
+	prev.RunningState.Resume(co)
+	paused, err := co.IsCheckerPaused("merge")
+	re.NoError(err)
+	re.True(paused)
+	record, err = Load(client, clusterID)
+	re.NoError(err)
+	re.Equal(OwnerSchedulingService, record.Owner)
+	re.Equal(OwnerPD, record.PrevOwner)
+	re.Equal("pd-1", record.PrevName)
+
+	// The stale owner can't release the scheduling taken over by others.
+	record, err = Release(ctx, client, clusterID, pdLeadership, pd, newTestCoordinator(ctx), 0)
+	re.NoError(err)
+	re.Equal(StateServing, record.State)
+	re.Equal(OwnerSchedulingService, record.Owner)
+
+	// Take over the scheduling after the timeout if the previous owner doesn't release it.
+	start := time.Now()
+	prev, err = Acquire(ctx, client, clusterID, pdLeadership, pd, 500*time.Millisecond)
+	re.NoError(err)
+	re.Equal(StateServing, prev.State)
+	re.GreaterOrEqual(time.Since(start), 500*time.Millisecond)
+	record, err = Load(client, clusterID)
+	re.NoError(err)
+	re.Equal(OwnerPD, record.Owner)
+
+	// The owner can neither release nor acquire the scheduling after losing the leadership.
+	pdLeadership.Reset()
+	_, err = Release(ctx, client, clusterID, pdLeadership, pd, newTestCoordinator(ctx), 0)
+	re.Error(err)
+	_, err = Acquire(ctx, client, clusterID, pdLeadership, pd, 0)
+	re.Error(err)
+	record, err = Load(client, clusterID)
+	re.NoError(err)
+	re.Equal(StateServing, record.State)
+
+	// The owner of the same kind also waits for the previous owner to release it.
+	pd2 := &Record{Owner: OwnerPD, Name: "pd-2", Addr: "http://127.0.0.1:2380"}
+	pd2Leadership := newLeadership("/pd/leader", pd2.Name)
+	start = time.Now()
+	prev, err = Acquire(ctx, client, clusterID, pd2Leadership, pd2, 500*time.Millisecond)
+	re.NoError(err)
+	re.Equal("pd-1", prev.Name)
+	re.GreaterOrEqual(time.Since(start), 500*time.Millisecond)
+
+	// The same owner doesn't wait for the record left by its previous term.
+	start = time.Now()
+	prev, err = Acquire(ctx, client, clusterID, pd2Leadership, pd2, time.Minute)
+	re.NoError(err)
+	re.Equal("pd-2", prev.Name)
+	re.Less(time.Since(start), time.Minute)
+}
+
+func TestBoundDrainTimeout(t *testing.T) {
+	re := require.New(t)
+	re.Equal(MinDrainTimeout, BoundDrainTimeout(0))
+	re.Equal(MinDrainTimeout, BoundDrainTimeout(time.Second))
+	re.Equal(5*time.Second, BoundDrainTimeout(5*time.Second))
+	re.Equal(DefaultDrainTimeout, BoundDrainTimeout(time.Minute))
+}
@@ -64,6 +64,8 @@ var (
 	ExceedWaitLimit CancelReasonType = "exceed wait limit"
 	// RelatedMergeRegion is the cancel reason when the operator is cancelled by related merge region.
 	RelatedMergeRegion CancelReasonType = "related merge region"
+	// Draining is the cancel reason when the operator controller is draining for the scheduling handoff.
+	Draining CancelReasonType = "draining"
 	// Unknown is the cancel reason when the operator is cancelled by an unknown reason.
 	Unknown CancelReasonType = "unknown"
 )
@@ -46,6 +46,7 @@ const (
 var (
 	slowNotifyInterval = 5 * time.Second
 	fastNotifyInterval = 2 * time.Second
+	drainCheckInterval = 100 * time.Millisecond
 	// StoreBalanceBaseTime represents the base time of balance rate.
 	StoreBalanceBaseTime float64 = 60
 	// FastOperatorFinishTime min finish time, if finish duration less than it, op will be pushed to fast operator queue
@@ -66,6 +67,8 @@ type Controller struct {
 	wop             WaitingOperator
 	wopStatus       *waitingOperatorStatus
 	opNotifierQueue operatorQueue
+	// draining is set when the scheduling is handed off, no new operator will be accepted then.
+	draining bool
 }
 
 // NewController creates a Controller.
@@ -397,12 +400,19 @@ func (oc *Controller) PromoteWaitingOperator() {
 
 // checkAddOperator checks if the operator can be added.
 // There are several situations that cannot be added:
+// - The controller is draining
 // - There is no such region in the cluster
 // - The epoch of the operator and the epoch of the corresponding region are no longer consistent.
 // - The region already has a higher priority or same priority
 // - Exceed the max number of waiting operators
 // - At least one operator is expired.
 func (oc *Controller) checkAddOperator(isPromoting bool, ops ...*Operator) (bool, CancelReasonType) {
+	if oc.draining {
+		for _, op := range ops {
+			operatorCounter.WithLabelValues(op.Desc(), "draining").Inc()
+		}
+		return false, Draining
+	}
 	for _, op := range ops {
 		region := oc.cluster.GetRegion(op.RegionID())
 		if region == nil {
@@ -560,6 +570,65 @@ func (oc *Controller) ack(op *Operator) {
 	}
 }
 
+// SetDraining sets whether the controller is draining. A draining controller
+// rejects all the new operators.
+func (oc *Controller) SetDraining(draining bool) {
+	oc.Lock()
+	defer oc.Unlock()
+	oc.draining = draining
+}
+
+// IsDraining returns whether the controller is draining.
+func (oc *Controller) IsDraining() bool {
+	oc.RLock()
+	defer oc.RUnlock()
+	return oc.draining
+}
+
+// Drain stops accepting new operators and waits for the running operators to finish
+// within the timeout. The waiting operators and the operators still running after the
+// timeout are canceled. It returns the number of the canceled running operators.
+func (oc *Controller) Drain(ctx context.Context, timeout time.Duration) int {
+	oc.SetDraining(true)
+	// The waiting operators can't be promoted anymore, so they are all canceled here.
+	oc.PromoteWaitingOperator()
+	if timeout > 0 {
+		oc.waitOperatorsFinished(ctx, timeout)
+	}
+	canceled := 0
+	for _, op := range oc.GetOperators() {
+		if oc.RemoveOperator(op, Draining) {
+			canceled++
+		}
+	}
+	if canceled > 0 {
+		log.Info("operators canceled by draining", zap.Int("count", canceled))
+	}
+	return canceled
+}
+
+func (oc *Controller) waitOperatorsFinished(ctx context.Context, timeout time.Duration) {
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		oc.RLock()
+		running := len(oc.operators)
+		oc.RUnlock()
+		if running == 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // RemoveOperator removes an operator from the running operators.
 func (oc *Controller) RemoveOperator(op *Operator, reasons ...CancelReasonType) bool {
 	oc.Lock()
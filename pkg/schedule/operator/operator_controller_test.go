@@ -941,3 +941,37 @@ func (suite *operatorControllerTestSuite) TestInvalidStoreId() {
 	// Although store 3 does not exist in PD, PD can also send op to TiKV.
 	suite.Equal(pdpb.OperatorStatus_RUNNING, oc.GetOperatorStatus(1).Status)
 }
+
+func (suite *operatorControllerTestSuite) TestDrain() {
+	opt := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(suite.ctx, opt)
+	stream := hbstream.NewTestHeartbeatStreams(suite.ctx, tc.ID, tc, false /* no need to run */)
+	oc := NewController(suite.ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), stream)
+	tc.AddLeaderStore(1, 2)
+	tc.AddLeaderStore(2, 1)
+	tc.AddLeaderRegion(1, 1, 2)
+	tc.AddLeaderRegion(2, 1, 2)
+
+	op1 := NewTestOperator(1, &metapb.RegionEpoch{}, OpLeader, TransferLeader{ToStore: 2})
+	suite.True(oc.AddOperator(op1))
+	// The operator finished during draining is not canceled.
+	go func() {
+		time.Sleep(2 * drainCheckInterval)
+		suite.True(oc.RemoveOperator(op1))
+	}()
+	suite.Equal(0, oc.Drain(suite.ctx, time.Minute))
+	suite.True(oc.IsDraining())
+
+	// No new operator is accepted when draining.
+	op2 := NewTestOperator(2, &metapb.RegionEpoch{}, OpLeader, TransferLeader{ToStore: 2})
+	suite.False(oc.AddOperator(op2))
+	suite.Equal(CANCELED, op2.Status())
+
+	// The running operators are canceled after the timeout.
+	oc.SetDraining(false)
+	op3 := NewTestOperator(2, &metapb.RegionEpoch{}, OpLeader, TransferLeader{ToStore: 2})
+	suite.True(oc.AddOperator(op3))
+	suite.Equal(1, oc.Drain(suite.ctx, drainCheckInterval))
+	suite.Equal(CANCELED, op3.Status())
+	suite.Empty(oc.GetOperators())
+}
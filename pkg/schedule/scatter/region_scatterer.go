@@ -39,7 +39,13 @@ import (
 	"go.uber.org/zap"
 )
 
-const regionScatterName = "region-scatter"
+const (
+	regionScatterName = "region-scatter"
+	// ScatterRegionDesc is the description of the operator to scatter the peers and leader of a region.
+	ScatterRegionDesc = "scatter-region"
+	// ScatterLeaderDesc is the description of the operator to scatter the leader of a region.
+	ScatterLeaderDesc = "scatter-leader"
+)
 
 var (
 	gcInterval = time.Minute
@@ -100,6 +106,21 @@ func (s *selectedStores) Get(id uint64, group string) uint64 {
 	return count
 }
 
+// Restore adds the distribution to the group, which is used to recover the distribution
+// after the leader is changed.
+func (s *selectedStores) Restore(group string, distribution map[uint64]uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result, ok := s.getDistributionByGroupLocked(group)
+	if !ok {
+		result = make(map[uint64]uint64, len(distribution))
+	}
+	for id, count := range distribution {
+		result[id] += count
+	}
+	s.groupDistribution.Put(group, result)
+}
+
 // GetGroupDistribution get distribution group by `group`
 func (s *selectedStores) GetGroupDistribution(group string) (map[uint64]uint64, bool) {
 	s.mu.RLock()
@@ -265,26 +286,76 @@ func (r *RegionScatterer) scatterRegions(regions map[uint64]*core.RegionInfo, fa
 // Scatter relocates the region. If the group is defined, the regions' leader with the same group would be scattered
 // in a group level instead of cluster level.
 func (r *RegionScatterer) Scatter(region *core.RegionInfo, group string, skipStoreLimit bool) (*operator.Operator, error) {
+	if err := r.checkRegion(region); err != nil {
+		return nil, err
+	}
+	return r.scatterRegion(region, group, skipStoreLimit)
+}
+
+// ScatterLeader relocates the leader of the region among its peers. If the group is defined, the regions' leader
+// with the same group would be scattered in a group level instead of cluster level.
+func (r *RegionScatterer) ScatterLeader(region *core.RegionInfo, group string) (*operator.Operator, error) {
+	if err := r.checkRegion(region); err != nil {
+		return nil, err
+	}
+	engineFilter := filter.NewEngineFilter(r.name, filter.NotSpecialEngines)
+	fit := r.cluster.GetRuleManager().FitRegion(r.cluster, region)
+	leaderCandidateStores := make([]uint64, 0, len(region.GetPeers()))
+	for _, peer := range region.GetPeers() {
+		store := r.cluster.GetStore(peer.GetStoreId())
+		if store == nil || !engineFilter.Target(r.cluster.GetSharedConfig(), store).IsOK() {
+			continue
+		}
+		if allowLeader(fit, peer) {
+			leaderCandidateStores = append(leaderCandidateStores, peer.GetStoreId())
+		}
+	}
+	targetLeader, leaderStorePickedCount := r.selectAvailableLeaderStore(group, region, leaderCandidateStores, r.ordinaryEngine)
+	if targetLeader == 0 {
+		scatterSkipNoLeaderCounter.Inc()
+		return nil, errs.ErrGetTargetStore.FastGenByArgs(fmt.Sprintf("no target leader store found, region: %v", region))
+	}
+	sourceLeader := region.GetLeader().GetStoreId()
+	if targetLeader == sourceLeader {
+		scatterUnnecessaryCounter.Inc()
+		r.putLeader(targetLeader, group)
+		return nil, nil
+	}
+	op, err := operator.CreateTransferLeaderOperator(ScatterLeaderDesc, r.cluster, region, sourceLeader, targetLeader, []uint64{}, operator.OpLeader)
+	if err != nil {
+		scatterFailCounter.Inc()
+		r.putLeader(sourceLeader, group)
+		log.Debug("fail to create scatter leader operator", errs.ZapError(err))
+		return nil, errs.ErrCreateOperator.FastGenByArgs(fmt.Sprintf("failed to create scatter leader operator for region %v", region.GetID()))
+	}
+	scatterSuccessCounter.Inc()
+	r.putLeader(targetLeader, group)
+	op.AdditionalInfos["group"] = group
+	op.AdditionalInfos["leader-picked-count"] = strconv.FormatUint(leaderStorePickedCount, 10)
+	op.SetPriorityLevel(constant.High)
+	return op, nil
+}
+
+func (r *RegionScatterer) checkRegion(region *core.RegionInfo) error {
 	if !filter.IsRegionReplicated(r.cluster, region) {
 		r.addSuspectRegions(region.GetID())
 		scatterSkipNotReplicatedCounter.Inc()
 		log.Warn("region not replicated during scatter", zap.Uint64("region-id", region.GetID()))
-		return nil, errors.Errorf("region %d is not fully replicated", region.GetID())
+		return errors.Errorf("region %d is not fully replicated", region.GetID())
 	}
 
 	if region.GetLeader() == nil {
 		scatterSkipNoLeaderCounter.Inc()
 		log.Warn("region no leader during scatter", zap.Uint64("region-id", region.GetID()))
-		return nil, errors.Errorf("region %d has no leader", region.GetID())
+		return errors.Errorf("region %d has no leader", region.GetID())
 	}
 
 	if r.cluster.IsRegionHot(region) {
 		scatterSkipHotRegionCounter.Inc()
 		log.Warn("region too hot during scatter", zap.Uint64("region-id", region.GetID()))
-		return nil, errors.Errorf("region %d is hot", region.GetID())
+		return errors.Errorf("region %d is hot", region.GetID())
 	}
-
-	return r.scatterRegion(region, group, skipStoreLimit)
+	return nil
 }
 
 func (r *RegionScatterer) scatterRegion(region *core.RegionInfo, group string, skipStoreLimit bool) (*operator.Operator, error) {
@@ -377,7 +448,7 @@ func (r *RegionScatterer) scatterRegion(region *core.RegionInfo, group string, s
 		r.Put(targetPeers, targetLeader, group)
 		return nil, nil
 	}
-	op, err := operator.CreateScatterRegionOperator("scatter-region", r.cluster, region, targetPeers, targetLeader, skipStoreLimit)
+	op, err := operator.CreateScatterRegionOperator(ScatterRegionDesc, r.cluster, region, targetPeers, targetLeader, skipStoreLimit)
 	if err != nil {
 		scatterFailCounter.Inc()
 		for _, peer := range region.GetPeers() {
@@ -529,9 +600,19 @@ func (r *RegionScatterer) Put(peers map[uint64]*metapb.Peer, leaderStoreID uint6
 				engine).Inc()
 		}
 	}
+	r.putLeader(leaderStoreID, group)
+}
+
+func (r *RegionScatterer) putLeader(leaderStoreID uint64, group string) {
 	r.ordinaryEngine.selectedLeader.Put(leaderStoreID, group)
 	scatterDistributionCounter.WithLabelValues(
 		fmt.Sprintf("%v", leaderStoreID),
 		fmt.Sprintf("%v", true),
 		core.EngineTiKV).Inc()
 }
+
+// RestoreGroupDistribution adds the peer and leader distribution of the ordinary stores to the group.
+func (r *RegionScatterer) RestoreGroupDistribution(group string, peers, leaders map[uint64]uint64) {
+	r.ordinaryEngine.selectedPeer.Restore(group, peers)
+	r.ordinaryEngine.selectedLeader.Restore(group, leaders)
+}
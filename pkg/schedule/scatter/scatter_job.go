@@ -0,0 +1,399 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scatter
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/errs"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"go.uber.org/zap"
+)
+
+// JobStatus is the status of a scatter job.
+type JobStatus string
+
+const (
+	// JobRunning means the regions of the job are being scattered.
+	JobRunning JobStatus = "running"
+	// JobFinished means all regions of the job have been scattered.
+	JobFinished JobStatus = "finished"
+	// JobCanceled means the job is canceled before finished.
+	JobCanceled JobStatus = "canceled"
+)
+
+const (
+	// jobBatchSize is the max number of regions scattered by a job at a time. The next
+	// batch is started after all operators of the current batch are finished.
+	jobBatchSize = 64
+	// maxCompletedJobs is the max number of the finished or canceled jobs to keep.
+	maxCompletedJobs = 32
+)
+
+// Job is a persistent job to scatter the regions in a key range. The progress of the job is
+// persisted after each batch, so that it can be continued after the leader is changed.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type Job struct {
+	ID       uint64 `json:"id"`
+	StartKey string `json:"start-key"`
+	EndKey   string `json:"end-key"`
+	Group    string `json:"group"`
+	// ScatterPeer means the peers and leaders are scattered, otherwise only the leaders are
+	// scattered among the existing peers.
+	ScatterPeer bool      `json:"scatter-peer"`
+	Status      JobStatus `json:"status"`
+	CreateTime  time.Time `json:"create-time"`
+	UpdateTime  time.Time `json:"update-time"`
+	// Cursor is the start key of the regions to be scattered by the next batch.
+	Cursor string `json:"cursor"`
+	// Scattered is the number of the scattered regions, including the ones already in place.
+	Scattered int `json:"scattered"`
+	Failed    int `json:"failed"`
+	// PeerDistribution and LeaderDistribution are the number of peers and leaders of the
+	// scattered regions on each store.
+	PeerDistribution   map[uint64]uint64 `json:"peer-distribution"`
+	LeaderDistribution map[uint64]uint64 `json:"leader-distribution"`
+}
+
+func (j *Job) clone() *Job {
+	job := *j
+	job.PeerDistribution = make(map[uint64]uint64, len(j.PeerDistribution))
+	for storeID, count := range j.PeerDistribution {
+		job.PeerDistribution[storeID] = count
+	}
+	job.LeaderDistribution = make(map[uint64]uint64, len(j.LeaderDistribution))
+	for storeID, count := range j.LeaderDistribution {
+		job.LeaderDistribution[storeID] = count
+	}
+	return &job
+}
+
+// jobState is the in-memory state of a running job.
+type jobState struct {
+	*Job
+	startKey, endKey []byte
+	// inBatch means the current batch is being scattered. batch is the regions
+	// scattered by the current batch, batchOps is the operators created for them
+	// and batchEnd is the cursor after the batch.
+	inBatch     bool
+	batch       []uint64
+	batchOps    map[uint64]*operator.Operator
+	batchEnd    []byte
+	batchFailed int
+	lastBatch   bool
+}
+
+// JobManager manages the scatter jobs.
+type JobManager struct {
+	syncutil.RWMutex
+	cluster      sche.SharedCluster
+	storage      endpoint.ScatterJobStorage
+	scatterer    *RegionScatterer
+	opController *operator.Controller
+	jobs         map[uint64]*jobState
+	nextID       uint64
+}
+
+// NewJobManager creates a scatter job manager.
+func NewJobManager(cluster sche.SharedCluster, storage endpoint.ScatterJobStorage, scatterer *RegionScatterer, opController *operator.Controller) *JobManager {
+	return &JobManager{
+		cluster:      cluster,
+		storage:      storage,
+		scatterer:    scatterer,
+		opController: opController,
+		jobs:         make(map[uint64]*jobState),
+		nextID:       1,
+	}
+}
+
+// Load loads the jobs from storage. The distribution of the running jobs is restored
+// to the scatterer, and their unfinished batches are scattered again.
+func (m *JobManager) Load() error {
+	m.Lock()
+	defer m.Unlock()
+	var err error
+	if loadErr := m.storage.LoadScatterJobs(func(k, v string) {
+		job := &Job{}
+		if e := json.Unmarshal([]byte(v), job); e != nil {
+			err = errs.ErrJSONUnmarshal.Wrap(e).GenWithStackByCause()
+			return
+		}
+		state, e := newJobState(job)
+		if e != nil {
+			err = errs.ErrHexDecodingString.Wrap(e).GenWithStackByCause()
+			return
+		}
+		m.jobs[job.ID] = state
+		if job.ID >= m.nextID {
+			m.nextID = job.ID + 1
+		}
+		if job.Status == JobRunning {
+			m.scatterer.RestoreGroupDistribution(job.Group, job.PeerDistribution, job.LeaderDistribution)
+		}
+	}); loadErr != nil {
+		return loadErr
+	}
+	return err
+}
+
+func newJobState(job *Job) (*jobState, error) {
+	startKey, err := hex.DecodeString(job.StartKey)
+	if err != nil {
+		return nil, err
+	}
+	endKey, err := hex.DecodeString(job.EndKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := hex.DecodeString(job.Cursor); err != nil {
+		return nil, err
+	}
+	if job.PeerDistribution == nil {
+		job.PeerDistribution = make(map[uint64]uint64)
+	}
+	if job.LeaderDistribution == nil {
+		job.LeaderDistribution = make(map[uint64]uint64)
+	}
+	return &jobState{Job: job, startKey: startKey, endKey: endKey}, nil
+}
+
+// CreateJob creates a job to scatter the regions in [startKey, endKey) within the group.
+// If scatterPeer is false, only the leaders are scattered.
+func (m *JobManager) CreateJob(startKey, endKey []byte, group string, scatterPeer bool) (*Job, error) {
+	if len(endKey) != 0 && bytes.Compare(startKey, endKey) >= 0 {
+		return nil, errs.ErrInvalidScatterJobRange.FastGenByArgs(hex.EncodeToString(startKey), hex.EncodeToString(endKey))
+	}
+	m.Lock()
+	defer m.Unlock()
+	now := time.Now()
+	job := &Job{
+		ID:                 m.nextID,
+		StartKey:           hex.EncodeToString(startKey),
+		EndKey:             hex.EncodeToString(endKey),
+		Group:              group,
+		ScatterPeer:        scatterPeer,
+		Status:             JobRunning,
+		CreateTime:         now,
+		UpdateTime:         now,
+		Cursor:             hex.EncodeToString(startKey),
+		PeerDistribution:   make(map[uint64]uint64),
+		LeaderDistribution: make(map[uint64]uint64),
+	}
+	if err := m.storage.SaveScatterJob(job.ID, job); err != nil {
+		return nil, err
+	}
+	m.nextID++
+	m.jobs[job.ID] = &jobState{Job: job, startKey: startKey, endKey: endKey}
+	log.Info("scatter job is created",
+		zap.Uint64("job-id", job.ID),
+		zap.String("start-key", job.StartKey),
+		zap.String("end-key", job.EndKey),
+		zap.String("group", group),
+		zap.Bool("scatter-peer", scatterPeer))
+	return job.clone(), nil
+}
+
+// GetJob returns the job with the given ID.
+func (m *JobManager) GetJob(id uint64) (*Job, error) {
+	m.RLock()
+	defer m.RUnlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, errs.ErrScatterJobNotFound.FastGenByArgs(id)
+	}
+	return job.clone(), nil
+}
+
+// GetJobs returns all jobs sorted by ID.
+func (m *JobManager) GetJobs() []*Job {
+	m.RLock()
+	defer m.RUnlock()
+	jobs := make([]*Job, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, job.clone())
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	return jobs
+}
+
+// CancelJob cancels the running job, and stops the scatter operators of its current batch.
+func (m *JobManager) CancelJob(id uint64) error {
+	m.Lock()
+	defer m.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return errs.ErrScatterJobNotFound.FastGenByArgs(id)
+	}
+	if job.Status != JobRunning {
+		return errs.ErrScatterJobNotRunning.FastGenByArgs(id, job.Status)
+	}
+	for _, regionID := range job.batch {
+		if op := m.opController.GetOperator(regionID); op != nil && isScatterOperator(op) {
+			m.opController.RemoveOperator(op, operator.AdminStop)
+		}
+	}
+	job.resetBatch()
+	if err := m.complete(job, JobCanceled); err != nil {
+		return err
+	}
+	log.Info("scatter job is canceled", zap.Uint64("job-id", id))
+	return nil
+}
+
+// Run makes progress on the running jobs in the order of their IDs.
+func (m *JobManager) Run() {
+	m.Lock()
+	defer m.Unlock()
+	ids := make([]uint64, 0, len(m.jobs))
+	for id, job := range m.jobs {
+		if job.Status == JobRunning {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		if err := m.runJob(m.jobs[id]); err != nil {
+			log.Warn("failed to run scatter job", zap.Uint64("job-id", id), errs.ZapError(err))
+		}
+	}
+}
+
+func (m *JobManager) runJob(job *jobState) error {
+	if job.inBatch {
+		for _, regionID := range job.batch {
+			if op := m.opController.GetOperator(regionID); op != nil && isScatterOperator(op) {
+				// Wait for the current batch to finish.
+				return nil
+			}
+		}
+		if err := m.finishBatch(job); err != nil || job.Status != JobRunning {
+			return err
+		}
+	}
+	return m.startBatch(job)
+}
+
+func (m *JobManager) startBatch(job *jobState) error {
+	cursor, _ := hex.DecodeString(job.Cursor)
+	regions := m.cluster.ScanRegions(cursor, job.endKey, jobBatchSize)
+	if len(regions) == 0 {
+		return m.complete(job, JobFinished)
+	}
+	job.batch, job.batchOps, job.batchFailed = job.batch[:0], make(map[uint64]*operator.Operator), 0
+	for _, region := range regions {
+		var (
+			op  *operator.Operator
+			err error
+		)
+		if job.ScatterPeer {
+			op, err = m.scatterer.Scatter(region, job.Group, false)
+		} else {
+			op, err = m.scatterer.ScatterLeader(region, job.Group)
+		}
+		if err == nil && op != nil && !m.opController.AddOperator(op) {
+			err = errs.ErrAddOperator
+		}
+		if err != nil {
+			job.batchFailed++
+			log.Debug("failed to scatter region in scatter job",
+				zap.Uint64("job-id", job.ID), zap.Uint64("region-id", region.GetID()), errs.ZapError(err))
+			continue
+		}
+		job.batch = append(job.batch, region.GetID())
+		if op != nil {
+			job.batchOps[region.GetID()] = op
+		}
+	}
+	last := regions[len(regions)-1].GetEndKey()
+	job.inBatch, job.batchEnd = true, last
+	job.lastBatch = len(last) == 0 || (len(job.endKey) != 0 && bytes.Compare(last, job.endKey) >= 0)
+	return nil
+}
+
+// finishBatch records the distribution of the regions scattered by the current batch,
+// and persists the progress. The regions whose operators are not succeeded, e.g. canceled
+// or timed out, are counted as failed.
+func (m *JobManager) finishBatch(job *jobState) error {
+	for _, regionID := range job.batch {
+		if op, ok := job.batchOps[regionID]; ok && op.Status() != operator.SUCCESS {
+			job.Failed++
+			continue
+		}
+		job.Scattered++
+		region := m.cluster.GetRegion(regionID)
+		if region == nil {
+			continue
+		}
+		for storeID := range region.GetStoreIDs() {
+			job.PeerDistribution[storeID]++
+		}
+		job.LeaderDistribution[region.GetLeader().GetStoreId()]++
+	}
+	job.Failed += job.batchFailed
+	job.Cursor = hex.EncodeToString(job.batchEnd)
+	lastBatch := job.lastBatch
+	job.resetBatch()
+	if lastBatch {
+		return m.complete(job, JobFinished)
+	}
+	job.UpdateTime = time.Now()
+	return m.storage.SaveScatterJob(job.ID, job.Job)
+}
+
+// complete marks the job as finished or canceled, and removes the oldest completed jobs.
+func (m *JobManager) complete(job *jobState, status JobStatus) error {
+	job.Status = status
+	job.UpdateTime = time.Now()
+	if err := m.storage.SaveScatterJob(job.ID, job.Job); err != nil {
+		return err
+	}
+	if status == JobFinished {
+		log.Info("scatter job is finished", zap.Uint64("job-id", job.ID),
+			zap.Int("scattered", job.Scattered), zap.Int("failed", job.Failed))
+	}
+	completed := make([]uint64, 0, len(m.jobs))
+	for id, job := range m.jobs {
+		if job.Status != JobRunning {
+			completed = append(completed, id)
+		}
+	}
+	if len(completed) <= maxCompletedJobs {
+		return nil
+	}
+	sort.Slice(completed, func(i, j int) bool { return completed[i] < completed[j] })
+	for _, id := range completed[:len(completed)-maxCompletedJobs] {
+		if err := m.storage.DeleteScatterJob(id); err != nil {
+			return err
+		}
+		delete(m.jobs, id)
+	}
+	return nil
+}
+
+func (job *jobState) resetBatch() {
+	job.inBatch, job.batch, job.batchOps, job.batchEnd, job.batchFailed, job.lastBatch = false, nil, nil, nil, 0, false
+}
+
+func isScatterOperator(op *operator.Operator) bool {
+	return op.Desc() == ScatterRegionDesc || op.Desc() == ScatterLeaderDesc
+}
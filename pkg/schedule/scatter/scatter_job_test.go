@@ -0,0 +1,171 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scatter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule/hbstream"
+	"github.com/tikv/pd/pkg/schedule/operator"
+)
+
+func jobTestKey(i int) []byte {
+	return []byte(fmt.Sprintf("k%03d", i))
+}
+
+// putJobTestRegion puts a region [k<id>, k<id+1>) with the peers on stores 1, 2 and 3.
+func putJobTestRegion(tc *mockcluster.Cluster, id uint64, endKey []byte) {
+	peers := make([]*metapb.Peer, 0, 3)
+	for storeID := uint64(1); storeID <= 3; storeID++ {
+		peers = append(peers, &metapb.Peer{Id: id*10 + storeID, StoreId: storeID})
+	}
+	tc.PutRegion(core.NewRegionInfo(&metapb.Region{
+		Id:          id,
+		StartKey:    jobTestKey(int(id)),
+		EndKey:      endKey,
+		Peers:       peers,
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1},
+	}, peers[0], core.SetApproximateSize(96), core.SetApproximateKeys(10)))
+}
+
+func applyScatterOperators(tc *mockcluster.Cluster, oc *operator.Controller) {
+	for _, op := range oc.GetOperators() {
+		operator.ApplyOperator(tc, op)
+		oc.RemoveOperator(op)
+	}
+}
+
+func TestScatterJob(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())
+	stream := hbstream.NewTestHeartbeatStreams(ctx, tc.ID, tc, false)
+	oc := operator.NewController(ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), stream)
+	for storeID := uint64(1); storeID <= 5; storeID++ {
+		tc.AddRegionStore(storeID, 0)
+	}
+	for id := uint64(1); id <= 4; id++ {
+		putJobTestRegion(tc, id, jobTestKey(int(id)+1))
+	}
+	// The region out of the job range is not scattered.
+	putJobTestRegion(tc, 5, nil)
+
+	m := NewJobManager(tc, tc.GetStorage(), NewRegionScatterer(ctx, tc, oc, tc.AddSuspectRegions), oc)
+	_, err := m.CreateJob(jobTestKey(5), jobTestKey(1), "test", true)
+	re.True(errs.ErrInvalidScatterJobRange.Equal(err))
+	job, err := m.CreateJob(jobTestKey(1), jobTestKey(5), "test", true)
+	re.NoError(err)
+	re.Equal(uint64(1), job.ID)
+	re.Equal(JobRunning, job.Status)
+
+	m.Run()
+	re.True(m.jobs[1].inBatch)
+	re.Len(m.jobs[1].batch, 4)
+	re.Nil(oc.GetOperator(5))
+	// The job waits for the operators of the current batch.
+	re.NotEmpty(oc.GetOperators())
+	m.Run()
+	re.True(m.jobs[1].inBatch)
+	re.Zero(m.jobs[1].Scattered)
+	applyScatterOperators(tc, oc)
+	m.Run()
+	job, err = m.GetJob(1)
+	re.NoError(err)
+	re.Equal(JobFinished, job.Status)
+	re.Equal(4, job.Scattered)
+	re.Zero(job.Failed)
+	re.Equal(fmt.Sprintf("%x", jobTestKey(5)), job.Cursor)
+	var peers, leaders uint64
+	for _, count := range job.PeerDistribution {
+		peers += count
+	}
+	for _, count := range job.LeaderDistribution {
+		leaders += count
+	}
+	re.Equal(uint64(12), peers)
+	re.Equal(uint64(4), leaders)
+
+	re.True(errs.ErrScatterJobNotRunning.Equal(m.CancelJob(1)))
+	re.True(errs.ErrScatterJobNotFound.Equal(m.CancelJob(100)))
+	_, err = m.GetJob(100)
+	re.True(errs.ErrScatterJobNotFound.Equal(err))
+
+	// The running job is continued by the new manager after reloading, e.g. the leader is changed.
+	job, err = m.CreateJob(jobTestKey(1), nil, "test", false)
+	re.NoError(err)
+	re.Equal(uint64(2), job.ID)
+	m2 := NewJobManager(tc, tc.GetStorage(), NewRegionScatterer(ctx, tc, oc, tc.AddSuspectRegions), oc)
+	re.NoError(m2.Load())
+	jobs := m2.GetJobs()
+	re.Len(jobs, 2)
+	re.Equal(JobFinished, jobs[0].Status)
+	re.Equal(job.ID, jobs[1].ID)
+	re.Equal(JobRunning, jobs[1].Status)
+	re.False(jobs[1].ScatterPeer)
+	m2.Run()
+	re.Len(m2.jobs[2].batch, 5)
+	for _, op := range oc.GetOperators() {
+		re.Equal(ScatterLeaderDesc, op.Desc())
+	}
+	re.NoError(m2.CancelJob(2))
+	re.Empty(oc.GetOperators())
+	job, err = m2.GetJob(2)
+	re.NoError(err)
+	re.Equal(JobCanceled, job.Status)
+	job, err = m2.CreateJob(nil, nil, "", true)
+	re.NoError(err)
+	re.Equal(uint64(3), job.ID)
+}
+
+func TestScatterJobCanceledOperator(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())
+	stream := hbstream.NewTestHeartbeatStreams(ctx, tc.ID, tc, false)
+	oc := operator.NewController(ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), stream)
+	for storeID := uint64(1); storeID <= 5; storeID++ {
+		tc.AddRegionStore(storeID, 0)
+	}
+	for id := uint64(1); id <= 4; id++ {
+		putJobTestRegion(tc, id, jobTestKey(int(id)+1))
+	}
+
+	m := NewJobManager(tc, tc.GetStorage(), NewRegionScatterer(ctx, tc, oc, tc.AddSuspectRegions), oc)
+	_, err := m.CreateJob(jobTestKey(1), jobTestKey(5), "test", true)
+	re.NoError(err)
+	m.Run()
+	ops := oc.GetOperators()
+	re.NotEmpty(ops)
+	// The regions whose operators are canceled are not counted as scattered.
+	for _, op := range ops {
+		oc.RemoveOperator(op, operator.AdminStop)
+	}
+	m.Run()
+	job, err := m.GetJob(1)
+	re.NoError(err)
+	re.Equal(JobFinished, job.Status)
+	re.Equal(len(ops), job.Failed)
+	re.Equal(4-len(ops), job.Scattered)
+}
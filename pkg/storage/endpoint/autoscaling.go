@@ -0,0 +1,78 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"encoding/json"
+
+	"github.com/tikv/pd/pkg/errs"
+)
+
+// AutoScalingStorage defines the storage operations on the auto scaling strategy and plan history.
+type AutoScalingStorage interface {
+	LoadAutoScalingStrategy(strategy interface{}) (bool, error)
+	SaveAutoScalingStrategy(strategy interface{}) error
+	RemoveAutoScalingStrategy() error
+	LoadAutoScalingPlans(startTS, endTS int64, limit int, f func(k, v string)) error
+	SaveAutoScalingPlans(ts int64, plans interface{}) error
+	RemoveAutoScalingPlans(ts int64) error
+}
+
+var _ AutoScalingStorage = (*StorageEndpoint)(nil)
+
+// LoadAutoScalingStrategy loads the auto scaling strategy from storage then unmarshal it to strategy.
+func (se *StorageEndpoint) LoadAutoScalingStrategy(strategy interface{}) (bool, error) {
+	value, err := se.Load(autoScalingStrategyPath)
+	if err != nil || value == "" {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(value), strategy); err != nil {
+		return false, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return true, nil
+}
+
+// SaveAutoScalingStrategy stores the auto scaling strategy to storage.
+func (se *StorageEndpoint) SaveAutoScalingStrategy(strategy interface{}) error {
+	return se.saveJSON(autoScalingStrategyPath, strategy)
+}
+
+// RemoveAutoScalingStrategy removes the auto scaling strategy from storage.
+func (se *StorageEndpoint) RemoveAutoScalingStrategy() error {
+	return se.Remove(autoScalingStrategyPath)
+}
+
+// LoadAutoScalingPlans loads the plans evaluated in [startTS, endTS) from storage in the order of the time.
+// The limit is the max number of the loaded plans, and 0 means no limit.
+func (se *StorageEndpoint) LoadAutoScalingPlans(startTS, endTS int64, limit int, f func(k, v string)) error {
+	keys, values, err := se.LoadRange(autoScalingPlansKeyPath(startTS), autoScalingPlansKeyPath(endTS), limit)
+	if err != nil {
+		return err
+	}
+	for i := range keys {
+		f(keys[i], values[i])
+	}
+	return nil
+}
+
+// SaveAutoScalingPlans stores the plans evaluated at the timestamp to storage.
+func (se *StorageEndpoint) SaveAutoScalingPlans(ts int64, plans interface{}) error {
+	return se.saveJSON(autoScalingPlansKeyPath(ts), plans)
+}
+
+// RemoveAutoScalingPlans removes the plans evaluated at the timestamp from storage.
+func (se *StorageEndpoint) RemoveAutoScalingPlans(ts int64) error {
+	return se.Remove(autoScalingPlansKeyPath(ts))
+}
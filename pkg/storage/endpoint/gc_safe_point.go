@@ -42,6 +42,9 @@ type GCSafePointStorage interface {
 	LoadAllServiceGCSafePoints() ([]*ServiceSafePoint, error)
 	SaveServiceGCSafePoint(ssp *ServiceSafePoint) error
 	RemoveServiceGCSafePoint(serviceID string) error
+	LoadExpiredServiceGCSafePoint(serviceID string) (*ServiceSafePoint, error)
+	SaveExpiredServiceGCSafePoint(ssp *ServiceSafePoint) error
+	RemoveExpiredServiceGCSafePoint(serviceID string) error
 }
 
 var _ GCSafePointStorage = (*StorageEndpoint)(nil)
@@ -186,3 +189,34 @@ func (se *StorageEndpoint) RemoveServiceGCSafePoint(serviceID string) error {
 	key := gcSafePointServicePath(serviceID)
 	return se.Remove(key)
 }
+
+// LoadExpiredServiceGCSafePoint loads the force expired GC safepoint of the service.
+// It returns nil if the service safepoint is not force expired.
+func (se *StorageEndpoint) LoadExpiredServiceGCSafePoint(serviceID string) (*ServiceSafePoint, error) {
+	value, err := se.Load(gcSafePointExpiredServicePath(serviceID))
+	if err != nil || value == "" {
+		return nil, err
+	}
+	ssp := &ServiceSafePoint{}
+	if err := json.Unmarshal([]byte(value), ssp); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return ssp, nil
+}
+
+// SaveExpiredServiceGCSafePoint saves the force expired GC safepoint of the service.
+func (se *StorageEndpoint) SaveExpiredServiceGCSafePoint(ssp *ServiceSafePoint) error {
+	if ssp.ServiceID == "" {
+		return errors.New("service id of service safepoint cannot be empty")
+	}
+	value, err := json.Marshal(ssp)
+	if err != nil {
+		return err
+	}
+	return se.Save(gcSafePointExpiredServicePath(ssp.ServiceID), string(value))
+}
+
+// RemoveExpiredServiceGCSafePoint removes the force expired GC safepoint of the service.
+func (se *StorageEndpoint) RemoveExpiredServiceGCSafePoint(serviceID string) error {
+	return se.Remove(gcSafePointExpiredServicePath(serviceID))
+}
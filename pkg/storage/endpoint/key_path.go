@@ -36,6 +36,9 @@ const (
 	regionLabelPath           = "region_label"
 	replicationPath           = "replication_mode"
 	customSchedulerConfigPath = "scheduler_config"
+	scatterJobPath            = "scatter_job"
+	autoScalingStrategyPath   = "autoscaling/strategy"
+	autoScalingPlansPath      = "autoscaling/plans"
 	// GCWorkerServiceSafePointID is the service id of GC worker.
 	GCWorkerServiceSafePointID = "gc_worker"
 	minResolvedTS              = "min_resolved_ts"
@@ -53,16 +56,21 @@ const (
 	resourceGroupSettingsPath = "settings"
 	resourceGroupStatesPath   = "states"
 	controllerConfigPath      = "controller"
+	runawayViolationsPath     = "runaway_violations"
 	// tso storage endpoint has prefix `tso`
 	tsoServiceKey                = utils.TSOServiceName
 	globalTSOAllocatorEtcdPrefix = "gta"
 	// TimestampKey is the key of timestamp oracle used for the suffix.
 	TimestampKey = "timestamp"
+	// TimestampLayoutKey is the key of the timestamp layout used for the suffix.
+	TimestampLayoutKey = "timestamp_layout"
 
 	tsoKeyspaceGroupPrefix      = tsoServiceKey + "/" + utils.KeyspaceGroupsKey
 	keyspaceGroupsMembershipKey = "membership"
 	keyspaceGroupsElectionKey   = "election"
 
+	schedulingOwnerKey = "owner"
+
 	// we use uint64 to represent ID, the max length of uint64 is 20.
 	keyLen = 20
 )
@@ -173,6 +181,18 @@ func resourceGroupStateKeyPath(groupName string) string {
 	return path.Join(resourceGroupStatesPath, groupName)
 }
 
+func runawayViolationsKeyPath(groupName string) string {
+	return path.Join(runawayViolationsPath, groupName)
+}
+
+func scatterJobKeyPath(jobID uint64) string {
+	return path.Join(scatterJobPath, fmt.Sprintf("%020d", jobID))
+}
+
+func autoScalingPlansKeyPath(timestamp int64) string {
+	return path.Join(autoScalingPlansPath, fmt.Sprintf("%020d", timestamp))
+}
+
 func ruleKeyPath(ruleKey string) string {
 	return path.Join(rulesPath, ruleKey)
 }
@@ -202,6 +222,10 @@ func gcSafePointServicePath(serviceID string) string {
 	return path.Join(gcSafePointPath(), "service", serviceID)
 }
 
+func gcSafePointExpiredServicePath(serviceID string) string {
+	return path.Join(gcSafePointPath(), "expired_service", serviceID)
+}
+
 // MinResolvedTSPath returns the min resolved ts path.
 func MinResolvedTSPath() string {
 	return path.Join(clusterPath, minResolvedTS)
@@ -331,6 +355,12 @@ func SchedulingPrimaryPath(clusterID uint64) string {
 	return path.Join(SchedulingSvcRootPath(clusterID), utils.PrimaryKey)
 }
 
+// SchedulingOwnerPath returns the path of the scheduling owner.
+// Path: /ms/{cluster_id}/scheduling/owner
+func SchedulingOwnerPath(clusterID uint64) string {
+	return path.Join(SchedulingSvcRootPath(clusterID), schedulingOwnerKey)
+}
+
 // KeyspaceGroupsElectionPath returns the path of keyspace groups election.
 // default keyspace group: "/ms/{cluster_id}/tso/00000".
 // non-default keyspace group: "/ms/{cluster_id}/tso/keyspace_groups/election/{group}".
@@ -396,6 +426,11 @@ func TimestampPath(tsPath string) string {
 	return path.Join(tsPath, TimestampKey)
 }
 
+// TimestampLayoutPath returns the timestamp layout path for the given timestamp oracle path prefix.
+func TimestampLayoutPath(tsPath string) string {
+	return path.Join(tsPath, TimestampLayoutKey)
+}
+
 // FullTimestampPath returns the full timestamp path.
 //  1. for the default keyspace group:
 //     /pd/{cluster_id}/timestamp
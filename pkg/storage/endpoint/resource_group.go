@@ -28,6 +28,9 @@ type ResourceGroupStorage interface {
 	DeleteResourceGroupStates(name string) error
 	SaveControllerConfig(config interface{}) error
 	LoadControllerConfig() (string, error)
+	SaveRunawayViolations(name string, stats interface{}) error
+	LoadRunawayViolations(f func(k, v string)) error
+	DeleteRunawayViolations(name string) error
 }
 
 var _ ResourceGroupStorage = (*StorageEndpoint)(nil)
@@ -71,3 +74,18 @@ func (se *StorageEndpoint) SaveControllerConfig(config interface{}) error {
 func (se *StorageEndpoint) LoadControllerConfig() (string, error) {
 	return se.Load(controllerConfigPath)
 }
+
+// SaveRunawayViolations stores the aggregated runaway violations of a resource group to storage.
+func (se *StorageEndpoint) SaveRunawayViolations(name string, stats interface{}) error {
+	return se.saveJSON(runawayViolationsKeyPath(name), stats)
+}
+
+// LoadRunawayViolations loads the aggregated runaway violations of all resource groups from storage.
+func (se *StorageEndpoint) LoadRunawayViolations(f func(k, v string)) error {
+	return se.loadRangeByPrefix(runawayViolationsPath+"/", f)
+}
+
+// DeleteRunawayViolations removes the aggregated runaway violations of a resource group from storage.
+func (se *StorageEndpoint) DeleteRunawayViolations(name string) error {
+	return se.Remove(runawayViolationsKeyPath(name))
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+// ScatterJobStorage defines the storage operations on the scatter jobs.
+type ScatterJobStorage interface {
+	LoadScatterJobs(f func(k, v string)) error
+	SaveScatterJob(jobID uint64, job interface{}) error
+	DeleteScatterJob(jobID uint64) error
+}
+
+var _ ScatterJobStorage = (*StorageEndpoint)(nil)
+
+// LoadScatterJobs loads all scatter jobs from storage.
+func (se *StorageEndpoint) LoadScatterJobs(f func(k, v string)) error {
+	return se.loadRangeByPrefix(scatterJobPath+"/", f)
+}
+
+// SaveScatterJob stores a scatter job to storage.
+func (se *StorageEndpoint) SaveScatterJob(jobID uint64, job interface{}) error {
+	return se.saveJSON(scatterJobKeyPath(jobID), job)
+}
+
+// DeleteScatterJob removes a scatter job from storage.
+func (se *StorageEndpoint) DeleteScatterJob(jobID uint64) error {
+	return se.Remove(scatterJobKeyPath(jobID))
+}
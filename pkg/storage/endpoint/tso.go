@@ -32,6 +32,8 @@ type TSOStorage interface {
 	LoadTimestamp(prefix string) (time.Time, error)
 	SaveTimestamp(key string, ts time.Time) error
 	DeleteTimestamp(key string) error
+	LoadTimestampLayout(key string) (string, error)
+	SaveTimestampLayout(key string, layout string) error
 }
 
 var _ TSOStorage = (*StorageEndpoint)(nil)
@@ -97,3 +99,14 @@ func (se *StorageEndpoint) DeleteTimestamp(key string) error {
 		return txn.Remove(key)
 	})
 }
+
+// LoadTimestampLayout loads the timestamp layout from the storage.
+// An empty string will be returned if the layout has never been saved.
+func (se *StorageEndpoint) LoadTimestampLayout(key string) (string, error) {
+	return se.Load(key)
+}
+
+// SaveTimestampLayout saves the timestamp layout to the storage.
+func (se *StorageEndpoint) SaveTimestampLayout(key string, layout string) error {
+	return se.Save(key, layout)
+}
@@ -53,6 +53,8 @@ func (s *RegionSyncer) reset() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.syncedTime.Store(0)
+
 	if s.mu.clientCancel != nil {
 		s.mu.clientCancel()
 	}
@@ -61,7 +63,8 @@ func (s *RegionSyncer) reset() {
 
 func (s *RegionSyncer) syncRegion(ctx context.Context, conn *grpc.ClientConn) (ClientStream, error) {
 	cli := pdpb.NewPDClient(conn)
-	syncStream, err := cli.SyncRegions(ctx)
+	// Declare the features supported, the leader will send the delta-encoded and compressed regions.
+	syncStream, err := cli.SyncRegions(withFeatures(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +82,30 @@ func (s *RegionSyncer) syncRegion(ctx context.Context, conn *grpc.ClientConn) (C
 
 var regionGuide = core.GenerateRegionGuideFunc(false)
 
+// GetAppliedIndex returns the index of the next region record to be synced from the leader.
+func (s *RegionSyncer) GetAppliedIndex() uint64 {
+	return s.history.GetNextIndex()
+}
+
+// GetStaleness returns how far the regions synced from the leader may lag behind the leader.
+// A keepalive carries the next history index of the leader when it's sent, and the follower is
+// confirmed to have applied the whole history of the leader at that moment only if its own next
+// index is the same. The changes received in between don't confirm anything since the leader may
+// have more changes not sent yet, so the staleness is the time since the last confirmation. It
+// returns false if the follower has not caught up with the leader yet.
+func (s *RegionSyncer) GetStaleness() (time.Duration, bool) {
+	syncedTime := s.syncedTime.Load()
+	if syncedTime == 0 {
+		return 0, false
+	}
+	return time.Since(time.Unix(0, syncedTime)), true
+}
+
+// markSynced records that the follower is up to date with the leader at the given time.
+func (s *RegionSyncer) markSynced(now time.Time) {
+	s.syncedTime.Store(now.UnixNano())
+}
+
 // StartSyncWithLeader starts to sync with leader.
 func (s *RegionSyncer) StartSyncWithLeader(addr string) {
 	s.wg.Add(1)
@@ -135,8 +162,10 @@ func (s *RegionSyncer) StartSyncWithLeader(addr string) {
 			default:
 			}
 
-			stream, err := s.syncRegion(ctx, conn)
+			streamCtx, streamCancel := context.WithCancel(ctx)
+			stream, err := s.syncRegion(streamCtx, conn)
 			if err != nil {
+				streamCancel()
 				if ev, ok := status.FromError(err); ok {
 					if ev.Code() == codes.Canceled {
 						return
@@ -161,6 +190,7 @@ func (s *RegionSyncer) StartSyncWithLeader(addr string) {
 					if err = stream.CloseSend(); err != nil {
 						log.Warn("failed to terminate client stream", errs.ZapError(errs.ErrGRPCCloseSend, err))
 					}
+					streamCancel()
 					timerutil.SafeResetTimer(timer, retryInterval)
 					select {
 					case <-ctx.Done():
@@ -170,6 +200,9 @@ func (s *RegionSyncer) StartSyncWithLeader(addr string) {
 					}
 					break
 				}
+				// The keepalive following the own index confirms that the follower has caught up
+				// with the history of the leader.
+				caughtUp := len(resp.GetRegions()) == 0 && s.history.GetNextIndex() == resp.GetStartIndex()
 				if s.history.GetNextIndex() != resp.GetStartIndex() {
 					log.Warn("server sync index not match the leader",
 						zap.String("server", s.server.Name()),
@@ -178,57 +211,86 @@ func (s *RegionSyncer) StartSyncWithLeader(addr string) {
 						zap.Int("records-length", len(resp.GetRegions())))
 					// reset index
 					s.history.ResetWithIndex(resp.GetStartIndex())
+					s.syncedTime.Store(0)
 				}
-				stats := resp.GetRegionStats()
-				regions := resp.GetRegions()
-				buckets := resp.GetBuckets()
-				regionLeaders := resp.GetRegionLeaders()
-				hasStats := len(stats) == len(regions)
-				hasBuckets := len(buckets) == len(regions)
-				for i, r := range regions {
-					var (
-						region       *core.RegionInfo
-						regionLeader *metapb.Peer
-					)
-					if len(regionLeaders) > i && regionLeaders[i].GetId() != 0 {
-						regionLeader = regionLeaders[i]
-					}
-					if hasStats {
-						region = core.NewRegionInfo(r, regionLeader,
-							core.SetWrittenBytes(stats[i].BytesWritten),
-							core.SetWrittenKeys(stats[i].KeysWritten),
-							core.SetReadBytes(stats[i].BytesRead),
-							core.SetReadKeys(stats[i].KeysRead),
-							core.SetSource(core.Sync),
-						)
-					} else {
-						region = core.NewRegionInfo(r, regionLeader, core.SetSource(core.Sync))
-					}
-
-					origin, _, err := bc.PreCheckPutRegion(region)
-					if err != nil {
-						log.Debug("region is stale", zap.Stringer("origin", origin.GetMeta()), errs.ZapError(err))
-						continue
-					}
-					saveKV, _, _ := regionGuide(region, origin)
-					overlaps := bc.PutRegion(region)
-
-					if hasBuckets {
-						if old := origin.GetBuckets(); buckets[i].GetVersion() > old.GetVersion() {
-							region.UpdateBuckets(buckets[i], old)
-						}
-					}
-					if saveKV {
-						err = regionStorage.SaveRegion(r)
-					}
-					if err == nil {
-						s.history.Record(region)
-					}
-					for _, old := range overlaps {
-						_ = regionStorage.DeleteRegion(old.GetMeta())
-					}
+				if missed, ok := s.applyRegions(bc, regionStorage, resp); !ok {
+					// The follower missed some changes of the region, so the delta-encoded region can't
+					// be restored. Re-establish the stream from the missed record, the leader sends the
+					// full regions of the history or the snapshot to the follower then.
+					regionSyncerDeltaMissCounter.Inc()
+					log.Info("failed to decode the delta-encoded region, resync with leader",
+						zap.String("server", s.server.Name()), zap.Uint64("resync-index", missed))
+					s.history.ResetWithIndex(missed)
+					s.syncedTime.Store(0)
+					streamCancel()
+					break
+				}
+				if caughtUp {
+					s.markSynced(time.Now())
 				}
 			}
 		}
 	}()
 }
+
+// applyRegions applies the regions synced from the leader to the local cache and storage. It stops
+// and returns the history index of the region if a delta-encoded region can't be restored with the
+// local one, false is returned then.
+func (s *RegionSyncer) applyRegions(bc *core.BasicCluster, regionStorage storage.Storage, resp *pdpb.SyncRegionResponse) (uint64, bool) {
+	stats := resp.GetRegionStats()
+	regions := resp.GetRegions()
+	buckets := resp.GetBuckets()
+	regionLeaders := resp.GetRegionLeaders()
+	hasStats := len(stats) == len(regions)
+	hasBuckets := len(buckets) == len(regions)
+	for i, r := range regions {
+		var (
+			region       *core.RegionInfo
+			regionLeader *metapb.Peer
+		)
+		if isDeltaRegionMeta(r) {
+			if r = decodeRegionMeta(r, bc.GetRegion(r.GetId())); r == nil {
+				log.Debug("failed to decode the delta-encoded region", zap.Uint64("region-id", regions[i].GetId()))
+				return resp.GetStartIndex() + uint64(i), false
+			}
+		}
+		if len(regionLeaders) > i && regionLeaders[i].GetId() != 0 {
+			regionLeader = regionLeaders[i]
+		}
+		if hasStats {
+			region = core.NewRegionInfo(r, regionLeader,
+				core.SetWrittenBytes(stats[i].BytesWritten),
+				core.SetWrittenKeys(stats[i].KeysWritten),
+				core.SetReadBytes(stats[i].BytesRead),
+				core.SetReadKeys(stats[i].KeysRead),
+				core.SetSource(core.Sync),
+			)
+		} else {
+			region = core.NewRegionInfo(r, regionLeader, core.SetSource(core.Sync))
+		}
+
+		origin, _, err := bc.PreCheckPutRegion(region)
+		if err != nil {
+			log.Debug("region is stale", zap.Stringer("origin", origin.GetMeta()), errs.ZapError(err))
+			continue
+		}
+		saveKV, _, _ := regionGuide(region, origin)
+		overlaps := bc.PutRegion(region)
+
+		if hasBuckets {
+			if old := origin.GetBuckets(); buckets[i].GetVersion() > old.GetVersion() {
+				region.UpdateBuckets(buckets[i], old)
+			}
+		}
+		if saveKV {
+			err = regionStorage.SaveRegion(r)
+		}
+		if err == nil {
+			s.history.Record(region)
+		}
+		for _, old := range overlaps {
+			_ = regionStorage.DeleteRegion(old.GetMeta())
+		}
+	}
+	return 0, true
+}
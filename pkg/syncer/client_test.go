@@ -83,3 +83,52 @@ func TestErrorCode(t *testing.T) {
 	re.True(ok)
 	re.Equal(codes.Canceled, ev.Code())
 }
+
+func TestStaleness(t *testing.T) {
+	re := require.New(t)
+	server := mockserver.NewMockServer(
+		context.Background(),
+		nil,
+		nil,
+		storage.NewStorageWithMemoryBackend(),
+		core.NewBasicCluster(),
+	)
+	rc := NewRegionSyncer(server)
+	_, synced := rc.GetStaleness()
+	re.False(synced)
+	rc.markSynced(time.Now().Add(-time.Second))
+	staleness, synced := rc.GetStaleness()
+	re.True(synced)
+	re.GreaterOrEqual(staleness, time.Second)
+	re.Less(staleness, time.Minute)
+	rc.reset()
+	_, synced = rc.GetStaleness()
+	re.False(synced)
+}
+
+func TestApplyDeltaRegions(t *testing.T) {
+	re := require.New(t)
+	server := mockserver.NewMockServer(
+		context.Background(),
+		nil,
+		nil,
+		storage.NewStorageWithMemoryBackend(),
+		core.NewBasicCluster(),
+	)
+	rc := NewRegionSyncer(server)
+	bc := server.GetBasicCluster()
+	prev, cur := newTestRegion(1, 1, 1), newTestRegion(1, 1, 2)
+	full := newSyncRegionResponse(server.ClusterID(), 5, []*core.RegionInfo{cur})
+	resp := newDeltaSyncRegionResponse(full, []*core.RegionInfo{prev}, []*core.RegionInfo{cur})
+
+	// The region is missing locally, the follower needs to resync from its record.
+	missed, ok := rc.applyRegions(bc, server.GetStorage(), resp)
+	re.False(ok)
+	re.Equal(uint64(5), missed)
+	re.Nil(bc.GetRegion(1))
+
+	bc.PutRegion(prev)
+	_, ok = rc.applyRegions(bc, server.GetStorage(), resp)
+	re.True(ok)
+	re.Equal(uint64(2), bc.GetRegion(1).GetLeader().GetStoreId())
+}
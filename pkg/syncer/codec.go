@@ -0,0 +1,169 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/tikv/pd/pkg/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// featuresMetadataKey is the gRPC metadata key used by the follower to declare the
+	// features of the region syncer it supports.
+	featuresMetadataKey = "pd-region-syncer-features"
+	// featureDelta indicates the follower understands the delta-encoded regions.
+	featureDelta = "delta"
+)
+
+// withFeatures declares the features supported by the follower.
+func withFeatures(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, featuresMetadataKey, featureDelta)
+}
+
+// supportDelta returns whether the follower of the stream understands the delta-encoded regions.
+func supportDelta(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, feature := range md.Get(featuresMetadataKey) {
+		if feature == featureDelta {
+			return true
+		}
+	}
+	return false
+}
+
+// trySetCompressor compresses the responses with gzip if the follower is able to decompress it.
+func trySetCompressor(ctx context.Context) bool {
+	for _, name := range grpc.ClientSupportedCompressors(ctx) {
+		if name == gzip.Name {
+			return grpc.SetSendCompressor(ctx, gzip.Name) == nil
+		}
+	}
+	return false
+}
+
+// encodeRegionMeta returns the delta-encoded meta of the region if its meta is not changed since
+// the previous record of the same region, which only carries the ID, the epoch and the flashback
+// state. A valid region always has peers, so the follower regards the meta without any peer as a
+// delta-encoded one, and restores it with the region in its own cache.
+func encodeRegionMeta(prev, cur *core.RegionInfo) *metapb.Region {
+	meta := cur.GetMeta()
+	if prev == nil || len(meta.GetPeers()) == 0 || !proto.Equal(prev.GetMeta(), meta) {
+		return meta
+	}
+	return &metapb.Region{
+		Id:               meta.GetId(),
+		RegionEpoch:      meta.GetRegionEpoch(),
+		IsInFlashback:    meta.GetIsInFlashback(),
+		FlashbackStartTs: meta.GetFlashbackStartTs(),
+	}
+}
+
+// encodeBuckets returns empty buckets if the buckets are not changed since the previous record of
+// the same region. The follower only updates the buckets with a newer version.
+func encodeBuckets(prev, cur *core.RegionInfo) *metapb.Buckets {
+	buckets := cur.GetBuckets()
+	if buckets == nil || (prev != nil && prev.GetBuckets().GetVersion() == buckets.GetVersion()) {
+		// bucket should not be nil to avoid grpc marshal panic.
+		return &metapb.Buckets{}
+	}
+	return buckets
+}
+
+func isDeltaRegionMeta(meta *metapb.Region) bool {
+	return len(meta.GetPeers()) == 0
+}
+
+// decodeRegionMeta restores the delta-encoded meta with the region in the local cache. It returns
+// nil if the local region is missing or has a different epoch, which means the follower missed
+// some changes of the region.
+func decodeRegionMeta(delta *metapb.Region, local *core.RegionInfo) *metapb.Region {
+	if local == nil {
+		return nil
+	}
+	meta := local.GetMeta()
+	if meta.GetRegionEpoch().GetVersion() != delta.GetRegionEpoch().GetVersion() ||
+		meta.GetRegionEpoch().GetConfVer() != delta.GetRegionEpoch().GetConfVer() {
+		return nil
+	}
+	return &metapb.Region{
+		Id:               delta.GetId(),
+		StartKey:         meta.GetStartKey(),
+		EndKey:           meta.GetEndKey(),
+		RegionEpoch:      delta.GetRegionEpoch(),
+		Peers:            meta.GetPeers(),
+		EncryptionMeta:   meta.GetEncryptionMeta(),
+		IsInFlashback:    delta.GetIsInFlashback(),
+		FlashbackStartTs: delta.GetFlashbackStartTs(),
+	}
+}
+
+// newSyncRegionResponse builds the response carrying the full information of the regions.
+func newSyncRegionResponse(clusterID, startIndex uint64, regions []*core.RegionInfo) *pdpb.SyncRegionResponse {
+	metas := make([]*metapb.Region, len(regions))
+	stats := make([]*pdpb.RegionStat, len(regions))
+	leaders := make([]*metapb.Peer, len(regions))
+	buckets := make([]*metapb.Buckets, len(regions))
+	for i, r := range regions {
+		metas[i] = r.GetMeta()
+		stats[i] = r.GetStat()
+		leader := &metapb.Peer{}
+		if r.GetLeader() != nil {
+			leader = r.GetLeader()
+		}
+		leaders[i] = leader
+		// bucket should not be nil to avoid grpc marshal panic.
+		buckets[i] = &metapb.Buckets{}
+		if r.GetBuckets() != nil {
+			buckets[i] = r.GetBuckets()
+		}
+	}
+	return &pdpb.SyncRegionResponse{
+		Header:        &pdpb.ResponseHeader{ClusterId: clusterID},
+		Regions:       metas,
+		StartIndex:    startIndex,
+		RegionStats:   stats,
+		RegionLeaders: leaders,
+		Buckets:       buckets,
+	}
+}
+
+// newDeltaSyncRegionResponse builds the response carrying only the changed fields of the regions
+// comparing with their previous records. It shares the stats and leaders with the full response.
+func newDeltaSyncRegionResponse(full *pdpb.SyncRegionResponse, prevs, regions []*core.RegionInfo) *pdpb.SyncRegionResponse {
+	metas := make([]*metapb.Region, len(regions))
+	buckets := make([]*metapb.Buckets, len(regions))
+	for i, r := range regions {
+		metas[i] = encodeRegionMeta(prevs[i], r)
+		buckets[i] = encodeBuckets(prevs[i], r)
+	}
+	return &pdpb.SyncRegionResponse{
+		Header:        full.GetHeader(),
+		Regions:       metas,
+		StartIndex:    full.GetStartIndex(),
+		RegionStats:   full.GetRegionStats(),
+		RegionLeaders: full.GetRegionLeaders(),
+		Buckets:       buckets,
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/core"
+)
+
+func newTestRegion(version, bucketVersion uint64, leaderStore uint64) *core.RegionInfo {
+	peers := []*metapb.Peer{{Id: 11, StoreId: 1}, {Id: 12, StoreId: 2}, {Id: 13, StoreId: 3}}
+	meta := &metapb.Region{
+		Id:          1,
+		StartKey:    []byte("a"),
+		EndKey:      []byte("z"),
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: version},
+		Peers:       peers,
+	}
+	return core.NewRegionInfo(meta, peers[leaderStore-1],
+		core.SetBuckets(&metapb.Buckets{RegionId: 1, Version: bucketVersion, Keys: [][]byte{[]byte("a"), []byte("m"), []byte("z")}}))
+}
+
+func TestDeltaEncoding(t *testing.T) {
+	re := require.New(t)
+	prev := newTestRegion(1, 1, 1)
+	// The leader is changed only.
+	cur := newTestRegion(1, 1, 2)
+	delta := encodeRegionMeta(prev, cur)
+	re.True(isDeltaRegionMeta(delta))
+	re.Empty(delta.GetStartKey())
+	re.Empty(encodeBuckets(prev, cur).GetKeys())
+	decoded := decodeRegionMeta(delta, prev)
+	re.Equal(cur.GetMeta(), decoded)
+	// The local region has a different epoch.
+	re.Nil(decodeRegionMeta(delta, newTestRegion(2, 1, 1)))
+	re.Nil(decodeRegionMeta(delta, nil))
+
+	// The meta and the buckets are changed.
+	cur = newTestRegion(2, 2, 1)
+	re.Equal(cur.GetMeta(), encodeRegionMeta(prev, cur))
+	re.Equal(cur.GetBuckets(), encodeBuckets(prev, cur))
+	// There is no previous record.
+	re.Equal(cur.GetMeta(), encodeRegionMeta(nil, cur))
+	re.Equal(cur.GetBuckets(), encodeBuckets(nil, cur))
+
+	regions := []*core.RegionInfo{newTestRegion(1, 1, 2), cur}
+	full := newSyncRegionResponse(1, 10, regions)
+	deltaResp := newDeltaSyncRegionResponse(full, []*core.RegionInfo{prev, nil}, regions)
+	re.Equal(full.GetStartIndex(), deltaResp.GetStartIndex())
+	re.Equal(full.GetRegionLeaders(), deltaResp.GetRegionLeaders())
+	re.True(isDeltaRegionMeta(deltaResp.GetRegions()[0]))
+	re.False(isDeltaRegionMeta(deltaResp.GetRegions()[1]))
+	re.Less(deltaResp.Size(), full.Size())
+}
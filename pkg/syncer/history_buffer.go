@@ -46,6 +46,8 @@ type historyBuffer struct {
 	size       int
 	kv         kv.Base
 	flushCount int
+	// latest is the index of the latest record of each region in the buffer.
+	latest map[uint64]uint64
 }
 
 func newHistoryBuffer(size int, kv kv.Base) *historyBuffer {
@@ -88,6 +90,13 @@ func (h *historyBuffer) Record(r *core.RegionInfo) {
 	h.Lock()
 	defer h.Unlock()
 	syncIndexGauge.Set(float64(h.index))
+	// The record in the head will be overwritten if the buffer is full.
+	if h.len() == h.size-1 {
+		if old := h.records[h.head]; h.latest[old.GetID()] == h.firstIndex() {
+			delete(h.latest, old.GetID())
+		}
+	}
+	h.latest[r.GetID()] = h.index
 	h.records[h.tail] = r
 	h.tail = (h.tail + 1) % h.size
 	if h.tail == h.head {
@@ -124,6 +133,18 @@ func (h *historyBuffer) ResetWithIndex(index uint64) {
 	h.head = 0
 	h.tail = 0
 	h.flushCount = defaultFlushCount
+	h.latest = make(map[uint64]uint64)
+}
+
+// GetLatestRecord returns the latest record of the region in the buffer, nil if there is none.
+func (h *historyBuffer) GetLatestRecord(regionID uint64) *core.RegionInfo {
+	h.RLock()
+	defer h.RUnlock()
+	index, ok := h.latest[regionID]
+	if !ok {
+		return nil
+	}
+	return h.get(index)
 }
 
 func (h *historyBuffer) GetNextIndex() uint64 {
@@ -146,14 +167,29 @@ func (h *historyBuffer) reload() {
 		log.Warn("load history index failed", zap.String("error", err.Error()))
 	}
 	if v != "" {
-		h.index, err = strconv.ParseUint(v, 10, 64)
+		index, err := strconv.ParseUint(v, 10, 64)
 		if err != nil {
 			log.Fatal("load history index failed", errs.ZapError(errs.ErrStrconvParseUint, err))
 		}
+		// The records in the buffer are not persisted, drop them if they don't match the loaded index.
+		if index != h.index {
+			h.index, h.head, h.tail = index, 0, 0
+		}
 	}
+	h.rebuildLatest()
 	log.Info("start from history index", zap.Uint64("start-index", h.firstIndex()))
 }
 
+// rebuildLatest rebuilds the index of the latest record of each region from the records in the buffer.
+func (h *historyBuffer) rebuildLatest() {
+	h.latest = make(map[uint64]uint64, h.len())
+	index := h.firstIndex()
+	for i := h.head; i != h.tail; i = (i + 1) % h.size {
+		h.latest[h.records[i].GetID()] = index
+		index++
+	}
+}
+
 func (h *historyBuffer) persist() {
 	firstIndexGauge.Set(float64(h.firstIndex()))
 	lastIndexGauge.Set(float64(h.nextIndex()))
@@ -86,3 +86,53 @@ func TestBufferSize(t *testing.T) {
 	re.Equal(uint64(7), h2.firstIndex())
 	re.Equal(regions[1:], histories)
 }
+
+func TestGetLatestRecord(t *testing.T) {
+	re := require.New(t)
+	h := newHistoryBuffer(3, kv.NewMemoryKV())
+	re.Nil(h.GetLatestRecord(1))
+	r1 := core.NewRegionInfo(&metapb.Region{Id: 1}, nil)
+	r2 := core.NewRegionInfo(&metapb.Region{Id: 2}, nil)
+	r1New := core.NewRegionInfo(&metapb.Region{Id: 1, RegionEpoch: &metapb.RegionEpoch{Version: 1}}, nil)
+	h.Record(r1)
+	h.Record(r2)
+	re.Equal(r1, h.GetLatestRecord(1))
+	h.Record(r1New)
+	re.Equal(r1New, h.GetLatestRecord(1))
+	// The first record of region 1 is overwritten, but the latest one is kept.
+	h.Record(r2)
+	re.Equal(r1New, h.GetLatestRecord(1))
+	re.Equal(r2, h.GetLatestRecord(2))
+	h.Record(r2)
+	h.Record(r2)
+	re.Nil(h.GetLatestRecord(1))
+	re.Equal(r2, h.GetLatestRecord(2))
+
+	h.ResetWithIndex(100)
+	re.Nil(h.GetLatestRecord(2))
+}
+
+func TestReloadLatestRecord(t *testing.T) {
+	re := require.New(t)
+	kvMem := kv.NewMemoryKV()
+	h := newHistoryBuffer(3, kvMem)
+	r1 := core.NewRegionInfo(&metapb.Region{Id: 1}, nil)
+	r2 := core.NewRegionInfo(&metapb.Region{Id: 2}, nil)
+	h.Record(r1)
+	h.Record(r2)
+	h.persist()
+
+	// The records are kept if they match the loaded index.
+	h.reload()
+	re.Equal(r1, h.GetLatestRecord(1))
+	re.Equal(r2, h.GetLatestRecord(2))
+
+	// The records are dropped if they don't match the loaded index.
+	re.NoError(kvMem.Save(historyKey, "10"))
+	h.reload()
+	re.Equal(uint64(10), h.nextIndex())
+	re.Nil(h.GetLatestRecord(1))
+	re.Nil(h.GetLatestRecord(2))
+	h.Record(r1)
+	re.Equal(r1, h.GetLatestRecord(1))
+}
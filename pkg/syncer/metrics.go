@@ -16,14 +16,52 @@ package syncer
 
 import "github.com/prometheus/client_golang/prometheus"
 
-var regionSyncerStatus = prometheus.NewGaugeVec(
-	prometheus.GaugeOpts{
-		Namespace: "pd",
-		Subsystem: "region_syncer",
-		Name:      "status",
-		Help:      "Inner status of the region syncer.",
-	}, []string{"type"})
+var (
+	regionSyncerStatus = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "region_syncer",
+			Name:      "status",
+			Help:      "Inner status of the region syncer.",
+		}, []string{"type"})
+
+	regionSyncerSentBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "region_syncer",
+			Name:      "sent_bytes",
+			Help:      "Counter of the bytes of the regions sent by the region syncer before compression.",
+		}, []string{"type"})
+
+	regionSyncerSavedBytes = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "region_syncer",
+			Name:      "delta_saved_bytes",
+			Help:      "Counter of the bytes saved by the delta encoding of the region syncer.",
+		})
+
+	regionSyncerEventCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "region_syncer",
+			Name:      "events",
+			Help:      "Counter of the region syncer events.",
+		}, []string{"type"})
+)
+
+var (
+	// WithLabelValues is a heavy operation, define variable to avoid call it every time.
+	regionSyncerSentBytesBroadcast = regionSyncerSentBytes.WithLabelValues("broadcast")
+	regionSyncerSentBytesHistory   = regionSyncerSentBytes.WithLabelValues("history")
+	regionSyncerSentBytesSnapshot  = regionSyncerSentBytes.WithLabelValues("snapshot")
+	regionSyncerSnapshotCounter    = regionSyncerEventCounter.WithLabelValues("snapshot")
+	regionSyncerDeltaMissCounter   = regionSyncerEventCounter.WithLabelValues("delta_miss")
+)
 
 func init() {
 	prometheus.MustRegister(regionSyncerStatus)
+	prometheus.MustRegister(regionSyncerSentBytes)
+	prometheus.MustRegister(regionSyncerSavedBytes)
+	prometheus.MustRegister(regionSyncerEventCounter)
 }
@@ -18,12 +18,12 @@ import (
 	"context"
 	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/docker/go-units"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
-	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/pkg/core"
@@ -45,6 +45,10 @@ const (
 	maxSyncRegionBatchSize   = 100
 	syncerKeepAliveInterval  = 10 * time.Second
 	defaultHistoryBufferSize = 10000
+	// followerReadKeepAliveInterval is used instead of syncerKeepAliveInterval if the follower read
+	// is enabled. Every keepalive confirms that the follower has applied the history of the leader,
+	// so it bounds the staleness of the reads served by the follower.
+	followerReadKeepAliveInterval = time.Second
 )
 
 // ClientStream is the client side of the region syncer.
@@ -69,13 +73,21 @@ type Server interface {
 	GetRegions() []*core.RegionInfo
 	GetTLSConfig() *grpcutil.TLSConfig
 	GetBasicCluster() *core.BasicCluster
+	IsFollowerReadEnabled() bool
+}
+
+// downstream is a bound stream of a follower.
+type downstream struct {
+	ServerStream
+	// supportDelta indicates whether the follower understands the delta-encoded regions.
+	supportDelta bool
 }
 
 // RegionSyncer is used to sync the region information without raft.
 type RegionSyncer struct {
 	mu struct {
 		syncutil.RWMutex
-		streams      map[string]ServerStream
+		streams      map[string]downstream
 		clientCtx    context.Context
 		clientCancel context.CancelFunc
 	}
@@ -84,6 +96,9 @@ type RegionSyncer struct {
 	history   *historyBuffer
 	limit     *ratelimit.RateLimiter
 	tlsConfig *grpcutil.TLSConfig
+	// syncedTime is the unix nano time when the follower is confirmed to have applied the whole
+	// history of the leader last time, 0 means the follower has not caught up with the leader.
+	syncedTime atomic.Int64
 }
 
 // NewRegionSyncer returns a region syncer.
@@ -102,23 +117,25 @@ func NewRegionSyncer(s Server) *RegionSyncer {
 		limit:     ratelimit.NewRateLimiter(defaultBucketRate, defaultBucketCapacity),
 		tlsConfig: s.GetTLSConfig(),
 	}
-	syncer.mu.streams = make(map[string]ServerStream)
+	syncer.mu.streams = make(map[string]downstream)
 	return syncer
 }
 
 // RunServer runs the server of the region syncer.
 // regionNotifier is used to get the changed regions.
 func (s *RegionSyncer) RunServer(ctx context.Context, regionNotifier <-chan *core.RegionInfo) {
-	var requests []*metapb.Region
-	var stats []*pdpb.RegionStat
-	var leaders []*metapb.Peer
-	var buckets []*metapb.Buckets
-	ticker := time.NewTicker(syncerKeepAliveInterval)
+	// prevs are the previous records of the changed regions, which are used to do the delta encoding.
+	var regions, prevs []*core.RegionInfo
+	keepAliveInterval := syncerKeepAliveInterval
+	if s.server.IsFollowerReadEnabled() {
+		keepAliveInterval = followerReadKeepAliveInterval
+	}
+	ticker := time.NewTicker(keepAliveInterval)
 
 	defer func() {
 		ticker.Stop()
 		s.mu.Lock()
-		s.mu.streams = make(map[string]ServerStream)
+		s.mu.streams = make(map[string]downstream)
 		s.mu.Unlock()
 	}()
 
@@ -128,51 +145,32 @@ func (s *RegionSyncer) RunServer(ctx context.Context, regionNotifier <-chan *cor
 			log.Info("region syncer has been stopped")
 			return
 		case first := <-regionNotifier:
-			requests = append(requests, first.GetMeta())
-			stats = append(stats, first.GetStat())
-			// bucket should not be nil to avoid grpc marshal panic.
-			bucket := &metapb.Buckets{}
-			if b := first.GetBuckets(); b != nil {
-				bucket = b
-			}
-			buckets = append(buckets, bucket)
-			leaders = append(leaders, first.GetLeader())
 			startIndex := s.history.GetNextIndex()
+			prevs = append(prevs, s.history.GetLatestRecord(first.GetID()))
+			regions = append(regions, first)
 			s.history.Record(first)
 			pending := len(regionNotifier)
 			for i := 0; i < pending && i < maxSyncRegionBatchSize; i++ {
 				region := <-regionNotifier
-				requests = append(requests, region.GetMeta())
-				stats = append(stats, region.GetStat())
-				// bucket should not be nil to avoid grpc marshal panic.
-				bucket := &metapb.Buckets{}
-				if b := region.GetBuckets(); b != nil {
-					bucket = b
-				}
-				buckets = append(buckets, bucket)
-				leaders = append(leaders, region.GetLeader())
+				prevs = append(prevs, s.history.GetLatestRecord(region.GetID()))
+				regions = append(regions, region)
 				s.history.Record(region)
 			}
-			regions := &pdpb.SyncRegionResponse{
-				Header:        &pdpb.ResponseHeader{ClusterId: s.server.ClusterID()},
-				Regions:       requests,
-				StartIndex:    startIndex,
-				RegionStats:   stats,
-				RegionLeaders: leaders,
-				Buckets:       buckets,
+			full := newSyncRegionResponse(s.server.ClusterID(), startIndex, regions)
+			var delta *pdpb.SyncRegionResponse
+			if s.hasDeltaStreams() {
+				delta = newDeltaSyncRegionResponse(full, prevs, regions)
 			}
-			s.broadcast(ctx, regions)
+			s.broadcast(ctx, full, delta)
 		case <-ticker.C:
 			alive := &pdpb.SyncRegionResponse{
 				Header:     &pdpb.ResponseHeader{ClusterId: s.server.ClusterID()},
 				StartIndex: s.history.GetNextIndex(),
 			}
-			s.broadcast(ctx, alive)
+			s.broadcast(ctx, alive, nil)
 		}
-		requests = requests[:0]
-		stats = stats[:0]
-		leaders = leaders[:0]
-		buckets = buckets[:0]
+		regions = regions[:0]
+		prevs = prevs[:0]
 	}
 }
 
@@ -209,15 +207,18 @@ func (s *RegionSyncer) Sync(ctx context.Context, stream pdpb.PD_SyncRegionsServe
 		if clusterID != s.server.ClusterID() {
 			return status.Errorf(codes.FailedPrecondition, "mismatch cluster id, need %d but got %d", s.server.ClusterID(), clusterID)
 		}
+		delta := supportDelta(stream.Context())
+		compressed := trySetCompressor(stream.Context())
 		log.Info("establish sync region stream",
 			zap.String("requested-server", request.GetMember().GetName()),
-			zap.String("url", request.GetMember().GetClientUrls()[0]))
+			zap.String("url", request.GetMember().GetClientUrls()[0]),
+			zap.Bool("delta", delta), zap.Bool("compressed", compressed))
 
 		err = s.syncHistoryRegion(ctx, request, stream)
 		if err != nil {
 			return err
 		}
-		s.bindStream(request.GetMember().GetName(), stream)
+		s.bindStream(request.GetMember().GetName(), stream, delta)
 	}
 }
 
@@ -231,109 +232,107 @@ func (s *RegionSyncer) syncHistoryRegion(ctx context.Context, request *pdpb.Sync
 				zap.String("requested-server", name), zap.String("server", s.server.Name()), zap.Uint64("last-index", startIndex))
 			return nil
 		}
-		// do full synchronization
-		if startIndex == 0 {
-			regions := s.server.GetRegions()
-			lastIndex := 0
-			start := time.Now()
-			metas := make([]*metapb.Region, 0, maxSyncRegionBatchSize)
-			stats := make([]*pdpb.RegionStat, 0, maxSyncRegionBatchSize)
-			leaders := make([]*metapb.Peer, 0, maxSyncRegionBatchSize)
-			buckets := make([]*metapb.Buckets, 0, maxSyncRegionBatchSize)
-			for syncedIndex, r := range regions {
-				select {
-				case <-ctx.Done():
-					log.Info("discontinue sending sync region response")
-					failpoint.Inject("noFastExitSync", func() {
-						failpoint.Goto("doSync")
-					})
-					return nil
-				default:
-				}
-				failpoint.Label("doSync")
-				metas = append(metas, r.GetMeta())
-				stats = append(stats, r.GetStat())
-				leader := &metapb.Peer{}
-				if r.GetLeader() != nil {
-					leader = r.GetLeader()
-				}
-				leaders = append(leaders, leader)
-				bucket := &metapb.Buckets{}
-				if r.GetBuckets() != nil {
-					bucket = r.GetBuckets()
-				}
-				buckets = append(buckets, bucket)
-				if len(metas) < maxSyncRegionBatchSize && syncedIndex < len(regions)-1 {
-					continue
-				}
-				resp := &pdpb.SyncRegionResponse{
-					Header:        &pdpb.ResponseHeader{ClusterId: s.server.ClusterID()},
-					Regions:       metas,
-					StartIndex:    uint64(lastIndex),
-					RegionStats:   stats,
-					RegionLeaders: leaders,
-					Buckets:       buckets,
-				}
-				s.limit.WaitN(ctx, resp.Size())
-				lastIndex += len(metas)
-				if err := stream.Send(resp); err != nil {
-					log.Error("failed to send sync region response", errs.ZapError(errs.ErrGRPCSend, err))
-					return err
-				}
-				metas = metas[:0]
-				stats = stats[:0]
-				leaders = leaders[:0]
-				buckets = buckets[:0]
-			}
-			log.Info("requested server has completed full synchronization with server",
-				zap.String("requested-server", name), zap.String("server", s.server.Name()), zap.Duration("cost", time.Since(start)))
-			return nil
+		// The requested index is out of the history buffer, e.g, the requested server is new,
+		// it lags too much, or the leader is restarted. Do full synchronization by a snapshot.
+		if startIndex != 0 {
+			log.Warn("no history regions from index, sync the snapshot instead",
+				zap.String("requested-server", name), zap.Uint64("index", startIndex))
 		}
-		log.Warn("no history regions from index, the leader may be restarted", zap.Uint64("index", startIndex))
-		return nil
+		return s.syncSnapshot(ctx, name, stream)
 	}
 	log.Info("sync the history regions with server",
 		zap.String("server", name),
 		zap.Uint64("from-index", startIndex),
 		zap.Uint64("last-index", s.history.GetNextIndex()),
 		zap.Int("records-length", len(records)))
-	regions := make([]*metapb.Region, len(records))
-	stats := make([]*pdpb.RegionStat, len(records))
-	leaders := make([]*metapb.Peer, len(records))
-	buckets := make([]*metapb.Buckets, len(records))
-	for i, r := range records {
-		regions[i] = r.GetMeta()
-		stats[i] = r.GetStat()
-		leader := &metapb.Peer{}
-		if r.GetLeader() != nil {
-			leader = r.GetLeader()
+	resp := newSyncRegionResponse(s.server.ClusterID(), startIndex, records)
+	regionSyncerSentBytesHistory.Add(float64(resp.Size()))
+	return stream.Send(resp)
+}
+
+// syncSnapshot sends all the regions in batches, followed by the records appended to the history
+// during sending, i.e, the tail. The index of the snapshot is aligned with the tail, so the
+// requested server can continue to sync with the history after the snapshot.
+func (s *RegionSyncer) syncSnapshot(ctx context.Context, name string, stream ServerStream) error {
+	regionSyncerSnapshotCounter.Inc()
+	tailIndex := s.history.GetNextIndex()
+	regions := s.server.GetRegions()
+	var lastIndex uint64
+	if tailIndex >= uint64(len(regions)) {
+		lastIndex = tailIndex - uint64(len(regions))
+	}
+	start := time.Now()
+	batch := make([]*core.RegionInfo, 0, maxSyncRegionBatchSize)
+	for syncedIndex, r := range regions {
+		select {
+		case <-ctx.Done():
+			log.Info("discontinue sending sync region response")
+			failpoint.Inject("noFastExitSync", func() {
+				failpoint.Goto("doSync")
+			})
+			return nil
+		default:
+		}
+		failpoint.Label("doSync")
+		batch = append(batch, r)
+		if len(batch) < maxSyncRegionBatchSize && syncedIndex < len(regions)-1 {
+			continue
 		}
-		leaders[i] = leader
-		// bucket should not be nil to avoid grpc marshal panic.
-		buckets[i] = &metapb.Buckets{}
-		if r.GetBuckets() != nil {
-			buckets[i] = r.GetBuckets()
+		resp := newSyncRegionResponse(s.server.ClusterID(), lastIndex, batch)
+		s.limit.WaitN(ctx, resp.Size())
+		lastIndex += uint64(len(batch))
+		if err := stream.Send(resp); err != nil {
+			log.Error("failed to send sync region response", errs.ZapError(errs.ErrGRPCSend, err))
+			return err
 		}
+		regionSyncerSentBytesSnapshot.Add(float64(resp.Size()))
+		batch = batch[:0]
 	}
-	resp := &pdpb.SyncRegionResponse{
-		Header:        &pdpb.ResponseHeader{ClusterId: s.server.ClusterID()},
-		Regions:       regions,
-		StartIndex:    startIndex,
-		RegionStats:   stats,
-		RegionLeaders: leaders,
-		Buckets:       buckets,
+	tail := s.history.RecordsFrom(tailIndex)
+	if len(tail) > 0 {
+		resp := newSyncRegionResponse(s.server.ClusterID(), tailIndex, tail)
+		if err := stream.Send(resp); err != nil {
+			log.Error("failed to send sync region response", errs.ZapError(errs.ErrGRPCSend, err))
+			return err
+		}
+		regionSyncerSentBytesHistory.Add(float64(resp.Size()))
 	}
-	return stream.Send(resp)
+	log.Info("requested server has completed full synchronization with server",
+		zap.String("requested-server", name), zap.String("server", s.server.Name()),
+		zap.Int("snapshot-length", len(regions)), zap.Int("tail-length", len(tail)),
+		zap.Duration("cost", time.Since(start)))
+	return nil
 }
 
 // bindStream binds the established server stream.
-func (s *RegionSyncer) bindStream(name string, stream ServerStream) {
+func (s *RegionSyncer) bindStream(name string, stream ServerStream, supportDelta bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.mu.streams[name] = stream
+	s.mu.streams[name] = downstream{ServerStream: stream, supportDelta: supportDelta}
+}
+
+// hasDeltaStreams returns whether any bound stream understands the delta-encoded regions.
+func (s *RegionSyncer) hasDeltaStreams() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, stream := range s.mu.streams {
+		if stream.supportDelta {
+			return true
+		}
+	}
+	return false
 }
 
-func (s *RegionSyncer) broadcast(ctx context.Context, regions *pdpb.SyncRegionResponse) {
+// broadcast sends the regions to all the bound streams. The delta-encoded regions are sent
+// instead to the streams which understand them if they are provided.
+func (s *RegionSyncer) broadcast(ctx context.Context, regions, delta *pdpb.SyncRegionResponse) {
+	var fullSize, deltaSize int
+	if len(regions.GetRegions()) > 0 {
+		fullSize = regions.Size()
+		if delta != nil {
+			deltaSize = delta.Size()
+		}
+	}
 	broadcastDone := make(chan struct{}, 1)
 	go func() {
 		defer logutil.LogPanic()
@@ -347,7 +346,13 @@ func (s *RegionSyncer) broadcast(ctx context.Context, regions *pdpb.SyncRegionRe
 				return
 			default:
 			}
-			err := sender.Send(regions)
+			resp, size := regions, fullSize
+			if delta != nil && sender.supportDelta {
+				resp, size = delta, deltaSize
+				regionSyncerSavedBytes.Add(float64(fullSize - deltaSize))
+			}
+			err := sender.Send(resp)
+			regionSyncerSentBytesBroadcast.Add(float64(size))
 			if err != nil {
 				log.Warn("region syncer send data meet error", errs.ZapError(errs.ErrGRPCSend, err))
 				failed = append(failed, name)
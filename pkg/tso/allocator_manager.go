@@ -40,6 +40,7 @@ import (
 	"github.com/tikv/pd/pkg/utils/grpcutil"
 	"github.com/tikv/pd/pkg/utils/logutil"
 	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
 	"go.etcd.io/etcd/clientv3"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -186,6 +187,7 @@ type AllocatorManager struct {
 	// the primary/leader again. Etcd only supports seconds TTL, so here is second too.
 	leaderLease    int64
 	maxResetTSGap  func() time.Duration
+	layout         tsoutil.Layout
 	securityConfig *grpcutil.TLSConfig
 	// for gRPC use
 	localAllocatorConn struct {
@@ -217,6 +219,7 @@ func NewAllocatorManager(
 		updatePhysicalInterval: cfg.GetTSOUpdatePhysicalInterval(),
 		leaderLease:            cfg.GetLeaderLease(),
 		maxResetTSGap:          cfg.GetMaxResetTSGap,
+		layout:                 cfg.GetTSOLayout(),
 		securityConfig:         cfg.GetTLSConfig(),
 	}
 	am.mu.allocatorGroups = make(map[string]*allocatorGroup)
@@ -1158,6 +1161,11 @@ func (am *AllocatorManager) HandleRequest(ctx context.Context, dcLocation string
 	return allocatorGroup.allocator.GenerateTSO(ctx, count)
 }
 
+// GetTSOLayout returns the layout of the TSO generated by the allocators.
+func (am *AllocatorManager) GetTSOLayout() tsoutil.Layout {
+	return am.layout
+}
+
 // ResetAllocatorGroup will reset the allocator's leadership and TSO initialized in memory.
 // It usually should be called before re-triggering an Allocator leader campaign.
 func (am *AllocatorManager) ResetAllocatorGroup(dcLocation string) {
@@ -1332,6 +1340,27 @@ func (am *AllocatorManager) GetMaxLocalTSO(ctx context.Context) (*pdpb.Timestamp
 	return maxTSO, nil
 }
 
+// HandoffGlobalTSO stops the Global TSO Allocator before the leader hands off its leadership
+// and returns the last allocated Global TSO.
+func (am *AllocatorManager) HandoffGlobalTSO() (uint64, error) {
+	globalAllocator, err := am.GetAllocator(GlobalDCLocation)
+	if err != nil {
+		return 0, err
+	}
+	return globalAllocator.(*GlobalTSOAllocator).Handoff()
+}
+
+// SetHandoffGlobalTSO sets the last Global TSO allocated by the previous leader before it handed
+// off the leadership, which bounds the start of the next initialization of the Global TSO Allocator.
+func (am *AllocatorManager) SetHandoffGlobalTSO(ts uint64) error {
+	globalAllocator, err := am.GetAllocator(GlobalDCLocation)
+	if err != nil {
+		return err
+	}
+	globalAllocator.(*GlobalTSOAllocator).SetHandoffTSO(ts)
+	return nil
+}
+
 func (am *AllocatorManager) getGRPCConn(addr string) (*grpc.ClientConn, bool) {
 	am.localAllocatorConn.RLock()
 	defer am.localAllocatorConn.RUnlock()
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/tikv/pd/pkg/utils/grpcutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
 )
 
 // ServiceConfig defines the configuration interface for the TSO service.
@@ -46,6 +47,8 @@ type Config interface {
 	GetTSOSaveInterval() time.Duration
 	// GetMaxResetTSGap returns the MaxResetTSGap.
 	GetMaxResetTSGap() time.Duration
+	// GetTSOLayout returns the layout of the TSO.
+	GetTSOLayout() tsoutil.Layout
 	// GetTLSConfig returns the TLS config.
 	GetTLSConfig() *grpcutil.TLSConfig
 }
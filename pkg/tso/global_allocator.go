@@ -121,6 +121,7 @@ func newGlobalTimestampOracle(am *AllocatorManager) *timestampOracle {
 		saveInterval:           am.saveInterval,
 		updatePhysicalInterval: am.updatePhysicalInterval,
 		maxResetTSGap:          am.maxResetTSGap,
+		layout:                 am.layout,
 		dcLocation:             GlobalDCLocation,
 		tsoMux:                 &tsoObject{},
 		metrics:                newTSOMetrics(am.getGroupIDStr(), GlobalDCLocation),
@@ -539,6 +540,18 @@ func (gta *GlobalTSOAllocator) getCurrentTSO(ctx context.Context) (*pdpb.Timesta
 	return tsoutil.GenerateTimestamp(currentPhysical, uint64(currentLogical)), nil
 }
 
+// Handoff stops the allocation before the leader hands off its leadership and returns the
+// last allocated TSO. The allocator could be initialized again if the handoff fails.
+func (gta *GlobalTSOAllocator) Handoff() (uint64, error) {
+	return gta.timestampOracle.handoffTimestamp()
+}
+
+// SetHandoffTSO sets the last TSO allocated by the previous leader which handed off the leadership,
+// so that the next initialization starts right after it instead of the end of the saved time window.
+func (gta *GlobalTSOAllocator) SetHandoffTSO(ts uint64) {
+	gta.timestampOracle.setHandoffTimestamp(ts)
+}
+
 // Reset is used to reset the TSO allocator.
 func (gta *GlobalTSOAllocator) Reset() {
 	gta.tsoAllocatorRoleGauge.Set(0)
@@ -1079,6 +1079,7 @@ func (kgm *KeyspaceGroupManager) HandleTSORequest(
 	// timestamp one more time before serving the TSO request to make sure that the
 	// TSO is the latest one from the storage, which could prevent the potential
 	// fallback caused by the rolling update of the mixed old PD and TSO service deployment.
+	finishSpan := traceFromContext(ctx).StartSpan(SpanSyncWait)
 	err = kgm.markGroupRequested(curKeyspaceGroupID, func() error {
 		allocator, err := am.GetAllocator(dcLocation)
 		if err != nil {
@@ -1087,6 +1088,7 @@ func (kgm *KeyspaceGroupManager) HandleTSORequest(
 		// TODO: support the Local TSO Allocator.
 		return allocator.Initialize(0)
 	})
+	finishSpan()
 	if err != nil {
 		return pdpb.Timestamp{}, curKeyspaceGroupID, err
 	}
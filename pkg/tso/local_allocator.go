@@ -77,6 +77,7 @@ func newLocalTimestampOracle(am *AllocatorManager, leadership *election.Leadersh
 		saveInterval:           am.saveInterval,
 		updatePhysicalInterval: am.updatePhysicalInterval,
 		maxResetTSGap:          am.maxResetTSGap,
+		layout:                 am.layout,
 		dcLocation:             dcLocation,
 		tsoMux:                 &tsoObject{},
 		metrics:                newTSOMetrics(am.getGroupIDStr(), dcLocation),
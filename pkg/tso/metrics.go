@@ -67,6 +67,15 @@ var (
 			Help:      "Indicate the PD server role info, whether it's a TSO allocator.",
 		}, []string{groupLabel, dcLabel})
 
+	tsoTraceSpanDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: pdNamespace,
+			Subsystem: "tso",
+			Name:      "trace_span_duration_seconds",
+			Help:      "Bucketed histogram of the duration(s) of each stage of the sampled TSO requests.",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 2, 20),
+		}, []string{"span"})
+
 	// Keyspace Group metrics
 	keyspaceGroupStateGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -92,6 +101,7 @@ func init() {
 	prometheus.MustRegister(tsoGap)
 	prometheus.MustRegister(tsoOpDuration)
 	prometheus.MustRegister(tsoAllocatorRole)
+	prometheus.MustRegister(tsoTraceSpanDuration)
 	prometheus.MustRegister(keyspaceGroupStateGauge)
 	prometheus.MustRegister(keyspaceGroupOpDuration)
 }
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/tikv/pd/pkg/utils/grpcutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
 )
 
 var _ ServiceConfig = (*TestServiceConfig)(nil)
@@ -33,6 +34,7 @@ type TestServiceConfig struct {
 	TSOUpdatePhysicalInterval time.Duration       // Interval to update TSO in physical storage.
 	TSOSaveInterval           time.Duration       // Interval to save TSO to physical storage.
 	MaxResetTSGap             time.Duration       // Maximum gap to reset TSO.
+	Layout                    tsoutil.Layout      // Layout of TSO, the default layout is used if it's not set.
 	TLSConfig                 *grpcutil.TLSConfig // TLS configuration.
 }
 
@@ -81,6 +83,14 @@ func (c *TestServiceConfig) GetMaxResetTSGap() time.Duration {
 	return c.MaxResetTSGap
 }
 
+// GetTSOLayout returns the Layout field of TestServiceConfig.
+func (c *TestServiceConfig) GetTSOLayout() tsoutil.Layout {
+	if c.Layout == (tsoutil.Layout{}) {
+		return tsoutil.DefaultLayout
+	}
+	return c.Layout
+}
+
 // GetTLSConfig returns the TLSConfig field of TestServiceConfig.
 func (c *TestServiceConfig) GetTLSConfig() *grpcutil.TLSConfig {
 	return c.TLSConfig
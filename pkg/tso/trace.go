@@ -0,0 +1,300 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
+)
+
+// The names of the spans recorded by the TSO server.
+const (
+	// SpanHandle is the root span which covers the whole handling of the request.
+	SpanHandle = "handle"
+	// SpanReceive covers the time from the request being received to being handed to the allocator.
+	SpanReceive = "receive"
+	// SpanGenerate covers the time the allocator spends on generating the timestamp in memory.
+	SpanGenerate = "generate"
+	// SpanSyncWait covers the time the allocator waits for the TSO window to be synced to etcd,
+	// e.g, the logical part is exhausted or the TSO has not been initialized yet.
+	SpanSyncWait = "sync-wait"
+	// SpanRespond covers the time to send the response back to the client.
+	SpanRespond = "respond"
+)
+
+// The attributes of the root span.
+const (
+	AttrClient          = "client"
+	AttrKeyspaceGroupID = "keyspace-group-id"
+	AttrDCLocation      = "dc-location"
+	AttrCount           = "count"
+	AttrSeq             = "seq"
+	AttrError           = "error"
+)
+
+const (
+	defaultTraceCapacity = 1024
+	maxTraceCapacity     = 65536
+)
+
+type traceCtxKey struct{}
+
+// Span is a timed operation of a traced TSO request, it follows the data model of OpenTelemetry
+// so the traces can be correlated with the spans reported by the client with the same trace ID.
+type Span struct {
+	TraceID      string            `json:"trace-id"`
+	SpanID       string            `json:"span-id"`
+	ParentSpanID string            `json:"parent-span-id,omitempty"`
+	Name         string            `json:"name"`
+	StartTime    time.Time         `json:"start-time"`
+	EndTime      time.Time         `json:"end-time"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+}
+
+// Duration returns the duration of the span.
+func (s *Span) Duration() time.Duration {
+	return s.EndTime.Sub(s.StartTime)
+}
+
+// Trace is the sampled record of a TSO request handled by the server. The first span is the
+// root span, and the others are its children. All methods of Trace are safe to be called on
+// a nil Trace, which is the case when the request is not sampled.
+type Trace struct {
+	TraceID string `json:"trace-id"`
+	Spans   []Span `json:"spans"`
+
+	mu syncutil.Mutex
+}
+
+// WithTrace returns a new context carrying the given trace, so the allocator can record
+// its spans into it.
+func WithTrace(ctx context.Context, trace *Trace) context.Context {
+	if trace == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, traceCtxKey{}, trace)
+}
+
+func traceFromContext(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(traceCtxKey{}).(*Trace)
+	return trace
+}
+
+// StartSpan starts a child span of the root span with the given name and returns the function to finish it.
+func (t *Trace) StartSpan(name string) func() {
+	if t == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		t.AddSpan(name, start, time.Since(start))
+	}
+}
+
+// AddSpan adds a finished child span of the root span into the trace.
+func (t *Trace) AddSpan(name string, start time.Time, duration time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Spans = append(t.Spans, Span{
+		TraceID:      t.TraceID,
+		SpanID:       newSpanID(),
+		ParentSpanID: t.Spans[0].SpanID,
+		Name:         name,
+		StartTime:    start,
+		EndTime:      start.Add(duration),
+	})
+}
+
+// root returns the root span of the trace.
+func (t *Trace) root() *Span {
+	return &t.Spans[0]
+}
+
+func newSpanID() string {
+	var id [8]byte
+	binary.BigEndian.PutUint64(id[:], rand.Uint64())
+	return hex.EncodeToString(id[:])
+}
+
+func newTraceID() string {
+	var id [16]byte
+	binary.BigEndian.PutUint64(id[:8], rand.Uint64())
+	binary.BigEndian.PutUint64(id[8:], rand.Uint64())
+	return hex.EncodeToString(id[:])
+}
+
+// Tracer samples the TSO requests and keeps the latest traces in a ring buffer.
+type Tracer struct {
+	// sampleRate is stored as the bits of a float64, 0 means the tracing is disabled.
+	sampleRate atomic.Uint64
+	mu         struct {
+		syncutil.RWMutex
+		traces []*Trace
+		next   int
+		full   bool
+	}
+}
+
+// NewTracer creates a new Tracer which keeps at most `capacity` traces. The tracing is
+// disabled until a positive sample rate is set.
+func NewTracer(capacity int) *Tracer {
+	if capacity <= 0 {
+		capacity = defaultTraceCapacity
+	}
+	t := &Tracer{}
+	t.mu.traces = make([]*Trace, capacity)
+	return t
+}
+
+// TracerConfig is the config of the Tracer which can be changed online.
+type TracerConfig struct {
+	SampleRate float64 `json:"sample-rate"`
+	Capacity   int     `json:"capacity"`
+}
+
+// GetConfig returns the current config of the tracer.
+func (t *Tracer) GetConfig() TracerConfig {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return TracerConfig{SampleRate: t.getSampleRate(), Capacity: len(t.mu.traces)}
+}
+
+// SetConfig updates the config of the tracer. Changing the capacity drops the traces kept.
+func (t *Tracer) SetConfig(cfg TracerConfig) error {
+	if cfg.SampleRate < 0 || cfg.SampleRate > 1 {
+		return errors.Errorf("sample rate should be in [0, 1], but got %v", cfg.SampleRate)
+	}
+	if cfg.Capacity < 0 || cfg.Capacity > maxTraceCapacity {
+		return errors.Errorf("capacity should be in [0, %d], but got %d", maxTraceCapacity, cfg.Capacity)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cfg.Capacity > 0 && cfg.Capacity != len(t.mu.traces) {
+		t.mu.traces = make([]*Trace, cfg.Capacity)
+		t.mu.next, t.mu.full = 0, false
+	}
+	t.sampleRate.Store(math.Float64bits(cfg.SampleRate))
+	return nil
+}
+
+func (t *Tracer) getSampleRate() float64 {
+	return math.Float64frombits(t.sampleRate.Load())
+}
+
+// StartTrace decides whether to sample the request and returns a new trace if so, otherwise nil
+// is returned. The request is sampled as the client decides if it propagates the trace context,
+// otherwise it's sampled with the sample rate of the tracer.
+func (t *Tracer) StartTrace(reqTrace tsoutil.RequestTrace, client string, keyspaceGroupID uint32, dcLocation string, count uint32, start time.Time) *Trace {
+	if t == nil {
+		return nil
+	}
+	traceID := reqTrace.TraceID
+	if len(traceID) > 0 {
+		if !reqTrace.Sampled {
+			return nil
+		}
+	} else {
+		rate := t.getSampleRate()
+		if rate <= 0 || (rate < 1 && rand.Float64() >= rate) {
+			return nil
+		}
+		traceID = newTraceID()
+	}
+	attributes := map[string]string{
+		AttrClient:          client,
+		AttrKeyspaceGroupID: strconv.FormatUint(uint64(keyspaceGroupID), 10),
+		AttrDCLocation:      dcLocation,
+		AttrCount:           strconv.FormatUint(uint64(count), 10),
+	}
+	if reqTrace.Seq > 0 {
+		attributes[AttrSeq] = strconv.FormatUint(reqTrace.Seq, 10)
+	}
+	return &Trace{
+		TraceID: traceID,
+		Spans: []Span{{
+			TraceID:    traceID,
+			SpanID:     newSpanID(),
+			Name:       SpanHandle,
+			StartTime:  start,
+			Attributes: attributes,
+		}},
+	}
+}
+
+// FinishTrace finishes the trace and keeps it in the tracer.
+func (t *Tracer) FinishTrace(trace *Trace, err error) {
+	if t == nil || trace == nil {
+		return
+	}
+	trace.mu.Lock()
+	root := trace.root()
+	root.EndTime = time.Now()
+	if err != nil {
+		root.Attributes[AttrError] = err.Error()
+	}
+	spans := make([]Span, len(trace.Spans))
+	copy(spans, trace.Spans)
+	trace.mu.Unlock()
+	for i := range spans {
+		tsoTraceSpanDuration.WithLabelValues(spans[i].Name).Observe(spans[i].Duration().Seconds())
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mu.traces[t.mu.next] = trace
+	t.mu.next++
+	if t.mu.next == len(t.mu.traces) {
+		t.mu.next, t.mu.full = 0, true
+	}
+}
+
+// GetTraces returns at most `limit` latest traces, the newest first. The traces can be
+// filtered by the client and the trace ID if they are not empty.
+func (t *Tracer) GetTraces(client, traceID string, limit int) []*Trace {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	size := t.mu.next
+	if t.mu.full {
+		size = len(t.mu.traces)
+	}
+	traces := make([]*Trace, 0, size)
+	for i := 1; i <= size; i++ {
+		if limit > 0 && len(traces) >= limit {
+			break
+		}
+		trace := t.mu.traces[(t.mu.next-i+len(t.mu.traces))%len(t.mu.traces)]
+		if len(client) > 0 && trace.root().Attributes[AttrClient] != client {
+			continue
+		}
+		if len(traceID) > 0 && trace.TraceID != traceID {
+			continue
+		}
+		traces = append(traces, trace)
+	}
+	return traces
+}
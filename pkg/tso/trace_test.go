@@ -0,0 +1,112 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTracer(t *testing.T) {
+	re := require.New(t)
+	tracer := NewTracer(3)
+	// The tracing is disabled by default.
+	re.Nil(tracer.StartTrace(tsoutil.RequestTrace{}, "client", 0, GlobalDCLocation, 1, time.Now()))
+	re.Error(tracer.SetConfig(TracerConfig{SampleRate: 2}))
+	re.Error(tracer.SetConfig(TracerConfig{SampleRate: 1, Capacity: -1}))
+	re.NoError(tracer.SetConfig(TracerConfig{SampleRate: 1}))
+	re.Equal(TracerConfig{SampleRate: 1, Capacity: 3}, tracer.GetConfig())
+
+	traceIDs := make([]string, 0, 5)
+	for i := 0; i < 5; i++ {
+		trace := tracer.StartTrace(tsoutil.RequestTrace{}, fmt.Sprintf("client-%d", i%2), 0, GlobalDCLocation, 1, time.Now())
+		re.NotNil(trace)
+		traceIDs = append(traceIDs, trace.TraceID)
+		ctx := WithTrace(context.Background(), trace)
+		traceFromContext(ctx).StartSpan(SpanGenerate)()
+		tracer.FinishTrace(trace, nil)
+	}
+	// Only the latest 3 traces are kept, the newest first.
+	traces := tracer.GetTraces("", "", 0)
+	re.Len(traces, 3)
+	for i, trace := range traces {
+		re.Equal(traceIDs[4-i], trace.TraceID)
+		re.Len(trace.Spans, 2)
+		root, span := trace.Spans[0], trace.Spans[1]
+		re.Equal(SpanHandle, root.Name)
+		re.Empty(root.ParentSpanID)
+		re.False(root.EndTime.Before(root.StartTime))
+		re.Equal(SpanGenerate, span.Name)
+		re.Equal(trace.TraceID, span.TraceID)
+		re.Equal(root.SpanID, span.ParentSpanID)
+	}
+	re.Len(tracer.GetTraces("", "", 1), 1)
+	traces = tracer.GetTraces("client-1", "", 0)
+	re.Len(traces, 1)
+	re.Equal(traceIDs[3], traces[0].TraceID)
+	traces = tracer.GetTraces("", traceIDs[2], 0)
+	re.Len(traces, 1)
+	re.Equal("client-0", traces[0].Spans[0].Attributes[AttrClient])
+
+	// Changing the capacity drops the traces.
+	re.NoError(tracer.SetConfig(TracerConfig{SampleRate: 0, Capacity: 10}))
+	re.Empty(tracer.GetTraces("", "", 0))
+	re.Nil(tracer.StartTrace(tsoutil.RequestTrace{}, "client", 0, GlobalDCLocation, 1, time.Now()))
+
+	// The nil trace is safe to use.
+	var trace *Trace
+	trace.StartSpan(SpanGenerate)()
+	re.Nil(traceFromContext(WithTrace(context.Background(), trace)))
+}
+
+func TestTracerWithClientTraceContext(t *testing.T) {
+	re := require.New(t)
+	// The tracing of the server is disabled, but the client decides to sample every 2 requests.
+	tracer := NewTracer(10)
+	var streamTraceID [16]byte
+	streamTraceID[15] = 0xff
+	md := metadata.Pairs(tsoutil.TraceIDMetadataKey, hex.EncodeToString(streamTraceID[:]),
+		tsoutil.TraceSampleIntervalMetadataKey, "2")
+	streamTrace := tsoutil.TraceContextFromIncomingContext(metadata.NewIncomingContext(context.Background(), md))
+	re.NotNil(streamTrace)
+	for i := 0; i < 4; i++ {
+		trace := tracer.StartTrace(streamTrace.Next(), "client", 0, GlobalDCLocation, 1, time.Now())
+		if i%2 == 0 {
+			re.Nil(trace)
+			continue
+		}
+		re.NotNil(trace)
+		tracer.FinishTrace(trace, errors.New("injected"))
+	}
+	traces := tracer.GetTraces("", "", 0)
+	re.Len(traces, 2)
+	// The trace ID is derived from the one of the stream and the sequence of the request.
+	re.Equal("00000000000000000000000000000103", traces[0].TraceID)
+	re.Equal("4", traces[0].Spans[0].Attributes[AttrSeq])
+	re.Equal("injected", traces[0].Spans[0].Attributes[AttrError])
+	re.Equal("00000000000000000000000000000101", traces[1].TraceID)
+
+	// The stream without the trace context is sampled by the server.
+	re.Nil(tsoutil.TraceContextFromIncomingContext(context.Background()))
+	re.Equal(tsoutil.RequestTrace{}, (*tsoutil.TraceContext)(nil).Next())
+}
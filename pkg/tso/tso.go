@@ -38,7 +38,7 @@ import (
 const (
 	// UpdateTimestampGuard is the min timestamp interval.
 	UpdateTimestampGuard = time.Millisecond
-	// maxLogical is the max upper limit for logical time.
+	// maxLogical is the max upper limit for logical time under the default layout.
 	// When a TSO's logical time reaches this limit,
 	// the physical time will be forced to increase.
 	maxLogical = int64(1 << 18)
@@ -70,12 +70,17 @@ type timestampOracle struct {
 	saveInterval           time.Duration
 	updatePhysicalInterval time.Duration
 	maxResetTSGap          func() time.Duration
+	// layout is how the TSO is split into the physical and logical parts.
+	layout tsoutil.Layout
 	// tso info stored in the memory
 	tsoMux *tsoObject
 	// last timestamp window stored in etcd
 	lastSavedTime atomic.Value // stored as time.Time
 	suffix        int
 	dcLocation    string
+	// handoffTS is the last timestamp allocated by the previous leader which handed off the
+	// leadership to this one, it bounds the start of the next synchronization only once.
+	handoffTS atomic.Uint64
 
 	// pre-initialized metrics
 	metrics *tsoMetrics
@@ -89,7 +94,7 @@ func (t *timestampOracle) setTSOPhysical(next time.Time, force bool) {
 		return
 	}
 	// make sure the ts won't fall back
-	if typeutil.SubTSOPhysicalByWallClock(next, t.tsoMux.physical) > 0 {
+	if t.layout.SubPhysical(next, t.tsoMux.physical) > 0 {
 		t.tsoMux.physical = next
 		t.tsoMux.logical = 0
 		t.tsoMux.updateTime = time.Now()
@@ -113,7 +118,7 @@ func (t *timestampOracle) generateTSO(ctx context.Context, count int64, suffixBi
 	if t.tsoMux.physical == typeutil.ZeroTime {
 		return 0, 0, typeutil.ZeroTime
 	}
-	physical = t.tsoMux.physical.UnixNano() / int64(time.Millisecond)
+	physical = t.layout.Physical(t.tsoMux.physical)
 	t.tsoMux.logical += count
 	logical = t.tsoMux.logical
 	if suffixBits > 0 && t.suffix >= 0 {
@@ -155,10 +160,42 @@ func (t *timestampOracle) GetTimestampPath() string {
 	return endpoint.TimestampPath(t.tsPath)
 }
 
+// getTimestampLayoutPath returns the timestamp layout path in etcd.
+func (t *timestampOracle) getTimestampLayoutPath() string {
+	return endpoint.TimestampLayoutPath(t.tsPath)
+}
+
+// checkLayout checks whether the layout of the timestamp oracle is compatible with the one
+// persisted in etcd, which is used to generate the TSO before `last`. A layout change is only
+// allowed when it won't make the TSO fall back, e.g, increasing the logical bits or switching
+// to the microsecond precision with enough logical bits.
+func (t *timestampOracle) checkLayout(last time.Time) (changed bool, err error) {
+	value, err := t.storage.LoadTimestampLayout(t.getTimestampLayoutPath())
+	if err != nil {
+		return false, err
+	}
+	prev := tsoutil.DefaultLayout
+	if value != "" {
+		if prev, err = tsoutil.ParseLayout(value); err != nil {
+			return false, errs.ErrIncompatibleTSOLayout.Wrap(err).GenWithStackByArgs(t.layout, value)
+		}
+	}
+	if prev == t.layout {
+		return false, nil
+	}
+	// No TSO has been generated yet if the timestamp window is never saved.
+	if last != typeutil.ZeroTime && !t.layout.IsCompatibleWith(prev, last) {
+		return false, errs.ErrIncompatibleTSOLayout.FastGenByArgs(t.layout, prev)
+	}
+	return true, nil
+}
+
 // SyncTimestamp is used to synchronize the timestamp.
 func (t *timestampOracle) SyncTimestamp(leadership *election.Leadership) error {
 	log.Info("start to sync timestamp", logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0))
 	t.metrics.syncEvent.Inc()
+	// The handoff TSO is only valid for the synchronization right after the handoff.
+	handoffTS := t.handoffTS.Swap(0)
 
 	failpoint.Inject("delaySyncTimestamp", func() {
 		time.Sleep(time.Second)
@@ -168,6 +205,10 @@ func (t *timestampOracle) SyncTimestamp(leadership *election.Leadership) error {
 	if err != nil {
 		return err
 	}
+	layoutChanged, err := t.checkLayout(last)
+	if err != nil {
+		return err
+	}
 	lastSavedTime := t.getLastSavedTime()
 	// We could skip the synchronization if the following conditions are met:
 	//   1. The timestamp in memory has been initialized.
@@ -176,7 +217,7 @@ func (t *timestampOracle) SyncTimestamp(leadership *election.Leadership) error {
 	//   4. The last saved timestamp in etcd is equal to the last saved timestamp in memory.
 	// 1 is to ensure the timestamp in memory could always be initialized. 2-4 are to ensure
 	// the synchronization could be skipped safely.
-	if t.isInitialized() &&
+	if t.isInitialized() && !layoutChanged &&
 		last != typeutil.ZeroTime &&
 		lastSavedTime != typeutil.ZeroTime &&
 		typeutil.SubRealTimeByWallClock(last, lastSavedTime) == 0 {
@@ -197,17 +238,32 @@ func (t *timestampOracle) SyncTimestamp(leadership *election.Leadership) error {
 	// If the current system time minus the saved etcd timestamp is less than `UpdateTimestampGuard`,
 	// the timestamp allocation will start from the saved etcd timestamp temporarily.
 	if typeutil.SubRealTimeByWallClock(next, last) < UpdateTimestampGuard {
-		log.Warn("system time may be incorrect",
-			logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0),
-			zap.Time("last", last), zap.Time("last-saved", lastSavedTime),
-			zap.Time("next", next),
-			errs.ZapError(errs.ErrIncorrectSystemTime))
-		next = last.Add(UpdateTimestampGuard)
+		if handoffPhysical, ok := t.handoffPhysical(handoffTS, last, layoutChanged); ok {
+			// The previous leader stopped allocating at the handoff point, so it's enough to
+			// start right after the handoff TSO rather than the end of the saved window.
+			if t.layout.SubPhysical(handoffPhysical, next) >= 0 {
+				next = handoffPhysical.Add(t.layout.PhysicalUnit())
+			}
+			log.Info("sync timestamp after the leader handoff",
+				logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0),
+				zap.Time("last", last), zap.Time("handoff", handoffPhysical), zap.Time("next", next))
+		} else {
+			log.Warn("system time may be incorrect",
+				logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0),
+				zap.Time("last", last), zap.Time("last-saved", lastSavedTime),
+				zap.Time("next", next),
+				errs.ZapError(errs.ErrIncorrectSystemTime))
+			next = last.Add(UpdateTimestampGuard)
+		}
 	}
 	failpoint.Inject("failedToSaveTimestamp", func() {
 		failpoint.Return(errs.ErrEtcdTxnInternal)
 	})
 	save := next.Add(t.saveInterval)
+	// The saved window never falls back, even if the allocation starts before its end.
+	if typeutil.SubRealTimeByWallClock(save, last) <= 0 {
+		save = last.Add(UpdateTimestampGuard)
+	}
 	start := time.Now()
 	if err = t.storage.SaveTimestamp(t.GetTimestampPath(), save); err != nil {
 		t.metrics.errSaveSyncTSEvent.Inc()
@@ -215,6 +271,16 @@ func (t *timestampOracle) SyncTimestamp(leadership *election.Leadership) error {
 	}
 	t.lastSavedTime.Store(save)
 	t.metrics.syncSaveDuration.Observe(time.Since(start).Seconds())
+	// Persist the layout after the timestamp window is saved, so the TSO generated with
+	// the previous layout is always less than `next`.
+	if layoutChanged {
+		if err = t.storage.SaveTimestampLayout(t.getTimestampLayoutPath(), t.layout.String()); err != nil {
+			return err
+		}
+		log.Info("the tso layout is changed",
+			logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0),
+			zap.Stringer("layout", t.layout))
+	}
 
 	t.metrics.syncOKEvent.Inc()
 	log.Info("sync and save timestamp",
@@ -253,9 +319,9 @@ func (t *timestampOracle) resetUserTimestampInner(leadership *election.Leadershi
 		return errs.ErrResetUserTimestamp.FastGenByArgs("lease expired")
 	}
 	var (
-		nextPhysical, nextLogical = tsoutil.ParseTS(tso)
+		nextPhysical, nextLogical = t.layout.ParseTS(tso)
 		logicalDifference         = int64(nextLogical) - t.tsoMux.logical
-		physicalDifference        = typeutil.SubTSOPhysicalByWallClock(nextPhysical, t.tsoMux.physical)
+		physicalDifference        = t.layout.SubPhysical(nextPhysical, t.tsoMux.physical)
 	)
 	// do not update if next physical time is less/before than prev
 	if physicalDifference < 0 {
@@ -274,7 +340,7 @@ func (t *timestampOracle) resetUserTimestampInner(leadership *election.Leadershi
 		return errs.ErrResetUserTimestamp.FastGenByArgs("the specified counter is smaller than now")
 	}
 	// do not update if physical time is too greater than prev
-	if !skipUpperBoundCheck && physicalDifference >= t.maxResetTSGap().Milliseconds() {
+	if !skipUpperBoundCheck && physicalDifference >= int64(t.maxResetTSGap()/t.layout.PhysicalUnit()) {
 		t.metrics.errResetLargeTSEvent.Inc()
 		return errs.ErrResetUserTimestamp.FastGenByArgs("the specified ts is too larger than now")
 	}
@@ -348,13 +414,13 @@ func (t *timestampOracle) UpdateTimestamp(leadership *election.Leadership) error
 	// If the system time is greater, it will be synchronized with the system time.
 	if jetLag > UpdateTimestampGuard {
 		next = now
-	} else if prevLogical > maxLogical/2 {
+	} else if prevLogical > t.layout.MaxLogical()/2 {
 		// The reason choosing maxLogical/2 here is that it's big enough for common cases.
 		// Because there is enough timestamp can be allocated before next update.
 		log.Warn("the logical time may be not enough",
 			logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0),
 			zap.Int64("prev-logical", prevLogical))
-		next = prevPhysical.Add(time.Millisecond)
+		next = prevPhysical.Add(t.layout.PhysicalUnit())
 	} else {
 		// It will still use the previous physical time to alloc the timestamp.
 		t.metrics.skipSaveEvent.Inc()
@@ -393,29 +459,37 @@ func (t *timestampOracle) getTS(ctx context.Context, leadership *election.Leader
 	if count == 0 {
 		return resp, errs.ErrGenerateTimestamp.FastGenByArgs("tso count should be positive")
 	}
+	trace := traceFromContext(ctx)
 	for i := 0; i < maxRetryCount; i++ {
 		currentPhysical, _ := t.getTSO()
 		if currentPhysical == typeutil.ZeroTime {
 			// If it's leader, maybe SyncTimestamp hasn't completed yet
 			if leadership.Check() {
+				finishSpan := trace.StartSpan(SpanSyncWait)
 				time.Sleep(200 * time.Millisecond)
+				finishSpan()
 				continue
 			}
 			t.metrics.notLeaderAnymoreEvent.Inc()
 			return pdpb.Timestamp{}, errs.ErrGenerateTimestamp.FastGenByArgs("timestamp in memory isn't initialized")
 		}
 		// Get a new TSO result with the given count
+		finishSpan := trace.StartSpan(SpanGenerate)
 		resp.Physical, resp.Logical, _ = t.generateTSO(ctx, int64(count), suffixBits)
+		finishSpan()
 		if resp.GetPhysical() == 0 {
 			return pdpb.Timestamp{}, errs.ErrGenerateTimestamp.FastGenByArgs("timestamp in memory has been reset")
 		}
-		if resp.GetLogical() >= maxLogical {
+		if resp.GetLogical() >= t.layout.MaxLogical() {
 			log.Warn("logical part outside of max logical interval, please check ntp time, or adjust config item `tso-update-physical-interval`",
 				logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0),
 				zap.Reflect("response", resp),
 				zap.Int("retry-count", i), errs.ZapError(errs.ErrLogicOverflow))
 			t.metrics.logicalOverflowEvent.Inc()
+			// Wait for the physical part to be updated, which may need to save the new TSO window into etcd.
+			finishSpan = trace.StartSpan(SpanSyncWait)
 			time.Sleep(t.updatePhysicalInterval)
+			finishSpan()
 			continue
 		}
 		// In case lease expired after the first check.
@@ -429,6 +503,63 @@ func (t *timestampOracle) getTS(ctx context.Context, leadership *election.Leader
 	return resp, errs.ErrGenerateTimestamp.FastGenByArgs(fmt.Sprintf("generate %s tso maximum number of retries exceeded", t.dcLocation))
 }
 
+// handoffTimestamp stops allocating timestamps from memory before the leader hands off its
+// leadership, and makes sure the persisted time window covers all the allocated timestamps,
+// so that the next leader could start from the window without waiting. It returns the last
+// allocated timestamp.
+func (t *timestampOracle) handoffTimestamp() (uint64, error) {
+	t.tsoMux.Lock()
+	if t.tsoMux.physical == typeutil.ZeroTime {
+		t.tsoMux.Unlock()
+		return 0, errs.ErrUpdateTimestamp.FastGenByArgs("timestamp in memory has not been initialized")
+	}
+	physical, logical, updateTime := t.tsoMux.physical, t.tsoMux.logical, t.tsoMux.updateTime
+	t.tsoMux.physical = typeutil.ZeroTime
+	t.tsoMux.logical = 0
+	t.tsoMux.updateTime = typeutil.ZeroTime
+	t.tsoMux.Unlock()
+
+	// The allocation is stopped, so the time window could be saved without blocking the
+	// requests on the lock.
+	if typeutil.SubRealTimeByWallClock(t.getLastSavedTime(), physical) <= UpdateTimestampGuard {
+		save := physical.Add(t.saveInterval)
+		if err := t.storage.SaveTimestamp(t.GetTimestampPath(), save); err != nil {
+			t.tsoMux.Lock()
+			// Resume the allocation if the timestamp is not initialized again in the meantime.
+			if t.tsoMux.physical == typeutil.ZeroTime {
+				t.tsoMux.physical, t.tsoMux.logical, t.tsoMux.updateTime = physical, logical, updateTime
+			}
+			t.tsoMux.Unlock()
+			return 0, err
+		}
+		t.lastSavedTime.Store(save)
+	}
+	log.Info("stop allocating timestamps for the leader handoff",
+		logutil.CondUint32("keyspace-group-id", t.keyspaceGroupID, t.keyspaceGroupID > 0),
+		zap.Time("physical", physical), zap.Int64("logical", logical), zap.Time("last-saved", t.getLastSavedTime()))
+	return t.layout.ComposeTS(t.layout.Physical(physical), logical), nil
+}
+
+// setHandoffTimestamp sets the last timestamp allocated by the previous leader before it handed off
+// the leadership, which should be called before the next synchronization.
+func (t *timestampOracle) setHandoffTimestamp(ts uint64) {
+	t.handoffTS.Store(ts)
+}
+
+// handoffPhysical returns the physical time of the handoff TSO if it could bound the start of the
+// synchronization, which requires the TSO to be generated with the same layout and covered by the
+// saved time window.
+func (t *timestampOracle) handoffPhysical(handoffTS uint64, last time.Time, layoutChanged bool) (time.Time, bool) {
+	if handoffTS == 0 || layoutChanged || last == typeutil.ZeroTime {
+		return typeutil.ZeroTime, false
+	}
+	physical, _ := t.layout.ParseTS(handoffTS)
+	if t.layout.SubPhysical(last, physical) <= 0 {
+		return typeutil.ZeroTime, false
+	}
+	return physical, true
+}
+
 // ResetTimestamp is used to reset the timestamp in memory.
 func (t *timestampOracle) ResetTimestamp() {
 	t.tsoMux.Lock()
@@ -438,4 +569,5 @@ func (t *timestampOracle) ResetTimestamp() {
 	t.tsoMux.logical = 0
 	t.tsoMux.updateTime = typeutil.ZeroTime
 	t.lastSavedTime.Store(typeutil.ZeroTime)
+	t.handoffTS.Store(0)
 }
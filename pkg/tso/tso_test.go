@@ -0,0 +1,115 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+func newTestTimestampOracle(storage endpoint.TSOStorage, layout tsoutil.Layout) *timestampOracle {
+	return &timestampOracle{
+		storage:                storage,
+		saveInterval:           3 * time.Second,
+		updatePhysicalInterval: 50 * time.Millisecond,
+		maxResetTSGap:          func() time.Duration { return 24 * time.Hour },
+		layout:                 layout,
+		dcLocation:             GlobalDCLocation,
+		tsoMux:                 &tsoObject{},
+		metrics:                newTSOMetrics("0", GlobalDCLocation),
+	}
+}
+
+func TestCheckLayoutPersistence(t *testing.T) {
+	re := require.New(t)
+	storage := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	microLayout := tsoutil.Layout{PhysicalPrecision: tsoutil.PhysicalPrecisionMicrosecond, LogicalBits: 11}
+
+	// Nothing is persisted before the first synchronization with the default layout.
+	oracle := newTestTimestampOracle(storage, tsoutil.DefaultLayout)
+	changed, err := oracle.checkLayout(typeutil.ZeroTime)
+	re.NoError(err)
+	re.False(changed)
+	re.NoError(oracle.SyncTimestamp(nil))
+	value, err := storage.LoadTimestampLayout(oracle.getTimestampLayoutPath())
+	re.NoError(err)
+	re.Empty(value)
+
+	// Switching to a compatible layout persists it after the timestamp window is saved.
+	oracle = newTestTimestampOracle(storage, microLayout)
+	last, err := storage.LoadTimestamp(oracle.tsPath)
+	re.NoError(err)
+	changed, err = oracle.checkLayout(last)
+	re.NoError(err)
+	re.True(changed)
+	re.NoError(oracle.SyncTimestamp(nil))
+	value, err = storage.LoadTimestampLayout(oracle.getTimestampLayoutPath())
+	re.NoError(err)
+	re.Equal(microLayout.String(), value)
+	changed, err = oracle.checkLayout(last)
+	re.NoError(err)
+	re.False(changed)
+
+	// Switching back to the default layout would make the TSO fall back.
+	oracle = newTestTimestampOracle(storage, tsoutil.DefaultLayout)
+	last, err = storage.LoadTimestamp(oracle.tsPath)
+	re.NoError(err)
+	_, err = oracle.checkLayout(last)
+	re.Error(err)
+	re.Error(oracle.SyncTimestamp(nil))
+
+	// A corrupted layout is never accepted.
+	re.NoError(storage.SaveTimestampLayout(oracle.getTimestampLayoutPath(), "invalid"))
+	_, err = oracle.checkLayout(last)
+	re.Error(err)
+}
+
+func TestSyncTimestampAfterHandoff(t *testing.T) {
+	re := require.New(t)
+	storage := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	layout := tsoutil.DefaultLayout
+
+	oracle := newTestTimestampOracle(storage, layout)
+	re.NoError(oracle.SyncTimestamp(nil))
+	handoffTS, err := oracle.handoffTimestamp()
+	re.NoError(err)
+	handoffPhysical, _ := layout.ParseTS(handoffTS)
+	last, err := storage.LoadTimestamp(oracle.tsPath)
+	re.NoError(err)
+
+	// The next leader starts right after the handoff TSO rather than the end of the saved window.
+	oracle = newTestTimestampOracle(storage, layout)
+	oracle.setHandoffTimestamp(handoffTS)
+	re.NoError(oracle.SyncTimestamp(nil))
+	physical, _ := oracle.getTSO()
+	re.Positive(layout.SubPhysical(physical, handoffPhysical))
+	re.Negative(layout.SubPhysical(physical, last))
+	saved, err := storage.LoadTimestamp(oracle.tsPath)
+	re.NoError(err)
+	re.True(saved.After(last))
+
+	// Without the handoff, the next leader starts after the end of the saved window.
+	last = saved
+	oracle = newTestTimestampOracle(storage, layout)
+	re.NoError(oracle.SyncTimestamp(nil))
+	physical, _ = oracle.getTSO()
+	re.True(physical.After(last))
+}
@@ -52,6 +52,10 @@ const (
 	PDRedirectorHeader = "PD-Redirector"
 	// PDAllowFollowerHandleHeader is used to mark whether this request is allowed to be handled by the follower PD.
 	PDAllowFollowerHandleHeader = "PD-Allow-follower-handle" // #nosec G101
+	// PDFollowerReadAppliedIndexHeader is used to mark the applied index of the regions served by the follower PD.
+	PDFollowerReadAppliedIndexHeader = "PD-Follower-Read-Applied-Index"
+	// PDFollowerReadStalenessHeader is used to mark the staleness of the regions served by the follower PD.
+	PDFollowerReadStalenessHeader = "PD-Follower-Read-Staleness"
 	// XForwardedForHeader is used to mark the client IP.
 	XForwardedForHeader = "X-Forwarded-For"
 	// XForwardedPortHeader is used to mark the client port.
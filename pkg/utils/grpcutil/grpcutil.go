@@ -34,11 +34,18 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 const (
 	// ForwardMetadataKey is used to record the forwarded host of PD.
 	ForwardMetadataKey = "pd-forwarded-host"
+	// FollowerReadMetadataKey is used to record the max staleness of the follower read.
+	FollowerReadMetadataKey = "pd-follower-read-max-staleness"
+	// FollowerReadAppliedIndexMetadataKey is used to record the applied index of the follower read.
+	FollowerReadAppliedIndexMetadataKey = "pd-follower-read-applied-index"
+	// FollowerReadStalenessMetadataKey is used to record the staleness of the follower read.
+	FollowerReadStalenessMetadataKey = "pd-follower-read-staleness"
 )
 
 // TLSConfig is the configuration for supporting tls.
@@ -182,6 +189,33 @@ func GetForwardedHost(ctx context.Context) string {
 	return ""
 }
 
+// GetFollowerReadMaxStaleness returns the max staleness of the follower read in metadata.
+// It returns false if the request does not ask for the follower read.
+func GetFollowerReadMaxStaleness(ctx context.Context) (time.Duration, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	t := md.Get(FollowerReadMetadataKey)
+	if len(t) == 0 {
+		return 0, false
+	}
+	maxStaleness, err := time.ParseDuration(t[0])
+	if err != nil || maxStaleness <= 0 {
+		return 0, false
+	}
+	return maxStaleness, true
+}
+
+// GetPeerAddr returns the address of the peer, an empty string is returned if it's unknown.
+func GetPeerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	return p.Addr.String()
+}
+
 func establish(ctx context.Context, addr string, tlsConfig *TLSConfig, do ...grpc.DialOption) (*grpc.ClientConn, error) {
 	tlsCfg, err := tlsConfig.ToTLSConfig()
 	if err != nil {
@@ -1,14 +1,17 @@
 package grpcutil
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/pd/pkg/errs"
+	"google.golang.org/grpc/metadata"
 )
 
 var (
@@ -67,3 +70,18 @@ func TestToTLSConfig(t *testing.T) {
 	_, err = tlsConfig.ToTLSConfig()
 	re.True(errors.ErrorEqual(err, errs.ErrCryptoAppendCertsFromPEM))
 }
+
+func TestGetFollowerReadMaxStaleness(t *testing.T) {
+	re := require.New(t)
+	_, ok := GetFollowerReadMaxStaleness(context.Background())
+	re.False(ok)
+	for _, s := range []string{"", "abc", "0s", "-1s"} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(FollowerReadMetadataKey, s))
+		_, ok = GetFollowerReadMaxStaleness(ctx)
+		re.False(ok, s)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(FollowerReadMetadataKey, "3s"))
+	maxStaleness, ok := GetFollowerReadMaxStaleness(ctx)
+	re.True(ok)
+	re.Equal(3*time.Second, maxStaleness)
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsoutil
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tikv/pd/client/tsoutil"
+	"github.com/tikv/pd/pkg/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The layout of the TSO is shared with the client, see the client side for the details.
+const (
+	LayoutMetadataKey            = tsoutil.LayoutMetadataKey
+	LayoutNegotiationMetadataKey = tsoutil.LayoutNegotiationMetadataKey
+	PhysicalPrecisionMillisecond = tsoutil.PhysicalPrecisionMillisecond
+	PhysicalPrecisionMicrosecond = tsoutil.PhysicalPrecisionMicrosecond
+)
+
+// Layout describes how a TSO is split into the physical and the logical parts.
+type Layout = tsoutil.Layout
+
+// DefaultLayout is the layout of the TSO used by default, which is compatible with all the components.
+var DefaultLayout = tsoutil.DefaultLayout
+
+// ParseLayout parses the layout from the string generated by `Layout.String`.
+func ParseLayout(s string) (Layout, error) {
+	return tsoutil.ParseLayout(s)
+}
+
+// NegotiateLayout tells the client the layout of the TSO through the stream header. A stream
+// from the client which does not declare it understands the non-default layout is rejected,
+// otherwise the client will compose the TSO in a wrong way.
+func NegotiateLayout(stream grpc.ServerStream, layout Layout) error {
+	if !layout.IsDefault() && !isLayoutNegotiated(stream.Context()) {
+		return status.Error(codes.FailedPrecondition, errs.ErrTSOLayoutNotNegotiated.FastGenByArgs(layout.String()).Error())
+	}
+	return stream.SendHeader(metadata.Pairs(LayoutMetadataKey, layout.String()))
+}
+
+// LayoutNegotiator negotiates the layout of the TSO with a client stream. The negotiation happens
+// right before the first TSO is sent, so that a follower or a proxy which forwards the stream can
+// negotiate with the layout of the server which actually generates the TSO.
+type LayoutNegotiator struct {
+	stream grpc.ServerStream
+
+	mu         sync.Mutex
+	negotiated *Layout
+}
+
+// NewLayoutNegotiator creates a LayoutNegotiator for the given client stream.
+func NewLayoutNegotiator(stream grpc.ServerStream) *LayoutNegotiator {
+	return &LayoutNegotiator{stream: stream}
+}
+
+// Negotiate negotiates the layout with the client stream if it's not negotiated yet. Since the
+// layout can't be changed once negotiated, an error is returned if the given layout is different
+// from the negotiated one, e.g, the stream is forwarded to a new leader with another layout.
+func (n *LayoutNegotiator) Negotiate(layout Layout) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.negotiated != nil {
+		if *n.negotiated != layout {
+			return status.Error(codes.FailedPrecondition, errs.ErrTSOLayoutChanged.FastGenByArgs(layout.String(), n.negotiated.String()).Error())
+		}
+		return nil
+	}
+	if err := NegotiateLayout(n.stream, layout); err != nil {
+		return err
+	}
+	n.negotiated = &layout
+	return nil
+}
+
+// LayoutFromHeader gets the layout of the TSO from the header of the stream to the TSO server.
+// The default layout is returned if the server doesn't send it.
+func LayoutFromHeader(stream grpc.ClientStream) (Layout, error) {
+	md, err := stream.Header()
+	if err != nil {
+		return Layout{}, err
+	}
+	values := md.Get(LayoutMetadataKey)
+	if len(values) == 0 {
+		return DefaultLayout, nil
+	}
+	return ParseLayout(values[0])
+}
+
+// ForwardLayoutNegotiation declares the outgoing context understands the non-default layout if the
+// incoming stream of the client does, it's used to forward the stream to the TSO server.
+func ForwardLayoutNegotiation(ctx, streamCtx context.Context) context.Context {
+	if !isLayoutNegotiated(streamCtx) {
+		return ctx
+	}
+	return withLayoutNegotiation(ctx)
+}
+
+// isLayoutNegotiated returns whether the incoming stream declares it understands the non-default layout.
+func isLayoutNegotiated(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get(LayoutNegotiationMetadataKey)) > 0
+}
+
+// withLayoutNegotiation declares the outgoing stream understands the non-default layout, it's used
+// by the proxy to forward the negotiation of the client to the TSO server.
+func withLayoutNegotiation(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, LayoutNegotiationMetadataKey, "true")
+}
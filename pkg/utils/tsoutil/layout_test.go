@@ -0,0 +1,92 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsoutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestDefaultLayout(t *testing.T) {
+	re := require.New(t)
+	now := time.Now()
+	// The default layout should be the same as the legacy functions.
+	physical, _ := DefaultLayout.ParseTimestamp(*GenerateTimestamp(now, 0))
+	legacy, _ := ParseTimestamp(*GenerateTimestamp(now, 0))
+	re.Equal(legacy, physical)
+	re.Equal(GenerateTS(GenerateTimestamp(now, 100)), DefaultLayout.ComposeTS(DefaultLayout.Physical(now), 100))
+	physical, logical := DefaultLayout.ParseTS(GenerateTS(GenerateTimestamp(now, 100)))
+	legacy, legacyLogical := ParseTS(GenerateTS(GenerateTimestamp(now, 100)))
+	re.Equal(legacy, physical)
+	re.Equal(legacyLogical, logical)
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockServerStream) SendHeader(md metadata.MD) error {
+	s.header = md
+	return nil
+}
+
+func TestNegotiateLayout(t *testing.T) {
+	re := require.New(t)
+	microLayout := Layout{PhysicalPrecision: PhysicalPrecisionMicrosecond, LogicalBits: 11}
+	// The default layout is always accepted.
+	stream := &mockServerStream{ctx: context.Background()}
+	re.NoError(NegotiateLayout(stream, DefaultLayout))
+	re.Equal([]string{DefaultLayout.String()}, stream.header.Get(LayoutMetadataKey))
+	// A non-default layout requires the client to declare it understands the layout.
+	stream = &mockServerStream{ctx: context.Background()}
+	err := NegotiateLayout(stream, microLayout)
+	re.Equal(codes.FailedPrecondition, status.Code(err))
+	re.Nil(stream.header)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(LayoutNegotiationMetadataKey, "true"))
+	stream = &mockServerStream{ctx: ctx}
+	re.NoError(NegotiateLayout(stream, microLayout))
+	re.Equal([]string{microLayout.String()}, stream.header.Get(LayoutMetadataKey))
+}
+
+func TestLayoutNegotiator(t *testing.T) {
+	re := require.New(t)
+	microLayout := Layout{PhysicalPrecision: PhysicalPrecisionMicrosecond, LogicalBits: 11}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(LayoutNegotiationMetadataKey, "true"))
+	stream := &mockServerStream{ctx: ctx}
+	negotiator := NewLayoutNegotiator(stream)
+	re.NoError(negotiator.Negotiate(microLayout))
+	re.Equal([]string{microLayout.String()}, stream.header.Get(LayoutMetadataKey))
+	// The header is sent only once.
+	stream.header = nil
+	re.NoError(negotiator.Negotiate(microLayout))
+	re.Nil(stream.header)
+	// The layout can't be changed once negotiated.
+	err := negotiator.Negotiate(DefaultLayout)
+	re.Equal(codes.FailedPrecondition, status.Code(err))
+	re.Nil(stream.header)
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsoutil
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// TraceIDMetadataKey is the gRPC metadata key used by the client to propagate the trace ID of the TSO stream.
+	// Note: keep the same as the one defined on the client side.
+	TraceIDMetadataKey = "pd-tso-trace-id"
+	// TraceSampleIntervalMetadataKey is the gRPC metadata key used by the client to tell the server every
+	// how many requests on the TSO stream one is sampled.
+	// Note: keep the same as the one defined on the client side.
+	TraceSampleIntervalMetadataKey = "pd-tso-trace-sample-interval"
+
+	traceIDLen = 16
+)
+
+// RequestTrace is the trace context of a single TSO request.
+type RequestTrace struct {
+	// TraceID is the hex encoded trace ID of the request, it's empty if the client doesn't propagate
+	// the trace context, in which case the server decides whether to sample the request by itself.
+	TraceID string
+	// Seq is the sequence number of the request on the stream, starting from 1.
+	Seq uint64
+	// Sampled indicates whether the client samples the request.
+	Sampled bool
+}
+
+// TraceContext is the trace context of a TSO stream propagated by the client through the gRPC metadata.
+// Since the requests on a stream are received in the same order as they are sent, both sides count
+// them to derive the same trace ID for each request without changing the protocol.
+type TraceContext struct {
+	streamTraceID  [traceIDLen]byte
+	sampleInterval uint64
+	seq            uint64
+}
+
+// TraceContextFromIncomingContext returns the trace context propagated by the client, nil is returned
+// if the client doesn't propagate it.
+func TraceContextFromIncomingContext(ctx context.Context) *TraceContext {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	ids, intervals := md.Get(TraceIDMetadataKey), md.Get(TraceSampleIntervalMetadataKey)
+	if len(ids) == 0 || len(intervals) == 0 {
+		return nil
+	}
+	id, err := hex.DecodeString(ids[0])
+	if err != nil || len(id) != traceIDLen {
+		return nil
+	}
+	interval, err := strconv.ParseUint(intervals[0], 10, 64)
+	if err != nil || interval == 0 {
+		return nil
+	}
+	c := &TraceContext{sampleInterval: interval}
+	copy(c.streamTraceID[:], id)
+	return c
+}
+
+// Next returns the trace context of the next request received on the stream. It must be called once
+// for every request received, no matter whether the request is handled locally or forwarded.
+func (c *TraceContext) Next() RequestTrace {
+	if c == nil {
+		return RequestTrace{}
+	}
+	c.seq++
+	return RequestTrace{
+		TraceID: RequestTraceID(c.streamTraceID, c.seq),
+		Seq:     c.seq,
+		Sampled: c.seq%c.sampleInterval == 0,
+	}
+}
+
+// RequestTraceID derives the trace ID of the seq-th request on the stream by adding seq to the
+// lower 64 bits of the trace ID of the stream.
+func RequestTraceID(streamTraceID [traceIDLen]byte, seq uint64) string {
+	id := streamTraceID
+	binary.BigEndian.PutUint64(id[8:], binary.BigEndian.Uint64(id[8:])+seq)
+	return hex.EncodeToString(id[:])
+}
@@ -39,6 +39,8 @@ const (
 	DefaultTSOProxyTimeout = 3 * time.Second
 	// tsoProxyStreamIdleTimeout defines how long Proxy stream will live if no request is received
 	tsoProxyStreamIdleTimeout = 5 * time.Minute
+	// layoutNegotiatedDispatchKeySuffix distinguishes the forwarding stream of the layout negotiated requests
+	layoutNegotiatedDispatchKeySuffix = "/layout-negotiated"
 )
 
 type tsoResp interface {
@@ -71,7 +73,13 @@ func NewTSODispatcher(tsoProxyHandleDuration, tsoProxyBatchSize prometheus.Histo
 
 // DispatchRequest is the entry point for dispatching/forwarding a tso request to the destination host
 func (s *TSODispatcher) DispatchRequest(serverCtx context.Context, req Request, tsoProtoFactory ProtoFactory, tsoPrimaryWatchers ...*etcdutil.LoopWatcher) context.Context {
+	// The requests from the clients which negotiated the TSO layout are forwarded through a dedicated
+	// stream carrying the negotiation, so that the others are still rejected by the TSO server.
 	key := req.getForwardedHost()
+	layoutNegotiated := req.isLayoutNegotiated()
+	if layoutNegotiated {
+		key += layoutNegotiatedDispatchKeySuffix
+	}
 	val, loaded := s.dispatchChs.Load(key)
 	if !loaded {
 		val = &tsoRequestProxyQueue{requestCh: make(chan Request, maxMergeRequests+1)}
@@ -84,7 +92,7 @@ func (s *TSODispatcher) DispatchRequest(serverCtx context.Context, req Request,
 		dispatcherCtx, ctxCancel := context.WithCancelCause(serverCtx)
 		tsoQueue.ctx = dispatcherCtx
 		tsoQueue.cancel = ctxCancel
-		go s.dispatch(tsoQueue, tsoProtoFactory, req.getForwardedHost(), req.getClientConn(), tsDeadlineCh, tsoPrimaryWatchers...)
+		go s.dispatch(tsoQueue, tsoProtoFactory, key, req.getForwardedHost(), req.getClientConn(), layoutNegotiated, tsDeadlineCh, tsoPrimaryWatchers...)
 		go WatchTSDeadline(dispatcherCtx, tsDeadlineCh)
 	}
 	tsoQueue.requestCh <- req
@@ -94,15 +102,20 @@ func (s *TSODispatcher) DispatchRequest(serverCtx context.Context, req Request,
 func (s *TSODispatcher) dispatch(
 	tsoQueue *tsoRequestProxyQueue,
 	tsoProtoFactory ProtoFactory,
-	forwardedHost string,
+	key, forwardedHost string,
 	clientConn *grpc.ClientConn,
+	layoutNegotiated bool,
 	tsDeadlineCh chan<- *TSDeadline,
 	tsoPrimaryWatchers ...*etcdutil.LoopWatcher) {
 	defer logutil.LogPanic()
 	dispatcherCtx := tsoQueue.ctx
-	defer s.dispatchChs.Delete(forwardedHost)
+	defer s.dispatchChs.Delete(key)
 
-	forwardStream, cancel, err := tsoProtoFactory.createForwardStream(tsoQueue.ctx, clientConn)
+	streamCtx := tsoQueue.ctx
+	if layoutNegotiated {
+		streamCtx = withLayoutNegotiation(streamCtx)
+	}
+	forwardStream, cancel, err := tsoProtoFactory.createForwardStream(streamCtx, clientConn)
 	failpoint.Inject("canNotCreateForwardStream", func() {
 		cancel()
 		err = errors.New("canNotCreateForwardStream")
@@ -186,7 +199,12 @@ func (s *TSODispatcher) processRequests(forwardStream stream, requests []Request
 	// This is different from the logic of client batch, for example, if we have a largest ts whose logical part is 10,
 	// count is 5, then the splitting results should be 5 and 10.
 	firstLogical := addLogical(logical, -int64(count), suffixBits)
-	return s.finishRequest(requests, physical, firstLogical, suffixBits)
+	// The header carrying the layout has been received along with the first response.
+	layout, err := forwardStream.getLayout()
+	if err != nil {
+		return err
+	}
+	return s.finishRequest(requests, physical, firstLogical, suffixBits, layout)
 }
 
 // Because of the suffix, we need to shift the count before we add it to the logical part.
@@ -194,10 +212,10 @@ func addLogical(logical, count int64, suffixBits uint32) int64 {
 	return logical + count<<suffixBits
 }
 
-func (s *TSODispatcher) finishRequest(requests []Request, physical, firstLogical int64, suffixBits uint32) error {
+func (s *TSODispatcher) finishRequest(requests []Request, physical, firstLogical int64, suffixBits uint32, layout Layout) error {
 	countSum := int64(0)
 	for i := 0; i < len(requests); i++ {
-		newCountSum, err := requests[i].postProcess(countSum, physical, firstLogical, suffixBits)
+		newCountSum, err := requests[i].postProcess(countSum, physical, firstLogical, suffixBits, layout)
 		if err != nil {
 			return err
 		}
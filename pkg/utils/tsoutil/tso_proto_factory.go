@@ -42,7 +42,7 @@ func (s *TSOProtoFactory) createForwardStream(ctx context.Context, clientConn *g
 	go grpcutil.CheckStream(cctx, cancel, done)
 	forwardStream, err := tsopb.NewTSOClient(clientConn).Tso(cctx)
 	done <- struct{}{}
-	return &tsoStream{forwardStream}, cancel, err
+	return &tsoStream{stream: forwardStream}, cancel, err
 }
 
 func (s *PDProtoFactory) createForwardStream(ctx context.Context, clientConn *grpc.ClientConn) (stream, context.CancelFunc, error) {
@@ -51,16 +51,40 @@ func (s *PDProtoFactory) createForwardStream(ctx context.Context, clientConn *gr
 	go grpcutil.CheckStream(cctx, cancel, done)
 	forwardStream, err := pdpb.NewPDClient(clientConn).Tso(cctx)
 	done <- struct{}{}
-	return &pdStream{forwardStream}, cancel, err
+	return &pdStream{stream: forwardStream}, cancel, err
 }
 
 type stream interface {
 	// process sends a request and receives the response through the stream
 	process(clusterID uint64, count, keyspaceID, keyspaceGroupID uint32, dcLocation string) (response, error)
+	// getLayout returns the layout of the TSO server, it's only valid after the first response is received
+	getLayout() (Layout, error)
+}
+
+// layoutCache caches the layout got from the header of the stream.
+type layoutCache struct {
+	layout *Layout
+}
+
+func (c *layoutCache) get(stream grpc.ClientStream) (Layout, error) {
+	if c.layout == nil {
+		layout, err := LayoutFromHeader(stream)
+		if err != nil {
+			return Layout{}, err
+		}
+		c.layout = &layout
+	}
+	return *c.layout, nil
 }
 
 type tsoStream struct {
 	stream tsopb.TSO_TsoClient
+	layoutCache
+}
+
+// getLayout returns the layout of the TSO server, it's only valid after the first response is received
+func (s *tsoStream) getLayout() (Layout, error) {
+	return s.layoutCache.get(s.stream)
 }
 
 // process sends a request and receives the response through the stream
@@ -86,6 +110,12 @@ func (s *tsoStream) process(clusterID uint64, count, keyspaceID, keyspaceGroupID
 
 type pdStream struct {
 	stream pdpb.PD_TsoClient
+	layoutCache
+}
+
+// getLayout returns the layout of the TSO server, it's only valid after the first response is received
+func (s *pdStream) getLayout() (Layout, error) {
+	return s.layoutCache.get(s.stream)
 }
 
 // process sends a request and receives the response through the stream
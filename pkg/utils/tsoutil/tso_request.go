@@ -30,11 +30,14 @@ type Request interface {
 	getClientConn() *grpc.ClientConn
 	// getCount returns the count of timestamps to retrieve
 	getCount() uint32
+	// isLayoutNegotiated returns whether the sender of the request understands the non-default TSO layout
+	isLayoutNegotiated() bool
 	// process sends request and receive response via stream.
 	// count defines the count of timestamps to retrieve.
 	process(forwardStream stream, count uint32, tsoProtoFactory ProtoFactory) (tsoResp, error)
-	// postProcess sends the response back to the sender of the request
-	postProcess(countSum, physical, firstLogical int64, suffixBits uint32) (int64, error)
+	// postProcess negotiates the layout of the TSO server with the sender of the request and
+	// sends the response back to it
+	postProcess(countSum, physical, firstLogical int64, suffixBits uint32, layout Layout) (int64, error)
 }
 
 // response is an interface wrapping tsopb.TsoResponse and pdpb.TsoResponse
@@ -48,15 +51,19 @@ type TSOProtoRequest struct {
 	clientConn    *grpc.ClientConn
 	request       *tsopb.TsoRequest
 	stream        tsopb.TSO_TsoServer
+	negotiator    *LayoutNegotiator
 }
 
 // NewTSOProtoRequest creates a TSOProtoRequest and returns as a Request
-func NewTSOProtoRequest(forwardedHost string, clientConn *grpc.ClientConn, request *tsopb.TsoRequest, stream tsopb.TSO_TsoServer) Request {
+func NewTSOProtoRequest(
+	forwardedHost string, clientConn *grpc.ClientConn, request *tsopb.TsoRequest, stream tsopb.TSO_TsoServer, negotiator *LayoutNegotiator,
+) Request {
 	tsoRequest := &TSOProtoRequest{
 		forwardedHost: forwardedHost,
 		clientConn:    clientConn,
 		request:       request,
 		stream:        stream,
+		negotiator:    negotiator,
 	}
 	return tsoRequest
 }
@@ -76,6 +83,11 @@ func (r *TSOProtoRequest) getCount() uint32 {
 	return r.request.GetCount()
 }
 
+// isLayoutNegotiated returns whether the sender of the request understands the non-default TSO layout
+func (r *TSOProtoRequest) isLayoutNegotiated() bool {
+	return isLayoutNegotiated(r.stream.Context())
+}
+
 // process sends request and receive response via stream.
 // count defines the count of timestamps to retrieve.
 func (r *TSOProtoRequest) process(forwardStream stream, count uint32, tsoProtoFactory ProtoFactory) (tsoResp, error) {
@@ -83,8 +95,12 @@ func (r *TSOProtoRequest) process(forwardStream stream, count uint32, tsoProtoFa
 		r.request.GetHeader().GetKeyspaceId(), r.request.GetHeader().GetKeyspaceGroupId(), r.request.GetDcLocation())
 }
 
-// postProcess sends the response back to the sender of the request
-func (r *TSOProtoRequest) postProcess(countSum, physical, firstLogical int64, suffixBits uint32) (int64, error) {
+// postProcess negotiates the layout of the TSO server with the sender of the request and
+// sends the response back to it
+func (r *TSOProtoRequest) postProcess(countSum, physical, firstLogical int64, suffixBits uint32, layout Layout) (int64, error) {
+	if err := r.negotiator.Negotiate(layout); err != nil {
+		return countSum, err
+	}
 	count := r.request.GetCount()
 	countSum += int64(count)
 	response := &tsopb.TsoResponse{
@@ -109,15 +125,19 @@ type PDProtoRequest struct {
 	clientConn    *grpc.ClientConn
 	request       *pdpb.TsoRequest
 	stream        pdpb.PD_TsoServer
+	negotiator    *LayoutNegotiator
 }
 
 // NewPDProtoRequest creates a PDProtoRequest and returns as a Request
-func NewPDProtoRequest(forwardedHost string, clientConn *grpc.ClientConn, request *pdpb.TsoRequest, stream pdpb.PD_TsoServer) Request {
+func NewPDProtoRequest(
+	forwardedHost string, clientConn *grpc.ClientConn, request *pdpb.TsoRequest, stream pdpb.PD_TsoServer, negotiator *LayoutNegotiator,
+) Request {
 	tsoRequest := &PDProtoRequest{
 		forwardedHost: forwardedHost,
 		clientConn:    clientConn,
 		request:       request,
 		stream:        stream,
+		negotiator:    negotiator,
 	}
 	return tsoRequest
 }
@@ -137,6 +157,11 @@ func (r *PDProtoRequest) getCount() uint32 {
 	return r.request.GetCount()
 }
 
+// isLayoutNegotiated returns whether the sender of the request understands the non-default TSO layout
+func (r *PDProtoRequest) isLayoutNegotiated() bool {
+	return isLayoutNegotiated(r.stream.Context())
+}
+
 // process sends request and receive response via stream.
 // count defines the count of timestamps to retrieve.
 func (r *PDProtoRequest) process(forwardStream stream, count uint32, tsoProtoFactory ProtoFactory) (tsoResp, error) {
@@ -144,8 +169,12 @@ func (r *PDProtoRequest) process(forwardStream stream, count uint32, tsoProtoFac
 		utils.DefaultKeyspaceID, utils.DefaultKeyspaceGroupID, r.request.GetDcLocation())
 }
 
-// postProcess sends the response back to the sender of the request
-func (r *PDProtoRequest) postProcess(countSum, physical, firstLogical int64, suffixBits uint32) (int64, error) {
+// postProcess negotiates the layout of the TSO server with the sender of the request and
+// sends the response back to it
+func (r *PDProtoRequest) postProcess(countSum, physical, firstLogical int64, suffixBits uint32, layout Layout) (int64, error) {
+	if err := r.negotiator.Negotiate(layout); err != nil {
+		return countSum, err
+	}
 	count := r.request.GetCount()
 	countSum += int64(count)
 	response := &pdpb.TsoResponse{
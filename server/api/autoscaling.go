@@ -0,0 +1,164 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tikv/pd/pkg/autoscaling"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	"github.com/tikv/pd/server"
+	"github.com/unrolled/render"
+)
+
+type autoScalingHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newAutoScalingHandler(svr *server.Server, rd *render.Render) *autoScalingHandler {
+	return &autoScalingHandler{
+		svr: svr,
+		rd:  rd,
+	}
+}
+
+// @Tags     autoscaling
+// @Summary  Get the auto scaling strategy which is evaluated periodically.
+// @Produce  json
+// @Success  200  {object}  autoscaling.Strategy
+// @Failure  404  {string}  string  "The strategy is not set."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /autoscaling/strategy [get]
+func (h *autoScalingHandler) GetStrategy(w http.ResponseWriter, r *http.Request) {
+	strategy, err := autoscaling.LoadStrategy(h.svr.GetStorage())
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if strategy == nil {
+		h.rd.JSON(w, http.StatusNotFound, "The strategy is not set.")
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, strategy)
+}
+
+// @Tags     autoscaling
+// @Summary  Set the auto scaling strategy. The plans of the strategy are evaluated and recorded periodically.
+// @Accept   json
+// @Param    body  body  autoscaling.Strategy  true  "The auto scaling strategy"
+// @Produce  json
+// @Success  200  {string}  string  "The strategy is updated."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /autoscaling/strategy [post]
+func (h *autoScalingHandler) SetStrategy(w http.ResponseWriter, r *http.Request) {
+	strategy := &autoscaling.Strategy{}
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, strategy); err != nil {
+		return
+	}
+	if err := autoscaling.SaveStrategy(h.svr.GetStorage(), strategy); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The strategy is updated.")
+}
+
+// @Tags     autoscaling
+// @Summary  Remove the auto scaling strategy to stop the periodic evaluation.
+// @Produce  json
+// @Success  200  {string}  string  "The strategy is removed."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /autoscaling/strategy [delete]
+func (h *autoScalingHandler) RemoveStrategy(w http.ResponseWriter, r *http.Request) {
+	if err := autoscaling.RemoveStrategy(h.svr.GetStorage()); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The strategy is removed.")
+}
+
+// @Tags     autoscaling
+// @Summary  Get the plans recorded by the periodic evaluation in the time range.
+// @Param    start_time  query  integer  false  "Start time in unix seconds, inclusive"
+// @Param    end_time    query  integer  false  "End time in unix seconds, exclusive"
+// @Param    limit       query  integer  false  "Max number of the records"
+// @Produce  json
+// @Success  200  {array}   autoscaling.PlanRecord
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /autoscaling/plans [get]
+func (h *autoScalingHandler) GetPlanHistory(w http.ResponseWriter, r *http.Request) {
+	start, end, limit := time.Unix(0, 0), time.Unix(0, math.MaxInt64), 0
+	query := r.URL.Query()
+	if startStr := query.Get("start_time"); startStr != "" {
+		ts, err := strconv.ParseInt(startStr, 10, 64)
+		if err != nil {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		start = time.Unix(ts, 0)
+	}
+	if endStr := query.Get("end_time"); endStr != "" {
+		ts, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		end = time.Unix(ts, 0)
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			h.rd.JSON(w, http.StatusBadRequest, "limit should be a non-negative integer.")
+			return
+		}
+	}
+	records, err := autoscaling.LoadPlanHistory(h.svr.GetStorage(), start, end, limit)
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, records)
+}
+
+// @Tags     autoscaling
+// @Summary  Calculate the plans of the strategy against the supplied snapshot of the instances and metrics.
+// @Accept   json
+// @Param    body  body  autoscaling.SimulationInput  true  "The strategy and the snapshot"
+// @Produce  json
+// @Success  200  {array}   autoscaling.Plan
+// @Failure  400  {string}  string  "The input is invalid."
+// @Router   /autoscaling/simulate [post]
+func (h *autoScalingHandler) Simulate(w http.ResponseWriter, r *http.Request) {
+	input := &autoscaling.SimulationInput{}
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, input); err != nil {
+		return
+	}
+	if input.Strategy == nil || input.Snapshot == nil {
+		h.rd.JSON(w, http.StatusBadRequest, "strategy and snapshot are required.")
+		return
+	}
+	plans, err := autoscaling.Simulate(input.Strategy, input.Snapshot)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, plans)
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/errcode"
+	"github.com/pingcap/errors"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	"github.com/tikv/pd/server"
+	"github.com/unrolled/render"
+)
+
+// followerReadHandler serves the region and store reads on both the leader and the followers.
+// The requests should carry the `PD-Allow-follower-handle` header to be served by the follower
+// which receives them, and the `max-staleness` query to bound the staleness of the result.
+type followerReadHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newFollowerReadHandler(svr *server.Server, rd *render.Render) *followerReadHandler {
+	return &followerReadHandler{
+		svr: svr,
+		rd:  rd,
+	}
+}
+
+// getBasicCluster returns the cluster to serve the read. The leader serves the read with its own
+// cluster, while the follower serves it with the regions synced from the leader if they are
+// fresh enough. It writes the error response and returns nil if the read can't be served.
+func (h *followerReadHandler) getBasicCluster(w http.ResponseWriter, r *http.Request) *core.BasicCluster {
+	maxStaleness, err := time.ParseDuration(r.URL.Query().Get("max-staleness"))
+	if err == nil && maxStaleness <= 0 {
+		err = errors.Errorf("max staleness should be positive, but got %s", maxStaleness)
+	}
+	if err != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(err))
+		return nil
+	}
+	if h.svr.GetMember().IsLeader() {
+		rc := h.svr.GetRaftCluster()
+		if rc == nil {
+			h.rd.JSON(w, http.StatusInternalServerError, errs.ErrNotBootstrapped.FastGenByArgs().Error())
+			return nil
+		}
+		return rc.GetBasicCluster()
+	}
+	state, err := h.svr.CheckFollowerRead(maxStaleness)
+	if err != nil {
+		h.rd.JSON(w, http.StatusServiceUnavailable, err.Error())
+		return nil
+	}
+	w.Header().Set(apiutil.PDFollowerReadAppliedIndexHeader, strconv.FormatUint(state.AppliedIndex, 10))
+	w.Header().Set(apiutil.PDFollowerReadStalenessHeader, state.Staleness.String())
+	return h.svr.GetBasicCluster()
+}
+
+// @Tags     follower-read
+// @Summary  Search for a region by region ID, which can be served by the follower.
+// @Param    id             path   integer  true  "Region Id"
+// @Param    max-staleness  query  string   true  "The max staleness of the region, e.g, 3s"
+// @Produce  json
+// @Success  200  {object}  RegionInfo
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  503  {string}  string  "The follower is too stale to serve the read."
+// @Router   /follower-read/region/id/{id} [get]
+func (h *followerReadHandler) GetRegionByID(w http.ResponseWriter, r *http.Request) {
+	regionID, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	bc := h.getBasicCluster(w, r)
+	if bc == nil {
+		return
+	}
+	b, err := marshalRegionInfoJSON(r.Context(), bc.GetRegion(regionID))
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.Data(w, http.StatusOK, b)
+}
+
+// @Tags     follower-read
+// @Summary  Search for a region by a key, which can be served by the follower.
+// @Param    key            path   string  true  "Region key"
+// @Param    max-staleness  query  string  true  "The max staleness of the region, e.g, 3s"
+// @Produce  json
+// @Success  200  {object}  RegionInfo
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  503  {string}  string  "The follower is too stale to serve the read."
+// @Router   /follower-read/region/key/{key} [get]
+func (h *followerReadHandler) GetRegion(w http.ResponseWriter, r *http.Request) {
+	key, err := url.QueryUnescape(mux.Vars(r)["key"])
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// decode hex if query has params with hex format
+	if r.URL.Query().Get("format") == "hex" {
+		keyBytes, err := hex.DecodeString(key)
+		if err != nil {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		key = string(keyBytes)
+	}
+	bc := h.getBasicCluster(w, r)
+	if bc == nil {
+		return
+	}
+	b, err := marshalRegionInfoJSON(r.Context(), bc.GetRegionByKey([]byte(key)))
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.Data(w, http.StatusOK, b)
+}
+
+// @Tags     follower-read
+// @Summary  Get the meta of a store, which can be served by the follower. The status of the store is only returned by the leader.
+// @Param    id             path   integer  true  "Store Id"
+// @Param    max-staleness  query  string   true  "The max staleness of the store, e.g, 3s"
+// @Produce  json
+// @Success  200  {object}  StoreInfo
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The store does not exist."
+// @Failure  503  {string}  string  "The follower is too stale to serve the read."
+// @Router   /follower-read/store/{id} [get]
+func (h *followerReadHandler) GetStore(w http.ResponseWriter, r *http.Request) {
+	storeID, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	bc := h.getBasicCluster(w, r)
+	if bc == nil {
+		return
+	}
+	if h.svr.GetMember().IsLeader() {
+		store := bc.GetStore(storeID)
+		if store == nil {
+			h.rd.JSON(w, http.StatusNotFound, errs.ErrStoreNotFound.FastGenByArgs(storeID).Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusOK, newStoreInfo(h.svr.GetScheduleConfig(), store))
+		return
+	}
+	// The stores are not synced to the follower, so only the meta in the storage is returned.
+	meta, err := h.svr.GetStoreForFollowerRead(storeID)
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if meta == nil {
+		h.rd.JSON(w, http.StatusNotFound, errs.ErrStoreNotFound.FastGenByArgs(storeID).Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, &StoreInfo{Store: &MetaStore{Store: meta, StateName: meta.GetState().String()}})
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/tikv/pd/pkg/capture"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	"github.com/tikv/pd/server"
+	"github.com/unrolled/render"
+)
+
+type heartbeatCaptureHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newHeartbeatCaptureHandler(svr *server.Server, rd *render.Render) *heartbeatCaptureHandler {
+	return &heartbeatCaptureHandler{
+		svr: svr,
+		rd:  rd,
+	}
+}
+
+// @Tags     admin
+// @Summary  Start to record the region and store heartbeats received by this PD into a capture file under `heartbeat-capture.dir`, which can be replayed by pd-heartbeat-bench. The oldest capture files are removed to keep their total size under `heartbeat-capture.max-total-size`.
+// @Accept   json
+// @Param    body  body  capture.RecordConfig  true  "The duration, sample rate and size cap of the recording, e.g. {\"duration\": \"10m\", \"sample-rate\": 0.1, \"max-size\": \"256MiB\"}"
+// @Produce  json
+// @Success  200  {object}  capture.RecordStatus
+// @Failure  400  {string}  string  "The input is invalid or a recording is running."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /admin/heartbeat-capture [post]
+func (h *heartbeatCaptureHandler) StartCapture(w http.ResponseWriter, r *http.Request) {
+	var cfg capture.RecordConfig
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &cfg); err != nil {
+		return
+	}
+	status, err := h.svr.GetHeartbeatRecorder().Start(cfg)
+	if err != nil {
+		if errs.ErrCaptureIsRunning.Equal(err) || errs.ErrCaptureInvalidConfig.Equal(err) {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, status)
+}
+
+// @Tags     admin
+// @Summary  Get the status of the current or the last heartbeat capture.
+// @Produce  json
+// @Success  200  {object}  capture.RecordStatus
+// @Router   /admin/heartbeat-capture [get]
+func (h *heartbeatCaptureHandler) GetCapture(w http.ResponseWriter, _ *http.Request) {
+	h.rd.JSON(w, http.StatusOK, h.svr.GetHeartbeatRecorder().Status())
+}
+
+// @Tags     admin
+// @Summary  Stop the current heartbeat capture.
+// @Produce  json
+// @Success  200  {object}  capture.RecordStatus
+// @Router   /admin/heartbeat-capture [delete]
+func (h *heartbeatCaptureHandler) StopCapture(w http.ResponseWriter, _ *http.Request) {
+	h.rd.JSON(w, http.StatusOK, h.svr.GetHeartbeatRecorder().Stop())
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/capture"
+	tu "github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/server"
+)
+
+type heartbeatCaptureTestSuite struct {
+	suite.Suite
+	svr       *server.Server
+	cleanup   tu.CleanupFunc
+	urlPrefix string
+}
+
+func TestHeartbeatCaptureTestSuite(t *testing.T) {
+	suite.Run(t, new(heartbeatCaptureTestSuite))
+}
+
+func (suite *heartbeatCaptureTestSuite) SetupSuite() {
+	re := suite.Require()
+	suite.svr, suite.cleanup = mustNewServer(re)
+	server.MustWaitLeader(re, []*server.Server{suite.svr})
+
+	addr := suite.svr.GetAddr()
+	suite.urlPrefix = fmt.Sprintf("%s%s/api/v1/admin/heartbeat-capture", addr, apiPrefix)
+
+	mustBootstrapCluster(re, suite.svr)
+}
+
+func (suite *heartbeatCaptureTestSuite) TearDownSuite() {
+	suite.cleanup()
+}
+
+func (suite *heartbeatCaptureTestSuite) TestHeartbeatCapture() {
+	re := suite.Require()
+	status := &capture.RecordStatus{}
+	re.NoError(tu.ReadGetJSON(re, testDialClient, suite.urlPrefix, status))
+	re.False(status.Recording)
+
+	re.NoError(tu.CheckPostJSON(testDialClient, suite.urlPrefix, []byte(`{"sample-rate": 2}`), tu.StatusNotOK(re)))
+	re.NoError(tu.CheckPostJSON(testDialClient, suite.urlPrefix, []byte(`{"duration": "1m", "max-size": "1MiB"}`),
+		tu.StatusOK(re), tu.ExtractJSON(re, status)))
+	re.True(status.Recording)
+	re.Equal(1.0, status.Config.SampleRate)
+	// Only one recording can be running.
+	re.NoError(tu.CheckPostJSON(testDialClient, suite.urlPrefix, []byte(`{}`), tu.StatusNotOK(re)))
+
+	re.NoError(tu.CheckDelete(testDialClient, suite.urlPrefix, tu.StatusOK(re), tu.ExtractJSON(re, status)))
+	re.False(status.Recording)
+	re.Equal(capture.StopReasonStopped, status.StopReason)
+	stat, err := os.Stat(status.File)
+	re.NoError(err)
+	re.Equal(stat.Size(), status.Size)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/member"
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/pkg/utils/etcdutil"
@@ -45,10 +46,22 @@ func newMemberHandler(svr *server.Server, rd *render.Render) *memberHandler {
 	}
 }
 
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type memberWithLabels struct {
+	*pdpb.Member
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type getMembersResponse struct {
+	*pdpb.GetMembersResponse
+	Members []*memberWithLabels `json:"members"`
+}
+
 // @Tags     member
 // @Summary  List all PD servers in the cluster.
 // @Produce  json
-// @Success  200  {object}  pdpb.GetMembersResponse
+// @Success  200  {object}  getMembersResponse
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /members [get]
 func (h *memberHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
@@ -57,7 +70,18 @@ func (h *memberHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	h.rd.JSON(w, http.StatusOK, members)
+	resp := &getMembersResponse{
+		GetMembersResponse: members,
+		Members:            make([]*memberWithLabels, 0, len(members.GetMembers())),
+	}
+	for _, m := range members.GetMembers() {
+		labels, err := h.svr.GetMember().GetMemberLabels(m.GetMemberId())
+		if err != nil {
+			log.Warn("failed to load labels", zap.Uint64("member", m.GetMemberId()), errs.ZapError(err))
+		}
+		resp.Members = append(resp.Members, &memberWithLabels{Member: m, Labels: labels})
+	}
+	h.rd.JSON(w, http.StatusOK, resp)
 }
 
 func getMembers(svr *server.Server) (*pdpb.GetMembersResponse, error) {
@@ -312,3 +336,128 @@ func (h *leaderHandler) TransferLeader(w http.ResponseWriter, r *http.Request) {
 
 	h.rd.JSON(w, http.StatusOK, "The transfer command is submitted.")
 }
+
+// leaderPreference is the leader preference and the members ranked by it.
+type leaderPreference struct {
+	*member.LeaderPreference
+	Candidates []*member.LeaderCandidate `json:"candidates"`
+}
+
+// @Tags     leader
+// @Summary  Get the leader preference by labels and the members ranked by it.
+// @Produce  json
+// @Success  200  {object}  leaderPreference
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /leader/preference [get]
+func (h *leaderHandler) GetLeaderPreference(w http.ResponseWriter, r *http.Request) {
+	preference, err := h.svr.GetMember().GetLeaderPreference()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if preference == nil {
+		preference = &member.LeaderPreference{}
+	}
+	members, err := h.svr.GetMembers()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	candidates, err := h.svr.GetMember().GetLeaderCandidates(preference, members)
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.rd.JSON(w, http.StatusOK, &leaderPreference{LeaderPreference: preference, Candidates: candidates})
+}
+
+// @Tags     leader
+// @Summary  Set the leader preference by labels. The leader resigns if there is a healthy member with a higher preference.
+// @Accept   json
+// @Param    body  body  member.LeaderPreference  true  "The label preferences in the order of priority"
+// @Produce  json
+// @Success  200  {string}  string  "The leader preference is updated."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /leader/preference [post]
+func (h *leaderHandler) SetLeaderPreference(w http.ResponseWriter, r *http.Request) {
+	preference := &member.LeaderPreference{}
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, preference); err != nil {
+		return
+	}
+	for _, pref := range preference.Preferences {
+		if pref.Key == "" || pref.Value == "" {
+			h.rd.JSON(w, http.StatusBadRequest, "the key and value of the label preference should not be empty")
+			return
+		}
+	}
+	if err := h.svr.GetMember().SetLeaderPreference(preference); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.rd.JSON(w, http.StatusOK, "The leader preference is updated.")
+}
+
+// @Tags     leader
+// @Summary  Remove the leader preference by labels.
+// @Produce  json
+// @Success  200  {string}  string  "The leader preference is removed."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /leader/preference [delete]
+func (h *leaderHandler) DeleteLeaderPreference(w http.ResponseWriter, r *http.Request) {
+	if err := h.svr.GetMember().DeleteLeaderPreference(); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.rd.JSON(w, http.StatusOK, "The leader preference is removed.")
+}
+
+// @Tags     leader
+// @Summary  Hand off the PD leadership to the specific PD server gracefully.
+// @Param    nextLeader  path  string  true  "PD server that hands off the leadership to"
+// @Produce  json
+// @Success  200  {object}  member.LeaderHandoff
+// @Failure  400  {string}  string  "The leadership can't be handed off now."
+// @Failure  404  {string}  string  "The member is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /leader/handoff/{nextLeader} [post]
+func (h *leaderHandler) HandoffLeader(w http.ResponseWriter, r *http.Request) {
+	handoff, err := h.svr.HandoffLeader(mux.Vars(r)["next_leader"])
+	if err != nil {
+		switch {
+		case errs.ErrLeaderHandoffMemberNotFound.Equal(err):
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+		case errs.ErrLeaderHandoff.Equal(err):
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		default:
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	h.rd.JSON(w, http.StatusOK, handoff)
+}
+
+// @Tags     leader
+// @Summary  Get the ongoing leader handoff.
+// @Produce  json
+// @Success  200  {object}  member.LeaderHandoff
+// @Failure  404  {string}  string  "There is no ongoing leader handoff."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /leader/handoff [get]
+func (h *leaderHandler) GetLeaderHandoff(w http.ResponseWriter, r *http.Request) {
+	handoff, err := h.svr.GetLeaderHandoff()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if handoff == nil {
+		h.rd.JSON(w, http.StatusNotFound, "There is no ongoing leader handoff.")
+		return
+	}
+
+	h.rd.JSON(w, http.StatusOK, handoff)
+}
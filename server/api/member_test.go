@@ -82,6 +82,15 @@ func (suite *memberTestSuite) checkListResponse(body []byte, cfgs []*config.Conf
 			relaxEqualStings(re, member.PeerUrls, strings.Split(cfg.PeerUrls, ","))
 		}
 	}
+	// The labels are listed with the members.
+	labels := make(map[string][]struct {
+		Labels map[string]string `json:"labels"`
+	})
+	suite.NoError(json.Unmarshal(body, &labels))
+	suite.Len(labels["members"], len(cfgs))
+	for _, member := range labels["members"] {
+		suite.Equal(map[string]string{config.ZoneLabel: "dc-1"}, member.Labels)
+	}
 }
 
 func (suite *memberTestSuite) TestMemberList() {
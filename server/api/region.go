@@ -35,6 +35,7 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/keyspace"
+	"github.com/tikv/pd/pkg/schedule/checker"
 	"github.com/tikv/pd/pkg/schedule/filter"
 	"github.com/tikv/pd/pkg/statistics"
 	"github.com/tikv/pd/pkg/utils/apiutil"
@@ -1173,6 +1174,90 @@ func (h *regionsHandler) SplitRegions(w http.ResponseWriter, r *http.Request) {
 	h.rd.JSON(w, http.StatusOK, &s)
 }
 
+// @Tags     region
+// @Summary  Start to merge the small regions in the given key range in bulk.
+// @Accept   json
+// @Param    body  body  object  true  "json params"
+// @Produce  json
+// @Success  200  {string}  string  "The bulk merge is started."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/bulk-merge [post]
+func (h *regionsHandler) StartBulkMerge(w http.ResponseWriter, r *http.Request) {
+	var input map[string]interface{}
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &input); err != nil {
+		return
+	}
+	startKey, _, err := apiutil.ParseKey("start_key", input)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	endKey, _, err := apiutil.ParseKey("end_key", input)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	crossTable, _ := input["cross_table"].(bool)
+	// store_rate is the max number of the regions which have a peer on the same store to be merged per minute.
+	storeRate := float64(checker.DefaultBulkMergeStoreRate)
+	if rate, ok := input["store_rate"].(float64); ok {
+		if rate <= 0 {
+			h.rd.JSON(w, http.StatusBadRequest, "store_rate should be positive.")
+			return
+		}
+		storeRate = rate
+	}
+	if err := h.svr.GetHandler().StartBulkMerge(startKey, endKey, crossTable, storeRate); err != nil {
+		if errs.ErrBulkMergeRunning.Equal(err) {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The bulk merge is started.")
+}
+
+// @Tags     region
+// @Summary  Get the status of the bulk merge.
+// @Produce  json
+// @Success  200  {object}  checker.BulkMergeStatus
+// @Failure  404  {string}  string  "The bulk merge is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/bulk-merge [get]
+func (h *regionsHandler) GetBulkMergeStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.svr.GetHandler().GetBulkMergeStatus()
+	if err != nil {
+		if errs.ErrBulkMergeNotFound.Equal(err) {
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, status)
+}
+
+// @Tags     region
+// @Summary  Stop the running bulk merge.
+// @Produce  json
+// @Success  200  {string}  string  "The bulk merge is stopped."
+// @Failure  404  {string}  string  "The bulk merge is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/bulk-merge [delete]
+func (h *regionsHandler) StopBulkMerge(w http.ResponseWriter, r *http.Request) {
+	if err := h.svr.GetHandler().StopBulkMerge(); err != nil {
+		if errs.ErrBulkMergeNotFound.Equal(err) {
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The bulk merge is stopped.")
+}
+
 // RegionHeap implements heap.Interface, used for selecting top n regions.
 type RegionHeap struct {
 	regions []*core.RegionInfo
@@ -155,6 +155,34 @@ func (suite *regionTestSuite) TestRegion() {
 	suite.Equal(NewAPIRegionInfo(r), r2)
 }
 
+func (suite *regionTestSuite) TestFollowerReadOnLeader() {
+	re := suite.Require()
+	r := core.NewTestRegionInfo(20, 1, []byte("fa"), []byte("fb"))
+	mustRegionHeartbeat(re, suite.svr, r)
+
+	url := fmt.Sprintf("%s/follower-read/region/id/%d", suite.urlPrefix, r.GetID())
+	re.NoError(tu.CheckGetJSON(testDialClient, url, nil, tu.Status(re, http.StatusBadRequest)))
+	re.NoError(tu.CheckGetJSON(testDialClient, url+"?max-staleness=0s", nil, tu.Status(re, http.StatusBadRequest)))
+	// The leader serves the follower read by itself.
+	r1 := &RegionInfo{}
+	re.NoError(tu.ReadGetJSON(re, testDialClient, url+"?max-staleness=3s", r1))
+	r1.Adjust()
+	re.Equal(NewAPIRegionInfo(r), r1)
+
+	url = fmt.Sprintf("%s/follower-read/region/key/%s?max-staleness=3s", suite.urlPrefix, "fa")
+	r2 := &RegionInfo{}
+	re.NoError(tu.ReadGetJSON(re, testDialClient, url, r2))
+	r2.Adjust()
+	re.Equal(NewAPIRegionInfo(r), r2)
+
+	url = fmt.Sprintf("%s/follower-read/store/%d?max-staleness=3s", suite.urlPrefix, store.GetId())
+	storeInfo := &StoreInfo{}
+	re.NoError(tu.ReadGetJSON(re, testDialClient, url, storeInfo))
+	re.Equal(store.GetId(), storeInfo.Store.GetId())
+	url = fmt.Sprintf("%s/follower-read/store/%d?max-staleness=3s", suite.urlPrefix, 100)
+	re.NoError(tu.CheckGetJSON(testDialClient, url, nil, tu.Status(re, http.StatusNotFound)))
+}
+
 func (suite *regionTestSuite) TestRegionCheck() {
 	r := core.NewTestRegionInfo(2, 1, []byte("a"), []byte("b"),
 		core.SetApproximateKeys(10),
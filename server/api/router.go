@@ -244,6 +244,11 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(clusterRouter, "/region/id/{id}", regionHandler.GetRegionByID, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(clusterRouter.UseEncodedPath(), "/region/key/{key}", regionHandler.GetRegion, setMethods(http.MethodGet), setAuditBackend(prometheus))
 
+	followerReadHandler := newFollowerReadHandler(svr, rd)
+	registerFunc(apiRouter, "/follower-read/region/id/{id}", followerReadHandler.GetRegionByID, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter.NewRoute().Subrouter().UseEncodedPath(), "/follower-read/region/key/{key}", followerReadHandler.GetRegion, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/follower-read/store/{id}", followerReadHandler.GetStore, setMethods(http.MethodGet), setAuditBackend(prometheus))
+
 	srd := createStreamingRender()
 	regionsAllHandler := newRegionsHandler(svr, srd)
 	registerFunc(clusterRouter, "/regions", regionsAllHandler.GetRegions, setMethods(http.MethodGet), setAuditBackend(localLog, prometheus))
@@ -276,7 +281,15 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(clusterRouter, "/regions/accelerate-schedule", regionsHandler.AccelerateRegionsScheduleInRange, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(clusterRouter, "/regions/accelerate-schedule/batch", regionsHandler.AccelerateRegionsScheduleInRanges, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(clusterRouter, "/regions/scatter", regionsHandler.ScatterRegions, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	scatterJobHandler := newScatterJobHandler(svr, rd)
+	registerFunc(clusterRouter, "/regions/scatter/jobs", scatterJobHandler.CreateScatterJob, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(clusterRouter, "/regions/scatter/jobs", scatterJobHandler.GetScatterJobs, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(clusterRouter, "/regions/scatter/jobs/{id}", scatterJobHandler.GetScatterJob, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(clusterRouter, "/regions/scatter/jobs/{id}", scatterJobHandler.CancelScatterJob, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 	registerFunc(clusterRouter, "/regions/split", regionsHandler.SplitRegions, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(clusterRouter, "/regions/bulk-merge", regionsHandler.StartBulkMerge, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(clusterRouter, "/regions/bulk-merge", regionsHandler.GetBulkMergeStatus, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(clusterRouter, "/regions/bulk-merge", regionsHandler.StopBulkMerge, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 	registerFunc(clusterRouter, "/regions/range-holes", regionsHandler.GetRangeHoles, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(clusterRouter, "/regions/replicated", regionsHandler.CheckRegionsReplicated, setMethods(http.MethodGet), setQueries("startKey", "{startKey}", "endKey", "{endKey}"), setAuditBackend(prometheus))
 
@@ -293,6 +306,11 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(apiRouter, "/leader", leaderHandler.GetLeader, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/leader/resign", leaderHandler.ResignLeader, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/leader/transfer/{next_leader}", leaderHandler.TransferLeader, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/leader/preference", leaderHandler.GetLeaderPreference, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/leader/preference", leaderHandler.SetLeaderPreference, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/leader/preference", leaderHandler.DeleteLeaderPreference, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/leader/handoff", leaderHandler.GetLeaderHandoff, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/leader/handoff/{next_leader}", leaderHandler.HandoffLeader, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 
 	statsHandler := newStatsHandler(svr, rd)
 	registerFunc(clusterRouter, "/stats/region", statsHandler.GetRegionStatus, setMethods(http.MethodGet), setAuditBackend(prometheus))
@@ -310,6 +328,11 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(apiRouter, "/admin/cluster/markers/snapshot-recovering", adminHandler.UnmarkSnapshotRecovering, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/admin/base-alloc-id", adminHandler.RecoverAllocID, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 
+	heartbeatCaptureHandler := newHeartbeatCaptureHandler(svr, rd)
+	registerFunc(apiRouter, "/admin/heartbeat-capture", heartbeatCaptureHandler.GetCapture, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/admin/heartbeat-capture", heartbeatCaptureHandler.StartCapture, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/admin/heartbeat-capture", heartbeatCaptureHandler.StopCapture, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
+
 	serviceMiddlewareHandler := newServiceMiddlewareHandler(svr, rd)
 	registerFunc(apiRouter, "/service-middleware/config", serviceMiddlewareHandler.GetServiceMiddlewareConfig, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/service-middleware/config", serviceMiddlewareHandler.SetServiceMiddlewareConfig, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
@@ -350,6 +373,19 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	serviceGCSafepointHandler := newServiceGCSafepointHandler(svr, rd)
 	registerFunc(apiRouter, "/gc/safepoint", serviceGCSafepointHandler.GetGCSafePoint, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/gc/safepoint/{service_id}", serviceGCSafepointHandler.DeleteGCSafePoint, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/gc/safepoint/{service_id}/expire", serviceGCSafepointHandler.ExpireGCSafePoint, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+
+	// auto scaling API
+	autoScalingHandler := newAutoScalingHandler(svr, rd)
+	registerFunc(apiRouter, "/autoscaling/strategy", autoScalingHandler.GetStrategy, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/autoscaling/strategy", autoScalingHandler.SetStrategy, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/autoscaling/strategy", autoScalingHandler.RemoveStrategy, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/autoscaling/plans", autoScalingHandler.GetPlanHistory, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/autoscaling/simulate", autoScalingHandler.Simulate, setMethods(http.MethodPost), setAuditBackend(prometheus))
+
+	// scheduling owner API
+	schedulingOwnerHandler := newSchedulingOwnerHandler(svr, rd)
+	registerFunc(apiRouter, "/scheduling/owner", schedulingOwnerHandler.GetOwner, setMethods(http.MethodGet), setAuditBackend(prometheus))
 
 	// min resolved ts API
 	minResolvedTSHandler := newMinResolvedTSHandler(svr, rd)
@@ -366,6 +402,9 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	// tso API
 	tsoHandler := newTSOHandler(svr, rd)
 	registerFunc(apiRouter, "/tso/allocator/transfer/{name}", tsoHandler.TransferLocalTSOAllocator, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/debug/tso/traces", tsoHandler.GetTraces, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/debug/tso/traces/config", tsoHandler.GetTraceConfig, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/debug/tso/traces/config", tsoHandler.SetTraceConfig, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	tsoAdminHandler := tso.NewAdminHandler(svr.GetHandler(), rd)
 	// br ebs restore phase 1 will reset ts, but at that time the cluster hasn't bootstrapped, so cannot use clusterRouter
 	registerFunc(apiRouter, "/admin/reset-ts", tsoAdminHandler.ResetTS, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
@@ -0,0 +1,159 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/errcode"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	"github.com/tikv/pd/server"
+	"github.com/unrolled/render"
+)
+
+type scatterJobHandler struct {
+	*server.Handler
+	rd *render.Render
+}
+
+func newScatterJobHandler(svr *server.Server, rd *render.Render) *scatterJobHandler {
+	return &scatterJobHandler{
+		Handler: svr.GetHandler(),
+		rd:      rd,
+	}
+}
+
+// @Tags     region
+// @Summary  Create a persistent job to scatter the regions in the given key range.
+// @Accept   json
+// @Param    body  body  object  true  "json params"
+// @Produce  json
+// @Success  200  {object}  scatter.Job
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/scatter/jobs [post]
+func (h *scatterJobHandler) CreateScatterJob(w http.ResponseWriter, r *http.Request) {
+	var input map[string]interface{}
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &input); err != nil {
+		return
+	}
+	startKey, _, err := apiutil.ParseKey("start_key", input)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	endKey, _, err := apiutil.ParseKey("end_key", input)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(endKey) != 0 && bytes.Compare(startKey, endKey) >= 0 {
+		h.rd.JSON(w, http.StatusBadRequest, "start_key should be less than end_key.")
+		return
+	}
+	group, _ := input["group"].(string)
+	// Both the peers and leaders are scattered by default.
+	scatterPeer := true
+	if dimension, ok := input["dimension"].(string); ok {
+		switch dimension {
+		case "peer":
+		case "leader":
+			scatterPeer = false
+		default:
+			h.rd.JSON(w, http.StatusBadRequest, "dimension should be peer or leader.")
+			return
+		}
+	}
+	job, err := h.Handler.CreateScatterJob(startKey, endKey, group, scatterPeer)
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, job)
+}
+
+// @Tags     region
+// @Summary  List all scatter jobs.
+// @Produce  json
+// @Success  200  {array}   scatter.Job
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/scatter/jobs [get]
+func (h *scatterJobHandler) GetScatterJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.Handler.GetScatterJobs()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, jobs)
+}
+
+// @Tags     region
+// @Summary  Get the scatter job by ID.
+// @Param    id  path  integer  true  "Job ID"
+// @Produce  json
+// @Success  200  {object}  scatter.Job
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The scatter job is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/scatter/jobs/{id} [get]
+func (h *scatterJobHandler) GetScatterJob(w http.ResponseWriter, r *http.Request) {
+	id, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	job, err := h.Handler.GetScatterJob(id)
+	if err != nil {
+		if errs.ErrScatterJobNotFound.Equal(err) {
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, job)
+}
+
+// @Tags     region
+// @Summary  Cancel the running scatter job by ID.
+// @Param    id  path  integer  true  "Job ID"
+// @Produce  json
+// @Success  200  {string}  string  "The scatter job is canceled."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The scatter job is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/scatter/jobs/{id} [delete]
+func (h *scatterJobHandler) CancelScatterJob(w http.ResponseWriter, r *http.Request) {
+	id, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	if err := h.Handler.CancelScatterJob(id); err != nil {
+		switch {
+		case errs.ErrScatterJobNotFound.Equal(err):
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+		case errs.ErrScatterJobNotRunning.Equal(err):
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		default:
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The scatter job is canceled.")
+}
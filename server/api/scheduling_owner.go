@@ -0,0 +1,55 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/tikv/pd/pkg/schedule/handoff"
+	"github.com/tikv/pd/server"
+	"github.com/unrolled/render"
+)
+
+type schedulingOwnerHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newSchedulingOwnerHandler(svr *server.Server, rd *render.Render) *schedulingOwnerHandler {
+	return &schedulingOwnerHandler{
+		svr: svr,
+		rd:  rd,
+	}
+}
+
+// @Tags     scheduling
+// @Summary  Get the owner of the scheduling, which is either the PD leader or the scheduling service primary, and when the ownership is switched last time. The owner still runs the scheduling if it fails to record the ownership, e.g. etcd is unavailable, so the record may lag behind the actual owner.
+// @Produce  json
+// @Success  200  {object}  handoff.Record
+// @Failure  404  {string}  string  "The scheduling owner is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /scheduling/owner [get]
+func (h *schedulingOwnerHandler) GetOwner(w http.ResponseWriter, r *http.Request) {
+	record, err := handoff.Load(h.svr.GetClient(), h.svr.ClusterID())
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if record == nil {
+		h.rd.JSON(w, http.StatusNotFound, "The scheduling owner is not found.")
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, record)
+}
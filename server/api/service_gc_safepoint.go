@@ -16,9 +16,12 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/server"
 	"github.com/unrolled/render"
 )
@@ -71,12 +74,17 @@ func (h *serviceGCSafepointHandler) GetGCSafePoint(w http.ResponseWriter, r *htt
 // @Param    service_id  path  string  true  "Service ID"
 // @Produce  json
 // @Success  200  {string}  string  "Delete service GC safepoint successfully."
+// @Failure  400  {string}  string  "The service GC safepoint of gc_worker cannot be removed."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /gc/safepoint/{service_id} [delete]
 // @Tags     rule
 func (h *serviceGCSafepointHandler) DeleteGCSafePoint(w http.ResponseWriter, r *http.Request) {
 	storage := h.svr.GetStorage()
 	serviceID := mux.Vars(r)["service_id"]
+	if serviceID == endpoint.GCWorkerServiceSafePointID {
+		h.rd.JSON(w, http.StatusBadRequest, errs.ErrRemoveGCWorkerServiceSafePoint.FastGenByArgs().Error())
+		return
+	}
 	err := storage.RemoveServiceGCSafePoint(serviceID)
 	if err != nil {
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
@@ -84,3 +92,32 @@ func (h *serviceGCSafepointHandler) DeleteGCSafePoint(w http.ResponseWriter, r *
 	}
 	h.rd.JSON(w, http.StatusOK, "Delete service GC safepoint successfully.")
 }
+
+// @Tags     service_gc_safepoint
+// @Summary  Force a service GC safepoint to expire. The service can only register again with a newer safepoint.
+// @Param    service_id  path  string  true  "Service ID"
+// @Produce  json
+// @Success  200  {object}  endpoint.ServiceSafePoint
+// @Failure  400  {string}  string  "The service GC safepoint of gc_worker cannot be expired."
+// @Failure  404  {string}  string  "The service GC safepoint is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /gc/safepoint/{service_id}/expire [post]
+func (h *serviceGCSafepointHandler) ExpireGCSafePoint(w http.ResponseWriter, r *http.Request) {
+	serviceID := mux.Vars(r)["service_id"]
+	ip, _ := apiutil.GetIPPortFromHTTPRequest(r)
+	caller := apiutil.GetComponentNameOnHTTP(r) + "@" + ip
+	ssp, err := h.svr.GetGCSafePointManager().ExpireServiceGCSafePoint(serviceID, caller, time.Now())
+	if err != nil {
+		if errs.ErrServiceGCSafePointNotFound.Equal(err) {
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if errs.ErrRemoveGCWorkerServiceSafePoint.Equal(err) {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, ssp)
+}
@@ -16,6 +16,7 @@ package api
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -98,4 +99,16 @@ func (suite *serviceGCSafepointTestSuite) TestServiceGCSafepoint() {
 	left, err := storage.LoadAllServiceGCSafePoints()
 	suite.NoError(err)
 	suite.Equal(list.ServiceGCSafepoints[1:], left)
+
+	err = testutil.CheckPostJSON(testDialClient, sspURL+"/b/expire", nil, testutil.StatusOK(suite.Require()))
+	suite.NoError(err)
+	left, err = storage.LoadAllServiceGCSafePoints()
+	suite.NoError(err)
+	suite.Equal(list.ServiceGCSafepoints[2:], left)
+	err = testutil.CheckPostJSON(testDialClient, sspURL+"/b/expire", nil, testutil.Status(suite.Require(), http.StatusNotFound))
+	suite.NoError(err)
+	err = testutil.CheckDelete(testDialClient, sspURL+"/gc_worker", testutil.Status(suite.Require(), http.StatusBadRequest))
+	suite.NoError(err)
+	err = testutil.CheckPostJSON(testDialClient, sspURL+"/gc_worker/expire", nil, testutil.Status(suite.Require(), http.StatusBadRequest))
+	suite.NoError(err)
 }
@@ -17,8 +17,10 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/server"
 	"github.com/unrolled/render"
 )
@@ -77,3 +79,55 @@ func (h *tsoHandler) TransferLocalTSOAllocator(w http.ResponseWriter, r *http.Re
 	}
 	h.rd.JSON(w, http.StatusOK, "The transfer command is submitted.")
 }
+
+// @Tags     tso
+// @Summary  Get the sampled traces of the TSO requests, the newest first.
+// @Param    client    query  string   false  "The address of the client to filter the traces"
+// @Param    trace_id  query  string   false  "The trace ID propagated by the client to filter the traces"
+// @Param    limit     query  integer  false  "The max number of the traces"
+// @Produce  json
+// @Success  200  {array}   tso.Trace
+// @Failure  400  {string}  string  "The input is invalid."
+// @Router   /debug/tso/traces [get]
+func (h *tsoHandler) GetTraces(w http.ResponseWriter, r *http.Request) {
+	var (
+		limit int
+		err   error
+	)
+	if limitStr := r.URL.Query().Get("limit"); len(limitStr) > 0 {
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit < 0 {
+			h.rd.JSON(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+	h.rd.JSON(w, http.StatusOK, h.svr.GetTSOTracer().GetTraces(r.URL.Query().Get("client"), r.URL.Query().Get("trace_id"), limit))
+}
+
+// @Tags     tso
+// @Summary  Get the config of the TSO request tracing.
+// @Produce  json
+// @Success  200  {object}  tso.TracerConfig
+// @Router   /debug/tso/traces/config [get]
+func (h *tsoHandler) GetTraceConfig(w http.ResponseWriter, _ *http.Request) {
+	h.rd.JSON(w, http.StatusOK, h.svr.GetTSOTracer().GetConfig())
+}
+
+// @Tags     tso
+// @Summary  Set the config of the TSO request tracing, the tracing is disabled if the sample rate is 0.
+// @Accept   json
+// @Param    body  body  tso.TracerConfig  true  "json params"
+// @Produce  json
+// @Success  200  {string}  string  "The tracing config is updated."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Router   /debug/tso/traces/config [post]
+func (h *tsoHandler) SetTraceConfig(w http.ResponseWriter, r *http.Request) {
+	cfg := h.svr.GetTSOTracer().GetConfig()
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &cfg); err != nil {
+		return
+	}
+	if err := h.svr.GetTSOTracer().SetConfig(cfg); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The tracing config is updated.")
+}
@@ -16,10 +16,12 @@ package api
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/tso"
 	tu "github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/server"
 	"github.com/tikv/pd/server/config"
@@ -63,3 +65,22 @@ func (suite *tsoTestSuite) TestTransferAllocator() {
 	err := tu.CheckPostJSON(testDialClient, addr, nil, tu.StatusOK(re))
 	suite.NoError(err)
 }
+
+func (suite *tsoTestSuite) TestTraceConfig() {
+	re := suite.Require()
+	addr := suite.urlPrefix + "/debug/tso/traces/config"
+	err := tu.CheckPostJSON(testDialClient, addr, []byte(`{"sample-rate":2}`), tu.Status(re, http.StatusBadRequest))
+	re.NoError(err)
+	err = tu.CheckPostJSON(testDialClient, addr, []byte(`{"sample-rate":1,"capacity":16}`), tu.StatusOK(re))
+	re.NoError(err)
+	var cfg tso.TracerConfig
+	re.NoError(tu.ReadGetJSON(re, testDialClient, addr, &cfg))
+	re.Equal(tso.TracerConfig{SampleRate: 1, Capacity: 16}, cfg)
+
+	var traces []*tso.Trace
+	re.NoError(tu.ReadGetJSON(re, testDialClient, suite.urlPrefix+"/debug/tso/traces?limit=10", &traces))
+	err = tu.CheckGetJSON(testDialClient, suite.urlPrefix+"/debug/tso/traces?limit=x", nil, tu.Status(re, http.StatusBadRequest))
+	re.NoError(err)
+	err = tu.CheckPostJSON(testDialClient, addr, []byte(`{"sample-rate":0}`), tu.StatusOK(re))
+	re.NoError(err)
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tikv/pd/pkg/mcs/discovery"
+	"github.com/tikv/pd/server"
+	"github.com/tikv/pd/server/apiv2/middlewares"
+)
+
+// RegisterMicroService registers microservice handlers to the server.
+func RegisterMicroService(r *gin.RouterGroup) {
+	router := r.Group("ms")
+	router.GET("members/:service", GetMicroServiceMembers)
+}
+
+// GetMicroServiceMembers gets the registry entries of the given microservice. The entries can be
+// filtered by the version, the labels in the form of `label=key:value` and the health status, and
+// the healthy and less loaded ones come first.
+func GetMicroServiceMembers(c *gin.Context) {
+	filters := make([]discovery.EntryFilter, 0, 3)
+	if version := c.Query("version"); version != "" {
+		filters = append(filters, discovery.WithVersion(version))
+	}
+	if labelQueries := c.QueryArray("label"); len(labelQueries) > 0 {
+		labels := make(map[string]string, len(labelQueries))
+		for _, label := range labelQueries {
+			kv := strings.SplitN(label, ":", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				c.AbortWithStatusJSON(http.StatusBadRequest, "invalid label "+label)
+				return
+			}
+			labels[kv[0]] = kv[1]
+		}
+		filters = append(filters, discovery.WithLabels(labels))
+	}
+	if healthy, set := c.GetQuery("healthy"); set {
+		onlyHealthy, err := strconv.ParseBool(healthy)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, "invalid healthy "+healthy)
+			return
+		}
+		if onlyHealthy {
+			filters = append(filters, discovery.WithHealthy(discovery.DefaultStaleTimeout))
+		}
+	}
+
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	entries, err := discovery.DiscoverEntries(svr.GetClient(), strconv.FormatUint(svr.ClusterID(), 10), c.Param("service"), filters...)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	discovery.SortEntries(entries, discovery.DefaultStaleTimeout)
+	c.IndentedJSON(http.StatusOK, entries)
+}
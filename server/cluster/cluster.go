@@ -36,6 +36,7 @@ import (
 	"github.com/tikv/pd/pkg/cluster"
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/storelimit"
+	"github.com/tikv/pd/pkg/election"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/gc"
 	"github.com/tikv/pd/pkg/gctuner"
@@ -47,6 +48,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule"
 	"github.com/tikv/pd/pkg/schedule/checker"
 	sc "github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/schedule/handoff"
 	"github.com/tikv/pd/pkg/schedule/hbstream"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
@@ -125,6 +127,7 @@ type Server interface {
 	GetKeyspaceGroupManager() *keyspace.GroupManager
 	IsAPIServiceMode() bool
 	GetSafePointV2Manager() *gc.SafePointV2Manager
+	GetLeadership() *election.Leadership
 }
 
 // RaftCluster is used for cluster config management.
@@ -174,6 +177,10 @@ type RaftCluster struct {
 	regionSyncer             *syncer.RegionSyncer
 	changedRegions           chan *core.RegionInfo
 	keyspaceGroupManager     *keyspace.GroupManager
+	// schedulingOwner identifies this server as the scheduling owner when it runs the coordinator.
+	schedulingOwner *handoff.Record
+	// leadership is the PD leadership, which the scheduling ownership is bound to.
+	leadership *election.Leadership
 }
 
 // Status saves some state information.
@@ -291,6 +298,12 @@ func (c *RaftCluster) Start(s Server) error {
 	}
 
 	c.isAPIServiceMode = s.IsAPIServiceMode()
+	c.schedulingOwner = &handoff.Record{
+		Owner: handoff.OwnerPD,
+		Name:  s.GetConfig().Name,
+		Addr:  s.GetConfig().AdvertiseClientUrls,
+	}
+	c.leadership = s.GetLeadership()
 	c.InitCluster(s.GetAllocator(), s.GetPersistOptions(), s.GetStorage(), s.GetBasicCluster(), s.GetKeyspaceGroupManager())
 	cluster, err := c.LoadClusterInfo()
 	if err != nil {
@@ -701,9 +714,59 @@ func (c *RaftCluster) runUpdateStoreStats() {
 func (c *RaftCluster) runCoordinator() {
 	defer logutil.LogPanic()
 	defer c.wg.Done()
+	c.acquireScheduling()
 	c.coordinator.RunUntilStop()
 }
 
+// acquireScheduling takes over the scheduling ownership before running the coordinator,
+// and resumes the paused checkers and schedulers handed off by the previous owner. It runs
+// in the coordinator goroutine, so the leader serves without waiting. Acquiring only fails if
+// the leadership is lost or etcd is unavailable, in which case the coordinator still runs
+// until the leader steps down, without being recorded as the owner.
+func (c *RaftCluster) acquireScheduling() {
+	if c.etcdClient == nil || c.leadership == nil {
+		return
+	}
+	prev, err := handoff.Acquire(c.ctx, c.etcdClient, c.clusterID, c.leadership, c.schedulingOwner, handoff.DefaultWaitTimeout)
+	if err != nil {
+		log.Warn("failed to acquire the scheduling ownership", errs.ZapError(err))
+		return
+	}
+	if prev == nil || prev.RunningState == nil {
+		return
+	}
+	c.wg.Add(1)
+	go func() {
+		defer logutil.LogPanic()
+		defer c.wg.Done()
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for !c.coordinator.AreSchedulersInitialized() {
+			select {
+			case <-c.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+		prev.RunningState.Resume(c.coordinator)
+	}()
+}
+
+// ReleaseScheduling releases the scheduling ownership before the coordinator stops. It should
+// be called before the leader resigns, so that the in-flight operators could be drained while
+// the lease is still valid, and the release could be recorded. It's a no-op if the ownership
+// has already been released.
+func (c *RaftCluster) ReleaseScheduling() {
+	if !c.IsRunning() || c.etcdClient == nil || c.leadership == nil || c.isAPIServiceMode {
+		return
+	}
+	drainTimeout := handoff.BoundDrainTimeout(c.leadership.LeaseRemaining())
+	if _, err := handoff.Release(c.ctx, c.etcdClient, c.clusterID, c.leadership, c.schedulingOwner,
+		c.coordinator, drainTimeout); err != nil {
+		log.Warn("failed to release the scheduling ownership", errs.ZapError(err))
+	}
+}
+
 func (c *RaftCluster) syncRegions() {
 	defer logutil.LogPanic()
 	defer c.wg.Done()
@@ -718,6 +781,7 @@ func (c *RaftCluster) runReplicationMode() {
 
 // Stop stops the cluster.
 func (c *RaftCluster) Stop() {
+	c.ReleaseScheduling()
 	c.Lock()
 	if !c.running {
 		c.Unlock()
@@ -846,6 +910,11 @@ func (c *RaftCluster) GetRuleManager() *placement.RuleManager {
 	return c.ruleManager
 }
 
+// GetProgressManager returns the progress manager.
+func (c *RaftCluster) GetProgressManager() *progress.Manager {
+	return c.progressManager
+}
+
 // GetRegionLabeler returns the region labeler.
 func (c *RaftCluster) GetRegionLabeler() *labeler.RegionLabeler {
 	return c.regionLabeler
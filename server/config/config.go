@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -36,6 +37,7 @@ import (
 	"github.com/tikv/pd/pkg/utils/configutil"
 	"github.com/tikv/pd/pkg/utils/grpcutil"
 	"github.com/tikv/pd/pkg/utils/metricutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/pkg/versioninfo"
 	"go.etcd.io/etcd/embed"
@@ -95,12 +97,25 @@ type Config struct {
 	// be automatically clamped to the range.
 	TSOUpdatePhysicalInterval typeutil.Duration `toml:"tso-update-physical-interval" json:"tso-update-physical-interval"`
 
+	// TSOPhysicalPrecision is the precision of the physical part of timestamp, "ms" or "us".
+	// TSOLogicalBits is the number of bits of the logical part of timestamp.
+	// Only the clients which negotiate the layout with PD can get the timestamp with the non-default layout.
+	// The layout can only be changed in the direction that keeps the timestamp monotonic, e.g, increasing the
+	// logical bits, otherwise PD will refuse to provide the TSO service.
+	TSOPhysicalPrecision string `toml:"tso-physical-precision" json:"tso-physical-precision"`
+	TSOLogicalBits       int    `toml:"tso-logical-bits" json:"tso-logical-bits"`
+
 	// EnableLocalTSO is used to enable the Local TSO Allocator feature,
 	// which allows the PD server to generate Local TSO for certain DC-level transactions.
 	// To make this feature meaningful, user has to set the "zone" label for the PD server
 	// to indicate which DC this PD belongs to.
 	EnableLocalTSO bool `toml:"enable-local-tso" json:"enable-local-tso"`
 
+	// EnableFollowerRead is used to allow the follower to serve the region and store reads
+	// with the regions synced from the leader, only the requests which tolerate the staleness
+	// of the follower will be served. It requires `use-region-storage` to sync the regions.
+	EnableFollowerRead bool `toml:"enable-follower-read" json:"enable-follower-read"`
+
 	Metric metricutil.MetricConfig `toml:"metric" json:"metric"`
 
 	Schedule sc.ScheduleConfig `toml:"schedule" json:"schedule"`
@@ -164,6 +179,8 @@ type Config struct {
 
 	Keyspace KeyspaceConfig `toml:"keyspace" json:"keyspace"`
 
+	HeartbeatCapture HeartbeatCaptureConfig `toml:"heartbeat-capture" json:"heartbeat-capture"`
+
 	Controller rm.ControllerConfig `toml:"controller" json:"controller"`
 }
 
@@ -221,6 +238,9 @@ const (
 
 	defaultDRWaitStoreTimeout = time.Minute
 
+	defaultHeartbeatCaptureDir          = "heartbeat-capture"
+	defaultHeartbeatCaptureMaxTotalSize = typeutil.ByteSize(4 * units.GiB)
+
 	defaultMaxConcurrentTSOProxyStreamings = 5000
 	defaultTSOProxyRecvFromClientTimeout   = 1 * time.Hour
 
@@ -414,6 +434,18 @@ func (c *Config) Adjust(meta *toml.MetaData, reloading bool) error {
 			zap.Duration("update-physical-interval", c.TSOUpdatePhysicalInterval.Duration))
 	}
 
+	configutil.AdjustString(&c.TSOPhysicalPrecision, tsoutil.DefaultLayout.PhysicalPrecision)
+	configutil.AdjustInt(&c.TSOLogicalBits, tsoutil.DefaultLayout.LogicalBits)
+	if layout := c.GetTSOLayout(); !layout.IsDefault() {
+		if err := layout.Validate(); err != nil {
+			return err
+		}
+		if c.EnableLocalTSO {
+			return errors.New("the non-default tso layout is not supported when local tso is enabled")
+		}
+		log.Warn("tso layout is non-default", zap.Stringer("layout", layout))
+	}
+
 	if c.Labels == nil {
 		c.Labels = make(map[string]string)
 	}
@@ -460,6 +492,8 @@ func (c *Config) Adjust(meta *toml.MetaData, reloading bool) error {
 
 	c.Keyspace.adjust(configMetaData.Child("keyspace"))
 
+	c.HeartbeatCapture.adjust(c.DataDir)
+
 	c.Security.Encryption.Adjust()
 
 	c.Controller.Adjust(configMetaData.Child("controller"))
@@ -520,6 +554,18 @@ type PDServerConfig struct {
 	GCTunerThreshold float64 `toml:"gc-tuner-threshold" json:"gc-tuner-threshold"`
 	// BlockSafePointV1 is used to control gc safe point v1 and service safe point v1 can not be updated.
 	BlockSafePointV1 bool `toml:"block-safe-point-v1" json:"block-safe-point-v1,string"`
+	// MaxServiceGCSafePointLag is the max duration a service safe point can lag behind the current TSO.
+	// The update of a service safe point lagging behind more than it will be rejected. 0 means no limit.
+	MaxServiceGCSafePointLag typeutil.Duration `toml:"max-service-gc-safe-point-lag" json:"max-service-gc-safe-point-lag"`
+	// MaxServiceGCSafePointTTL is the max TTL of a service safe point, a larger TTL will be capped to it.
+	// 0 means no limit.
+	MaxServiceGCSafePointTTL typeutil.Duration `toml:"max-service-gc-safe-point-ttl" json:"max-service-gc-safe-point-ttl"`
+	// ServiceGCSafePointAllowList is the service ID patterns which are allowed to update the service safe point.
+	// Empty means all services are allowed. The pattern syntax is the same as `path.Match`.
+	ServiceGCSafePointAllowList typeutil.StringSlice `toml:"service-gc-safe-point-allow-list" json:"service-gc-safe-point-allow-list"`
+	// ServiceGCSafePointDenyList is the service ID patterns which are denied to update the service safe point.
+	// It takes precedence over ServiceGCSafePointAllowList.
+	ServiceGCSafePointDenyList typeutil.StringSlice `toml:"service-gc-safe-point-deny-list" json:"service-gc-safe-point-deny-list"`
 }
 
 func (c *PDServerConfig) adjust(meta *configutil.ConfigMetaData) error {
@@ -587,8 +633,12 @@ func migrateConfigurationFromFile(meta *configutil.ConfigMetaData) error {
 // Clone returns a cloned PD server config.
 func (c *PDServerConfig) Clone() *PDServerConfig {
 	runtimeServices := append(c.RuntimeServices[:0:0], c.RuntimeServices...)
+	allowList := append(c.ServiceGCSafePointAllowList[:0:0], c.ServiceGCSafePointAllowList...)
+	denyList := append(c.ServiceGCSafePointDenyList[:0:0], c.ServiceGCSafePointDenyList...)
 	cfg := *c
 	cfg.RuntimeServices = runtimeServices
+	cfg.ServiceGCSafePointAllowList = allowList
+	cfg.ServiceGCSafePointDenyList = denyList
 	return &cfg
 }
 
@@ -618,6 +668,16 @@ func (c *PDServerConfig) Validate() error {
 	if c.GCTunerThreshold < minGCTunerThreshold || c.GCTunerThreshold > maxGCTunerThreshold {
 		return errors.New(fmt.Sprintf("gc-tuner-threshold should between %v and %v", minGCTunerThreshold, maxGCTunerThreshold))
 	}
+	if c.MaxServiceGCSafePointLag.Duration < 0 || c.MaxServiceGCSafePointTTL.Duration < 0 {
+		return errs.ErrConfigItem.GenWithStack("max-service-gc-safe-point-lag and max-service-gc-safe-point-ttl cannot be negative")
+	}
+	for _, patterns := range []typeutil.StringSlice{c.ServiceGCSafePointAllowList, c.ServiceGCSafePointDenyList} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return errs.ErrConfigItem.GenWithStack("invalid service gc safe point pattern %s", pattern)
+			}
+		}
+	}
 
 	return nil
 }
@@ -653,6 +713,11 @@ func (c *Config) IsLocalTSOEnabled() bool {
 	return c.EnableLocalTSO
 }
 
+// IsFollowerReadEnabled returns if the follower read is enabled.
+func (c *Config) IsFollowerReadEnabled() bool {
+	return c.EnableFollowerRead
+}
+
 // GetMaxConcurrentTSOProxyStreamings returns the max concurrent TSO proxy streamings.
 // If the value is negative, there is no limit.
 func (c *Config) GetMaxConcurrentTSOProxyStreamings() int {
@@ -674,6 +739,11 @@ func (c *Config) GetTSOSaveInterval() time.Duration {
 	return c.TSOSaveInterval.Duration
 }
 
+// GetTSOLayout returns the layout of the TSO.
+func (c *Config) GetTSOLayout() tsoutil.Layout {
+	return tsoutil.Layout{PhysicalPrecision: c.TSOPhysicalPrecision, LogicalBits: c.TSOLogicalBits}
+}
+
 // GetTLSConfig returns the TLS config.
 func (c *Config) GetTLSConfig() *grpcutil.TLSConfig {
 	return &c.Security.TLSConfig
@@ -887,3 +957,18 @@ func (c *KeyspaceConfig) GetWaitRegionSplitTimeout() time.Duration {
 func (c *KeyspaceConfig) GetCheckRegionSplitInterval() time.Duration {
 	return c.CheckRegionSplitInterval.Duration
 }
+
+// HeartbeatCaptureConfig is the configuration for the heartbeat capture.
+type HeartbeatCaptureConfig struct {
+	// Dir is the directory to store the capture files, which is under the data dir by default.
+	Dir string `toml:"dir" json:"dir"`
+	// MaxTotalSize is the size cap of all the capture files in the directory. The oldest
+	// capture files are removed to make room for a new recording.
+	MaxTotalSize typeutil.ByteSize `toml:"max-total-size" json:"max-total-size"`
+}
+
+func (c *HeartbeatCaptureConfig) adjust(dataDir string) {
+	configutil.AdjustString(&c.Dir, filepath.Join(dataDir, defaultHeartbeatCaptureDir))
+	configutil.AdjustPath(&c.Dir)
+	configutil.AdjustByteSize(&c.MaxTotalSize, defaultHeartbeatCaptureMaxTotalSize)
+}
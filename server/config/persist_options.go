@@ -407,6 +407,11 @@ func (o *PersistOptions) IsCrossTableMergeEnabled() bool {
 	return o.GetScheduleConfig().EnableCrossTableMerge
 }
 
+// IsLoadSplitEnabled returns if the load split is enabled for the given keyspace.
+func (o *PersistOptions) IsLoadSplitEnabled(keyspaceID uint32) bool {
+	return slice.Contains(o.GetScheduleConfig().LoadSplitKeyspaces, keyspaceID)
+}
+
 // GetPatrolRegionInterval returns the interval of patrolling region.
 func (o *PersistOptions) GetPatrolRegionInterval() time.Duration {
 	return o.GetScheduleConfig().PatrolRegionInterval.Duration
@@ -0,0 +1,78 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/tikv/pd/pkg/errs"
+)
+
+// FollowerReadState is the state of the regions synced from the leader when serving the follower read.
+type FollowerReadState struct {
+	// AppliedIndex is the index of the next region record to be synced from the leader.
+	AppliedIndex uint64
+	// Staleness is how far the synced regions may lag behind the leader.
+	Staleness time.Duration
+}
+
+// CheckFollowerRead checks whether the follower is able to serve the reads with the regions synced
+// from the leader, i.e, the follower read is enabled and the synced regions lag behind the leader
+// by at most the given max staleness. Note that the leader serves the reads by itself and should
+// not call it.
+func (s *Server) CheckFollowerRead(maxStaleness time.Duration) (*FollowerReadState, error) {
+	state, err := s.checkFollowerRead(maxStaleness)
+	if err != nil {
+		followerReadCounter.WithLabelValues("rejected").Inc()
+		return nil, err
+	}
+	followerReadCounter.WithLabelValues("ok").Inc()
+	return state, nil
+}
+
+func (s *Server) checkFollowerRead(maxStaleness time.Duration) (*FollowerReadState, error) {
+	if !s.IsFollowerReadEnabled() {
+		return nil, errs.ErrFollowerReadDisabled.FastGenByArgs()
+	}
+	syncer := s.cluster.GetRegionSyncer()
+	if syncer == nil {
+		return nil, errs.ErrFollowerReadNotSynced.FastGenByArgs()
+	}
+	staleness, synced := syncer.GetStaleness()
+	if !synced {
+		return nil, errs.ErrFollowerReadNotSynced.FastGenByArgs()
+	}
+	if staleness > maxStaleness {
+		return nil, errs.ErrFollowerReadTooStale.FastGenByArgs(staleness, maxStaleness)
+	}
+	return &FollowerReadState{AppliedIndex: syncer.GetAppliedIndex(), Staleness: staleness}, nil
+}
+
+// IsFollowerReadEnabled returns whether the follower read is enabled.
+func (s *Server) IsFollowerReadEnabled() bool {
+	return s.cfg.IsFollowerReadEnabled()
+}
+
+// GetStoreForFollowerRead returns the meta of the store for the follower read. The stores are
+// not synced to the follower, so it's loaded from the storage, nil is returned if not found.
+func (s *Server) GetStoreForFollowerRead(storeID uint64) (*metapb.Store, error) {
+	store := &metapb.Store{}
+	ok, err := s.storage.LoadStoreMeta(storeID, store)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return store, nil
+}
@@ -27,7 +27,6 @@ import (
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/etcdutil"
-	"github.com/tikv/pd/pkg/utils/tsoutil"
 	"go.etcd.io/etcd/clientv3"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -106,11 +105,10 @@ func (s *GrpcServer) UpdateServiceSafePointV2(ctx context.Context, request *pdpb
 		return rsp.(*pdpb.UpdateServiceSafePointV2Response), err
 	}
 
-	nowTSO, err := s.getGlobalTSO(ctx)
+	now, err := s.getGlobalTSOTime(ctx)
 	if err != nil {
 		return nil, err
 	}
-	now, _ := tsoutil.ParseTimestamp(nowTSO)
 
 	var minServiceSafePoint *endpoint.ServiceSafePointV2
 	if request.Ttl < 0 {
@@ -44,12 +44,14 @@ import (
 	"github.com/tikv/pd/pkg/utils/logutil"
 	"github.com/tikv/pd/pkg/utils/syncutil"
 	"github.com/tikv/pd/pkg/utils/tsoutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/pkg/versioninfo"
 	"github.com/tikv/pd/server/cluster"
 	"go.etcd.io/etcd/clientv3"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -371,10 +373,13 @@ func (s *GrpcServer) Tso(stream pdpb.PD_TsoServer) error {
 	if s.IsAPIServiceMode() {
 		return s.forwardTSO(stream)
 	}
-
+	// The layout is negotiated right before the first TSO is sent, so that the forwarded stream
+	// negotiates with the layout of the leader rather than the one of this server.
+	negotiator := tsoutil.NewLayoutNegotiator(stream)
 	var tsoRequestProxyCtx context.Context
 	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
+	streamTrace := tsoutil.TraceContextFromIncomingContext(stream.Context())
 	for {
 		var (
 			request *pdpb.TsoRequest
@@ -414,6 +419,7 @@ func (s *GrpcServer) Tso(stream pdpb.PD_TsoServer) error {
 		} else if err != nil {
 			return errors.WithStack(err)
 		}
+		reqTrace := streamTrace.Next()
 
 		// TSO uses leader lease to determine validity. No need to check leader here.
 		if s.IsClosed() {
@@ -427,7 +433,7 @@ func (s *GrpcServer) Tso(stream pdpb.PD_TsoServer) error {
 				return errors.WithStack(err)
 			}
 
-			tsoRequest := tsoutil.NewPDProtoRequest(forwardedHost, clientConn, request, stream)
+			tsoRequest := tsoutil.NewPDProtoRequest(forwardedHost, clientConn, request, stream, negotiator)
 			// don't pass a stream context here as dispatcher serves multiple streams
 			tsoRequestProxyCtx = s.tsoDispatcher.DispatchRequest(s.ctx, tsoRequest, s.pdProtoFactory, s.tsoPrimaryWatcher)
 			continue
@@ -439,11 +445,17 @@ func (s *GrpcServer) Tso(stream pdpb.PD_TsoServer) error {
 			return status.Errorf(codes.FailedPrecondition,
 				"mismatch cluster id, need %d but got %d", s.clusterID, request.GetHeader().GetClusterId())
 		}
+		if err := negotiator.Negotiate(s.tsoAllocatorManager.GetTSOLayout()); err != nil {
+			return err
+		}
 		count := request.GetCount()
+		tsoTrace := s.tsoTracer.StartTrace(reqTrace, grpcutil.GetPeerAddr(ctx), utils.DefaultKeyspaceGroupID, request.GetDcLocation(), count, start)
+		tsoTrace.AddSpan(tso.SpanReceive, start, time.Since(start))
 		ctx, task := trace.NewTask(ctx, "tso")
-		ts, err := s.tsoAllocatorManager.HandleRequest(ctx, request.GetDcLocation(), count)
+		ts, err := s.tsoAllocatorManager.HandleRequest(tso.WithTrace(ctx, tsoTrace), request.GetDcLocation(), count)
 		task.End()
 		if err != nil {
+			s.tsoTracer.FinishTrace(tsoTrace, err)
 			return status.Errorf(codes.Unknown, err.Error())
 		}
 		tsoHandleDuration.Observe(time.Since(start).Seconds())
@@ -452,7 +464,11 @@ func (s *GrpcServer) Tso(stream pdpb.PD_TsoServer) error {
 			Timestamp: &ts,
 			Count:     count,
 		}
-		if err := stream.Send(response); err != nil {
+		finishSpan := tsoTrace.StartSpan(tso.SpanRespond)
+		err = stream.Send(response)
+		finishSpan()
+		s.tsoTracer.FinishTrace(tsoTrace, err)
+		if err != nil {
 			return errors.WithStack(err)
 		}
 	}
@@ -462,7 +478,9 @@ func (s *GrpcServer) Tso(stream pdpb.PD_TsoServer) error {
 func (s *GrpcServer) forwardTSO(stream pdpb.PD_TsoServer) error {
 	var (
 		server            = &tsoServer{stream: stream}
+		negotiator        = tsoutil.NewLayoutNegotiator(stream)
 		forwardStream     tsopb.TSO_TsoClient
+		forwardLayout     *tsoutil.Layout
 		forwardCtx        context.Context
 		cancelForward     context.CancelFunc
 		lastForwardedHost string
@@ -518,11 +536,12 @@ func (s *GrpcServer) forwardTSO(stream pdpb.PD_TsoServer) error {
 			if err != nil {
 				return errors.WithStack(err)
 			}
-			forwardStream, forwardCtx, cancelForward, err =
-				s.createTSOForwardStream(stream.Context(), clientConn)
+			forwardStream, forwardCtx, cancelForward, err = s.createTSOForwardStream(
+				tsoutil.ForwardLayoutNegotiation(stream.Context(), stream.Context()), clientConn)
 			if err != nil {
 				return errors.WithStack(err)
 			}
+			forwardLayout = nil
 			lastForwardedHost = forwardedHost
 		}
 
@@ -550,6 +569,17 @@ func (s *GrpcServer) forwardTSO(stream pdpb.PD_TsoServer) error {
 			}
 		}
 
+		if forwardLayout == nil {
+			// The header carrying the layout has been received along with the first response.
+			layout, err := tsoutil.LayoutFromHeader(forwardStream)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if err := negotiator.Negotiate(layout); err != nil {
+				return err
+			}
+			forwardLayout = &layout
+		}
 		response := &pdpb.TsoResponse{
 			Header: &pdpb.ResponseHeader{
 				ClusterId: tsopbResp.GetHeader().GetClusterId(),
@@ -827,6 +857,21 @@ func (s *GrpcServer) GetStore(ctx context.Context, request *pdpb.GetStoreRequest
 			}, nil
 		}
 	}
+	if followerRead, err := s.followerRead(ctx, request.GetHeader()); err != nil {
+		return &pdpb.GetStoreResponse{Header: s.wrapErrorToHeader(pdpb.ErrorType_UNKNOWN, err.Error())}, nil
+	} else if followerRead {
+		store, err := s.GetStoreForFollowerRead(request.GetStoreId())
+		if err != nil {
+			return &pdpb.GetStoreResponse{Header: s.wrapErrorToHeader(pdpb.ErrorType_UNKNOWN, err.Error())}, nil
+		}
+		if store == nil {
+			return &pdpb.GetStoreResponse{
+				Header: s.wrapErrorToHeader(pdpb.ErrorType_UNKNOWN,
+					fmt.Sprintf("invalid store ID %d, not found", request.GetStoreId())),
+			}, nil
+		}
+		return &pdpb.GetStoreResponse{Header: s.header(), Store: store}, nil
+	}
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetStore(ctx, request)
 	}
@@ -991,6 +1036,7 @@ func (s *GrpcServer) StoreHeartbeat(ctx context.Context, request *pdpb.StoreHear
 	if rc == nil {
 		return &pdpb.StoreHeartbeatResponse{Header: s.notBootstrappedHeader()}, nil
 	}
+	s.heartbeatRecorder.RecordStoreHeartbeat(request)
 
 	if pberr := checkStore(rc, request.GetStats().GetStoreId()); pberr != nil {
 		return &pdpb.StoreHeartbeatResponse{
@@ -1318,6 +1364,7 @@ func (s *GrpcServer) RegionHeartbeat(stream pdpb.PD_RegionHeartbeatServer) error
 		if err = s.validateRequest(request.GetHeader()); err != nil {
 			return err
 		}
+		s.heartbeatRecorder.RecordRegionHeartbeat(request)
 
 		storeID := request.GetLeader().GetStoreId()
 		storeLabel := strconv.FormatUint(storeID, 10)
@@ -1438,6 +1485,11 @@ func (s *GrpcServer) GetRegion(ctx context.Context, request *pdpb.GetRegionReque
 			}, nil
 		}
 	}
+	if followerRead, err := s.followerRead(ctx, request.GetHeader()); err != nil {
+		return &pdpb.GetRegionResponse{Header: s.wrapErrorToHeader(pdpb.ErrorType_UNKNOWN, err.Error())}, nil
+	} else if followerRead {
+		return s.followerRegionResponse(s.GetBasicCluster().GetRegionByKey(request.GetRegionKey()), request.GetNeedBuckets()), nil
+	}
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetRegion(ctx, request)
 	}
@@ -1482,6 +1534,11 @@ func (s *GrpcServer) GetPrevRegion(ctx context.Context, request *pdpb.GetRegionR
 			}, nil
 		}
 	}
+	if followerRead, err := s.followerRead(ctx, request.GetHeader()); err != nil {
+		return &pdpb.GetRegionResponse{Header: s.wrapErrorToHeader(pdpb.ErrorType_UNKNOWN, err.Error())}, nil
+	} else if followerRead {
+		return s.followerRegionResponse(s.GetBasicCluster().GetPrevRegionByKey(request.GetRegionKey()), request.GetNeedBuckets()), nil
+	}
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetPrevRegion(ctx, request)
 	}
@@ -1527,6 +1584,11 @@ func (s *GrpcServer) GetRegionByID(ctx context.Context, request *pdpb.GetRegionB
 			}, nil
 		}
 	}
+	if followerRead, err := s.followerRead(ctx, request.GetHeader()); err != nil {
+		return &pdpb.GetRegionResponse{Header: s.wrapErrorToHeader(pdpb.ErrorType_UNKNOWN, err.Error())}, nil
+	} else if followerRead {
+		return s.followerRegionResponse(s.GetBasicCluster().GetRegion(request.GetRegionId()), request.GetNeedBuckets()), nil
+	}
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetRegionByID(ctx, request)
 	}
@@ -1571,6 +1633,12 @@ func (s *GrpcServer) ScanRegions(ctx context.Context, request *pdpb.ScanRegionsR
 			}, nil
 		}
 	}
+	if followerRead, err := s.followerRead(ctx, request.GetHeader()); err != nil {
+		return &pdpb.ScanRegionsResponse{Header: s.wrapErrorToHeader(pdpb.ErrorType_UNKNOWN, err.Error())}, nil
+	} else if followerRead {
+		regions := s.GetBasicCluster().ScanRegions(request.GetStartKey(), request.GetEndKey(), int(request.GetLimit()))
+		return s.scanRegionsResponse(regions), nil
+	}
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).ScanRegions(ctx, request)
 	}
@@ -1585,6 +1653,10 @@ func (s *GrpcServer) ScanRegions(ctx context.Context, request *pdpb.ScanRegionsR
 		return &pdpb.ScanRegionsResponse{Header: s.notBootstrappedHeader()}, nil
 	}
 	regions := rc.ScanRegions(request.GetStartKey(), request.GetEndKey(), int(request.GetLimit()))
+	return s.scanRegionsResponse(regions), nil
+}
+
+func (s *GrpcServer) scanRegionsResponse(regions []*core.RegionInfo) *pdpb.ScanRegionsResponse {
 	resp := &pdpb.ScanRegionsResponse{Header: s.header()}
 	for _, r := range regions {
 		leader := r.GetLeader()
@@ -1601,7 +1673,27 @@ func (s *GrpcServer) ScanRegions(ctx context.Context, request *pdpb.ScanRegionsR
 			PendingPeers: r.GetPendingPeers(),
 		})
 	}
-	return resp, nil
+	return resp
+}
+
+// followerRegionResponse builds the response of the region served by the follower. The buckets
+// are returned if the leader has synced them to the follower.
+func (s *GrpcServer) followerRegionResponse(region *core.RegionInfo, needBuckets bool) *pdpb.GetRegionResponse {
+	if region == nil {
+		return &pdpb.GetRegionResponse{Header: s.header()}
+	}
+	var buckets *metapb.Buckets
+	if needBuckets {
+		buckets = region.GetBuckets()
+	}
+	return &pdpb.GetRegionResponse{
+		Header:       s.header(),
+		Region:       region.GetMeta(),
+		Leader:       region.GetLeader(),
+		DownPeers:    region.GetDownPeers(),
+		PendingPeers: region.GetPendingPeers(),
+		Buckets:      buckets,
+	}
 }
 
 // AskSplit implements gRPC PDServer.
@@ -1948,11 +2040,10 @@ func (s *GrpcServer) UpdateServiceGCSafePoint(ctx context.Context, request *pdpb
 			return nil, err
 		}
 	}
-	nowTSO, err := s.getGlobalTSO(ctx)
+	now, err := s.getGlobalTSOTime(ctx)
 	if err != nil {
 		return nil, err
 	}
-	now, _ := tsoutil.ParseTimestamp(nowTSO)
 	serviceID := string(request.ServiceId)
 	min, updated, err := s.gcSafePointManager.UpdateServiceGCSafePoint(serviceID, request.GetSafePoint(), request.GetTTL(), now)
 	if err != nil {
@@ -2020,6 +2111,31 @@ func (s *GrpcServer) validateRequest(header *pdpb.RequestHeader) error {
 	return nil
 }
 
+// followerRead returns whether the request should be served by this follower with the regions
+// synced from the leader. The client asks for the follower read by carrying the max staleness
+// it tolerates in the metadata, and an error is returned if the follower is too stale to serve.
+// The applied index and the staleness of the follower are returned in the response metadata.
+func (s *GrpcServer) followerRead(ctx context.Context, header *pdpb.RequestHeader) (bool, error) {
+	maxStaleness, ok := grpcutil.GetFollowerReadMaxStaleness(ctx)
+	if !ok || s.IsClosed() || s.member.IsLeader() {
+		return false, nil
+	}
+	if header.GetClusterId() != s.clusterID {
+		return false, status.Errorf(codes.FailedPrecondition, "mismatch cluster id, need %d but got %d", s.clusterID, header.GetClusterId())
+	}
+	state, err := s.CheckFollowerRead(maxStaleness)
+	if err != nil {
+		return false, err
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(
+		grpcutil.FollowerReadAppliedIndexMetadataKey, strconv.FormatUint(state.AppliedIndex, 10),
+		grpcutil.FollowerReadStalenessMetadataKey, state.Staleness.String(),
+	)); err != nil {
+		log.Debug("failed to set the follower read metadata", errs.ZapError(err))
+	}
+	return true, nil
+}
+
 func (s *GrpcServer) header() *pdpb.ResponseHeader {
 	if s.clusterID == 0 {
 		return s.wrapErrorToHeader(pdpb.ErrorType_NOT_BOOTSTRAPPED, "cluster id is not ready")
@@ -2512,6 +2628,17 @@ func (s *GrpcServer) getGlobalTSO(ctx context.Context) (pdpb.Timestamp, error) {
 	return pdpb.Timestamp{}, err
 }
 
+// getGlobalTSOTime returns the physical time of a newly allocated global TSO, which is decoded
+// with the layout of the TSO rather than the default one.
+func (s *GrpcServer) getGlobalTSOTime(ctx context.Context) (time.Time, error) {
+	nowTSO, err := s.getGlobalTSO(ctx)
+	if err != nil {
+		return typeutil.ZeroTime, err
+	}
+	now, _ := s.getGlobalTSOLayout().ParseTimestamp(nowTSO)
+	return now, nil
+}
+
 func (s *GrpcServer) getTSOForwardStream(forwardedHost string) (*streamWrapper, error) {
 	s.tsoClientPool.RLock()
 	forwardStream, ok := s.tsoClientPool.clients[forwardedHost]
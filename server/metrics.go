@@ -170,6 +170,13 @@ var (
 			Help:      "Bucketed histogram of processing time (s) of handled forward tso requests.",
 			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 13),
 		})
+	followerReadCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "server",
+			Name:      "follower_read_total",
+			Help:      "Counter of the reads asked to be served by the follower.",
+		}, []string{"result"})
 )
 
 func init() {
@@ -191,6 +198,7 @@ func init() {
 	prometheus.MustRegister(bucketReportInterval)
 	prometheus.MustRegister(serverMaxProcs)
 	prometheus.MustRegister(forwardTsoDuration)
+	prometheus.MustRegister(followerReadCounter)
 
 	prometheus.DefaultRegisterer.Unregister(collectors.NewGoCollector())
 	prometheus.MustRegister(collectors.NewGoCollector(collectors.WithGoCollectorRuntimeMetrics(collectors.MetricsGC, collectors.MetricsMemory, collectors.MetricsScheduler)))
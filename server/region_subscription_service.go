@@ -0,0 +1,125 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RegionSubscriptionServiceName is the name of the region subscription service. It's not
+// defined by pdpb, so it's named under PD instead of the protocol.
+// Note: keep the same as the one defined on the client side.
+const RegionSubscriptionServiceName = "pd.region.RegionSubscription"
+
+const (
+	regionSubscriptionBufferSize    = 1024
+	regionSubscriptionCheckInterval = time.Second
+)
+
+// RegionSubscriptionServer wraps GrpcServer to push the region changes to the clients.
+// The service reuses the messages of pdpb: the request is a ScanRegionsRequest with
+// the key range to subscribe, and every changed region is pushed as a GetRegionResponse.
+// The first response carries no region and acknowledges the subscription. A removed region
+// is pushed with only its ID, range and epoch, without any peer.
+type RegionSubscriptionServer struct {
+	*GrpcServer
+}
+
+// regionSubscriptionService is the interface of the region subscription service.
+type regionSubscriptionService interface {
+	SubscribeRegions(*pdpb.ScanRegionsRequest, grpc.ServerStream) error
+}
+
+// RegionSubscriptionServiceDesc is the gRPC service descriptor of the region subscription service.
+var RegionSubscriptionServiceDesc = grpc.ServiceDesc{
+	ServiceName: RegionSubscriptionServiceName,
+	HandlerType: (*regionSubscriptionService)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeRegions",
+			Handler:       subscribeRegionsHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+func subscribeRegionsHandler(srv interface{}, stream grpc.ServerStream) error {
+	request := &pdpb.ScanRegionsRequest{}
+	if err := stream.RecvMsg(request); err != nil {
+		return err
+	}
+	return srv.(regionSubscriptionService).SubscribeRegions(request, stream)
+}
+
+// SubscribeRegions pushes the epoch and leader changes and the removals of the regions in the requested
+// key range until the client goes away or this server is no longer the leader.
+// The stream fails with ResourceExhausted if the client cannot keep up with the changes,
+// and the client should drop the regions it cached and subscribe again.
+func (s *RegionSubscriptionServer) SubscribeRegions(request *pdpb.ScanRegionsRequest, stream grpc.ServerStream) error {
+	if err := s.validateRequest(request.GetHeader()); err != nil {
+		return err
+	}
+	rc := s.GetRaftCluster()
+	if rc == nil {
+		return stream.SendMsg(&pdpb.GetRegionResponse{Header: s.notBootstrappedHeader()})
+	}
+	regions := rc.GetBasicCluster()
+	sub := regions.SubscribeRegionChanges(request.GetStartKey(), request.GetEndKey(), regionSubscriptionBufferSize)
+	defer regions.UnsubscribeRegionChanges(sub.ID())
+	if err := stream.SendMsg(&pdpb.GetRegionResponse{Header: s.header()}); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(regionSubscriptionCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-sub.Overflow():
+			return status.Errorf(codes.ResourceExhausted, "too many region changes to push")
+		case <-ticker.C:
+			if s.IsClosed() || !s.member.IsLeader() {
+				return ErrNotLeader
+			}
+		case change := <-sub.Changes():
+			region := change.Region
+			resp := &pdpb.GetRegionResponse{Header: s.header()}
+			if change.Removed {
+				resp.Region = &metapb.Region{
+					Id:          region.GetID(),
+					StartKey:    region.GetStartKey(),
+					EndKey:      region.GetEndKey(),
+					RegionEpoch: region.GetRegionEpoch(),
+				}
+			} else {
+				resp.Region = region.GetMeta()
+				resp.Leader = region.GetLeader()
+				resp.DownPeers = region.GetDownPeers()
+				resp.PendingPeers = region.GetPendingPeers()
+			}
+			if err := stream.SendMsg(resp); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -44,7 +44,9 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/sysutil"
 	"github.com/tikv/pd/pkg/audit"
+	"github.com/tikv/pd/pkg/capture"
 	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/election"
 	"github.com/tikv/pd/pkg/encryption"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/gc"
@@ -186,10 +188,14 @@ type Server struct {
 	basicCluster *core.BasicCluster
 	// for tso.
 	tsoAllocatorManager *tso.AllocatorManager
+	// tsoTracer samples the TSO requests to break down their latency.
+	tsoTracer *tso.Tracer
 	// for raft cluster
 	cluster *cluster.RaftCluster
 	// For async region heartbeat.
 	hbStreams *hbstream.HeartbeatStreams
+	// heartbeatRecorder records the received heartbeats for replaying.
+	heartbeatRecorder *capture.Recorder
 	// Zap logger
 	lg       *zap.Logger
 	logProps *log.ZapProperties
@@ -199,6 +205,8 @@ type Server struct {
 	startCallbacks []func()
 	// leaderCallbacks will be called after the server becomes leader.
 	leaderCallbacks []func(context.Context) error
+	// leaderHandoffCh notifies the leader to step down after the leader handoff is prepared.
+	leaderHandoffCh chan struct{}
 	// closeCallbacks will be called before the server is closed.
 	closeCallbacks []func()
 
@@ -265,6 +273,7 @@ func CreateServer(ctx context.Context, cfg *config.Config, services []string, le
 		startTimestamp:                  time.Now().Unix(),
 		DiagnosticsServer:               sysutil.NewDiagnosticsServer(cfg.Log.File.Filename),
 		mode:                            mode,
+		leaderHandoffCh:                 make(chan struct{}, 1),
 		tsoClientPool: struct {
 			syncutil.RWMutex
 			clients map[string]*streamWrapper
@@ -312,6 +321,7 @@ func CreateServer(ctx context.Context, cfg *config.Config, services []string, le
 		grpcServer := &GrpcServer{Server: s}
 		pdpb.RegisterPDServer(gs, grpcServer)
 		keyspacepb.RegisterKeyspaceServer(gs, &KeyspaceServer{GrpcServer: grpcServer})
+		gs.RegisterService(&RegionSubscriptionServiceDesc, &RegionSubscriptionServer{GrpcServer: grpcServer})
 		diagnosticspb.RegisterDiagnosticsServer(gs, s)
 		// Register the micro services GRPC service.
 		s.registry.InstallAllGRPCServices(s, gs)
@@ -443,6 +453,9 @@ func (s *Server) startServer(ctx context.Context) error {
 	s.member.SetMemberDeployPath(s.member.ID())
 	s.member.SetMemberBinaryVersion(s.member.ID(), versioninfo.PDReleaseVersion)
 	s.member.SetMemberGitHash(s.member.ID(), versioninfo.PDGitHash)
+	if err := s.member.SetMemberLabels(s.member.ID(), s.cfg.Labels); err != nil {
+		log.Warn("failed to save the member labels, the leader preference may not take effect on this member", errs.ZapError(err))
+	}
 	s.idAllocator = id.NewAllocator(&id.AllocatorParams{
 		Client:    s.client,
 		RootPath:  s.rootPath,
@@ -461,6 +474,7 @@ func (s *Server) startServer(ctx context.Context) error {
 	defaultStorage := storage.NewStorageWithEtcdBackend(s.client, s.rootPath)
 	s.storage = storage.NewCoreStorage(defaultStorage, regionStorage)
 	s.tsoDispatcher = tsoutil.NewTSODispatcher(tsoProxyHandleDuration, tsoProxyBatchSize)
+	s.tsoTracer = tso.NewTracer(0)
 	s.tsoProtoFactory = &tsoutil.TSOProtoFactory{}
 	s.pdProtoFactory = &tsoutil.PDProtoFactory{}
 	if !s.IsAPIServiceMode() {
@@ -478,7 +492,8 @@ func (s *Server) startServer(ctx context.Context) error {
 		}
 	}
 
-	s.gcSafePointManager = gc.NewSafePointManager(s.storage, s.cfg.PDServerCfg)
+	s.gcSafePointManager = gc.NewSafePointManager(s.storage, s.persistOptions, s.getGlobalTSOLayout)
+	s.heartbeatRecorder = capture.NewRecorder(s.cfg.HeartbeatCapture.Dir, int64(s.cfg.HeartbeatCapture.MaxTotalSize))
 	s.basicCluster = core.NewBasicCluster()
 	s.cluster = cluster.NewRaftCluster(ctx, s.clusterID, syncer.NewRegionSyncer(s), s.client, s.httpClient)
 	keyspaceIDAllocator := id.NewAllocator(&id.AllocatorParams{
@@ -556,6 +571,9 @@ func (s *Server) Close() {
 	if s.hbStreams != nil {
 		s.hbStreams.Close()
 	}
+	if s.heartbeatRecorder != nil {
+		s.heartbeatRecorder.Stop()
+	}
 	if err := s.storage.Close(); err != nil {
 		log.Error("close storage meet error", errs.ZapError(err))
 	}
@@ -829,6 +847,11 @@ func (s *Server) GetLeaderListenUrls() []string {
 	return s.member.GetLeaderListenUrls()
 }
 
+// GetLeadership returns the leadership of the PD leader.
+func (s *Server) GetLeadership() *election.Leadership {
+	return s.member.GetLeadership()
+}
+
 // GetMember returns the member of server.
 func (s *Server) GetMember() *member.EmbeddedEtcdMember {
 	return s.member
@@ -870,6 +893,11 @@ func (s *Server) GetHBStreams() *hbstream.HeartbeatStreams {
 	return s.hbStreams
 }
 
+// GetHeartbeatRecorder returns the heartbeat recorder.
+func (s *Server) GetHeartbeatRecorder() *capture.Recorder {
+	return s.heartbeatRecorder
+}
+
 // GetAllocator returns the ID allocator of server.
 func (s *Server) GetAllocator() id.Allocator {
 	return s.idAllocator
@@ -880,6 +908,11 @@ func (s *Server) GetTSOAllocatorManager() *tso.AllocatorManager {
 	return s.tsoAllocatorManager
 }
 
+// GetTSOTracer returns the tracer of the TSO requests.
+func (s *Server) GetTSOTracer() *tso.Tracer {
+	return s.tsoTracer
+}
+
 // GetKeyspaceManager returns the keyspace manager of server.
 func (s *Server) GetKeyspaceManager() *keyspace.Manager {
 	return s.keyspaceManager
@@ -891,6 +924,11 @@ func (s *Server) SetKeyspaceManager(keyspaceManager *keyspace.Manager) {
 	s.keyspaceManager = keyspaceManager
 }
 
+// GetGCSafePointManager returns the GC safe point manager of server.
+func (s *Server) GetGCSafePointManager() *gc.SafePointManager {
+	return s.gcSafePointManager
+}
+
 // GetSafePointV2Manager returns the safe point v2 manager of server.
 func (s *Server) GetSafePointV2Manager() *gc.SafePointV2Manager {
 	return s.safePointV2Manager
@@ -1644,6 +1682,12 @@ func (s *Server) leaderLoop() {
 			time.Sleep(200 * time.Millisecond)
 			continue
 		}
+		if !s.member.ShouldCampaign() {
+			log.Info("skip campaigning of pd leader during the leader handoff to another member",
+				zap.String("server-name", s.Name()))
+			time.Sleep(200 * time.Millisecond)
+			continue
+		}
 		s.campaignLeader()
 	}
 }
@@ -1683,6 +1727,7 @@ func (s *Server) campaignLeader() {
 			log.Error("failed to get the global TSO allocator", errs.ZapError(err))
 			return
 		}
+		s.setHandoffTSO()
 		log.Info("initializing the global TSO allocator")
 		if err := allocator.Initialize(0); err != nil {
 			log.Error("failed to initialize the global TSO allocator", errs.ZapError(err))
@@ -1750,8 +1795,11 @@ func (s *Server) campaignLeader() {
 		s.member.ResetLeader()
 		member.ServiceMemberGauge.WithLabelValues(s.mode).Set(0)
 	})
+	// Release the scheduling ownership before resetting the leadership.
+	defer s.cluster.ReleaseScheduling()
 
 	CheckPDVersion(s.persistOptions)
+	s.finishLeaderHandoff()
 	log.Info(fmt.Sprintf("%s leader is ready to serve", s.mode), zap.String("leader-name", s.Name()))
 
 	leaderTicker := time.NewTicker(mcs.LeaderTickInterval)
@@ -1779,6 +1827,9 @@ func (s *Server) campaignLeader() {
 				log.Info("etcd leader changed, resigns pd leadership", zap.String("old-pd-leader-name", s.Name()))
 				return
 			}
+		case <-s.leaderHandoffCh:
+			log.Info("pd leader steps down for the leader handoff", zap.String("leader-name", s.Name()))
+			return
 		case <-ctx.Done():
 			// Server is closed and it should return nil.
 			log.Info("server is closed")
@@ -1787,6 +1838,85 @@ func (s *Server) campaignLeader() {
 	}
 }
 
+// HandoffLeader hands off the PD leadership to the given member gracefully. Unlike transferring
+// the etcd leader only, the leader announces the handoff so that only the designated member
+// campaigns, stops allocating TSO and persists the time window at the handoff point, and then
+// steps down immediately rather than after the next leader check.
+func (s *Server) HandoffLeader(nextLeader string) (*member.LeaderHandoff, error) {
+	// The handoff expires if the designated member fails to campaign in time, and then all
+	// members could campaign again.
+	timeout := 2 * time.Duration(s.cfg.LeaderLease) * time.Second
+	handoff, err := s.member.AnnounceLeaderHandoff(s.ctx, nextLeader, timeout)
+	if err != nil {
+		return nil, err
+	}
+	// Stop the TSO and record where it stops before moving the etcd leader, otherwise the
+	// next leader may be elected and initialize its TSO without the handoff TSO.
+	if !s.IsAPIServiceMode() {
+		if handoff.TSO, err = s.tsoAllocatorManager.HandoffGlobalTSO(); err != nil {
+			log.Warn("failed to persist the tso for the leader handoff", errs.ZapError(err))
+		} else if err := s.member.UpdateLeaderHandoff(handoff); err != nil {
+			log.Warn("failed to record the tso of the leader handoff", errs.ZapError(err))
+		}
+	}
+	if err := s.member.MoveEtcdLeader(s.ctx, s.member.ID(), handoff.ToID); err != nil {
+		// The stopped TSO makes the leader step down, and it's initialized again in the
+		// next term of whichever member wins the campaign.
+		if err := s.member.FinishLeaderHandoff(handoff); err != nil {
+			log.Warn("failed to remove the leader handoff", errs.ZapError(err))
+		}
+		return nil, err
+	}
+	select {
+	case s.leaderHandoffCh <- struct{}{}:
+	default:
+	}
+	return handoff, nil
+}
+
+// GetLeaderHandoff returns the ongoing leader handoff, or nil if there is none.
+func (s *Server) GetLeaderHandoff() (*member.LeaderHandoff, error) {
+	return s.member.GetLeaderHandoff()
+}
+
+// setHandoffTSO bounds the start of the Global TSO by the TSO recorded in the handoff to this member.
+func (s *Server) setHandoffTSO() {
+	handoff, err := s.member.GetLeaderHandoff()
+	if err != nil {
+		log.Warn("failed to load the leader handoff", errs.ZapError(err))
+		return
+	}
+	if handoff == nil || handoff.ToID != s.member.ID() || handoff.TSO == 0 {
+		return
+	}
+	if err := s.tsoAllocatorManager.SetHandoffGlobalTSO(handoff.TSO); err != nil {
+		log.Warn("failed to set the tso of the leader handoff", errs.ZapError(err))
+	}
+}
+
+// finishLeaderHandoff removes the handoff to the new leader, and drops the stale step-down
+// notification left by the previous term.
+func (s *Server) finishLeaderHandoff() {
+	select {
+	case <-s.leaderHandoffCh:
+	default:
+	}
+	handoff, err := s.member.GetLeaderHandoff()
+	if err != nil {
+		log.Warn("failed to load the leader handoff", errs.ZapError(err))
+		return
+	}
+	if handoff == nil || handoff.ToID != s.member.ID() {
+		return
+	}
+	if err := s.member.FinishLeaderHandoff(handoff); err != nil {
+		log.Warn("failed to remove the leader handoff", errs.ZapError(err))
+		return
+	}
+	log.Info("leader handoff is finished", zap.String("from", handoff.From), zap.String("to", handoff.To),
+		zap.Uint64("handoff-tso", handoff.TSO), zap.Duration("cost", time.Since(handoff.StartTime)))
+}
+
 func (s *Server) etcdLeaderLoop() {
 	defer logutil.LogPanic()
 	defer s.serverLoopWg.Done()
@@ -1799,6 +1929,9 @@ func (s *Server) etcdLeaderLoop() {
 		select {
 		case <-ticker.C:
 			s.member.CheckPriority(ctx)
+			if s.member.IsLeader() {
+				s.checkLeaderPreference()
+			}
 			// Note: we reset the ticker here to support updating configuration dynamically.
 			ticker.Reset(s.cfg.LeaderPriorityCheckInterval.Duration)
 		case <-ctx.Done():
@@ -1808,6 +1941,50 @@ func (s *Server) etcdLeaderLoop() {
 	}
 }
 
+// checkLeaderPreference hands off the leadership if there is a healthy member with a higher
+// leader preference by labels.
+func (s *Server) checkLeaderPreference() {
+	preference, err := s.member.GetLeaderPreference()
+	if err != nil {
+		log.Error("failed to load leader preference", errs.ZapError(err))
+		return
+	}
+	if preference == nil || len(preference.Preferences) == 0 {
+		return
+	}
+	members, err := s.GetMembers()
+	if err != nil {
+		log.Error("failed to get members", errs.ZapError(err))
+		return
+	}
+	candidates, err := s.member.GetLeaderCandidates(preference, members)
+	if err != nil {
+		log.Error("failed to rank leader candidates", errs.ZapError(err))
+		return
+	}
+	rank := preference.Rank(s.cfg.Labels)
+	var healthy map[uint64]*pdpb.Member
+	for _, candidate := range candidates {
+		if candidate.Rank >= rank {
+			return
+		}
+		if healthy == nil {
+			healthy = cluster.CheckHealth(s.httpClient, members)
+		}
+		if _, ok := healthy[candidate.MemberID]; !ok {
+			continue
+		}
+		log.Info("resign the pd leadership to the member with a higher leader preference",
+			zap.String("leader-name", s.Name()), zap.String("next-leader", candidate.Name),
+			zap.Int("rank", rank), zap.Int("next-leader-rank", candidate.Rank))
+		if _, err := s.HandoffLeader(candidate.Name); err != nil {
+			log.Warn("failed to hand off the leadership", zap.String("next-leader", candidate.Name), errs.ZapError(err))
+			continue
+		}
+		return
+	}
+}
+
 func (s *Server) reloadConfigFromKV() error {
 	err := s.persistOptions.Reload(s.storage)
 	if err != nil {
@@ -2121,6 +2298,33 @@ func (s *Server) GetMaxResetTSGap() time.Duration {
 	return s.persistOptions.GetMaxResetTSGap()
 }
 
+// GetTSOLayout returns the layout of the TSO.
+func (s *Server) GetTSOLayout() tsoutil.Layout {
+	return s.cfg.GetTSOLayout()
+}
+
+// getGlobalTSOLayout returns the layout of the global TSO which is actually in use. It's the layout
+// of the local allocators in PD mode, and the one persisted by the TSO primary in API service mode.
+func (s *Server) getGlobalTSOLayout() tsoutil.Layout {
+	if !s.IsAPIServiceMode() {
+		return s.tsoAllocatorManager.GetTSOLayout()
+	}
+	layoutPath := endpoint.TimestampLayoutPath(endpoint.KeyspaceGroupGlobalTSPath(mcs.DefaultKeyspaceGroupID))
+	value, err := s.storage.LoadTimestampLayout(layoutPath)
+	if err != nil || value == "" {
+		if err != nil {
+			log.Warn("failed to load the tso layout, use the default one", errs.ZapError(err))
+		}
+		return tsoutil.DefaultLayout
+	}
+	layout, err := tsoutil.ParseLayout(value)
+	if err != nil {
+		log.Warn("failed to parse the tso layout, use the default one", zap.String("layout", value), errs.ZapError(err))
+		return tsoutil.DefaultLayout
+	}
+	return layout
+}
+
 // SetClient sets the etcd client.
 // Notes: it is only used for test.
 func (s *Server) SetClient(client *clientv3.Client) {
@@ -806,6 +806,70 @@ func TestCloseClient(t *testing.T) {
 	cli.Close()
 }
 
+func TestSharedTransport(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 3)
+	re.NoError(err)
+	defer cluster.Destroy()
+	endpoints := runServer(re, cluster)
+	transport, err := pd.NewSharedTransport(ctx, endpoints, pd.SecurityOption{})
+	re.NoError(err)
+	defer transport.Close()
+
+	clients := make([]pd.Client, 0, 3)
+	for keyspaceID := uint32(1); keyspaceID <= 3; keyspaceID++ {
+		cli, err := pd.NewClientWithKeyspace(ctx, keyspaceID, nil, pd.SecurityOption{}, pd.WithSharedTransport(transport))
+		re.NoError(err)
+		re.Equal(transport.GetClusterID(), cli.GetClusterID(ctx))
+		clients = append(clients, cli)
+	}
+	// The clients share the connections.
+	getClientConns := func(cli pd.Client) *sync.Map {
+		return cli.(interface{ GetServiceDiscovery() pd.ServiceDiscovery }).GetServiceDiscovery().GetClientConns()
+	}
+	for _, cli := range clients[1:] {
+		re.Same(getClientConns(clients[0]), getClientConns(cli))
+	}
+
+	checkTSO := func() {
+		var lastTS uint64
+		for i := 0; i < 10; i++ {
+			for _, cli := range clients {
+				var physical, logical int64
+				testutil.Eventually(re, func() bool {
+					physical, logical, err = cli.GetTS(ctx)
+					return err == nil
+				})
+				ts := tsoutil.ComposeTS(physical, logical)
+				re.Less(lastTS, ts)
+				lastTS = ts
+			}
+		}
+	}
+	checkTSO()
+
+	// The dynamic options of the shared TSO client are only updated by the transport.
+	re.Error(clients[0].UpdateOption(pd.EnableTSOFollowerProxy, true))
+	re.NoError(transport.UpdateOption(pd.MaxTSOBatchWaitInterval, time.Millisecond))
+	checkTSO()
+
+	// The clients keep working after the leader changes.
+	oldLeaderName := cluster.WaitLeader()
+	re.NoError(cluster.GetServer(oldLeaderName).ResignLeader())
+	re.NotEqual(oldLeaderName, cluster.WaitLeader())
+	checkTSO()
+
+	// Closing a client does not affect the others.
+	clients[0].Close()
+	clients = clients[1:]
+	checkTSO()
+	for _, cli := range clients {
+		cli.Close()
+	}
+}
+
 type idAllocator struct {
 	allocator *mockid.IDAllocator
 }
@@ -1013,6 +1077,56 @@ func (suite *clientTestSuite) TestGetRegion() {
 	suite.NoError(failpoint.Disable("github.com/tikv/pd/server/useForwardRequest"))
 }
 
+func (suite *clientTestSuite) TestRegionCache() {
+	re := suite.Require()
+	cli := setupCli(re, suite.ctx, suite.srv.GetEndpoints(), pd.WithRegionCache(nil, nil))
+	defer cli.Close()
+
+	regionID := regionIDAllocator.alloc()
+	region := &metapb.Region{
+		Id: regionID,
+		RegionEpoch: &metapb.RegionEpoch{
+			ConfVer: 1,
+			Version: 1,
+		},
+		Peers: peers,
+	}
+	checkRegion := func(region *metapb.Region, leader *metapb.Peer) {
+		testutil.Eventually(re, func() bool {
+			r, err := cli.GetRegion(context.Background(), []byte("a"))
+			re.NoError(err)
+			if r == nil || !reflect.DeepEqual(region, r.Meta) || !reflect.DeepEqual(leader, r.Leader) {
+				return false
+			}
+			r, err = cli.GetRegionByID(context.Background(), regionID)
+			re.NoError(err)
+			if r == nil || !reflect.DeepEqual(region, r.Meta) {
+				return false
+			}
+			regions, err := cli.ScanRegions(context.Background(), []byte("a"), nil, 10)
+			re.NoError(err)
+			return len(regions) == 1 && reflect.DeepEqual(region, regions[0].Meta)
+		})
+	}
+	re.NoError(suite.regionHeartbeat.Send(&pdpb.RegionHeartbeatRequest{
+		Header: newHeader(suite.srv),
+		Region: region,
+		Leader: peers[0],
+	}))
+	checkRegion(region, peers[0])
+
+	// The cached region is refreshed by the pushed changes. Only the conf version is
+	// changed to avoid making the regions heartbeated by the other tests stale.
+	region = typeutil.DeepClone(region, core.RegionFactory)
+	region.RegionEpoch.ConfVer = 2
+	re.NoError(suite.regionHeartbeat.Send(&pdpb.RegionHeartbeatRequest{
+		Header: newHeader(suite.srv),
+		Region: region,
+		Leader: peers[1],
+	}))
+	checkRegion(region, peers[1])
+}
+
 func (suite *clientTestSuite) TestGetPrevRegion() {
 	regionLen := 10
 	regions := make([]*metapb.Region, 0, regionLen)
@@ -1547,6 +1661,163 @@ func TestPutGet(t *testing.T) {
 	re.Empty(getResp.GetKvs())
 }
 
+func TestDeleteTxnLease(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 1)
+	re.NoError(err)
+	defer cluster.Destroy()
+	endpoints := runServer(re, cluster)
+	cli := setupCli(re, ctx, endpoints)
+	defer cli.Close()
+	client, ok := cli.(pd.MetaStorageExtensionClient)
+	re.True(ok)
+
+	key := []byte("test-txn")
+	// The key does not exist, so the success operations are executed.
+	txnResp, err := client.Txn(ctx,
+		[]pd.Compare{pd.CompareModRevision(key, pd.CompareEqual, 0)},
+		[]pd.TxnOp{pd.OpPut(key, []byte("1"))},
+		[]pd.TxnOp{pd.OpGet(key)})
+	re.NoError(err)
+	re.True(txnResp.Succeeded)
+	// The value is not "2", so the failure operations are executed.
+	txnResp, err = client.Txn(ctx,
+		[]pd.Compare{pd.CompareValue(key, pd.CompareEqual, []byte("2"))},
+		[]pd.TxnOp{pd.OpDelete(key)},
+		[]pd.TxnOp{pd.OpGet(key)})
+	re.NoError(err)
+	re.False(txnResp.Succeeded)
+	re.Len(txnResp.Responses, 1)
+	re.Equal([]byte("1"), txnResp.Responses[0].Kvs[0].Value)
+
+	deleteResp, err := client.Delete(ctx, []byte("test-tx"), pd.WithPrefix(), pd.WithPrevKV())
+	re.NoError(err)
+	re.Equal(int64(1), deleteResp.Deleted)
+	re.Equal(key, deleteResp.PrevKvs[0].Key)
+	re.Equal([]byte("1"), deleteResp.PrevKvs[0].Value)
+	txnResp, err = client.Txn(ctx, nil, []pd.TxnOp{pd.OpGet(key)}, nil)
+	re.NoError(err)
+	re.Empty(txnResp.Responses[0].Kvs)
+
+	// The keys share the same key space with Get, Put and Watch.
+	_, err = cli.Put(ctx, key, []byte("1"))
+	re.NoError(err)
+	txnResp, err = client.Txn(ctx,
+		[]pd.Compare{pd.CompareValue(key, pd.CompareEqual, []byte("1"))},
+		[]pd.TxnOp{pd.OpPut(key, []byte("2"))}, nil)
+	re.NoError(err)
+	re.True(txnResp.Succeeded)
+	getResp, err := cli.Get(ctx, key)
+	re.NoError(err)
+	re.Equal([]byte("2"), getResp.GetKvs()[0].Value)
+	deleteResp, err = client.Delete(ctx, key)
+	re.NoError(err)
+	re.Equal(int64(1), deleteResp.Deleted)
+	getResp, err = cli.Get(ctx, key)
+	re.NoError(err)
+	re.Empty(getResp.GetKvs())
+
+	// The keys reserved by PD are read-only for all the requests.
+	reservedKey := []byte("/pd/test-txn")
+	etcdClient := cluster.GetLeaderServer().GetEtcdClient()
+	_, err = etcdClient.Put(ctx, string(reservedKey), "1")
+	re.NoError(err)
+	getResp, err = cli.Get(ctx, reservedKey)
+	re.NoError(err)
+	re.Equal([]byte("1"), getResp.GetKvs()[0].Value)
+	txnResp, err = client.Txn(ctx,
+		[]pd.Compare{pd.CompareValue(reservedKey, pd.CompareEqual, []byte("1"))},
+		[]pd.TxnOp{pd.OpGet(reservedKey)}, nil)
+	re.NoError(err)
+	re.True(txnResp.Succeeded)
+	re.Equal([]byte("1"), txnResp.Responses[0].Kvs[0].Value)
+	_, err = cli.Put(ctx, reservedKey, []byte("2"))
+	re.Error(err)
+	_, err = client.Delete(ctx, reservedKey)
+	re.Error(err)
+	_, err = client.Delete(ctx, []byte("/"), pd.WithPrefix())
+	re.Error(err)
+	_, err = client.Txn(ctx, nil, []pd.TxnOp{pd.OpPut(reservedKey, []byte("2"))}, nil)
+	re.Error(err)
+	getResp, err = cli.Get(ctx, reservedKey)
+	re.NoError(err)
+	re.Equal([]byte("1"), getResp.GetKvs()[0].Value)
+
+	// The TTL of the lease is bounded.
+	_, err = client.LeaseGrant(ctx, 0)
+	re.Error(err)
+	_, err = client.LeaseGrant(ctx, 24*3600)
+	re.Error(err)
+	// The key attached to the lease is deleted after the lease is revoked.
+	leaseResp, err := client.LeaseGrant(ctx, 10)
+	re.NoError(err)
+	re.Equal(int64(10), leaseResp.TTL)
+	keepAliveCtx, keepAliveCancel := context.WithCancel(ctx)
+	keepAliveCh, err := client.KeepAlive(keepAliveCtx, leaseResp.ID)
+	re.NoError(err)
+	re.Equal(leaseResp.ID, (<-keepAliveCh).ID)
+	txnResp, err = client.Txn(ctx, nil, []pd.TxnOp{pd.OpPut(key, []byte("1"), pd.WithLease(leaseResp.ID))}, nil)
+	re.NoError(err)
+	re.True(txnResp.Succeeded)
+	re.NoError(client.Revoke(ctx, leaseResp.ID))
+	txnResp, err = client.Txn(ctx, nil, []pd.TxnOp{pd.OpGet(key)}, nil)
+	re.NoError(err)
+	re.Empty(txnResp.Responses[0].Kvs)
+	keepAliveCancel()
+	for range keepAliveCh {
+	}
+
+	// The lease attached to the reserved keys can't be revoked.
+	leaseResp, err = client.LeaseGrant(ctx, 10)
+	re.NoError(err)
+	_, err = etcdClient.Put(ctx, string(reservedKey), "1", clientv3.WithLease(clientv3.LeaseID(leaseResp.ID)))
+	re.NoError(err)
+	re.Error(client.Revoke(ctx, leaseResp.ID))
+}
+
+func TestWatcherResumeAfterLeaderChange(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 3)
+	re.NoError(err)
+	defer cluster.Destroy()
+	endpoints := runServer(re, cluster)
+	client := setupCli(re, ctx, endpoints)
+	defer client.Close()
+
+	prefix := []byte("test-watcher/")
+	watcherClient := client.(pd.MetaStorageWatcherClient)
+	informer, err := pd.NewInformer(ctx, watcherClient, prefix, nil, pd.WithPrefix())
+	re.NoError(err)
+	defer informer.Close()
+	watcher, err := watcherClient.NewWatcher(ctx, prefix, nil, pd.WithPrefix())
+	re.NoError(err)
+	defer watcher.Close()
+
+	_, err = client.Put(ctx, []byte("test-watcher/1"), []byte("1"))
+	re.NoError(err)
+	events := <-watcher.EventChan()
+	re.Equal([]byte("test-watcher/1"), events[0].Kv.Key)
+
+	// The watcher resumes from the last received revision after the leader changes.
+	oldLeaderName := cluster.WaitLeader()
+	re.NoError(cluster.GetServer(oldLeaderName).ResignLeader())
+	re.NotEqual(oldLeaderName, cluster.WaitLeader())
+	testutil.Eventually(re, func() bool {
+		_, err = client.Put(ctx, []byte("test-watcher/2"), []byte("2"))
+		return err == nil
+	})
+	events = <-watcher.EventChan()
+	re.Equal([]byte("test-watcher/2"), events[0].Kv.Key)
+	testutil.Eventually(re, func() bool {
+		return len(informer.List()) == 2
+	})
+	re.NoError(watcher.Err())
+}
+
 // TestClientWatchWithRevision is the same as TestClientWatchWithRevision in global config.
 func TestClientWatchWithRevision(t *testing.T) {
 	re := require.New(t)
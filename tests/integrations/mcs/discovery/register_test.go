@@ -16,6 +16,7 @@ package register_test
 
 import (
 	"context"
+	"net/http"
 	"strconv"
 	"testing"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/tikv/pd/pkg/mcs/utils"
 	"github.com/tikv/pd/pkg/utils/tempurl"
 	"github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/pkg/versioninfo"
 	"github.com/tikv/pd/tests"
 	"go.uber.org/goleak"
 )
@@ -94,6 +96,11 @@ func (suite *serverRegisterTestSuite) checkServerRegister(serviceName string) {
 	returnedEntry := &discovery.ServiceRegistryEntry{}
 	returnedEntry.Deserialize([]byte(endpoints[0]))
 	re.Equal(addr, returnedEntry.ServiceAddr)
+	// test the discovery API with the filters
+	suite.checkDiscoveryAPI(serviceName, "", []string{addr})
+	suite.checkDiscoveryAPI(serviceName, "?version="+versioninfo.PDReleaseVersion+"&healthy=true", []string{addr})
+	suite.checkDiscoveryAPI(serviceName, "?version=v0.0.0", nil)
+	suite.checkDiscoveryAPI(serviceName, "?label=zone:z1", nil)
 
 	// test primary when only one server
 	expectedPrimary := tests.WaitForPrimaryServing(suite.Require(), map[string]bs.Server{addr: s})
@@ -111,6 +118,17 @@ func (suite *serverRegisterTestSuite) checkServerRegister(serviceName string) {
 	}, testutil.WithWaitFor(3*time.Second), testutil.WithTickInterval(50*time.Millisecond))
 }
 
+func (suite *serverRegisterTestSuite) checkDiscoveryAPI(serviceName, query string, expectedAddrs []string) {
+	re := suite.Require()
+	var entries []*discovery.ServiceRegistryEntry
+	url := suite.backendEndpoints + "/pd/api/v2/ms/members/" + serviceName + query
+	re.NoError(testutil.ReadGetJSON(re, http.DefaultClient, url, &entries))
+	re.Len(entries, len(expectedAddrs))
+	for i, entry := range entries {
+		re.Equal(expectedAddrs[i], entry.ServiceAddr)
+	}
+}
+
 func (suite *serverRegisterTestSuite) TestServerPrimaryChange() {
 	suite.checkServerPrimaryChange(utils.TSOServiceName, 3)
 	// TODO: uncomment after resource-manager is ready
@@ -28,9 +28,11 @@ import (
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/tsopb"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	pd "github.com/tikv/pd/client"
+	bs "github.com/tikv/pd/pkg/basicserver"
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/mcs/discovery"
 	tso "github.com/tikv/pd/pkg/mcs/tso/server"
@@ -149,6 +151,39 @@ func (suite *tsoServerTestSuite) TestParticipantStartWithAdvertiseListenAddr() {
 	re.Equal(fmt.Sprintf("%s-%05d", cfg.AdvertiseListenAddr, utils.DefaultKeyspaceGroupID), member.Name())
 }
 
+// TestDiscoverTSOPrimaryWithLegacyPath tests that the client is able to discover the TSO primary
+// by reading the legacy primary key through the Get of the meta storage service.
+func (suite *tsoServerTestSuite) TestDiscoverTSOPrimaryWithLegacyPath() {
+	re := suite.Require()
+	s, cleanup := tests.StartSingleTSOTestServer(suite.ctx, re, suite.backendEndpoints, tempurl.Alloc())
+	defer cleanup()
+	tests.WaitForPrimaryServing(re, map[string]bs.Server{s.GetAddr(): s})
+
+	ctx, cancel := context.WithCancel(suite.ctx)
+	defer cancel()
+	client := mcs.SetupClientWithKeyspaceID(ctx, re, utils.DefaultKeyspaceID, []string{suite.backendEndpoints})
+	defer client.Close()
+	// The legacy primary key is rooted under the path of the microservices.
+	primaryKey := endpoint.KeyspaceGroupPrimaryPath(
+		endpoint.TSOSvcRootPath(suite.pdLeader.GetClusterID()), utils.DefaultKeyspaceGroupID)
+	resp, err := client.Get(ctx, []byte(primaryKey))
+	re.NoError(err)
+	re.Len(resp.GetKvs(), 1)
+	primary := &tsopb.Participant{}
+	re.NoError(primary.Unmarshal(resp.GetKvs()[0].Value))
+	re.Equal([]string{s.GetAddr()}, primary.GetListenUrls())
+
+	// Simulate the case that the server returns no TSO addresses, so the client falls back to
+	// the legacy path.
+	re.NoError(failpoint.Enable("github.com/tikv/pd/client/serverReturnsNoTSOAddrs", `return(true)`))
+	defer func() {
+		re.NoError(failpoint.Disable("github.com/tikv/pd/client/serverReturnsNoTSOAddrs"))
+	}()
+	legacyClient := mcs.SetupClientWithKeyspaceID(ctx, re, utils.DefaultKeyspaceID, []string{suite.backendEndpoints})
+	defer legacyClient.Close()
+	mcs.WaitForTSOServiceAvailable(ctx, re, legacyClient)
+}
+
 func TestTSOPath(t *testing.T) {
 	re := require.New(t)
 	checkTSOPath(re, true /*isAPIServiceMode*/)
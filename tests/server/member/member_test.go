@@ -32,6 +32,7 @@ import (
 	"github.com/tikv/pd/pkg/utils/assertutil"
 	"github.com/tikv/pd/pkg/utils/etcdutil"
 	"github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/server"
 	"github.com/tikv/pd/server/config"
 	"github.com/tikv/pd/tests"
@@ -226,6 +227,105 @@ func TestLeaderResign(t *testing.T) {
 	re.Equal(leader1, leader3)
 }
 
+func TestLeaderHandoff(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 3)
+	defer cluster.Destroy()
+	re.NoError(err)
+
+	err = cluster.RunInitialServers()
+	re.NoError(err)
+
+	leader1 := cluster.WaitLeader()
+	svr := cluster.GetServer(leader1).GetServer()
+	// The handoff to itself or an unknown member is rejected.
+	_, err = svr.HandoffLeader(leader1)
+	re.Error(err)
+	_, err = svr.HandoffLeader("unknown")
+	re.Error(err)
+
+	follower := cluster.GetFollower()
+	handoff, err := svr.HandoffLeader(follower)
+	re.NoError(err)
+	re.Equal(leader1, handoff.From)
+	re.Equal(follower, handoff.To)
+	re.NotZero(handoff.TSO)
+	re.NotZero(handoff.LeaseID)
+	leader2 := waitLeaderChange(re, cluster, leader1)
+	re.Equal(follower, leader2)
+	// The previous leader can't remove the handoff anymore.
+	re.Error(cluster.GetServer(leader1).GetServer().GetMember().FinishLeaderHandoff(handoff))
+	// The handoff is removed by the new leader.
+	testutil.Eventually(re, func() bool {
+		handoff, err := cluster.GetServer(leader2).GetServer().GetLeaderHandoff()
+		return err == nil && handoff == nil
+	})
+
+	addr2 := cluster.GetServer(leader2).GetConfig().ClientUrls
+	post(t, re, addr2+"/pd/api/v1/leader/handoff/"+leader1, "")
+	leader3 := waitLeaderChange(re, cluster, leader2)
+	re.Equal(leader1, leader3)
+}
+
+func TestLeaderPreference(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 3, func(conf *config.Config, serverName string) {
+		conf.Labels = map[string]string{"dc": "dc-" + serverName}
+		conf.LeaderPriorityCheckInterval = typeutil.NewDuration(100 * time.Millisecond)
+	})
+	defer cluster.Destroy()
+	re.NoError(err)
+
+	err = cluster.RunInitialServers()
+	re.NoError(err)
+
+	leader1 := cluster.WaitLeader()
+	addr1 := cluster.GetServer(leader1).GetConfig().ClientUrls
+	follower := cluster.GetFollower()
+	// The leader resigns to the member in the preferred DC.
+	post(t, re, addr1+"/pd/api/v1/leader/preference", fmt.Sprintf(`{"preferences": [{"key": "dc", "value": "dc-%s"}]}`, follower))
+	leader2 := waitLeaderChange(re, cluster, leader1)
+	re.Equal(follower, leader2)
+
+	addr2 := cluster.GetServer(leader2).GetConfig().ClientUrls
+	httpClient := &http.Client{Timeout: 15 * time.Second}
+	res, err := httpClient.Get(addr2 + "/pd/api/v1/leader/preference")
+	re.NoError(err)
+	defer res.Body.Close()
+	var preference struct {
+		Candidates []struct {
+			Name string `json:"name"`
+			Rank int    `json:"rank"`
+		} `json:"candidates"`
+	}
+	re.NoError(json.NewDecoder(res.Body).Decode(&preference))
+	re.Len(preference.Candidates, 3)
+	re.Equal(follower, preference.Candidates[0].Name)
+	re.Equal(0, preference.Candidates[0].Rank)
+	re.Equal(1, preference.Candidates[1].Rank)
+
+	// The leader stays in the preferred DC even if another member has a higher priority.
+	post(t, re, addr2+"/pd/api/v1/members/name/"+leader1, `{"leader-priority": 100}`)
+	member1 := cluster.GetServer(leader1).GetServer().GetMember()
+	priority, err := member1.GetMemberLeaderPriority(member1.ID())
+	re.NoError(err)
+	re.Equal(100, priority)
+	// The priority is checked every 100ms, so the leader is checked over several check intervals.
+	var checks, changes int
+	testutil.Eventually(re, func() bool {
+		if cluster.GetLeader() != leader2 {
+			changes++
+		}
+		checks++
+		return checks >= 10
+	}, testutil.WithTickInterval(200*time.Millisecond))
+	re.Zero(changes)
+}
+
 func TestLeaderResignWithBlock(t *testing.T) {
 	re := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
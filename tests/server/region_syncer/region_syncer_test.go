@@ -21,14 +21,18 @@ import (
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/mock/mockid"
+	"github.com/tikv/pd/pkg/utils/grpcutil"
 	"github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/server/config"
 	"github.com/tikv/pd/tests"
 	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestMain(m *testing.M) {
@@ -198,6 +202,56 @@ func TestFullSyncWithAddMember(t *testing.T) {
 	re.Len(loadRegions, regionLen)
 }
 
+func TestFollowerRead(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 3, func(conf *config.Config, serverName string) {
+		conf.PDServerCfg.UseRegionStorage = true
+		conf.EnableFollowerRead = true
+	})
+	defer cluster.Destroy()
+	re.NoError(err)
+
+	re.NoError(cluster.RunInitialServers())
+	cluster.WaitLeader()
+	leaderServer := cluster.GetLeaderServer()
+	re.NoError(leaderServer.BootstrapCluster())
+	rc := leaderServer.GetServer().GetRaftCluster()
+	re.NotNil(rc)
+	re.True(cluster.WaitRegionSyncerClientsReady(2))
+	regions := initRegions(10)
+	for _, region := range regions {
+		re.NoError(rc.HandleRegionHeartbeat(region))
+	}
+
+	followerServer := cluster.GetServer(cluster.GetFollower())
+	grpcPDClient := testutil.MustNewGrpcClient(re, followerServer.GetAddr())
+	req := &pdpb.GetRegionByIDRequest{
+		Header:   testutil.NewRequestHeader(leaderServer.GetClusterID()),
+		RegionId: regions[len(regions)-1].GetID(),
+	}
+	// The follower serves the read once it's confirmed to have applied the history of the leader.
+	var resp *pdpb.GetRegionResponse
+	testutil.Eventually(re, func() bool {
+		var md metadata.MD
+		readCtx := metadata.AppendToOutgoingContext(ctx, grpcutil.FollowerReadMetadataKey, "3s")
+		resp, err = grpcPDClient.GetRegionByID(readCtx, req, grpc.Header(&md))
+		re.NoError(err)
+		return resp.GetHeader().GetError() == nil && len(md.Get(grpcutil.FollowerReadAppliedIndexMetadataKey)) > 0
+	})
+	re.Equal(regions[len(regions)-1].GetMeta(), resp.GetRegion())
+	staleness, synced := followerServer.GetServer().GetRaftCluster().GetRegionSyncer().GetStaleness()
+	re.True(synced)
+	re.Less(staleness, 3*time.Second)
+
+	// The follower refuses the read if the staleness is not tolerated.
+	readCtx := metadata.AppendToOutgoingContext(ctx, grpcutil.FollowerReadMetadataKey, "1ns")
+	resp, err = grpcPDClient.GetRegionByID(readCtx, req)
+	re.NoError(err)
+	re.NotNil(resp.GetHeader().GetError())
+}
+
 func TestPrepareChecker(t *testing.T) {
 	re := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -0,0 +1,261 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventType is the type of the event parsed from PD log.
+type EventType string
+
+// The types of the events.
+const (
+	OperatorEvent  EventType = "operator"
+	ElectionEvent  EventType = "election"
+	StoreEvent     EventType = "store"
+	HotRegionEvent EventType = "hot-region"
+)
+
+// The actions of the operator events.
+const (
+	OperatorCreate  = "create"
+	OperatorFinish  = "finish"
+	OperatorTimeout = "timeout"
+	OperatorCancel  = "cancel"
+	OperatorExpire  = "expire"
+	OperatorReplace = "replace"
+)
+
+// Event is an event parsed from a line of PD log.
+type Event struct {
+	Time   time.Time `json:"time"`
+	Type   EventType `json:"type"`
+	Action string    `json:"action"`
+	// Name is the operator description for the operator and hot region events,
+	// the member name for the election events and the address for the store events.
+	Name        string        `json:"name,omitempty"`
+	RegionID    uint64        `json:"region-id,omitempty"`
+	StoreID     uint64        `json:"store-id,omitempty"`
+	SourceStore uint64        `json:"source-store,omitempty"`
+	TargetStore uint64        `json:"target-store,omitempty"`
+	Takes       time.Duration `json:"takes,omitempty"`
+}
+
+// IsIncident returns whether the event should be shown in the incident timeline.
+// The operators which are created and finished normally are only counted.
+func (e *Event) IsIncident() bool {
+	switch e.Type {
+	case OperatorEvent:
+		return e.Action != OperatorCreate && e.Action != OperatorFinish && e.Action != OperatorReplace
+	case HotRegionEvent:
+		return false
+	default:
+		return true
+	}
+}
+
+// EventInterpreter is the interface to interpret a line of PD log into an event.
+type EventInterpreter interface {
+	// Interpret returns nil if the line is not interested by the interpreter.
+	Interpret(t time.Time, content string) *Event
+}
+
+// DefaultEventInterpreters returns all the event interpreters.
+func DefaultEventInterpreters() []EventInterpreter {
+	return []EventInterpreter{
+		&OperatorInterpreter{},
+		&ElectionInterpreter{},
+		&StoreStateInterpreter{},
+		&HotRegionInterpreter{},
+	}
+}
+
+var (
+	regionIDField    = regexp.MustCompile(`\[region-id=([0-9]+)\]`)
+	storeIDField     = regexp.MustCompile(`\[store-id=([0-9]+)\]`)
+	takesField       = regexp.MustCompile(`\[takes=([0-9a-zµ.]+)\]`)
+	operatorDesc     = regexp.MustCompile(`\[operator="[\\"]*([^ {"\\]+) \{`)
+	operatorStores   = regexp.MustCompile(`store \[?([0-9]+)\]? to \[?([0-9]+)\]?`)
+	storeAddress     = regexp.MustCompile(`\[store-address=([^\]]+)\]`)
+	leaderNameField  = regexp.MustCompile(`\[(?:campaign-)?leader-name=([^\]]+)\]`)
+	mainRegionField  = regexp.MustCompile(`\[main-region=([0-9]+)\]`)
+	srcStoreField    = regexp.MustCompile(`\[src-store=([0-9]+)\]`)
+	dstStoreField    = regexp.MustCompile(`\[dst-store=([0-9]+)\]`)
+	electionMessages = []struct {
+		r      *regexp.Regexp
+		action string
+	}{
+		{regexp.MustCompile(`\["start to campaign [A-Za-z ]+ leader"\]`), "campaign"},
+		{regexp.MustCompile(`\["campaign [A-Za-z ]+ leader ok"\]`), "elected"},
+		{regexp.MustCompile(`\["[A-Za-z ]+ leader is ready to serve"\]`), "serving"},
+		{regexp.MustCompile(`\["pd leader has changed, try to re-campaign a pd leader"\]`), "leader-changed"},
+		{regexp.MustCompile(`\["the pd leader is lost for a long time`), "leader-lost"},
+	}
+	operatorMessages = map[string]string{
+		"add operator":         OperatorCreate,
+		"operator finish":      OperatorFinish,
+		"operator timeout":     OperatorTimeout,
+		"operator canceled":    OperatorCancel,
+		"operator expired":     OperatorExpire,
+		"replace old operator": OperatorReplace,
+	}
+	storeMessages = map[string]string{
+		"store has been offline":       "offline",
+		"store has been Tombstone":     "tombstone",
+		"store has been up":            "up",
+		"store has changed to serving": "serving",
+	}
+)
+
+// message returns the message of a line of PD log.
+func message(content string) string {
+	start := strings.Index(content, `] ["`)
+	if start < 0 {
+		return ""
+	}
+	start += len(`] ["`)
+	end := strings.Index(content[start:], `"]`)
+	if end < 0 {
+		return ""
+	}
+	return content[start : start+end]
+}
+
+func findUint64(r *regexp.Regexp, content string) uint64 {
+	subStrings := r.FindStringSubmatch(content)
+	if len(subStrings) != 2 {
+		return 0
+	}
+	num, err := strconv.ParseUint(subStrings[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return num
+}
+
+func findString(r *regexp.Regexp, content string) string {
+	subStrings := r.FindStringSubmatch(content)
+	if len(subStrings) != 2 {
+		return ""
+	}
+	return strings.Trim(subStrings[1], `"`)
+}
+
+// setOperatorStores sets the source and target stores from the brief of the operator,
+// e.g. `transfer leader: store 1 to 2` or `mv peer: store [1] to [2]`.
+func (e *Event) setOperatorStores(content string) {
+	if subStrings := operatorStores.FindStringSubmatch(content); len(subStrings) == 3 {
+		e.SourceStore, _ = strconv.ParseUint(subStrings[1], 10, 64)
+		e.TargetStore, _ = strconv.ParseUint(subStrings[2], 10, 64)
+	}
+}
+
+// OperatorInterpreter interprets the lifecycle of the operators, from the creation
+// to the finish, timeout or cancellation.
+type OperatorInterpreter struct{}
+
+// Interpret implements EventInterpreter.
+func (*OperatorInterpreter) Interpret(t time.Time, content string) *Event {
+	action, ok := operatorMessages[message(content)]
+	if !ok {
+		return nil
+	}
+	e := &Event{
+		Time:     t,
+		Type:     OperatorEvent,
+		Action:   action,
+		Name:     findString(operatorDesc, content),
+		RegionID: findUint64(regionIDField, content),
+	}
+	e.setOperatorStores(content)
+	if takes := findString(takesField, content); takes != "" {
+		e.Takes, _ = time.ParseDuration(takes)
+	}
+	return e
+}
+
+// ElectionInterpreter interprets the campaigns and the changes of the PD leader.
+type ElectionInterpreter struct{}
+
+// Interpret implements EventInterpreter.
+func (*ElectionInterpreter) Interpret(t time.Time, content string) *Event {
+	for _, m := range electionMessages {
+		if m.r.MatchString(content) {
+			return &Event{
+				Time:   t,
+				Type:   ElectionEvent,
+				Action: m.action,
+				Name:   findString(leaderNameField, content),
+			}
+		}
+	}
+	return nil
+}
+
+// StoreStateInterpreter interprets the state changes of the stores.
+type StoreStateInterpreter struct{}
+
+// Interpret implements EventInterpreter.
+func (*StoreStateInterpreter) Interpret(t time.Time, content string) *Event {
+	action, ok := storeMessages[message(content)]
+	if !ok {
+		return nil
+	}
+	return &Event{
+		Time:    t,
+		Type:    StoreEvent,
+		Action:  action,
+		Name:    findString(storeAddress, content),
+		StoreID: findUint64(storeIDField, content),
+	}
+}
+
+// HotRegionInterpreter interprets the decisions of the hot region scheduler.
+type HotRegionInterpreter struct{}
+
+// Interpret implements EventInterpreter.
+func (*HotRegionInterpreter) Interpret(t time.Time, content string) *Event {
+	switch message(content) {
+	case "add operator":
+		desc := findString(operatorDesc, content)
+		if !strings.Contains(desc, "-hot-") {
+			return nil
+		}
+		e := &Event{
+			Time:     t,
+			Type:     HotRegionEvent,
+			Action:   "schedule",
+			Name:     desc,
+			RegionID: findUint64(regionIDField, content),
+		}
+		e.setOperatorStores(content)
+		return e
+	case "use solution with revert regions":
+		return &Event{
+			Time:        t,
+			Type:        HotRegionEvent,
+			Action:      "revert",
+			RegionID:    findUint64(mainRegionField, content),
+			SourceStore: findUint64(srcStoreField, content),
+			TargetStore: findUint64(dstStoreField, content),
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func interpret(interpreter EventInterpreter, content string) *Event {
+	current, err := currentTime(DefaultLayout)(content)
+	if err != nil {
+		return nil
+	}
+	return interpreter.Interpret(current, content)
+}
+
+func TestOperatorInterpreter(t *testing.T) {
+	re := require.New(t)
+	interpreter := &OperatorInterpreter{}
+	content := `[2023/09/05 04:15:52.404 +00:00] [INFO] [operator_controller.go:704] ["operator finish"] [region-id=54252] [takes=4.2ms] [operator="\"balance-leader {transfer leader: store 4 to 6} (kind:leader, region:54252(8243, 398), createAt:2023-09-05 04:15:52.400290023 +0000 UTC m=+91268.739649520, startAt:2023-09-05 04:15:52.400489629 +0000 UTC m=+91268.739849120, currentStep:1, size:1, steps:[0:{transfer leader from store 4 to store 6}], timeout:[1m0s]) finished\""] [additional-info=]`
+	e := interpret(interpreter, content)
+	re.NotNil(e)
+	re.Equal(OperatorEvent, e.Type)
+	re.Equal(OperatorFinish, e.Action)
+	re.Equal("balance-leader", e.Name)
+	re.Equal(uint64(54252), e.RegionID)
+	re.Equal(uint64(4), e.SourceStore)
+	re.Equal(uint64(6), e.TargetStore)
+	re.Equal(4200*time.Microsecond, e.Takes)
+	re.False(e.IsIncident())
+
+	content = `[2023/09/05 14:05:49.718 +08:00] [INFO] [operator_controller.go:728] ["operator timeout"] [region-id=98] [takes=10m0.1s] [operator="\"move-hot-write-region {mv peer: store [2] to [10]} (kind:region,hot-region, region:98(1, 1), createAt:2023-09-05 14:05:49.718201432 +0800 CST m=+21.997446945, startAt:2023-09-05 14:05:49.718336308 +0800 CST m=+21.997581822, currentStep:0, size:1, steps:[0:{add learner peer 2048 on store 10}], timeout:[10m0s]) timeout\""] [additional-info=]`
+	e = interpret(interpreter, content)
+	re.NotNil(e)
+	re.Equal(OperatorTimeout, e.Action)
+	re.Equal("move-hot-write-region", e.Name)
+	re.Equal(uint64(2), e.SourceStore)
+	re.Equal(uint64(10), e.TargetStore)
+	re.True(e.IsIncident())
+
+	re.Nil(interpret(interpreter, `[2023/09/05 14:05:49.718 +08:00] [INFO] [cluster.go:1843] ["store has changed to serving"] [store-id=1]`))
+}
+
+func TestElectionInterpreter(t *testing.T) {
+	re := require.New(t)
+	interpreter := &ElectionInterpreter{}
+	testCases := []struct {
+		content string
+		action  string
+		name    string
+	}{
+		{`[2023/09/05 14:05:49.718 +08:00] [INFO] [server.go:1677] ["start to campaign PD leader"] [campaign-leader-name=pd-1]`, "campaign", "pd-1"},
+		{`[2023/09/05 14:05:49.718 +08:00] [INFO] [server.go:1703] ["campaign API Service leader ok"] [campaign-leader-name=pd-2]`, "elected", "pd-2"},
+		{`[2023/09/05 14:05:49.718 +08:00] [INFO] [server.go:1781] ["PD leader is ready to serve"] [leader-name=pd-1]`, "serving", "pd-1"},
+		{`[2023/09/05 14:05:49.718 +08:00] [INFO] [server.go:1634] ["pd leader has changed, try to re-campaign a pd leader"]`, "leader-changed", ""},
+	}
+	for _, testCase := range testCases {
+		e := interpret(interpreter, testCase.content)
+		re.NotNil(e)
+		re.Equal(ElectionEvent, e.Type)
+		re.Equal(testCase.action, e.Action)
+		re.Equal(testCase.name, e.Name)
+	}
+	re.Nil(interpret(interpreter, `[2023/09/05 14:05:49.718 +08:00] [INFO] [server.go:1600] ["server is closed, return PD leader loop"]`))
+}
+
+func TestStoreStateInterpreter(t *testing.T) {
+	re := require.New(t)
+	interpreter := &StoreStateInterpreter{}
+	e := interpret(interpreter, `[2023/09/05 14:05:49.718 +08:00] [WARN] [cluster.go:1578] ["store has been offline"] [store-id=3] [store-address=127.0.0.1:20162] [physically-destroyed=false]`)
+	re.NotNil(e)
+	re.Equal(StoreEvent, e.Type)
+	re.Equal("offline", e.Action)
+	re.Equal(uint64(3), e.StoreID)
+	re.Equal("127.0.0.1:20162", e.Name)
+	re.Nil(interpret(interpreter, `[2023/09/05 14:05:49.718 +08:00] [WARN] [cluster.go:1051] ["store does not have enough disk space"] [store-id=3]`))
+}
+
+func TestHotRegionInterpreter(t *testing.T) {
+	re := require.New(t)
+	interpreter := &HotRegionInterpreter{}
+	e := interpret(interpreter, `[2023/09/05 14:16:38.567 +08:00] [INFO] [operator_controller.go:498] ["add operator"] [region-id=85] [operator="\"transfer-hot-read-leader {transfer leader: store 1 to 5} (kind:leader,hot-region, region:85(1, 1), createAt:2023-09-05 14:16:38.567463945 +0800 CST m=+29.117453011, startAt:0001-01-01 00:00:00 +0000 UTC, currentStep:0, size:1, steps:[0:{transfer leader from store 1 to store 5}], timeout:[1m0s])\""] [additional-info=]`)
+	re.NotNil(e)
+	re.Equal(HotRegionEvent, e.Type)
+	re.Equal("schedule", e.Action)
+	re.Equal(uint64(85), e.RegionID)
+	re.Equal(uint64(1), e.SourceStore)
+	re.Equal(uint64(5), e.TargetStore)
+
+	e = interpret(interpreter, `[2023/09/05 14:16:38.567 +08:00] [INFO] [hot_region.go:1750] ["use solution with revert regions"] [src-store=2] [src-first-rate=1] [src-second-rate=1] [dst-store=3] [dst-first-rate=1] [dst-second-rate=1] [main-region=10] [main-first-rate=1] [main-second-rate=1] [revert-regions=11]`)
+	re.NotNil(e)
+	re.Equal("revert", e.Action)
+	re.Equal(uint64(10), e.RegionID)
+	re.Equal(uint64(2), e.SourceStore)
+	re.Equal(uint64(3), e.TargetStore)
+
+	re.Nil(interpret(interpreter, `[2023/09/05 14:16:38.567 +08:00] [INFO] [operator_controller.go:498] ["add operator"] [region-id=85] [operator="\"balance-leader {transfer leader: store 1 to 5} (kind:leader)\""]`))
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"encoding/json"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+)
+
+// StoreStats is the statistics of a store parsed from PD log.
+type StoreStats struct {
+	StoreID uint64 `json:"store-id"`
+	Address string `json:"address,omitempty"`
+	// State is the last state the store changed to.
+	State        string `json:"state,omitempty"`
+	StateChanges int    `json:"state-changes"`
+	// OperatorsOut and OperatorsIn are the finished operators which move peers
+	// or leaders out of and into the store.
+	OperatorsOut    int `json:"operators-out"`
+	OperatorsIn     int `json:"operators-in"`
+	FailedOperators int `json:"failed-operators"`
+	HotRegionsOut   int `json:"hot-regions-out"`
+	HotRegionsIn    int `json:"hot-regions-in"`
+}
+
+// OperatorStats is the statistics of the operators with the same description.
+type OperatorStats struct {
+	Desc     string `json:"desc"`
+	Created  int    `json:"created"`
+	Finished int    `json:"finished"`
+	Timeout  int    `json:"timeout"`
+	Canceled int    `json:"canceled"`
+	Expired  int    `json:"expired"`
+	Replaced int    `json:"replaced"`
+	// Unfinished is the number of the operators which are created but not ended
+	// before the end of the log.
+	Unfinished int           `json:"unfinished"`
+	AvgTakes   time.Duration `json:"avg-takes"`
+	MaxTakes   time.Duration `json:"max-takes"`
+}
+
+// Report is the incident timeline and statistics generated from PD log.
+type Report struct {
+	Start     time.Time        `json:"start"`
+	End       time.Time        `json:"end"`
+	Elections int              `json:"elections"`
+	Timeline  []*Event         `json:"timeline"`
+	Stores    []*StoreStats    `json:"stores"`
+	Operators []*OperatorStats `json:"operators"`
+}
+
+// ReportBuilder collects the events from PD log files and builds the report.
+type ReportBuilder struct {
+	interpreters []EventInterpreter
+	events       []*Event
+}
+
+// NewReportBuilder creates a ReportBuilder with the given interpreters. All the
+// interpreters are used if none is given.
+func NewReportBuilder(interpreters ...EventInterpreter) *ReportBuilder {
+	if len(interpreters) == 0 {
+		interpreters = DefaultEventInterpreters()
+	}
+	return &ReportBuilder{interpreters: interpreters}
+}
+
+// ParseLog is to parse a log file for the report. It can be called for several
+// files, e.g. the logs of different PD members.
+func (b *ReportBuilder) ParseLog(filename, start, end, layout string) error {
+	afterStart := isExpectTime(start, layout, false)
+	beforeEnd := isExpectTime(end, layout, true)
+	getCurrent := currentTime(layout)
+	return forEachLine(filename, func(content string) error {
+		current, err := getCurrent(content)
+		if err != nil || current.IsZero() {
+			return err
+		}
+		if afterStart(current) && beforeEnd(current) {
+			b.parseLine(current, content)
+		}
+		return nil
+	})
+}
+
+func (b *ReportBuilder) parseLine(current time.Time, content string) {
+	for _, interpreter := range b.interpreters {
+		if e := interpreter.Interpret(current, content); e != nil {
+			b.events = append(b.events, e)
+		}
+	}
+}
+
+// Report builds the report from the collected events.
+func (b *ReportBuilder) Report() *Report {
+	sort.SliceStable(b.events, func(i, j int) bool { return b.events[i].Time.Before(b.events[j].Time) })
+	report := &Report{Timeline: make([]*Event, 0)}
+	if len(b.events) > 0 {
+		report.Start = b.events[0].Time
+		report.End = b.events[len(b.events)-1].Time
+	}
+	stores := make(map[uint64]*StoreStats)
+	getStore := func(storeID uint64) *StoreStats {
+		s, ok := stores[storeID]
+		if !ok {
+			s = &StoreStats{StoreID: storeID}
+			stores[storeID] = s
+		}
+		return s
+	}
+	operators := make(map[string]*OperatorStats)
+	// running is the description of the running operator of each region.
+	running := make(map[uint64]string)
+	totalTakes := make(map[string]time.Duration)
+
+	for _, e := range b.events {
+		if e.IsIncident() {
+			report.Timeline = append(report.Timeline, e)
+		}
+		switch e.Type {
+		case OperatorEvent:
+			stats, ok := operators[e.Name]
+			if !ok {
+				stats = &OperatorStats{Desc: e.Name}
+				operators[e.Name] = stats
+			}
+			switch e.Action {
+			case OperatorCreate:
+				stats.Created++
+				running[e.RegionID] = e.Name
+				continue
+			case OperatorFinish:
+				stats.Finished++
+				totalTakes[e.Name] += e.Takes
+				if e.Takes > stats.MaxTakes {
+					stats.MaxTakes = e.Takes
+				}
+				if e.SourceStore != 0 {
+					getStore(e.SourceStore).OperatorsOut++
+				}
+				if e.TargetStore != 0 {
+					getStore(e.TargetStore).OperatorsIn++
+				}
+			case OperatorTimeout, OperatorCancel, OperatorExpire:
+				switch e.Action {
+				case OperatorTimeout:
+					stats.Timeout++
+				case OperatorCancel:
+					stats.Canceled++
+				default:
+					stats.Expired++
+				}
+				for _, storeID := range []uint64{e.SourceStore, e.TargetStore} {
+					if storeID != 0 {
+						getStore(storeID).FailedOperators++
+					}
+				}
+			case OperatorReplace:
+				stats.Replaced++
+			}
+			if running[e.RegionID] == e.Name {
+				delete(running, e.RegionID)
+			}
+		case ElectionEvent:
+			if e.Action == "elected" {
+				report.Elections++
+			}
+		case StoreEvent:
+			s := getStore(e.StoreID)
+			s.State = e.Action
+			s.StateChanges++
+			if e.Name != "" {
+				s.Address = e.Name
+			}
+		case HotRegionEvent:
+			if e.SourceStore != 0 {
+				getStore(e.SourceStore).HotRegionsOut++
+			}
+			if e.TargetStore != 0 {
+				getStore(e.TargetStore).HotRegionsIn++
+			}
+		}
+	}
+	for _, desc := range running {
+		operators[desc].Unfinished++
+	}
+
+	report.Stores = make([]*StoreStats, 0, len(stores))
+	for _, s := range stores {
+		report.Stores = append(report.Stores, s)
+	}
+	sort.Slice(report.Stores, func(i, j int) bool { return report.Stores[i].StoreID < report.Stores[j].StoreID })
+	report.Operators = make([]*OperatorStats, 0, len(operators))
+	for desc, stats := range operators {
+		if stats.Finished > 0 {
+			stats.AvgTakes = totalTakes[desc] / time.Duration(stats.Finished)
+		}
+		report.Operators = append(report.Operators, stats)
+	}
+	sort.Slice(report.Operators, func(i, j int) bool { return report.Operators[i].Desc < report.Operators[j].Desc })
+	return report
+}
+
+// WriteJSON writes the report in JSON format.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>PD log analysis</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 24px; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>PD log analysis</h1>
+<p>From {{.Start.Format "2006/01/02 15:04:05"}} to {{.End.Format "2006/01/02 15:04:05"}}, {{.Elections}} leader elections.</p>
+<h2>Incident timeline</h2>
+<table>
+<tr><th>time</th><th>type</th><th>action</th><th>name</th><th>region</th><th>store</th><th>source</th><th>target</th></tr>
+{{- range .Timeline}}
+<tr><td>{{.Time.Format "2006/01/02 15:04:05"}}</td><td>{{.Type}}</td><td>{{.Action}}</td><td>{{.Name}}</td><td>{{with .RegionID}}{{.}}{{end}}</td><td>{{with .StoreID}}{{.}}{{end}}</td><td>{{with .SourceStore}}{{.}}{{end}}</td><td>{{with .TargetStore}}{{.}}{{end}}</td></tr>
+{{- end}}
+</table>
+<h2>Stores</h2>
+<table>
+<tr><th>store</th><th>address</th><th>state</th><th>state changes</th><th>operators out</th><th>operators in</th><th>failed operators</th><th>hot regions out</th><th>hot regions in</th></tr>
+{{- range .Stores}}
+<tr><td>{{.StoreID}}</td><td>{{.Address}}</td><td>{{.State}}</td><td>{{.StateChanges}}</td><td>{{.OperatorsOut}}</td><td>{{.OperatorsIn}}</td><td>{{.FailedOperators}}</td><td>{{.HotRegionsOut}}</td><td>{{.HotRegionsIn}}</td></tr>
+{{- end}}
+</table>
+<h2>Operators</h2>
+<table>
+<tr><th>desc</th><th>created</th><th>finished</th><th>timeout</th><th>canceled</th><th>expired</th><th>replaced</th><th>unfinished</th><th>avg takes</th><th>max takes</th></tr>
+{{- range .Operators}}
+<tr><td>{{.Desc}}</td><td>{{.Created}}</td><td>{{.Finished}}</td><td>{{.Timeout}}</td><td>{{.Canceled}}</td><td>{{.Expired}}</td><td>{{.Replaced}}</td><td>{{.Unfinished}}</td><td>{{.AvgTakes}}</td><td>{{.MaxTakes}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as an HTML page.
+func (r *Report) WriteHTML(w io.Writer) error {
+	return reportTemplate.Execute(w, r)
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testLog = `[2023/09/05 14:00:00.000 +08:00] [INFO] [server.go:1703] ["campaign PD leader ok"] [campaign-leader-name=pd-1]
+[2023/09/05 14:00:01.000 +08:00] [INFO] [operator_controller.go:498] ["add operator"] [region-id=2] [operator="\"balance-region {mv peer: store [1] to [2]} (kind:region)\""] [additional-info=]
+[2023/09/05 14:00:02.000 +08:00] [INFO] [operator_controller.go:704] ["operator finish"] [region-id=2] [takes=1s] [operator="\"balance-region {mv peer: store [1] to [2]} (kind:region) finished\""] [additional-info=]
+[2023/09/05 14:00:03.000 +08:00] [INFO] [operator_controller.go:498] ["add operator"] [region-id=3] [operator="\"transfer-hot-write-leader {transfer leader: store 2 to 3} (kind:leader,hot-region)\""] [additional-info=]
+[2023/09/05 14:00:04.000 +08:00] [WARN] [cluster.go:1578] ["store has been offline"] [store-id=3] [store-address=127.0.0.1:20162] [physically-destroyed=false]
+[2023/09/05 14:00:05.000 +08:00] [INFO] [operator_controller.go:735] ["operator canceled"] [region-id=3] [takes=2s] [operator="\"transfer-hot-write-leader {transfer leader: store 2 to 3} (kind:leader,hot-region)\""] [additional-info=]
+[2023/09/05 14:00:06.000 +08:00] [INFO] [operator_controller.go:498] ["add operator"] [region-id=4] [operator="\"balance-region {mv peer: store [1] to [3]} (kind:region)\""] [additional-info=]
+`
+
+func TestReport(t *testing.T) {
+	re := require.New(t)
+	filename := filepath.Join(t.TempDir(), "pd.log")
+	re.NoError(os.WriteFile(filename, []byte(testLog), 0o600))
+
+	builder := NewReportBuilder()
+	re.NoError(builder.ParseLog(filename, "", "", DefaultLayout))
+	report := builder.Report()
+	re.Equal(1, report.Elections)
+	re.Equal(6*time.Second, report.End.Sub(report.Start))
+	// The election, the offline store and the canceled operator.
+	re.Len(report.Timeline, 3)
+	re.Equal(ElectionEvent, report.Timeline[0].Type)
+	re.Equal(StoreEvent, report.Timeline[1].Type)
+	re.Equal(OperatorCancel, report.Timeline[2].Action)
+
+	re.Len(report.Stores, 3)
+	re.Equal(StoreStats{StoreID: 1, OperatorsOut: 1}, *report.Stores[0])
+	re.Equal(StoreStats{StoreID: 2, OperatorsIn: 1, FailedOperators: 1, HotRegionsOut: 1}, *report.Stores[1])
+	re.Equal(StoreStats{StoreID: 3, Address: "127.0.0.1:20162", State: "offline", StateChanges: 1, FailedOperators: 1, HotRegionsIn: 1}, *report.Stores[2])
+
+	re.Len(report.Operators, 2)
+	re.Equal(OperatorStats{Desc: "balance-region", Created: 2, Finished: 1, Unfinished: 1, AvgTakes: time.Second, MaxTakes: time.Second}, *report.Operators[0])
+	re.Equal(OperatorStats{Desc: "transfer-hot-write-leader", Created: 1, Canceled: 1}, *report.Operators[1])
+
+	var buf bytes.Buffer
+	re.NoError(report.WriteJSON(&buf))
+	decoded := &Report{}
+	re.NoError(json.Unmarshal(buf.Bytes(), decoded))
+	re.Len(decoded.Timeline, 3)
+	buf.Reset()
+	re.NoError(report.WriteHTML(&buf))
+	re.True(strings.Contains(buf.String(), "<td>127.0.0.1:20162</td>"))
+
+	// Only the events in the time range are collected.
+	builder = NewReportBuilder(&StoreStateInterpreter{})
+	re.NoError(builder.ParseLog(filename, "2023/09/05 14:00:05", "", DefaultLayout))
+	report = builder.Report()
+	re.Empty(report.Timeline)
+	re.Empty(report.Stores)
+}
@@ -27,6 +27,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// DefaultResultPath is the default path of the file which the result is appended to.
+const DefaultResultPath = "result.txt"
+
 // TransferCounter is to count transfer schedule for judging whether redundant
 type TransferCounter struct {
 	storeNum          int
@@ -45,6 +48,7 @@ type TransferCounter struct {
 	mutex             syncutil.Mutex
 	loopResultPath    [][]int
 	loopResultCount   []uint64
+	resultPath        string
 }
 
 var once sync.Once
@@ -53,7 +57,7 @@ var instance *TransferCounter
 // GetTransferCounter is to return singleTon for TransferCounter
 func GetTransferCounter() *TransferCounter {
 	once.Do(func() {
-		instance = &TransferCounter{}
+		instance = &TransferCounter{resultPath: DefaultResultPath}
 	})
 	return instance
 }
@@ -74,6 +78,11 @@ func (c *TransferCounter) Init(storeNum, regionNum int) {
 	c.loopResultCount = c.loopResultCount[:0]
 }
 
+// SetResultPath sets the path of the file which the result is appended to.
+func (c *TransferCounter) SetResultPath(path string) {
+	c.resultPath = path
+}
+
 // AddTarget is be used to add target of edge in graph mat.
 // Firstly add a new peer and then delete the old peer of the scheduling,
 // So in the statistics, also firstly add the target and then add the source.
@@ -237,7 +246,7 @@ func (c *TransferCounter) PrintResult() {
 	log.Println("necessary schedules: ", c.Necessary)
 
 	// Output csv file
-	fd, err := os.OpenFile("result.txt", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	fd, err := os.OpenFile(c.resultPath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
 	if err != nil {
 		log.Fatal(err)
 	}
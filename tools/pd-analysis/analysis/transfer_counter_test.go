@@ -15,6 +15,9 @@
 package analysis
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -85,6 +88,11 @@ func TestCounterRedundant(t *testing.T) {
 		GetTransferCounter().Result()
 		re.Equal(uint64(1778), GetTransferCounter().Redundant)
 		re.Equal(uint64(938), GetTransferCounter().Necessary)
+		resultPath := filepath.Join(t.TempDir(), "result.txt")
+		GetTransferCounter().SetResultPath(resultPath)
 		GetTransferCounter().PrintResult()
+		result, err := os.ReadFile(resultPath)
+		re.NoError(err)
+		re.True(strings.HasPrefix(string(result), "8,3000,"))
 	}
 }
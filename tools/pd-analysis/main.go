@@ -17,6 +17,7 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
 
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/tools/pd-analysis/analysis"
@@ -24,13 +25,15 @@ import (
 )
 
 var (
-	input    = flag.String("input", "", "input pd log file, required")
+	input    = flag.String("input", "", "input pd log file, required. Multiple files separated by commas are supported by timeline")
 	output   = flag.String("output", "", "output file, default output to stdout")
 	logLevel = flag.String("logLevel", "info", "log level, default info")
-	style    = flag.String("style", "", "analysis style, e.g. transfer-counter")
+	style    = flag.String("style", "", "analysis style, e.g. transfer-counter, timeline")
 	operator = flag.String("operator", "", "operator style, e.g. balance-region, balance-leader, transfer-hot-read-leader, move-hot-read-region, transfer-hot-write-leader, move-hot-write-region")
 	start    = flag.String("start", "", "start time, e.g. 2019/09/10 12:20:07, default: total file")
 	end      = flag.String("end", "", "end time, e.g. 2019/09/10 14:20:07, default: total file")
+	format   = flag.String("format", "json", "report format of timeline, e.g. json, html")
+	result   = flag.String("result", analysis.DefaultResultPath, "result file of transfer-counter, which the counts are appended to in csv")
 )
 
 // Logger is the global logger used for simulator.
@@ -73,9 +76,30 @@ func main() {
 			if err != nil {
 				Logger.Fatal(err.Error())
 			}
+			analysis.GetTransferCounter().SetResultPath(*result)
 			analysis.GetTransferCounter().PrintResult()
 			break
 		}
+	case "timeline":
+		builder := analysis.NewReportBuilder()
+		for _, file := range strings.Split(*input, ",") {
+			if err := builder.ParseLog(file, *start, *end, analysis.DefaultLayout); err != nil {
+				Logger.Fatal(err.Error())
+			}
+		}
+		report := builder.Report()
+		var err error
+		switch *format {
+		case "json":
+			err = report.WriteJSON(os.Stdout)
+		case "html":
+			err = report.WriteHTML(os.Stdout)
+		default:
+			Logger.Fatal("format is not exist")
+		}
+		if err != nil {
+			Logger.Fatal(err.Error())
+		}
 	default:
 		Logger.Fatal("style is not exist")
 	}
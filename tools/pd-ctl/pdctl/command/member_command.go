@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -31,7 +32,7 @@ var (
 // NewMemberCommand return a member subcommand of rootCmd
 func NewMemberCommand() *cobra.Command {
 	m := &cobra.Command{
-		Use:   "member [leader|delete|leader_priority]",
+		Use:   "member [leader|delete|leader_priority|leader_preference]",
 		Short: "show the pd member status",
 		Run:   showMemberCommandFunc,
 	}
@@ -43,9 +44,34 @@ func NewMemberCommand() *cobra.Command {
 		Short: "set the member's priority to be elected as etcd leader",
 		Run:   setLeaderPriorityFunc,
 	})
+	m.AddCommand(NewLeaderPreferenceCommand())
 	return m
 }
 
+// NewLeaderPreferenceCommand return a leader_preference subcommand of memberCmd
+func NewLeaderPreferenceCommand() *cobra.Command {
+	p := &cobra.Command{
+		Use:   "leader_preference <subcommand>",
+		Short: "leader preference by labels commands",
+	}
+	p.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the leader preference and the members ranked by it",
+		Run:   showLeaderPreferenceCommandFunc,
+	})
+	p.AddCommand(&cobra.Command{
+		Use:   "set <key>=<value> [<key>=<value>...]",
+		Short: "set the label preferences of the leader in the order of priority",
+		Run:   setLeaderPreferenceCommandFunc,
+	})
+	p.AddCommand(&cobra.Command{
+		Use:   "delete",
+		Short: "delete the leader preference",
+		Run:   deleteLeaderPreferenceCommandFunc,
+	})
+	return p
+}
+
 // NewDeleteMemberCommand return a delete subcommand of memberCmd
 func NewDeleteMemberCommand() *cobra.Command {
 	d := &cobra.Command{
@@ -86,6 +112,16 @@ func NewLeaderMemberCommand() *cobra.Command {
 		Short: "transfer leadership to another pd",
 		Run:   transferPDLeaderCommandFunc,
 	})
+	d.AddCommand(&cobra.Command{
+		Use:   "handoff <member_name>",
+		Short: "hand off leadership to another pd gracefully",
+		Run:   handoffPDLeaderCommandFunc,
+	})
+	d.AddCommand(&cobra.Command{
+		Use:   "show-handoff",
+		Short: "show the ongoing leader handoff",
+		Run:   getLeaderHandoffCommandFunc,
+	})
 	return d
 }
 
@@ -159,6 +195,74 @@ func transferPDLeaderCommandFunc(cmd *cobra.Command, args []string) {
 	cmd.Println("Success!")
 }
 
+func handoffPDLeaderCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println("Usage: leader handoff <member_name>")
+		return
+	}
+	prefix := leaderMemberPrefix + "/handoff/" + args[0]
+	r, err := doRequest(cmd, prefix, http.MethodPost, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to hand off leadership: %s\n", err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func getLeaderHandoffCommandFunc(cmd *cobra.Command, args []string) {
+	prefix := leaderMemberPrefix + "/handoff"
+	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to get the leader handoff: %s\n", err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func showLeaderPreferenceCommandFunc(cmd *cobra.Command, args []string) {
+	prefix := leaderMemberPrefix + "/preference"
+	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to get the leader preference: %s\n", err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func setLeaderPreferenceCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		cmd.Println("Usage: leader_preference set <key>=<value> [<key>=<value>...]")
+		return
+	}
+	preferences := make([]map[string]string, 0, len(args))
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			cmd.Printf("invalid label preference %s, should be <key>=<value>\n", arg)
+			return
+		}
+		preferences = append(preferences, map[string]string{"key": kv[0], "value": kv[1]})
+	}
+	reqData, _ := json.Marshal(map[string]interface{}{"preferences": preferences})
+	prefix := leaderMemberPrefix + "/preference"
+	_, err := doRequest(cmd, prefix, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(reqData)))
+	if err != nil {
+		cmd.Printf("Failed to set the leader preference: %s\n", err)
+		return
+	}
+	cmd.Println("Success!")
+}
+
+func deleteLeaderPreferenceCommandFunc(cmd *cobra.Command, args []string) {
+	prefix := leaderMemberPrefix + "/preference"
+	_, err := doRequest(cmd, prefix, http.MethodDelete, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to delete the leader preference: %s\n", err)
+		return
+	}
+	cmd.Println("Success!")
+}
+
 func setLeaderPriorityFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 2 {
 		cmd.Println("Usage: leader_priority <member_name> <priority>")
@@ -0,0 +1,116 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"encoding/hex"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+var scatterJobsPrefix = "pd/api/v1/regions/scatter/jobs"
+
+// NewScatterJobCommand returns a scatter-job subcommand of regionCmd.
+func NewScatterJobCommand() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "scatter-job <subcommand>",
+		Short: "manage the persistent jobs to scatter the regions in a key range",
+	}
+	create := &cobra.Command{
+		Use:   "create [--format=raw|encode|hex] [--group=<group>] [--dimension=peer|leader] <start_key> <end_key>",
+		Short: "create a job to scatter the regions in the key range",
+		Run:   createScatterJobCommandFunc,
+	}
+	create.Flags().String("format", "hex", "the key format")
+	create.Flags().String("group", "", "the group of the scattered regions")
+	create.Flags().String("dimension", "peer", "scatter the peers or only the leaders of the regions")
+	r.AddCommand(create)
+	r.AddCommand(&cobra.Command{
+		Use:   "show [<job_id>]",
+		Short: "show all scatter jobs or the job with the given ID",
+		Run:   showScatterJobCommandFunc,
+	})
+	r.AddCommand(&cobra.Command{
+		Use:   "cancel <job_id>",
+		Short: "cancel the running scatter job",
+		Run:   cancelScatterJobCommandFunc,
+	})
+	return r
+}
+
+func createScatterJobCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	startKey, err := parseKey(cmd.Flags(), args[0])
+	if err != nil {
+		cmd.Println("Error: ", err)
+		return
+	}
+	endKey, err := parseKey(cmd.Flags(), args[1])
+	if err != nil {
+		cmd.Println("Error: ", err)
+		return
+	}
+	group, _ := cmd.Flags().GetString("group")
+	dimension, _ := cmd.Flags().GetString("dimension")
+
+	input := make(map[string]interface{})
+	input["start_key"] = hex.EncodeToString([]byte(startKey))
+	input["end_key"] = hex.EncodeToString([]byte(endKey))
+	input["group"] = group
+	input["dimension"] = dimension
+	postJSON(cmd, scatterJobsPrefix, input)
+}
+
+func showScatterJobCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	prefix := scatterJobsPrefix
+	if len(args) == 1 {
+		if _, err := parseUint64s(args); err != nil {
+			cmd.Println("Error: ", err)
+			return
+		}
+		prefix += "/" + args[0]
+	}
+	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to get scatter jobs: %s\n", err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func cancelScatterJobCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	if _, err := parseUint64s(args); err != nil {
+		cmd.Println("Error: ", err)
+		return
+	}
+	_, err := doRequest(cmd, scatterJobsPrefix+"/"+args[0], http.MethodDelete, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to cancel scatter job: %s\n", err)
+		return
+	}
+	cmd.Println("Success!")
+}
@@ -21,6 +21,14 @@ const (
 	defaultRound             = 0
 	defaultSample            = false
 
+	defaultZipfSkew           = 1.2
+	defaultSplitRatio         = 0.001
+	defaultLeaderChurnRatio   = 0.01
+	defaultFlappingStoreCount = 1
+	defaultFlappingPeriod     = 10
+	defaultFlappingDuration   = 2
+	defaultReplaySpeed        = 1.0
+
 	defaultLogFormat = "text"
 )
 
@@ -45,6 +53,24 @@ type Config struct {
 	FlowUpdateRatio   float64 `toml:"flow-update-ratio" json:"flow-update-ratio"`
 	Sample            bool    `toml:"sample" json:"sample"`
 	Round             int     `toml:"round" json:"round"`
+
+	// WorkloadModels are the workload models applied in each round besides the
+	// update ratios, e.g. zipf-hotness, rolling-split, leader-churn and store-flapping.
+	WorkloadModels   []string `toml:"workload-models" json:"workload-models"`
+	ZipfSkew         float64  `toml:"zipf-skew" json:"zipf-skew"`
+	SplitRatio       float64  `toml:"split-ratio" json:"split-ratio"`
+	LeaderChurnRatio float64  `toml:"leader-churn-ratio" json:"leader-churn-ratio"`
+	// FlappingStoreCount stores are down for FlappingDuration rounds in every
+	// FlappingPeriod rounds.
+	FlappingStoreCount int `toml:"flapping-store-count" json:"flapping-store-count"`
+	FlappingPeriod     int `toml:"flapping-period" json:"flapping-period"`
+	FlappingDuration   int `toml:"flapping-duration" json:"flapping-duration"`
+
+	// Replay is the heartbeat capture file recorded from PD. If it is set, the
+	// captured heartbeats are sent instead of the generated ones.
+	Replay string `toml:"replay" json:"replay"`
+	// ReplaySpeed is the speed ratio of replaying, 0 means as fast as possible.
+	ReplaySpeed float64 `toml:"replay-speed" json:"replay-speed"`
 }
 
 // NewConfig return a set of settings.
@@ -56,6 +82,7 @@ func NewConfig() *Config {
 	fs.StringVar(&cfg.configFile, "config", "", "config file")
 	fs.StringVar(&cfg.PDAddr, "pd", "http://127.0.0.1:2379", "pd address")
 	fs.StringVar(&cfg.StatusAddr, "status-addr", "http://127.0.0.1:20180", "status address")
+	fs.StringVar(&cfg.Replay, "replay", "", "replay the heartbeat capture file recorded from PD")
 
 	return cfg
 }
@@ -130,4 +157,26 @@ func (c *Config) Adjust(meta *toml.MetaData) {
 	if !meta.IsDefined("sample") {
 		c.Sample = defaultSample
 	}
+
+	if !meta.IsDefined("zipf-skew") {
+		configutil.AdjustFloat64(&c.ZipfSkew, defaultZipfSkew)
+	}
+	if !meta.IsDefined("split-ratio") {
+		configutil.AdjustFloat64(&c.SplitRatio, defaultSplitRatio)
+	}
+	if !meta.IsDefined("leader-churn-ratio") {
+		configutil.AdjustFloat64(&c.LeaderChurnRatio, defaultLeaderChurnRatio)
+	}
+	if !meta.IsDefined("flapping-store-count") {
+		configutil.AdjustInt(&c.FlappingStoreCount, defaultFlappingStoreCount)
+	}
+	if !meta.IsDefined("flapping-period") {
+		configutil.AdjustInt(&c.FlappingPeriod, defaultFlappingPeriod)
+	}
+	if !meta.IsDefined("flapping-duration") {
+		configutil.AdjustInt(&c.FlappingDuration, defaultFlappingDuration)
+	}
+	if !meta.IsDefined("replay-speed") {
+		configutil.AdjustFloat64(&c.ReplaySpeed, defaultReplaySpeed)
+	}
 }
@@ -172,6 +172,8 @@ func newEndKey(id uint64, keyLen int) []byte {
 // Regions simulates all regions to heartbeat.
 type Regions struct {
 	regions []*pdpb.RegionHeartbeatRequest
+	// nextID is the next ID for the regions and peers created by splitting.
+	nextID uint64
 
 	updateRound int
 
@@ -226,6 +228,7 @@ func (rs *Regions) init(cfg *config.Config) {
 		region.Leader = peers[0]
 		rs.regions = append(rs.regions, region)
 	}
+	rs.nextID = id
 
 	// Generate sample index
 	slice := make([]int, cfg.RegionCount)
@@ -335,15 +338,25 @@ func (rs *Regions) handleRegionHeartbeat(wg *sync.WaitGroup, stream pdpb.PD_Regi
 // Stores contains store stats with lock.
 type Stores struct {
 	stat []atomic.Value
+	// down marks the stores which stop sending heartbeats.
+	down []atomic.Bool
 }
 
 func newStores(storeCount int) *Stores {
 	return &Stores{
 		stat: make([]atomic.Value, storeCount+1),
+		down: make([]atomic.Bool, storeCount+1),
 	}
 }
 
+func (s *Stores) isDown(storeID uint64) bool {
+	return s.down[storeID].Load()
+}
+
 func (s *Stores) heartbeat(ctx context.Context, cli pdpb.PDClient, storeID uint64) {
+	if s.isDown(storeID) {
+		return
+	}
 	cctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	cli.StoreHeartbeat(cctx, &pdpb.StoreHeartbeatRequest{Header: header(), Stats: s.stat[storeID].Load().(*pdpb.StoreStats)})
@@ -433,6 +446,20 @@ func main() {
 	}()
 	cli := newClient(cfg)
 	initClusterID(ctx, cli)
+	latency := newLatencyReporter(cfg.PDAddr)
+	if cfg.Replay != "" {
+		bootstrap(ctx, cli)
+		latency.report(ctx)
+		if err := newReplayer(cfg, cli).replay(ctx); err != nil {
+			log.Fatal("failed to replay heartbeats", zap.Error(err))
+		}
+		latency.summary(ctx)
+		exit(0)
+	}
+	models, err := newWorkloadModels(cfg)
+	if err != nil {
+		log.Fatal("failed to create workload models", zap.Error(err))
+	}
 	regions := new(Regions)
 	regions.init(cfg)
 	log.Info("finish init regions")
@@ -445,12 +472,14 @@ func main() {
 	for i := 1; i <= cfg.StoreCount; i++ {
 		streams[uint64(i)] = createHeartbeatStream(ctx, cfg)
 	}
+	latency.report(ctx)
 	var heartbeatTicker = time.NewTicker(regionReportInterval * time.Second)
 	defer heartbeatTicker.Stop()
 	for {
 		select {
 		case <-heartbeatTicker.C:
 			if cfg.Round != 0 && regions.updateRound > cfg.Round {
+				latency.summary(ctx)
 				exit(0)
 			}
 			rep := newReport(cfg)
@@ -460,6 +489,9 @@ func main() {
 			wg := &sync.WaitGroup{}
 			for i := 1; i <= cfg.StoreCount; i++ {
 				id := uint64(i)
+				if stores.isDown(id) {
+					continue
+				}
 				wg.Add(1)
 				go regions.handleRegionHeartbeat(wg, streams[id], id, rep)
 			}
@@ -467,7 +499,7 @@ func main() {
 
 			since := time.Since(startTime).Seconds()
 			close(rep.Results())
-			regions.result(cfg.RegionCount, since)
+			regions.result(len(regions.regions), since)
 			stats := <-r
 			log.Info("region heartbeat stats", zap.String("total", fmt.Sprintf("%.4fs", stats.Total.Seconds())),
 				zap.String("slowest", fmt.Sprintf("%.4fs", stats.Slowest)),
@@ -477,7 +509,11 @@ func main() {
 				zap.String("rps", fmt.Sprintf("%.4f", stats.RPS)),
 			)
 			log.Info("store heartbeat stats", zap.String("max", fmt.Sprintf("%.4fs", since)))
+			latency.report(ctx)
 			regions.update(cfg.Replica)
+			for _, model := range models {
+				model.update(regions, stores, regions.updateRound)
+			}
 			go stores.update(regions) // update stores in background, unusually region heartbeat is slower than store update.
 		case <-ctx.Done():
 			log.Info("got signal to exit")
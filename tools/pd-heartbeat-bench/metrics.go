@@ -0,0 +1,201 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+)
+
+const (
+	regionHeartbeatHandleDuration = "pd_scheduler_handle_region_heartbeat_duration_seconds"
+	storeHeartbeatHandleDuration  = "pd_scheduler_handle_store_heartbeat_duration_seconds"
+)
+
+var percentiles = []float64{0.5, 0.9, 0.99, 0.999}
+
+// histogram is a histogram metric with the buckets of all the label values merged.
+type histogram struct {
+	upperBounds []float64
+	// counts are the cumulative counts of the buckets.
+	counts []uint64
+	count  uint64
+}
+
+func newHistogram(family *dto.MetricFamily) *histogram {
+	buckets := make(map[float64]uint64)
+	h := &histogram{}
+	for _, m := range family.GetMetric() {
+		h.count += m.GetHistogram().GetSampleCount()
+		for _, b := range m.GetHistogram().GetBucket() {
+			buckets[b.GetUpperBound()] += b.GetCumulativeCount()
+		}
+	}
+	for upperBound := range buckets {
+		h.upperBounds = append(h.upperBounds, upperBound)
+	}
+	sort.Float64s(h.upperBounds)
+	for _, upperBound := range h.upperBounds {
+		h.counts = append(h.counts, buckets[upperBound])
+	}
+	return h
+}
+
+// sub returns the histogram of the samples observed after the previous one.
+func (h *histogram) sub(prev *histogram) *histogram {
+	if prev == nil || len(prev.counts) != len(h.counts) {
+		return h
+	}
+	diff := &histogram{
+		upperBounds: h.upperBounds,
+		counts:      make([]uint64, len(h.counts)),
+		count:       h.count - prev.count,
+	}
+	for i := range h.counts {
+		diff.counts[i] = h.counts[i] - prev.counts[i]
+	}
+	return diff
+}
+
+// percentile estimates the percentile with the linear interpolation in the
+// bucket, like the histogram_quantile of Prometheus.
+func (h *histogram) percentile(p float64) float64 {
+	if h.count == 0 || len(h.counts) == 0 {
+		return math.NaN()
+	}
+	rank := p * float64(h.count)
+	var lowerBound float64
+	var lowerCount uint64
+	for i, upperBound := range h.upperBounds {
+		if float64(h.counts[i]) >= rank {
+			if math.IsInf(upperBound, 1) {
+				return lowerBound
+			}
+			if h.counts[i] == lowerCount {
+				return upperBound
+			}
+			return lowerBound + (upperBound-lowerBound)*(rank-float64(lowerCount))/float64(h.counts[i]-lowerCount)
+		}
+		lowerBound, lowerCount = upperBound, h.counts[i]
+	}
+	return lowerBound
+}
+
+func parseHistograms(r io.Reader, names ...string) (map[string]*histogram, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	histograms := make(map[string]*histogram, len(names))
+	for _, name := range names {
+		if family, ok := families[name]; ok && family.GetType() == dto.MetricType_HISTOGRAM {
+			histograms[name] = newHistogram(family)
+		}
+	}
+	return histograms, nil
+}
+
+// latencyReporter reports the percentiles of the heartbeat processing latency
+// of PD, which are calculated from the metrics of PD.
+type latencyReporter struct {
+	url    string
+	client *http.Client
+	first  map[string]*histogram
+	last   map[string]*histogram
+}
+
+func newLatencyReporter(pdAddr string) *latencyReporter {
+	if !strings.HasPrefix(pdAddr, "http://") && !strings.HasPrefix(pdAddr, "https://") {
+		pdAddr = "http://" + pdAddr
+	}
+	return &latencyReporter{
+		url:    strings.TrimSuffix(pdAddr, "/") + "/metrics",
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (l *latencyReporter) fetch(ctx context.Context) (map[string]*histogram, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.url, http.NoBody)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to fetch metrics, status %s", resp.Status)
+	}
+	return parseHistograms(resp.Body, regionHeartbeatHandleDuration, storeHeartbeatHandleDuration)
+}
+
+// report logs the latency percentiles since the last report.
+func (l *latencyReporter) report(ctx context.Context) {
+	current, err := l.fetch(ctx)
+	if err != nil {
+		log.Warn("failed to fetch the heartbeat latency metrics of PD", zap.Error(err))
+		return
+	}
+	if l.first == nil {
+		l.first = current
+	} else {
+		logLatency("pd heartbeat processing latency of the round", current, l.last)
+	}
+	l.last = current
+}
+
+// summary logs the latency percentiles since the first report.
+func (l *latencyReporter) summary(ctx context.Context) {
+	l.report(ctx)
+	if l.last != nil {
+		logLatency("pd heartbeat processing latency in total", l.last, l.first)
+	}
+}
+
+func logLatency(msg string, current, prev map[string]*histogram) {
+	fields := make([]zap.Field, 0, 2*(len(percentiles)+1))
+	for _, item := range []struct {
+		name   string
+		metric string
+	}{
+		{"region", regionHeartbeatHandleDuration},
+		{"store", storeHeartbeatHandleDuration},
+	} {
+		h, ok := current[item.metric]
+		if !ok {
+			continue
+		}
+		h = h.sub(prev[item.metric])
+		fields = append(fields, zap.Uint64(item.name+"-count", h.count))
+		for _, p := range percentiles {
+			fields = append(fields, zap.String(fmt.Sprintf("%s-p%v", item.name, p*100), fmt.Sprintf("%.4fs", h.percentile(p))))
+		}
+	}
+	log.Info(msg, fields...)
+}
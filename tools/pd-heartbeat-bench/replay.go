@@ -0,0 +1,156 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/capture"
+	"github.com/tikv/pd/tools/pd-heartbeat-bench/config"
+	"go.uber.org/zap"
+)
+
+// replayer sends the heartbeats in a capture recorded from PD.
+type replayer struct {
+	cfg     *config.Config
+	cli     pdpb.PDClient
+	stores  map[uint64]struct{}
+	streams map[uint64]pdpb.PD_RegionHeartbeatClient
+
+	regionHeartbeats int
+	storeHeartbeats  int
+}
+
+func newReplayer(cfg *config.Config, cli pdpb.PDClient) *replayer {
+	return &replayer{
+		cfg:     cfg,
+		cli:     cli,
+		stores:  make(map[uint64]struct{}),
+		streams: make(map[uint64]pdpb.PD_RegionHeartbeatClient),
+	}
+}
+
+func (r *replayer) replay(ctx context.Context) error {
+	f, err := os.Open(r.cfg.Replay)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+	reader, err := capture.NewReader(f)
+	if err != nil {
+		return err
+	}
+	log.Info("start to replay heartbeats", zap.String("file", r.cfg.Replay),
+		zap.Time("capture-start", reader.StartTime()), zap.Float64("speed", r.cfg.ReplaySpeed))
+	start := time.Now()
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if r.cfg.ReplaySpeed > 0 {
+			wait := time.Duration(float64(record.Offset)/r.cfg.ReplaySpeed) - time.Since(start)
+			if wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+		switch record.Type {
+		case capture.RegionHeartbeat:
+			err = r.sendRegionHeartbeat(ctx, record.RegionHeartbeat)
+		case capture.StoreHeartbeat:
+			err = r.sendStoreHeartbeat(ctx, record.StoreHeartbeat)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	log.Info("finish replaying heartbeats", zap.Duration("cost-time", time.Since(start)),
+		zap.Int("region-heartbeats", r.regionHeartbeats), zap.Int("store-heartbeats", r.storeHeartbeats),
+		zap.Int("stores", len(r.stores)))
+	return nil
+}
+
+func (r *replayer) sendRegionHeartbeat(ctx context.Context, req *pdpb.RegionHeartbeatRequest) error {
+	for _, peer := range req.GetRegion().GetPeers() {
+		if err := r.putStore(ctx, peer.GetStoreId()); err != nil {
+			return err
+		}
+	}
+	storeID := req.GetLeader().GetStoreId()
+	stream, ok := r.streams[storeID]
+	if !ok {
+		stream = createHeartbeatStream(ctx, r.cfg)
+		r.streams[storeID] = stream
+	}
+	req.Header = header()
+	if err := stream.Send(req); err != nil {
+		return errors.WithStack(err)
+	}
+	r.regionHeartbeats++
+	return nil
+}
+
+func (r *replayer) sendStoreHeartbeat(ctx context.Context, req *pdpb.StoreHeartbeatRequest) error {
+	if err := r.putStore(ctx, req.GetStats().GetStoreId()); err != nil {
+		return err
+	}
+	req.Header = header()
+	resp, err := r.cli.StoreHeartbeat(ctx, req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if resp.GetHeader().GetError() != nil {
+		log.Warn("failed to send store heartbeat", zap.Uint64("store-id", req.GetStats().GetStoreId()),
+			zap.String("err", resp.GetHeader().GetError().String()))
+	}
+	r.storeHeartbeats++
+	return nil
+}
+
+// putStore puts the store which is first seen in the capture.
+func (r *replayer) putStore(ctx context.Context, storeID uint64) error {
+	if _, ok := r.stores[storeID]; ok || storeID == 0 {
+		return nil
+	}
+	store := &metapb.Store{
+		Id:      storeID,
+		Address: fmt.Sprintf("localhost:%d", storeID),
+		Version: "6.4.0-alpha",
+	}
+	resp, err := r.cli.PutStore(ctx, &pdpb.PutStoreRequest{Header: header(), Store: store})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if resp.GetHeader().GetError() != nil {
+		return errors.Errorf("failed to put store %d: %s", storeID, resp.GetHeader().GetError().String())
+	}
+	r.stores[storeID] = struct{}{}
+	return nil
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"math/rand"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/tikv/pd/tools/pd-heartbeat-bench/config"
+)
+
+const (
+	zipfHotnessModel   = "zipf-hotness"
+	rollingSplitModel  = "rolling-split"
+	leaderChurnModel   = "leader-churn"
+	storeFlappingModel = "store-flapping"
+)
+
+// workloadModel changes the regions and stores in each round to simulate a
+// kind of workload.
+type workloadModel interface {
+	update(rs *Regions, stores *Stores, round int)
+}
+
+func newWorkloadModels(cfg *config.Config) ([]workloadModel, error) {
+	models := make([]workloadModel, 0, len(cfg.WorkloadModels))
+	for i, name := range cfg.WorkloadModels {
+		// Ensure consistent behavior multiple times
+		r := rand.New(rand.NewSource(int64(i)))
+		switch name {
+		case zipfHotnessModel:
+			if cfg.ZipfSkew <= 1 {
+				return nil, errors.Errorf("zipf-skew should be greater than 1, but got %v", cfg.ZipfSkew)
+			}
+			models = append(models, &zipfHotness{rand: r, skew: cfg.ZipfSkew, ratio: cfg.FlowUpdateRatio})
+		case rollingSplitModel:
+			models = append(models, &rollingSplit{rand: r, ratio: cfg.SplitRatio})
+		case leaderChurnModel:
+			models = append(models, &leaderChurn{rand: r, ratio: cfg.LeaderChurnRatio})
+		case storeFlappingModel:
+			if cfg.FlappingDuration >= cfg.FlappingPeriod {
+				return nil, errors.Errorf("flapping-duration %d should be less than flapping-period %d",
+					cfg.FlappingDuration, cfg.FlappingPeriod)
+			}
+			models = append(models, &storeFlapping{
+				rand:     r,
+				count:    cfg.FlappingStoreCount,
+				period:   cfg.FlappingPeriod,
+				duration: cfg.FlappingDuration,
+			})
+		default:
+			return nil, errors.Errorf("unknown workload model %s", name)
+		}
+	}
+	return models, nil
+}
+
+// zipfHotness makes the flow of the regions follow the Zipfian distribution,
+// so a few regions are much hotter than the others.
+type zipfHotness struct {
+	rand  *rand.Rand
+	skew  float64
+	ratio float64
+}
+
+func (m *zipfHotness) update(rs *Regions, _ *Stores, _ int) {
+	if len(rs.regions) == 0 {
+		return
+	}
+	zipf := rand.NewZipf(m.rand, m.skew, 1, uint64(len(rs.regions)-1))
+	hits := make(map[int]uint64)
+	for i := 0; i < int(float64(len(rs.regions))*m.ratio); i++ {
+		hits[int(zipf.Uint64())]++
+	}
+	for i, region := range rs.regions {
+		hit := hits[i]
+		region.BytesWritten = hit * bytesUnit
+		region.BytesRead = hit * bytesUnit
+		region.KeysWritten = hit * keysUint
+		region.KeysRead = hit * keysUint
+		region.QueryStats = &pdpb.QueryStats{
+			Get: hit * queryUnit,
+			Put: hit * queryUnit,
+		}
+	}
+}
+
+// rollingSplit splits some random regions in each round.
+type rollingSplit struct {
+	rand  *rand.Rand
+	ratio float64
+}
+
+func (m *rollingSplit) update(rs *Regions, _ *Stores, _ int) {
+	count := int(float64(len(rs.regions)) * m.ratio)
+	for i := 0; i < count; i++ {
+		rs.split(m.rand.Intn(len(rs.regions)))
+	}
+}
+
+// leaderChurn transfers the leaders of some random regions to other peers in
+// each round.
+type leaderChurn struct {
+	rand  *rand.Rand
+	ratio float64
+}
+
+func (m *leaderChurn) update(rs *Regions, _ *Stores, _ int) {
+	count := int(float64(len(rs.regions)) * m.ratio)
+	for i := 0; i < count; i++ {
+		region := rs.regions[m.rand.Intn(len(rs.regions))]
+		peers := region.Region.Peers
+		if len(peers) < 2 {
+			continue
+		}
+		leaderIdx := 0
+		for j, peer := range peers {
+			if peer.GetId() == region.Leader.GetId() {
+				leaderIdx = j
+				break
+			}
+		}
+		region.Leader = peers[(leaderIdx+1+m.rand.Intn(len(peers)-1))%len(peers)]
+		region.Term++
+	}
+}
+
+// storeFlapping makes some random stores down for a few rounds periodically.
+// The down stores send neither the store heartbeats nor the region heartbeats.
+type storeFlapping struct {
+	rand     *rand.Rand
+	count    int
+	period   int
+	duration int
+}
+
+func (m *storeFlapping) update(_ *Regions, stores *Stores, round int) {
+	switch round % m.period {
+	case 0:
+		storeIDs := m.rand.Perm(len(stores.down) - 1)
+		for i := 0; i < m.count && i < len(storeIDs); i++ {
+			stores.down[storeIDs[i]+1].Store(true)
+		}
+	case m.duration:
+		for i := range stores.down {
+			stores.down[i].Store(false)
+		}
+	}
+}
+
+// split splits the region at the given index. The original region keeps the
+// right part and a new region is created for the left part.
+func (rs *Regions) split(i int) {
+	origin := rs.regions[i]
+	splitKey, ok := findSplitKey(origin.Region.StartKey, origin.Region.EndKey)
+	if !ok {
+		return
+	}
+	origin.Region.RegionEpoch.Version++
+	peers := make([]*metapb.Peer, 0, len(origin.Region.Peers))
+	var leader *metapb.Peer
+	for _, p := range origin.Region.Peers {
+		peer := &metapb.Peer{Id: rs.allocID(), StoreId: p.GetStoreId(), Role: p.GetRole()}
+		if p.GetId() == origin.Leader.GetId() {
+			leader = peer
+		}
+		peers = append(peers, peer)
+	}
+	region := &pdpb.RegionHeartbeatRequest{
+		Header: header(),
+		Region: &metapb.Region{
+			Id:       rs.allocID(),
+			StartKey: origin.Region.StartKey,
+			EndKey:   splitKey,
+			RegionEpoch: &metapb.RegionEpoch{
+				ConfVer: origin.Region.RegionEpoch.ConfVer,
+				Version: origin.Region.RegionEpoch.Version,
+			},
+			Peers: peers,
+		},
+		Leader:          leader,
+		ApproximateSize: origin.ApproximateSize / 2,
+		ApproximateKeys: origin.ApproximateKeys / 2,
+		Interval: &pdpb.TimeInterval{
+			StartTimestamp: origin.Interval.StartTimestamp,
+			EndTimestamp:   origin.Interval.EndTimestamp,
+		},
+		QueryStats: &pdpb.QueryStats{},
+		Term:       origin.Term,
+	}
+	origin.Region.StartKey = splitKey
+	origin.ApproximateSize -= region.ApproximateSize
+	origin.ApproximateKeys -= region.ApproximateKeys
+	rs.regions = append(rs.regions, region)
+}
+
+// findSplitKey finds a key which is greater than the start key and less than
+// the end key. It returns false if there is no such key.
+func findSplitKey(startKey, endKey []byte) ([]byte, bool) {
+	key := append(append([]byte{}, startKey...), 0x80)
+	if len(endKey) == 0 || bytes.Compare(key, endKey) < 0 {
+		return key, true
+	}
+	// The end key must be the start key with a suffix less than or equal to 0x80.
+	for i := len(startKey); i < len(endKey); i++ {
+		switch {
+		case endKey[i] > 1:
+			return append(append([]byte{}, endKey[:i]...), endKey[i]/2), true
+		case endKey[i] == 1:
+			return append(append([]byte{}, endKey[:i]...), 0, 0x80), true
+		}
+	}
+	// The suffix is all zeros.
+	if len(endKey)-len(startKey) > 1 {
+		return append(append([]byte{}, startKey...), 0), true
+	}
+	return nil, false
+}
+
+func (rs *Regions) allocID() uint64 {
+	id := rs.nextID
+	rs.nextID++
+	return id
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/tools/pd-heartbeat-bench/config"
+)
+
+func newTestConfig() *config.Config {
+	return &config.Config{
+		StoreCount:         5,
+		RegionCount:        100,
+		KeyLength:          56,
+		Replica:            3,
+		FlowUpdateRatio:    0.5,
+		ZipfSkew:           1.2,
+		SplitRatio:         0.1,
+		LeaderChurnRatio:   0.2,
+		FlappingStoreCount: 2,
+		FlappingPeriod:     3,
+		FlappingDuration:   1,
+	}
+}
+
+func TestWorkloadModels(t *testing.T) {
+	re := require.New(t)
+	cfg := newTestConfig()
+	cfg.WorkloadModels = []string{"unknown"}
+	_, err := newWorkloadModels(cfg)
+	re.Error(err)
+	cfg.WorkloadModels = []string{zipfHotnessModel, rollingSplitModel, leaderChurnModel, storeFlappingModel}
+	models, err := newWorkloadModels(cfg)
+	re.NoError(err)
+	re.Len(models, 4)
+
+	rs := new(Regions)
+	rs.init(cfg)
+	stores := newStores(cfg.StoreCount)
+	for round := 1; round <= 6; round++ {
+		for _, model := range models {
+			model.update(rs, stores, round)
+		}
+		downCount := 0
+		for i := 1; i <= cfg.StoreCount; i++ {
+			if stores.isDown(uint64(i)) {
+				downCount++
+			}
+		}
+		if round%cfg.FlappingPeriod == 0 {
+			re.Equal(cfg.FlappingStoreCount, downCount)
+		} else {
+			re.Zero(downCount)
+		}
+	}
+	re.Greater(len(rs.regions), cfg.RegionCount)
+	checkRegions(re, rs)
+}
+
+func TestRollingSplit(t *testing.T) {
+	re := require.New(t)
+	rs := new(Regions)
+	rs.init(newTestConfig())
+	for i := 0; i < 50; i++ {
+		// Split the first region and the same region repeatedly.
+		rs.split(0)
+		rs.split(1)
+	}
+	re.Len(rs.regions, 200)
+	checkRegions(re, rs)
+}
+
+func TestFindSplitKey(t *testing.T) {
+	re := require.New(t)
+	testCases := []struct {
+		startKey, endKey string
+		ok               bool
+	}{
+		{"", "", true},
+		{"", "a", true},
+		{"a", "b", true},
+		{"a", "a\x80", true},
+		{"a", "a\x01", true},
+		{"a", "a\x00\x01", true},
+		{"a", "a\x00\x00", true},
+		{"a", "a\x00", false},
+	}
+	for _, testCase := range testCases {
+		key, ok := findSplitKey([]byte(testCase.startKey), []byte(testCase.endKey))
+		re.Equal(testCase.ok, ok)
+		if ok {
+			re.Positive(bytes.Compare(key, []byte(testCase.startKey)))
+			if len(testCase.endKey) > 0 {
+				re.Negative(bytes.Compare(key, []byte(testCase.endKey)))
+			}
+		}
+	}
+}
+
+// checkRegions checks the regions don't overlap with each other and the IDs are unique.
+func checkRegions(re *require.Assertions, rs *Regions) {
+	regions := append(rs.regions[:0:0], rs.regions...)
+	sort.Slice(regions, func(i, j int) bool {
+		return bytes.Compare(regions[i].Region.StartKey, regions[j].Region.StartKey) < 0
+	})
+	ids := make(map[uint64]struct{})
+	re.Empty(regions[0].Region.StartKey)
+	re.Empty(regions[len(regions)-1].Region.EndKey)
+	for i, region := range regions {
+		if i > 0 {
+			re.LessOrEqual(bytes.Compare(regions[i-1].Region.EndKey, region.Region.StartKey), 0)
+		}
+		if len(region.Region.EndKey) > 0 {
+			re.Negative(bytes.Compare(region.Region.StartKey, region.Region.EndKey))
+		}
+		re.NotContains(ids, region.Region.Id)
+		ids[region.Region.Id] = struct{}{}
+		leaderFound := false
+		for _, peer := range region.Region.Peers {
+			re.NotContains(ids, peer.Id)
+			ids[peer.Id] = struct{}{}
+			leaderFound = leaderFound || peer == region.Leader
+		}
+		re.True(leaderFound)
+	}
+}
+
+const testMetrics = `# HELP pd_scheduler_handle_region_heartbeat_duration_seconds Bucketed histogram of processing time (s) of handled region heartbeat requests.
+# TYPE pd_scheduler_handle_region_heartbeat_duration_seconds histogram
+pd_scheduler_handle_region_heartbeat_duration_seconds_bucket{address="a",store="1",le="0.001"} 50
+pd_scheduler_handle_region_heartbeat_duration_seconds_bucket{address="a",store="1",le="0.002"} 90
+pd_scheduler_handle_region_heartbeat_duration_seconds_bucket{address="a",store="1",le="+Inf"} 100
+pd_scheduler_handle_region_heartbeat_duration_seconds_sum{address="a",store="1"} 0.2
+pd_scheduler_handle_region_heartbeat_duration_seconds_count{address="a",store="1"} 100
+pd_scheduler_handle_region_heartbeat_duration_seconds_bucket{address="a",store="2",le="0.001"} 50
+pd_scheduler_handle_region_heartbeat_duration_seconds_bucket{address="a",store="2",le="0.002"} 90
+pd_scheduler_handle_region_heartbeat_duration_seconds_bucket{address="a",store="2",le="+Inf"} 100
+pd_scheduler_handle_region_heartbeat_duration_seconds_sum{address="a",store="2"} 0.2
+pd_scheduler_handle_region_heartbeat_duration_seconds_count{address="a",store="2"} 100
+`
+
+func TestHistogram(t *testing.T) {
+	re := require.New(t)
+	histograms, err := parseHistograms(strings.NewReader(testMetrics), regionHeartbeatHandleDuration, storeHeartbeatHandleDuration)
+	re.NoError(err)
+	re.Len(histograms, 1)
+	h := histograms[regionHeartbeatHandleDuration]
+	re.Equal(uint64(200), h.count)
+	re.InDelta(0.0005, h.percentile(0.25), 1e-9)
+	re.InDelta(0.0015, h.percentile(0.7), 1e-9)
+	// The percentile in the +Inf bucket is the largest finite upper bound.
+	re.InDelta(0.002, h.percentile(0.99), 1e-9)
+
+	prev := &histogram{
+		upperBounds: h.upperBounds,
+		counts:      []uint64{100, 100, 100},
+		count:       100,
+	}
+	diff := h.sub(prev)
+	re.Equal(uint64(100), diff.count)
+	re.InDelta(0.0015, diff.percentile(0.4), 1e-9)
+	re.True(math.IsNaN(h.sub(h).percentile(0.5)))
+}
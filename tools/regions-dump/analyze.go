@@ -0,0 +1,386 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/server/config"
+)
+
+// defaultMaxReplicas is the replica count to initialize the rule manager, whose default
+// rule is replaced by the rules in the file.
+const defaultMaxReplicas = 3
+
+// storeDistribution is the distribution of the peers on a store.
+type storeDistribution struct {
+	StoreID   uint64
+	Peers     int
+	Leaders   int
+	Learners  int
+	Witnesses int
+}
+
+// keyRange is a range of the key space in hex format.
+type keyRange struct {
+	StartKey string
+	EndKey   string
+}
+
+// ruleViolation is a region which doesn't satisfy the placement rules.
+type ruleViolation struct {
+	RegionID uint64
+	Reasons  []string
+}
+
+// analysisReport is the result of analyzing the dumped regions.
+type analysisReport struct {
+	RegionCount int
+	HasLeader   bool
+	Stores      []*storeDistribution
+	// HolesSkipped is true if the regions are filtered by the store, which leaves holes anyway.
+	HolesSkipped bool
+	Holes        []keyRange
+	// Overlaps are the pairs of the region IDs whose key ranges overlap.
+	Overlaps   [][2]uint64
+	Violations []*ruleViolation
+}
+
+// analyze analyzes the regions dumped in the text or JSON lines format. If the rules file
+// is given, the regions are also checked against the placement rules. The filter is the one
+// which the regions are dumped with, so the key space out of it is not counted as holes.
+func analyze(regionsPath, format, rulesPath, storesPath string, filter *regionFilter, out io.Writer) error {
+	records, err := loadRegionRecords(regionsPath, format)
+	if err != nil {
+		return err
+	}
+	regions := make([]*core.RegionInfo, 0, len(records))
+	for _, record := range records {
+		region, err := record.toRegionInfo()
+		if err != nil {
+			return err
+		}
+		regions = append(regions, region)
+	}
+	stores := core.NewBasicCluster()
+	if storesPath != "" {
+		if err := loadStores(storesPath, stores); err != nil {
+			return err
+		}
+	}
+	// The stores without labels are added for the peers not on the known stores.
+	for _, region := range regions {
+		for _, peer := range region.GetPeers() {
+			if stores.GetStore(peer.GetStoreId()) == nil {
+				stores.PutStore(core.NewStoreInfo(&metapb.Store{Id: peer.GetStoreId()}))
+			}
+		}
+	}
+	var ruleManager *placement.RuleManager
+	if rulesPath != "" {
+		if ruleManager, err = newRuleManager(rulesPath, stores); err != nil {
+			return err
+		}
+	}
+	report := analyzeRegions(regions, ruleManager, stores, filter)
+	report.print(out)
+	return nil
+}
+
+// loadRegionRecords loads the regions dumped in the given format. The CSV format can't be
+// analyzed since it doesn't keep the peers.
+func loadRegionRecords(path, format string) ([]*regionRecord, error) {
+	if format != formatText && format != formatJSONL {
+		return nil, errors.Errorf("unsupported format %s to analyze, it should be one of %s and %s",
+			format, formatText, formatJSONL)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer f.Close()
+	var records []*regionRecord
+	scanner := bufio.NewScanner(f)
+	// The region keys may be very long.
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		record, err := parseRegionRecord(data, format)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid region at line %d", line)
+		}
+		records = append(records, record)
+	}
+	return records, errors.WithStack(scanner.Err())
+}
+
+func parseRegionRecord(data []byte, format string) (*regionRecord, error) {
+	if format == formatText {
+		// The text format is the compact text of the region meta written by textWriter.
+		region := &metapb.Region{}
+		if err := proto.UnmarshalText(string(data), region); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return newRegionRecord(region), nil
+	}
+	record := &regionRecord{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return record, nil
+}
+
+// loadStores loads the stores in the format of the PD stores API.
+func loadStores(path string, stores *core.BasicCluster) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	var info struct {
+		Stores []struct {
+			Store *metapb.Store `json:"store"`
+		} `json:"stores"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return errors.Annotate(err, "invalid stores file")
+	}
+	for _, s := range info.Stores {
+		if s.Store != nil {
+			stores.PutStore(core.NewStoreInfo(s.Store))
+		}
+	}
+	return nil
+}
+
+// newRuleManager creates a rule manager with exactly the rules in the file, which is in the
+// same format as the output of `pd-ctl config placement-rules show` or the rule bundles of
+// `pd-ctl config placement-rules rule-bundle load`. The default rule is not kept unless it's
+// in the file, so the rules must cover the whole key space.
+func newRuleManager(path string, stores *core.BasicCluster) (*placement.RuleManager, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	bundles, err := parseRuleBundles(data)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid rules file")
+	}
+	opt := config.NewPersistOptions(config.NewConfig())
+	m := placement.NewRuleManager(storage.NewStorageWithMemoryBackend(), stores, opt)
+	if err := m.Initialize(defaultMaxReplicas, nil, ""); err != nil {
+		return nil, err
+	}
+	if err := m.SetAllGroupBundles(bundles, true); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// parseRuleBundles parses the rule bundles, or the rules which are grouped by their group IDs.
+func parseRuleBundles(data []byte) ([]placement.GroupBundle, error) {
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	if len(items) > 0 {
+		if _, ok := items[0]["rules"]; ok {
+			var bundles []placement.GroupBundle
+			if err := json.Unmarshal(data, &bundles); err != nil {
+				return nil, err
+			}
+			return bundles, nil
+		}
+	}
+	var rules []*placement.Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+	var bundles []placement.GroupBundle
+	groups := make(map[string]int)
+	for _, rule := range rules {
+		i, ok := groups[rule.GroupID]
+		if !ok {
+			i = len(bundles)
+			groups[rule.GroupID] = i
+			bundles = append(bundles, placement.GroupBundle{ID: rule.GroupID})
+		}
+		bundles[i].Rules = append(bundles[i].Rules, rule)
+	}
+	return bundles, nil
+}
+
+func analyzeRegions(regions []*core.RegionInfo, ruleManager *placement.RuleManager, stores placement.StoreSet, filter *regionFilter) *analysisReport {
+	report := &analysisReport{RegionCount: len(regions), HolesSkipped: filter.storeID != 0}
+	report.Stores = analyzeDistribution(regions, report)
+	report.Holes, report.Overlaps = analyzeKeySpace(regions, filter)
+	if ruleManager != nil {
+		for _, region := range regions {
+			fit := ruleManager.FitRegion(stores, region)
+			if !fit.IsSatisfied() {
+				report.Violations = append(report.Violations, &ruleViolation{
+					RegionID: region.GetID(),
+					Reasons:  violationReasons(fit),
+				})
+			}
+		}
+	}
+	return report
+}
+
+func analyzeDistribution(regions []*core.RegionInfo, report *analysisReport) []*storeDistribution {
+	distributions := make(map[uint64]*storeDistribution)
+	get := func(storeID uint64) *storeDistribution {
+		d, ok := distributions[storeID]
+		if !ok {
+			d = &storeDistribution{StoreID: storeID}
+			distributions[storeID] = d
+		}
+		return d
+	}
+	for _, region := range regions {
+		for _, peer := range region.GetPeers() {
+			d := get(peer.GetStoreId())
+			d.Peers++
+			if peer.GetRole() == metapb.PeerRole_Learner {
+				d.Learners++
+			}
+			if peer.GetIsWitness() {
+				d.Witnesses++
+			}
+		}
+		if leader := region.GetLeader(); leader != nil {
+			report.HasLeader = true
+			get(leader.GetStoreId()).Leaders++
+		}
+	}
+	result := make([]*storeDistribution, 0, len(distributions))
+	for _, d := range distributions {
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StoreID < result[j].StoreID })
+	return result
+}
+
+// analyzeKeySpace finds the holes and overlaps in the key space covered by the regions. The
+// holes are only found in the key range of the filter, and not at all if the regions are
+// filtered by the store.
+func analyzeKeySpace(regions []*core.RegionInfo, filter *regionFilter) (holes []keyRange, overlaps [][2]uint64) {
+	if len(regions) == 0 {
+		return nil, nil
+	}
+	sorted := append(regions[:0:0], regions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if c := bytes.Compare(sorted[i].GetStartKey(), sorted[j].GetStartKey()); c != 0 {
+			return c < 0
+		}
+		return sorted[i].GetID() < sorted[j].GetID()
+	})
+	addHole := func(startKey, endKey []byte) {
+		if filter.storeID == 0 {
+			holes = append(holes, keyRange{StartKey: core.HexRegionKeyStr(startKey), EndKey: core.HexRegionKeyStr(endKey)})
+		}
+	}
+	if first := sorted[0]; bytes.Compare(first.GetStartKey(), filter.startKey) > 0 {
+		addHole(filter.startKey, first.GetStartKey())
+	}
+	// last is the region which covers the key space to the farthest so far.
+	last := sorted[0]
+	for _, region := range sorted[1:] {
+		lastEnd := last.GetEndKey()
+		switch {
+		case len(lastEnd) == 0 || bytes.Compare(region.GetStartKey(), lastEnd) < 0:
+			overlaps = append(overlaps, [2]uint64{last.GetID(), region.GetID()})
+		case bytes.Compare(region.GetStartKey(), lastEnd) > 0:
+			addHole(lastEnd, region.GetStartKey())
+		}
+		if len(lastEnd) > 0 && (len(region.GetEndKey()) == 0 || bytes.Compare(region.GetEndKey(), lastEnd) > 0) {
+			last = region
+		}
+	}
+	if lastEnd := last.GetEndKey(); len(lastEnd) > 0 && (len(filter.endKey) == 0 || bytes.Compare(lastEnd, filter.endKey) < 0) {
+		addHole(lastEnd, filter.endKey)
+	}
+	return holes, overlaps
+}
+
+func violationReasons(fit *placement.RegionFit) []string {
+	var reasons []string
+	if len(fit.RuleFits) == 0 {
+		reasons = append(reasons, "no rule is applied")
+	}
+	for _, rf := range fit.RuleFits {
+		if len(rf.Peers) != rf.Rule.Count {
+			reasons = append(reasons, fmt.Sprintf("rule %s/%s wants %d %s peers but got %d",
+				rf.Rule.GroupID, rf.Rule.ID, rf.Rule.Count, rf.Rule.Role, len(rf.Peers)))
+		}
+		if len(rf.PeersWithDifferentRole) > 0 {
+			reasons = append(reasons, fmt.Sprintf("rule %s/%s has %d peers with a different role",
+				rf.Rule.GroupID, rf.Rule.ID, len(rf.PeersWithDifferentRole)))
+		}
+	}
+	if len(fit.OrphanPeers) > 0 {
+		storeIDs := make([]string, 0, len(fit.OrphanPeers))
+		for _, peer := range fit.OrphanPeers {
+			storeIDs = append(storeIDs, fmt.Sprint(peer.GetStoreId()))
+		}
+		reasons = append(reasons, fmt.Sprintf("orphan peers on stores %s", strings.Join(storeIDs, ",")))
+	}
+	return reasons
+}
+
+func (r *analysisReport) print(out io.Writer) {
+	fmt.Fprintf(out, "regions: %d\n", r.RegionCount)
+	fmt.Fprintln(out, "\nstore distribution:")
+	fmt.Fprintf(out, "%-10s %-10s %-10s %-10s %-10s\n", "store", "peers", "leaders", "learners", "witnesses")
+	for _, d := range r.Stores {
+		leaders := "-"
+		if r.HasLeader {
+			leaders = fmt.Sprint(d.Leaders)
+		}
+		fmt.Fprintf(out, "%-10d %-10d %-10s %-10d %-10d\n", d.StoreID, d.Peers, leaders, d.Learners, d.Witnesses)
+	}
+	if r.HolesSkipped {
+		fmt.Fprintln(out, "\nholes: skipped since the regions are filtered by store")
+	} else {
+		fmt.Fprintf(out, "\nholes: %d\n", len(r.Holes))
+	}
+	for _, hole := range r.Holes {
+		fmt.Fprintf(out, "  [%q, %q)\n", hole.StartKey, hole.EndKey)
+	}
+	fmt.Fprintf(out, "\noverlaps: %d\n", len(r.Overlaps))
+	for _, overlap := range r.Overlaps {
+		fmt.Fprintf(out, "  region %d and region %d\n", overlap[0], overlap[1])
+	}
+	fmt.Fprintf(out, "\nplacement rule violations: %d\n", len(r.Violations))
+	for _, v := range r.Violations {
+		fmt.Fprintf(out, "  region %d: %s\n", v.RegionID, strings.Join(v.Reasons, "; "))
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/core"
+)
+
+func newTestRegion(id uint64, startKey, endKey string, storeIDs ...uint64) *metapb.Region {
+	region := &metapb.Region{
+		Id:          id,
+		StartKey:    []byte(startKey),
+		EndKey:      []byte(endKey),
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1},
+	}
+	for i, storeID := range storeIDs {
+		region.Peers = append(region.Peers, &metapb.Peer{Id: id*10 + uint64(i), StoreId: storeID})
+	}
+	return region
+}
+
+func TestRegionFilter(t *testing.T) {
+	re := require.New(t)
+	region := newTestRegion(1, "a", "c", 1, 2)
+	filter, err := newRegionFilter(0, "", "")
+	re.NoError(err)
+	re.True(filter.match(region))
+	filter, err = newRegionFilter(3, "", "")
+	re.NoError(err)
+	re.False(filter.match(region))
+	// "b" is 62 and "d" is 64 in hex.
+	filter, err = newRegionFilter(2, "62", "64")
+	re.NoError(err)
+	re.True(filter.match(region))
+	filter, err = newRegionFilter(0, "63", "")
+	re.NoError(err)
+	re.False(filter.match(region))
+	_, err = newRegionFilter(0, "xyz", "")
+	re.Error(err)
+}
+
+func TestWriteAndAnalyze(t *testing.T) {
+	re := require.New(t)
+	regions := []*metapb.Region{
+		newTestRegion(1, "", "b", 1, 2, 3),
+		newTestRegion(2, "b", "d", 1, 2, 3),
+		// region 3 overlaps with region 2.
+		newTestRegion(3, "c", "e", 1, 2, 3),
+		// there is a hole between "e" and "f", and region 4 lacks a peer.
+		newTestRegion(4, "f", "", 1, 2),
+	}
+	var buf bytes.Buffer
+	w, err := newRegionWriter(formatJSONL, &buf)
+	re.NoError(err)
+	for _, region := range regions {
+		re.NoError(w.Write(region))
+	}
+	re.NoError(w.Flush())
+
+	dir := t.TempDir()
+	regionsPath := filepath.Join(dir, "regions.jsonl")
+	re.NoError(os.WriteFile(regionsPath, buf.Bytes(), 0o600))
+	records, err := loadRegionRecords(regionsPath, formatJSONL)
+	re.NoError(err)
+	re.Len(records, len(regions))
+	infos := make([]*core.RegionInfo, 0, len(records))
+	for i, record := range records {
+		info, err := record.toRegionInfo()
+		re.NoError(err)
+		re.Equal(regions[i].GetStartKey(), info.GetStartKey())
+		re.Equal(regions[i].GetEndKey(), info.GetEndKey())
+		infos = append(infos, info)
+	}
+
+	stores := core.NewBasicCluster()
+	for id := uint64(1); id <= 3; id++ {
+		stores.PutStore(core.NewStoreInfo(&metapb.Store{Id: id}))
+	}
+	rulesPath := filepath.Join(dir, "rules.json")
+	re.NoError(os.WriteFile(rulesPath, []byte(`[{"group_id":"pd","id":"default","role":"voter","count":3}]`), 0o600))
+	ruleManager, err := newRuleManager(rulesPath, stores)
+	re.NoError(err)
+
+	report := analyzeRegions(infos, ruleManager, stores, &regionFilter{})
+	re.Equal(4, report.RegionCount)
+	re.False(report.HasLeader)
+	re.Len(report.Stores, 3)
+	re.Equal(4, report.Stores[0].Peers)
+	re.Equal(3, report.Stores[2].Peers)
+	re.Equal([]keyRange{{StartKey: core.HexRegionKeyStr([]byte("e")), EndKey: core.HexRegionKeyStr([]byte("f"))}}, report.Holes)
+	re.Equal([][2]uint64{{2, 3}}, report.Overlaps)
+	re.Len(report.Violations, 1)
+	re.Equal(uint64(4), report.Violations[0].RegionID)
+
+	var out bytes.Buffer
+	report.print(&out)
+	re.Contains(out.String(), "placement rule violations: 1")
+}
+
+func TestAnalyzeFilteredRegions(t *testing.T) {
+	re := require.New(t)
+	infos := []*core.RegionInfo{
+		core.NewRegionInfo(newTestRegion(1, "b", "c", 1), nil),
+		core.NewRegionInfo(newTestRegion(2, "d", "e", 1), nil),
+	}
+	hexKey := func(key string) string { return core.HexRegionKeyStr([]byte(key)) }
+	// The holes out of the key range of the filter are skipped.
+	filter, err := newRegionFilter(0, hexKey("b"), hexKey("e"))
+	re.NoError(err)
+	report := analyzeRegions(infos, nil, nil, filter)
+	re.Equal([]keyRange{{StartKey: hexKey("c"), EndKey: hexKey("d")}}, report.Holes)
+	filter, err = newRegionFilter(0, hexKey("a"), hexKey("f"))
+	re.NoError(err)
+	report = analyzeRegions(infos, nil, nil, filter)
+	re.Equal([]keyRange{
+		{StartKey: hexKey("a"), EndKey: hexKey("b")},
+		{StartKey: hexKey("c"), EndKey: hexKey("d")},
+		{StartKey: hexKey("e"), EndKey: hexKey("f")},
+	}, report.Holes)
+	// No hole is reported if the regions are filtered by the store.
+	filter, err = newRegionFilter(1, "", "")
+	re.NoError(err)
+	report = analyzeRegions(infos, nil, nil, filter)
+	re.True(report.HolesSkipped)
+	re.Empty(report.Holes)
+	var out bytes.Buffer
+	report.print(&out)
+	re.Contains(out.String(), "holes: skipped")
+}
+
+func TestRulesReplaceDefault(t *testing.T) {
+	re := require.New(t)
+	stores := core.NewBasicCluster()
+	for id := uint64(1); id <= 3; id++ {
+		stores.PutStore(core.NewStoreInfo(&metapb.Store{Id: id}))
+	}
+	dir := t.TempDir()
+	// The rules only cover the keys before "m", so the default rule doesn't apply to region 2.
+	for i, rules := range []string{
+		`[{"group_id":"tidb","id":"r1","end_key":"6d","role":"voter","count":1}]`,
+		`[{"group_id":"tidb","rules":[{"group_id":"tidb","id":"r1","end_key":"6d","role":"voter","count":1}]}]`,
+	} {
+		rulesPath := filepath.Join(dir, fmt.Sprintf("rules-%d.json", i))
+		re.NoError(os.WriteFile(rulesPath, []byte(rules), 0o600))
+		ruleManager, err := newRuleManager(rulesPath, stores)
+		re.NoError(err)
+		re.Nil(ruleManager.GetRule("pd", "default"))
+		re.NotNil(ruleManager.GetRule("tidb", "r1"))
+		infos := []*core.RegionInfo{
+			core.NewRegionInfo(newTestRegion(1, "", "m", 1), nil),
+			core.NewRegionInfo(newTestRegion(2, "m", "", 1), nil),
+		}
+		report := analyzeRegions(infos, ruleManager, stores, &regionFilter{})
+		re.Len(report.Violations, 1)
+		re.Equal(uint64(2), report.Violations[0].RegionID)
+	}
+}
+
+func TestLoadTextRegions(t *testing.T) {
+	re := require.New(t)
+	regions := []*metapb.Region{
+		newTestRegion(1, "", "a\n", 1, 2, 3),
+		newTestRegion(2, "a\n", "", 1, 2, 3),
+	}
+	var buf bytes.Buffer
+	w, err := newRegionWriter(formatText, &buf)
+	re.NoError(err)
+	for _, region := range regions {
+		re.NoError(w.Write(region))
+	}
+	re.NoError(w.Flush())
+
+	regionsPath := filepath.Join(t.TempDir(), "regions.dump")
+	re.NoError(os.WriteFile(regionsPath, buf.Bytes(), 0o600))
+	records, err := loadRegionRecords(regionsPath, formatText)
+	re.NoError(err)
+	re.Len(records, len(regions))
+	for i, record := range records {
+		info, err := record.toRegionInfo()
+		re.NoError(err)
+		re.Equal(regions[i].GetId(), info.GetID())
+		re.Equal(regions[i].GetEndKey(), info.GetEndKey())
+		re.Len(info.GetPeers(), 3)
+	}
+
+	_, err = loadRegionRecords(regionsPath, formatCSV)
+	re.ErrorContains(err, "unsupported format csv to analyze")
+}
+
+func TestCSVWriter(t *testing.T) {
+	re := require.New(t)
+	var buf bytes.Buffer
+	w, err := newRegionWriter(formatCSV, &buf)
+	re.NoError(err)
+	region := newTestRegion(1, "a", "b", 1, 2)
+	region.Peers[1].Role = metapb.PeerRole_Learner
+	re.NoError(w.Write(region))
+	re.NoError(w.Flush())
+	re.Equal("id,start_key,end_key,conf_ver,version,peer_count,store_ids,learner_store_ids\n1,61,62,1,1,2,1;2,2\n", buf.String())
+
+	_, err = newRegionWriter("xlsx", &buf)
+	re.Error(err)
+}
+
+func TestParquetWriter(t *testing.T) {
+	re := require.New(t)
+	var buf bytes.Buffer
+	w, err := newRegionWriter(formatParquet, &buf)
+	re.NoError(err)
+	for id := uint64(1); id <= parquetRowGroupSize+1; id++ {
+		re.NoError(w.Write(newTestRegion(id, "a", "b", 1, 2, 3)))
+	}
+	re.NoError(w.Flush())
+	data := buf.Bytes()
+	re.Equal(parquetMagic, string(data[:4]))
+	re.Equal(parquetMagic, string(data[len(data)-4:]))
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footer := data[len(data)-8-footerLen : len(data)-8]
+	re.Equal(encodeFileMetaData(w.(*parquetWriter).rowGroups), footer)
+	rowGroups := w.(*parquetWriter).rowGroups
+	re.Len(rowGroups, 2)
+	re.Equal(int64(parquetRowGroupSize), rowGroups[0].rows)
+	re.Equal(int64(1), rowGroups[1].rows)
+	// The column chunks are written one after another from the magic to the footer.
+	offset := int64(len(parquetMagic))
+	for _, rowGroup := range rowGroups {
+		re.Len(rowGroup.chunks, len(parquetColumns))
+		for _, chunk := range rowGroup.chunks {
+			re.Equal(offset, chunk.offset)
+			offset += chunk.size
+		}
+	}
+	re.Equal(int64(len(data)-8-footerLen), offset)
+	for _, column := range parquetColumns {
+		re.True(bytes.Contains(footer, []byte(column.name)))
+	}
+}
@@ -15,7 +15,6 @@
 package main
 
 import (
-	"bufio"
 	"flag"
 	"fmt"
 	"math"
@@ -27,7 +26,6 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/utils/etcdutil"
 	"go.etcd.io/etcd/clientv3"
 	"go.etcd.io/etcd/pkg/transport"
@@ -38,13 +36,23 @@ var (
 	endpoints = flag.String("endpoints", "http://127.0.0.1:2379", "endpoints urls")
 	startID   = flag.Uint64("start-id", 0, "ID of the start region")
 	endID     = flag.Uint64("end-id", 0, "ID of the last region")
-	filePath  = flag.String("file", "regions.dump", "dump file path and name")
+	filePath  = flag.String("file", "regions.dump", "dump file path and name, which is the input in the analyze mode")
 	caPath    = flag.String("cacert", "", "path of file that contains list of trusted SSL CAs")
 	certPath  = flag.String("cert", "", "path of file that contains X509 certificate in PEM format")
 	keyPath   = flag.String("key", "", "path of file that contains X509 key in PEM format")
+	mode      = flag.String("mode", modeDump, "dump: dump the regions from etcd; analyze: analyze the dumped regions offline")
+	format    = flag.String("format", formatText, "format of the dump, one of text, jsonl, csv and parquet, and the analyze mode only supports text and jsonl")
+	storeID   = flag.Uint64("store-id", 0, "only dump the regions which have a peer on the store, and in the analyze mode, the store which the dump is filtered by")
+	startKey  = flag.String("start-key", "", "only dump the regions overlapping with the key range, in hex format, and in the analyze mode, the key range which the dump is filtered by")
+	endKey    = flag.String("end-key", "", "only dump the regions overlapping with the key range, in hex format, and in the analyze mode, the key range which the dump is filtered by")
+	rulesPath = flag.String("rules", "", "placement rules file in JSON to check the regions against in the analyze mode")
+	storePath = flag.String("stores", "", "stores file in the format of the PD stores API, whose labels are used to check the placement rules")
 )
 
 const (
+	modeDump    = "dump"
+	modeAnalyze = "analyze"
+
 	etcdTimeout = 1200 * time.Second
 
 	pdRootPath      = "/pd"
@@ -65,6 +73,15 @@ func checkErr(err error) {
 
 func main() {
 	flag.Parse()
+	filter, err := newRegionFilter(*storeID, *startKey, *endKey)
+	checkErr(err)
+	if *mode == modeAnalyze {
+		checkErr(analyze(*filePath, *format, *rulesPath, *storePath, filter, os.Stdout))
+		return
+	}
+	if *mode != modeDump {
+		checkErr(errors.Errorf("unsupported mode %s", *mode))
+	}
 	if *endID != 0 && *endID < *startID {
 		checkErr(errors.New("The end id should great or equal than start id"))
 	}
@@ -94,7 +111,9 @@ func main() {
 	})
 	checkErr(err)
 
-	err = loadRegions(client, f)
+	w, err := newRegionWriter(*format, f)
+	checkErr(err)
+	err = loadRegions(client, w, filter)
 	checkErr(err)
 	fmt.Println("successful!")
 }
@@ -103,14 +122,17 @@ func regionPath(regionID uint64) string {
 	return path.Join("raft", "r", fmt.Sprintf("%020d", regionID))
 }
 
-func loadRegions(client *clientv3.Client, f *os.File) error {
+func loadRegions(client *clientv3.Client, w regionWriter, filter *regionFilter) (err error) {
 	nextID := *startID
 	endKey := regionPath(math.MaxUint64)
 	if *endID != 0 {
 		endKey = regionPath(*endID)
 	}
-	w := bufio.NewWriter(f)
-	defer w.Flush()
+	defer func() {
+		if flushErr := w.Flush(); err == nil {
+			err = flushErr
+		}
+	}()
 	// Since the region key may be very long, using a larger rangeLimit will cause
 	// the message packet to exceed the grpc message size limit (4MB). Here we use
 	// a variable rangeLimit to work around.
@@ -131,7 +153,12 @@ func loadRegions(client *clientv3.Client, f *os.File) error {
 				return errors.WithStack(err)
 			}
 			nextID = region.GetId() + 1
-			fmt.Fprintln(w, core.RegionToHexMeta(region).Region)
+			if !filter.match(region) {
+				continue
+			}
+			if err := w.Write(region); err != nil {
+				return err
+			}
 		}
 
 		if len(res) < rangeLimit {
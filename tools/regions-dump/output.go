@@ -0,0 +1,211 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/tikv/pd/pkg/core"
+)
+
+const (
+	formatText    = "text"
+	formatJSONL   = "jsonl"
+	formatCSV     = "csv"
+	formatParquet = "parquet"
+)
+
+// regionRecord is the structured form of a region. The fields follow the region
+// API of PD, so the output of `pd-ctl region` could also be analyzed offline.
+type regionRecord struct {
+	ID       uint64              `json:"id"`
+	StartKey string              `json:"start_key"`
+	EndKey   string              `json:"end_key"`
+	Epoch    *metapb.RegionEpoch `json:"epoch,omitempty"`
+	Peers    []*metapb.Peer      `json:"peers,omitempty"`
+	Leader   *metapb.Peer        `json:"leader,omitempty"`
+}
+
+func newRegionRecord(region *metapb.Region) *regionRecord {
+	return &regionRecord{
+		ID:       region.GetId(),
+		StartKey: core.HexRegionKeyStr(region.GetStartKey()),
+		EndKey:   core.HexRegionKeyStr(region.GetEndKey()),
+		Epoch:    region.GetRegionEpoch(),
+		Peers:    region.GetPeers(),
+	}
+}
+
+// toRegionInfo converts the record back to the region info.
+func (r *regionRecord) toRegionInfo() (*core.RegionInfo, error) {
+	startKey, err := hex.DecodeString(r.StartKey)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid start key of region %d", r.ID)
+	}
+	endKey, err := hex.DecodeString(r.EndKey)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid end key of region %d", r.ID)
+	}
+	meta := &metapb.Region{
+		Id:          r.ID,
+		StartKey:    startKey,
+		EndKey:      endKey,
+		RegionEpoch: r.Epoch,
+		Peers:       r.Peers,
+	}
+	return core.NewRegionInfo(meta, r.Leader), nil
+}
+
+// regionFilter filters the dumped regions by the store and the key range.
+type regionFilter struct {
+	storeID  uint64
+	startKey []byte
+	endKey   []byte
+}
+
+func newRegionFilter(storeID uint64, startKey, endKey string) (*regionFilter, error) {
+	f := &regionFilter{storeID: storeID}
+	var err error
+	if f.startKey, err = hex.DecodeString(startKey); err != nil {
+		return nil, errors.Annotate(err, "invalid start key")
+	}
+	if f.endKey, err = hex.DecodeString(endKey); err != nil {
+		return nil, errors.Annotate(err, "invalid end key")
+	}
+	return f, nil
+}
+
+// match returns whether the region has a peer on the store and overlaps with the key range.
+func (f *regionFilter) match(region *metapb.Region) bool {
+	if f.storeID != 0 {
+		found := false
+		for _, peer := range region.GetPeers() {
+			if peer.GetStoreId() == f.storeID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.endKey) > 0 && bytes.Compare(region.GetStartKey(), f.endKey) >= 0 {
+		return false
+	}
+	if len(region.GetEndKey()) > 0 && bytes.Compare(region.GetEndKey(), f.startKey) <= 0 {
+		return false
+	}
+	return true
+}
+
+// regionWriter writes the dumped regions in a specific format.
+type regionWriter interface {
+	Write(region *metapb.Region) error
+	Flush() error
+}
+
+func newRegionWriter(format string, w io.Writer) (regionWriter, error) {
+	switch format {
+	case formatText:
+		return &textWriter{w: bufio.NewWriter(w)}, nil
+	case formatJSONL:
+		bw := bufio.NewWriter(w)
+		return &jsonlWriter{w: bw, encoder: json.NewEncoder(bw)}, nil
+	case formatCSV:
+		cw := &csvWriter{w: csv.NewWriter(w)}
+		if err := cw.w.Write(csvHeader); err != nil {
+			return nil, err
+		}
+		return cw, nil
+	case formatParquet:
+		return newParquetWriter(w)
+	default:
+		return nil, errors.Errorf("unsupported format %s, it should be one of %s, %s, %s and %s",
+			format, formatText, formatJSONL, formatCSV, formatParquet)
+	}
+}
+
+type textWriter struct {
+	w *bufio.Writer
+}
+
+func (t *textWriter) Write(region *metapb.Region) error {
+	_, err := fmt.Fprintln(t.w, core.RegionToHexMeta(region).Region)
+	return err
+}
+
+func (t *textWriter) Flush() error {
+	return t.w.Flush()
+}
+
+type jsonlWriter struct {
+	w       *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (j *jsonlWriter) Write(region *metapb.Region) error {
+	return j.encoder.Encode(newRegionRecord(region))
+}
+
+func (j *jsonlWriter) Flush() error {
+	return j.w.Flush()
+}
+
+var csvHeader = []string{"id", "start_key", "end_key", "conf_ver", "version", "peer_count", "store_ids", "learner_store_ids"}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// regionStoreIDs returns the store IDs of all peers and the learners of the region.
+func regionStoreIDs(region *metapb.Region) (storeIDs, learnerStoreIDs []string) {
+	for _, peer := range region.GetPeers() {
+		storeID := strconv.FormatUint(peer.GetStoreId(), 10)
+		storeIDs = append(storeIDs, storeID)
+		if peer.GetRole() == metapb.PeerRole_Learner {
+			learnerStoreIDs = append(learnerStoreIDs, storeID)
+		}
+	}
+	return storeIDs, learnerStoreIDs
+}
+
+func (c *csvWriter) Write(region *metapb.Region) error {
+	storeIDs, learnerStoreIDs := regionStoreIDs(region)
+	return c.w.Write([]string{
+		strconv.FormatUint(region.GetId(), 10),
+		core.HexRegionKeyStr(region.GetStartKey()),
+		core.HexRegionKeyStr(region.GetEndKey()),
+		strconv.FormatUint(region.GetRegionEpoch().GetConfVer(), 10),
+		strconv.FormatUint(region.GetRegionEpoch().GetVersion(), 10),
+		strconv.Itoa(len(region.GetPeers())),
+		strings.Join(storeIDs, ";"),
+		strings.Join(learnerStoreIDs, ";"),
+	})
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,339 @@
+// Copyright 2026 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/tikv/pd/pkg/core"
+)
+
+// The parquet output is written without any dependency. All columns are required, and each
+// column chunk has a single data page in the plain encoding without compression, which is
+// enough for the tools such as DuckDB and pandas to load the dump.
+const (
+	parquetMagic = "PAR1"
+	// parquetRowGroupSize is the max number of rows in a row group, which also bounds the
+	// memory to buffer the rows.
+	parquetRowGroupSize = 64 * 1024
+	parquetCreatedBy    = "pd regions-dump"
+
+	// The physical types of parquet.
+	parquetTypeInt64     = 2
+	parquetTypeByteArray = 6
+	// The converted type of the UTF-8 strings.
+	parquetConvertedTypeUTF8 = 0
+
+	parquetRepetitionRequired = 0
+	parquetPageTypeData       = 0
+	parquetEncodingPlain      = 0
+	parquetEncodingRLE        = 3
+	parquetCodecUncompressed  = 0
+)
+
+// parquetColumn is a column of the parquet output, whose name follows the CSV header.
+type parquetColumn struct {
+	name string
+	typ  int32
+}
+
+var parquetColumns = []parquetColumn{
+	{"id", parquetTypeInt64},
+	{"start_key", parquetTypeByteArray},
+	{"end_key", parquetTypeByteArray},
+	{"conf_ver", parquetTypeInt64},
+	{"version", parquetTypeInt64},
+	{"peer_count", parquetTypeInt64},
+	{"store_ids", parquetTypeByteArray},
+	{"learner_store_ids", parquetTypeByteArray},
+}
+
+// parquetChunk is the location of a column chunk in the file.
+type parquetChunk struct {
+	offset int64
+	size   int64
+}
+
+type parquetRowGroup struct {
+	chunks []parquetChunk
+	rows   int64
+}
+
+type parquetWriter struct {
+	w      *bufio.Writer
+	offset int64
+	// values are the plain encoded values of each column in the current row group.
+	values    []bytes.Buffer
+	rows      int64
+	rowGroups []parquetRowGroup
+}
+
+func newParquetWriter(w io.Writer) (*parquetWriter, error) {
+	p := &parquetWriter{
+		w:      bufio.NewWriter(w),
+		values: make([]bytes.Buffer, len(parquetColumns)),
+	}
+	if err := p.write([]byte(parquetMagic)); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *parquetWriter) write(data []byte) error {
+	n, err := p.w.Write(data)
+	p.offset += int64(n)
+	return errors.WithStack(err)
+}
+
+func (p *parquetWriter) Write(region *metapb.Region) error {
+	storeIDs, learnerStoreIDs := regionStoreIDs(region)
+	p.writeInt64(0, region.GetId())
+	p.writeString(1, core.HexRegionKeyStr(region.GetStartKey()))
+	p.writeString(2, core.HexRegionKeyStr(region.GetEndKey()))
+	p.writeInt64(3, region.GetRegionEpoch().GetConfVer())
+	p.writeInt64(4, region.GetRegionEpoch().GetVersion())
+	p.writeInt64(5, uint64(len(region.GetPeers())))
+	p.writeString(6, strings.Join(storeIDs, ";"))
+	p.writeString(7, strings.Join(learnerStoreIDs, ";"))
+	if p.rows++; p.rows >= parquetRowGroupSize {
+		return p.flushRowGroup()
+	}
+	return nil
+}
+
+func (p *parquetWriter) writeInt64(column int, v uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	p.values[column].Write(buf[:])
+}
+
+func (p *parquetWriter) writeString(column int, v string) {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], uint32(len(v)))
+	p.values[column].Write(buf[:])
+	p.values[column].WriteString(v)
+}
+
+// flushRowGroup writes the buffered rows as a row group.
+func (p *parquetWriter) flushRowGroup() error {
+	if p.rows == 0 {
+		return nil
+	}
+	rowGroup := parquetRowGroup{rows: p.rows}
+	for i := range p.values {
+		values := p.values[i].Bytes()
+		header := encodePageHeader(len(values), p.rows)
+		chunk := parquetChunk{offset: p.offset, size: int64(len(header) + len(values))}
+		if err := p.write(header); err != nil {
+			return err
+		}
+		if err := p.write(values); err != nil {
+			return err
+		}
+		p.values[i].Reset()
+		rowGroup.chunks = append(rowGroup.chunks, chunk)
+	}
+	p.rowGroups = append(p.rowGroups, rowGroup)
+	p.rows = 0
+	return nil
+}
+
+// Flush writes the last row group and the footer, so it should be called only once.
+func (p *parquetWriter) Flush() error {
+	if err := p.flushRowGroup(); err != nil {
+		return err
+	}
+	footer := encodeFileMetaData(p.rowGroups)
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	for _, data := range [][]byte{footer, length[:], []byte(parquetMagic)} {
+		if err := p.write(data); err != nil {
+			return err
+		}
+	}
+	return errors.WithStack(p.w.Flush())
+}
+
+func encodePageHeader(size int, rows int64) []byte {
+	t := newThriftWriter()
+	t.i32Field(1, parquetPageTypeData)
+	t.i32Field(2, int32(size))
+	t.i32Field(3, int32(size))
+	t.structField(5)
+	t.i32Field(1, int32(rows))
+	t.i32Field(2, parquetEncodingPlain)
+	t.i32Field(3, parquetEncodingRLE)
+	t.i32Field(4, parquetEncodingRLE)
+	t.endStruct()
+	t.endStruct()
+	return t.buf.Bytes()
+}
+
+func encodeFileMetaData(rowGroups []parquetRowGroup) []byte {
+	var rows int64
+	for _, rowGroup := range rowGroups {
+		rows += rowGroup.rows
+	}
+	t := newThriftWriter()
+	t.i32Field(1, 1)
+	t.listField(2, thriftTypeStruct, len(parquetColumns)+1)
+	// The root of the schema is a group of all columns.
+	t.beginStruct()
+	t.binaryField(4, "schema")
+	t.i32Field(5, int32(len(parquetColumns)))
+	t.endStruct()
+	for _, column := range parquetColumns {
+		t.beginStruct()
+		t.i32Field(1, column.typ)
+		t.i32Field(3, parquetRepetitionRequired)
+		t.binaryField(4, column.name)
+		if column.typ == parquetTypeByteArray {
+			t.i32Field(6, parquetConvertedTypeUTF8)
+		}
+		t.endStruct()
+	}
+	t.i64Field(3, rows)
+	t.listField(4, thriftTypeStruct, len(rowGroups))
+	for _, rowGroup := range rowGroups {
+		var size int64
+		t.beginStruct()
+		t.listField(1, thriftTypeStruct, len(rowGroup.chunks))
+		for i, chunk := range rowGroup.chunks {
+			size += chunk.size
+			t.beginStruct()
+			t.i64Field(2, chunk.offset)
+			t.structField(3)
+			t.i32Field(1, parquetColumns[i].typ)
+			t.listField(2, thriftTypeI32, 1)
+			t.i32(parquetEncodingPlain)
+			t.listField(3, thriftTypeBinary, 1)
+			t.binary(parquetColumns[i].name)
+			t.i32Field(4, parquetCodecUncompressed)
+			t.i64Field(5, rowGroup.rows)
+			t.i64Field(6, chunk.size)
+			t.i64Field(7, chunk.size)
+			t.i64Field(9, chunk.offset)
+			t.endStruct()
+			t.endStruct()
+		}
+		t.i64Field(2, size)
+		t.i64Field(3, rowGroup.rows)
+		t.endStruct()
+	}
+	t.binaryField(6, parquetCreatedBy)
+	t.endStruct()
+	return t.buf.Bytes()
+}
+
+// The types of the thrift compact protocol, which the parquet metadata is encoded in.
+const (
+	thriftTypeStop   = 0
+	thriftTypeI32    = 5
+	thriftTypeI64    = 6
+	thriftTypeBinary = 8
+	thriftTypeList   = 9
+	thriftTypeStruct = 12
+)
+
+// thriftWriter encodes the structs in the thrift compact protocol.
+type thriftWriter struct {
+	buf bytes.Buffer
+	// lastFieldIDs is the stack of the last field ID of the structs being encoded, since the
+	// field ID is encoded as the delta to the last one.
+	lastFieldIDs []int16
+}
+
+// newThriftWriter creates a writer to encode a top level struct, which should be ended by endStruct.
+func newThriftWriter() *thriftWriter {
+	t := &thriftWriter{}
+	t.beginStruct()
+	return t
+}
+
+func (t *thriftWriter) beginStruct() {
+	t.lastFieldIDs = append(t.lastFieldIDs, 0)
+}
+
+func (t *thriftWriter) endStruct() {
+	t.buf.WriteByte(thriftTypeStop)
+	t.lastFieldIDs = t.lastFieldIDs[:len(t.lastFieldIDs)-1]
+}
+
+func (t *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := &t.lastFieldIDs[len(t.lastFieldIDs)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.i32(int32(id))
+	}
+	*last = id
+}
+
+func (t *thriftWriter) varint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	t.buf.Write(buf[:binary.PutUvarint(buf[:], v)])
+}
+
+func (t *thriftWriter) i32(v int32) {
+	t.varint(uint64(uint32(v<<1) ^ uint32(v>>31)))
+}
+
+func (t *thriftWriter) i64(v int64) {
+	t.varint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+func (t *thriftWriter) binary(v string) {
+	t.varint(uint64(len(v)))
+	t.buf.WriteString(v)
+}
+
+func (t *thriftWriter) i32Field(id int16, v int32) {
+	t.fieldHeader(id, thriftTypeI32)
+	t.i32(v)
+}
+
+func (t *thriftWriter) i64Field(id int16, v int64) {
+	t.fieldHeader(id, thriftTypeI64)
+	t.i64(v)
+}
+
+func (t *thriftWriter) binaryField(id int16, v string) {
+	t.fieldHeader(id, thriftTypeBinary)
+	t.binary(v)
+}
+
+// structField begins a struct field, which should be ended by endStruct.
+func (t *thriftWriter) structField(id int16) {
+	t.fieldHeader(id, thriftTypeStruct)
+	t.beginStruct()
+}
+
+// listField begins a list field, whose elements should be encoded right after it.
+func (t *thriftWriter) listField(id int16, elemType byte, size int) {
+	t.fieldHeader(id, thriftTypeList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		t.buf.WriteByte(0xf0 | elemType)
+		t.varint(uint64(size))
+	}
+}